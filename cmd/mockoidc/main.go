@@ -0,0 +1,258 @@
+// Command mockoidc is a minimal OpenID Connect provider for trying the OIDC login flow
+// locally. It signs in whoever fills in its form, so never expose it publicly.
+//
+//	go run ./cmd/mockoidc -addr :9400
+//
+// and configure the API with:
+//
+//	OIDC_PROVIDERS=mock
+//	OIDC_MOCK_ISSUER=http://localhost:9400
+//	OIDC_MOCK_CLIENT_ID=menuvista
+//	OIDC_MOCK_CLIENT_SECRET=secret
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"html/template"
+	"log"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const keyID = "mock-1"
+
+type authorization struct {
+	ClientID      string
+	RedirectURI   string
+	Nonce         string
+	CodeChallenge string
+	Email         string
+	Name          string
+	Verified      bool
+	ExpiresAt     time.Time
+}
+
+type server struct {
+	issuer       string
+	clientID     string
+	clientSecret string
+	key          *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]authorization
+}
+
+var loginPage = template.Must(template.New("login").Parse(`<!DOCTYPE html>
+<html>
+<body style="font-family: sans-serif; max-width: 360px; margin: 60px auto;">
+    <h2>Mock OIDC sign-in</h2>
+    <form method="POST" action="/authorize">
+        {{range $k, $v := .Params}}<input type="hidden" name="{{$k}}" value="{{index $v 0}}">
+        {{end}}
+        <p><label>Email<br><input name="email" type="email" required style="width: 100%"></label></p>
+        <p><label>Name<br><input name="name" style="width: 100%"></label></p>
+        <p><label><input name="email_verified" type="checkbox" value="true" checked> Email verified</label></p>
+        <button type="submit">Sign in</button>
+    </form>
+</body>
+</html>`))
+
+func main() {
+	addr := flag.String("addr", ":9400", "listen address")
+	issuer := flag.String("issuer", "http://localhost:9400", "issuer URL the API is configured with")
+	clientID := flag.String("client-id", "menuvista", "accepted client ID")
+	clientSecret := flag.String("client-secret", "secret", "accepted client secret")
+	flag.Parse()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		log.Fatalf("failed to generate signing key: %v", err)
+	}
+
+	s := &server{
+		issuer:       strings.TrimRight(*issuer, "/"),
+		clientID:     *clientID,
+		clientSecret: *clientSecret,
+		key:          key,
+		codes:        make(map[string]authorization),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", s.discovery)
+	mux.HandleFunc("/authorize", s.authorize)
+	mux.HandleFunc("/token", s.token)
+	mux.HandleFunc("/jwks", s.jwks)
+
+	log.Printf("mock OIDC provider listening on %s (issuer %s)", *addr, s.issuer)
+	log.Fatal(http.ListenAndServe(*addr, mux))
+}
+
+func (s *server) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                s.issuer,
+		"authorization_endpoint":                s.issuer + "/authorize",
+		"token_endpoint":                        s.issuer + "/token",
+		"jwks_uri":                              s.issuer + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+// authorize shows the sign-in form on GET and redirects back with a code on POST
+func (s *server) authorize(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if r.Form.Get("client_id") != s.clientID || r.Form.Get("response_type") != "code" {
+		http.Error(w, "unknown client_id or unsupported response_type", http.StatusBadRequest)
+		return
+	}
+	if r.Form.Get("code_challenge_method") != "S256" || r.Form.Get("code_challenge") == "" {
+		http.Error(w, "PKCE with S256 is required", http.StatusBadRequest)
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		_ = loginPage.Execute(w, map[string]interface{}{"Params": r.URL.Query()})
+		return
+	}
+
+	email := strings.ToLower(strings.TrimSpace(r.PostForm.Get("email")))
+	if email == "" {
+		http.Error(w, "email is required", http.StatusBadRequest)
+		return
+	}
+
+	code := randomString()
+	s.mu.Lock()
+	s.codes[code] = authorization{
+		ClientID:      r.Form.Get("client_id"),
+		RedirectURI:   r.Form.Get("redirect_uri"),
+		Nonce:         r.Form.Get("nonce"),
+		CodeChallenge: r.Form.Get("code_challenge"),
+		Email:         email,
+		Name:          r.PostForm.Get("name"),
+		Verified:      r.PostForm.Get("email_verified") == "true",
+		ExpiresAt:     time.Now().Add(time.Minute),
+	}
+	s.mu.Unlock()
+
+	redirect, err := url.Parse(r.Form.Get("redirect_uri"))
+	if err != nil {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+	query := redirect.Query()
+	query.Set("code", code)
+	query.Set("state", r.Form.Get("state"))
+	redirect.RawQuery = query.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+func (s *server) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "authorization_code" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+		return
+	}
+
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientID, clientSecret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if clientID != s.clientID || clientSecret != s.clientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	// Codes are single use
+	s.mu.Lock()
+	auth, found := s.codes[r.PostForm.Get("code")]
+	delete(s.codes, r.PostForm.Get("code"))
+	s.mu.Unlock()
+
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	switch {
+	case !found, time.Now().After(auth.ExpiresAt), auth.ClientID != clientID,
+		auth.RedirectURI != r.PostForm.Get("redirect_uri"),
+		base64.RawURLEncoding.EncodeToString(sum[:]) != auth.CodeChallenge:
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":            s.issuer,
+		"sub":            subjectFor(auth.Email),
+		"aud":            clientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(5 * time.Minute).Unix(),
+		"nonce":          auth.Nonce,
+		"email":          auth.Email,
+		"email_verified": auth.Verified,
+		"name":           auth.Name,
+	})
+	token.Header["kid"] = keyID
+	idToken, err := token.SignedString(s.key)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": randomString(),
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     idToken,
+	})
+}
+
+func (s *server) jwks(w http.ResponseWriter, r *http.Request) {
+	pub := s.key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": keyID,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+}
+
+// subjectFor keeps the subject stable per email across restarts, like a real provider
+func subjectFor(email string) string {
+	sum := sha256.Sum256([]byte(email))
+	return base64.RawURLEncoding.EncodeToString(sum[:16])
+}
+
+func randomString() string {
+	b := make([]byte, 24)
+	_, _ = rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -11,11 +11,19 @@ import (
 	"menuvista/internal/services/activity"
 	"menuvista/internal/services/admin"
 	"menuvista/internal/services/analytics"
+	"menuvista/internal/services/apikey"
 	"menuvista/internal/services/auth"
+	"menuvista/internal/services/coupon"
 	"menuvista/internal/services/email"
+	"menuvista/internal/services/invoice"
 	"menuvista/internal/services/menu"
 	"menuvista/internal/services/payment"
+	"menuvista/internal/services/promotion"
+	"menuvista/internal/services/reservation"
 	"menuvista/internal/services/restaurant"
+	"menuvista/internal/services/review"
+	"menuvista/internal/services/scheduler"
+	"menuvista/internal/services/servicerequest"
 	"menuvista/internal/services/staff"
 	"menuvista/internal/services/subscription"
 	"menuvista/internal/storage/persistence"
@@ -60,16 +68,37 @@ func Init(ctx context.Context) *gin.Engine {
 	emailService := email.NewService(resendAPIKey, queries)
 
 	// 5. Services
-	paymentService := payment.NewService(queries)
-	webhookService := payment.NewWebhookService(queries, emailService)
+	paymentProvider, err := payment.NewProviderFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to initialize payment provider: %v", err)
+	}
+	invoiceService := invoice.NewService(queries, invoice.SellerFromEnv())
+	webhookService := payment.NewWebhookService(queries, emailService, paymentProvider, invoiceService)
+	paymentService := payment.NewService(queries, paymentProvider, webhookService)
 	authService := auth.NewService(queries, redisClient, r2Client, emailService, paymentService)
-	restaurantService := restaurant.NewService(queries, r2Client, emailService)
-	menuService := menu.NewService(queries, r2Client)
-	adminService := admin.NewService(queries)
-	staffService := staff.NewStaffService(queries, r2Client, emailService)
+	staffService := staff.NewStaffService(queries, r2Client, emailService, authService)
+	restaurantService := restaurant.NewService(queries, r2Client, emailService, staffService)
+	adminService := admin.NewService(queries, authService, authService)
 	activityService := activity.NewService(queries)
 	analyticsService := analytics.NewService(queries)
-	subscriptionService := subscription.NewService(queries)
+	subscriptionService := subscription.NewService(queries, emailService)
+	serviceRequestService := servicerequest.NewService(queries, redisClient, activityService)
+	reservationService := reservation.NewService(queries, redisClient, emailService)
+	promotionService := promotion.NewService(queries)
+	menuService := menu.NewService(queries, r2Client, emailService, reservationService, promotionService)
+	reviewService := review.NewService(queries, redisClient, r2Client)
+	apiKeyService := apikey.NewService(queries)
+	couponService := coupon.NewService(queries)
+	refundService := payment.NewRefundService(queries, paymentProvider, emailService, subscriptionService, invoiceService)
+
+	// Background jobs, run by whichever replica holds the scheduler lock
+	dunningWorker := payment.NewDunningWorker(queries, paymentService, emailService)
+	jobScheduler := scheduler.New(redisClient)
+	jobScheduler.Register("dunning", dunningWorker.Interval(), dunningWorker.RunOnce)
+	jobScheduler.Register("subscription-lifecycle", subscription.LifecycleInterval, subscriptionService.RunLifecycle)
+	jobScheduler.Register("subscription-reminders", subscription.ReminderInterval, subscriptionService.SendExpiryReminders)
+	jobScheduler.Register("coupon-reservations", coupon.ReleaseInterval, couponService.ReleaseExpiredReservations)
+	jobScheduler.Start(ctx)
 
 	// Assuming cfg and logger are defined elsewhere or need to be added.
 	// For now, I'll use the existing os.Getenv and log.New for the first two arguments
@@ -87,21 +116,30 @@ func Init(ctx context.Context) *gin.Engine {
 	// please provide the full context for `cfg` and `logger`.
 	// For now, I'll assume `smsService` is the *only* new argument to be added.
 	// Since `smsService` is not defined, I'll add a `nil` placeholder.
-	authMiddleware := middleware.NewAuthMiddleware(os.Getenv("AUTH_SECRET"), log.New(os.Stdout, "[AuthMiddleware] ", log.LstdFlags), nil /* smsService */)
+	authMiddleware := middleware.NewAuthMiddleware(os.Getenv("AUTH_SECRET"), log.New(os.Stdout, "[AuthMiddleware] ", log.LstdFlags), nil /* smsService */, redisClient, staffService, apiKeyService)
 
 	// 5. Router
 	router := glue.InitRouter(
 		glue.Services{
-			Auth:         authService,
-			Restaurant:   restaurantService,
-			Menu:         menuService,
-			Admin:        adminService,
-			Payment:      paymentService,
-			Webhook:      webhookService,
-			Staff:        staffService,
-			Activity:     activityService,
-			Analytics:    analyticsService,
-			Subscription: subscriptionService,
+			Auth:            authService,
+			Restaurant:      restaurantService,
+			Menu:            menuService,
+			Admin:           adminService,
+			Payment:         paymentService,
+			Webhook:         webhookService,
+			PaymentProvider: paymentProvider,
+			Staff:           staffService,
+			Activity:        activityService,
+			Analytics:       analyticsService,
+			Subscription:    subscriptionService,
+			ServiceRequest:  serviceRequestService,
+			Reservation:     reservationService,
+			Review:          reviewService,
+			Promotion:       promotionService,
+			APIKey:          apiKeyService,
+			Coupon:          couponService,
+			Invoice:         invoiceService,
+			Refund:          refundService,
 		},
 		authMiddleware,
 	)
@@ -5,16 +5,26 @@ import (
 	"log"
 	"menuvista/internal/handlers/middleware"
 	"menuvista/internal/handlers/rest"
+	"menuvista/internal/models"
 	"menuvista/internal/services/activity"
 	"menuvista/internal/services/admin"
 	"menuvista/internal/services/analytics"
+	"menuvista/internal/services/apikey"
 	"menuvista/internal/services/auth"
+	"menuvista/internal/services/coupon"
+	"menuvista/internal/services/invoice"
 	"menuvista/internal/services/menu"
 	"menuvista/internal/services/payment"
+	"menuvista/internal/services/promotion"
+	"menuvista/internal/services/reservation"
 	"menuvista/internal/services/restaurant"
+	"menuvista/internal/services/review"
+	"menuvista/internal/services/servicerequest"
 	"menuvista/internal/services/staff"
 	"menuvista/internal/services/subscription"
 	"menuvista/templates"
+	"os"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -25,16 +35,26 @@ type router struct {
 }
 
 type Services struct {
-	Auth         *auth.Service
-	Restaurant   *restaurant.Service
-	Menu         *menu.Service
-	Admin        *admin.Service
-	Payment      *payment.Service
-	Webhook      *payment.WebhookService
-	Staff        *staff.Service
-	Activity     *activity.Service
-	Analytics    *analytics.Service
-	Subscription *subscription.Service
+	Auth       *auth.Service
+	Restaurant *restaurant.Service
+	Menu       *menu.Service
+	Admin      *admin.Service
+	Payment    *payment.Service
+	Webhook    *payment.WebhookService
+	// PaymentProvider is the gateway the payment services were built with
+	PaymentProvider payment.PaymentProvider
+	Staff           *staff.Service
+	Activity        *activity.Service
+	Analytics       *analytics.Service
+	Subscription    *subscription.Service
+	ServiceRequest  *servicerequest.Service
+	Reservation     *reservation.Service
+	Review          *review.Service
+	Promotion       *promotion.Service
+	APIKey          *apikey.Service
+	Coupon          *coupon.Service
+	Invoice         *invoice.Service
+	Refund          *payment.RefundService
 }
 
 func InitRouter(
@@ -43,6 +63,12 @@ func InitRouter(
 ) *gin.Engine {
 	r := gin.New()
 
+	// Only proxies listed in TRUSTED_PROXIES may set X-Forwarded-For. Otherwise clients could
+	// pick their own IP and dodge the rate limits and lockouts keyed on it.
+	if err := r.SetTrustedProxies(trustedProxies()); err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
+
 	// Global Middleware
 	r.Use(middleware.LoggingMiddleware())
 	r.Use(gin.Recovery())
@@ -67,6 +93,14 @@ func InitRouter(
 
 	analyticsH := rest.NewAnalyticsHandler(services.Analytics)
 	subH := rest.NewSubscriptionHandler(services.Subscription)
+	serviceReqH := rest.NewServiceRequestHandler(services.ServiceRequest)
+	reservationH := rest.NewReservationHandler(services.Reservation)
+	reviewH := rest.NewReviewHandler(services.Review)
+	promotionH := rest.NewPromotionHandler(services.Promotion)
+	apiKeyH := rest.NewAPIKeyHandler(services.APIKey)
+	couponH := rest.NewCouponHandler(services.Coupon)
+	invoiceH := rest.NewInvoiceHandler(services.Invoice)
+	refundH := rest.NewRefundHandler(services.Refund)
 
 	// Load HTML templates
 	templ := template.Must(template.ParseFS(templates.FS, "*.html"))
@@ -79,20 +113,45 @@ func InitRouter(
 		{
 			auth.POST("/register", authH.Register)
 			auth.POST("/login", authH.Login)
+			auth.POST("/login/2fa", authH.VerifyTwoFactorLogin)
+			auth.POST("/login/2fa/setup", authH.BeginRequiredTwoFactorSetup)
+			auth.POST("/login/2fa/enable", authH.CompleteRequiredTwoFactorSetup)
+			auth.POST("/refresh", authH.RefreshToken)
 			auth.GET("/activate", authH.ActivateAccount)
+			auth.POST("/forgot-password", authH.ForgotPassword)
+			auth.POST("/reset-password", authH.ResetPassword)
+			auth.GET("/oidc/providers", authH.ListOIDCProviders)
+			auth.GET("/oidc/:provider/authorize", authH.BeginOIDCLogin)
+			auth.POST("/oidc/:provider/callback", authH.CompleteOIDCLogin)
 
 			protectedAuth := auth.Group("")
-			protectedAuth.Use(authMiddleware.AuthMiddleware())
+			protectedAuth.Use(authMiddleware.AuthMiddleware(), authMiddleware.RequireRole("admin", "owner", "staff"))
 			{
 				protectedAuth.GET("/me", authH.GetProfile)
 				protectedAuth.PATCH("/profile", authH.UpdateProfile)
+				protectedAuth.POST("/logout", authH.Logout)
+				protectedAuth.POST("/logout-all", authH.LogoutAll)
+				protectedAuth.GET("/sessions", authH.ListSessions)
+				protectedAuth.DELETE("/sessions/:session_id", authH.RevokeSession)
+				protectedAuth.POST("/change-password", authH.ChangePassword)
+				protectedAuth.GET("/2fa", authH.GetTwoFactorStatus)
+				protectedAuth.POST("/2fa/setup", authH.BeginTwoFactorSetup)
+				protectedAuth.POST("/2fa/enable", authH.EnableTwoFactor)
+				protectedAuth.POST("/2fa/disable", authH.DisableTwoFactor)
+				protectedAuth.POST("/2fa/recovery-codes", authH.RegenerateRecoveryCodes)
 			}
 		}
 
 		subscription := api.Group("/subscription")
-		subscription.Use(authMiddleware.AuthMiddleware())
+		subscription.Use(authMiddleware.AuthMiddleware(), authMiddleware.RequireRole("admin", "owner", "staff"))
 		{
 			subscription.GET("/me", subH.GetSubscriptionDetails)
+			subscription.DELETE("/scheduled-change", authMiddleware.RequireRole("owner"), subH.CancelScheduledChange)
+			subscription.POST("/cancel", authMiddleware.RequireRole("owner"), subH.CancelSubscription)
+			subscription.POST("/cancel/immediate", authMiddleware.RequireRole("owner"), subH.CancelSubscriptionNow)
+			subscription.POST("/resume", authMiddleware.RequireRole("owner"), subH.ResumeSubscription)
+			subscription.GET("/invoices", authMiddleware.RequireRole("owner"), invoiceH.ListInvoices)
+			subscription.GET("/invoices/:invoice_id/pdf", authMiddleware.RequireRole("owner"), invoiceH.DownloadInvoicePDF)
 		}
 
 		restaurants := api.Group("/restaurants")
@@ -101,12 +160,41 @@ func InitRouter(
 			restaurants.GET("/:slug", restH.GetRestaurant)
 			restaurants.GET("/:slug/categories", menuH.ListCategories)
 			restaurants.GET("/:slug/categories/:category_id/items", menuH.ListItems)
+			restaurants.GET("/:slug/reservations/slots", reservationH.ListSlots)
+			restaurants.POST("/:slug/reservations", reservationH.CreateReservation)
+			restaurants.GET("/:slug/reviews", reviewH.ListRestaurantReviews)
+			restaurants.POST("/:slug/reviews", reviewH.CreateRestaurantReview)
+			restaurants.GET("/:slug/items/:item_id/reviews", reviewH.ListMenuItemReviews)
+			restaurants.POST("/:slug/items/:item_id/reviews", reviewH.CreateMenuItemReview)
+		}
+
+		api.POST("/reviews/:review_id/report", reviewH.ReportReview)
+
+		reservations := api.Group("/reservations")
+		{
+			// The email links open a page; only its form post changes the booking
+			reservations.GET("/confirm", reservationH.ConfirmReservationPage)
+			reservations.POST("/confirm", reservationH.ConfirmReservation)
+			reservations.GET("/cancel", reservationH.CancelReservationPage)
+			reservations.POST("/cancel", reservationH.CancelReservation)
+		}
+
+		staffInvitations := api.Group("/staff-invitations")
+		{
+			staffInvitations.GET("", staffH.GetInvitation)
+			staffInvitations.POST("/accept", staffH.AcceptInvitation)
+		}
+
+		tables := api.Group("/tables/:token")
+		{
+			tables.GET("", serviceReqH.GetTable)
+			tables.POST("/requests", serviceReqH.RaiseRequest)
 		}
 
 		payments := api.Group("/payment")
 		{
-			payments.POST("/chapa/webhook", webhookH.ChapaWebhook)
-			payments.POST("/chapa/test", webhookH.ChapaWebhookTest)
+			payments.POST("/webhook", webhookH.ProviderWebhook)
+			payments.POST("/chapa/webhook", webhookH.ProviderWebhook)
 		}
 	}
 
@@ -114,6 +202,13 @@ func InitRouter(
 	r.GET("/payment/success", paymentH.PaymentSuccess)
 	r.GET("/payment/cancel", paymentH.PaymentCancel)
 
+	// Local checkout page of the fake provider (development and integration tests)
+	if fake, ok := services.PaymentProvider.(*payment.FakeProvider); ok {
+		fakeCheckoutH := rest.NewFakeCheckoutHandler(fake, services.Webhook)
+		r.GET("/payment/fake/checkout/:tx_ref", fakeCheckoutH.Checkout)
+		r.POST("/payment/fake/checkout/:tx_ref", fakeCheckoutH.Submit)
+	}
+
 	// Protected Routes
 	protected := api.Group("")
 	protected.Use(authMiddleware.AuthMiddleware())
@@ -130,46 +225,91 @@ func InitRouter(
 				myRestaurants.DELETE("/:restaurant_id", restH.DeleteRestaurant)
 			}
 
-			categories := owner.Group("/my-restaurants/:restaurant_id/categories")
-			{
-				categories.POST("", menuH.CreateCategory)
-				categories.GET("", menuH.ListCategories)
-				categories.PATCH("/:category_id", menuH.UpdateCategory)
-				categories.DELETE("/:category_id", menuH.DeleteCategory)
-			}
-
-			items := owner.Group("/my-restaurants/:restaurant_id/categories/:category_id/items")
-			{
-				items.POST("", menuH.CreateItem)
-				items.GET("", menuH.ListItems)
-				items.PATCH("/:item_id", menuH.UpdateItem)
-				items.DELETE("/:item_id", menuH.DeleteItem)
-			}
-
 			staff := owner.Group("/my-restaurants/:restaurant_id/staff")
 			{
 				staff.POST("", staffH.AddStaff)
 				staff.GET("", staffH.ListStaff)
+				staff.GET("/invitations", staffH.ListInvitations)
+				staff.POST("/invitations/:invitation_id/resend", staffH.ResendInvitation)
+				staff.DELETE("/invitations/:invitation_id", staffH.RevokeInvitation)
 				staff.PATCH("/:staff_id", staffH.UpdateStaff)
 				staff.DELETE("/:staff_id", staffH.RemoveStaff)
+				staff.PUT("/:staff_id/role", staffH.AssignRole)
 			}
 
-			analytics := owner.Group("/my-restaurants/:restaurant_id/analytics")
+			roles := owner.Group("/my-restaurants/:restaurant_id/roles")
 			{
-				analytics.GET("/overview", analyticsH.GetOverview)
+				roles.GET("", staffH.ListRoles)
+				roles.POST("", staffH.CreateRole)
+				roles.PATCH("/:role_id", staffH.UpdateRole)
+				roles.DELETE("/:role_id", staffH.DeleteRole)
 			}
 
-			activity := owner.Group("/my-restaurants/:restaurant_id/activity")
+			owner.GET("/staff-permissions", staffH.ListPermissions)
+
+			apiKeys := owner.Group("/my-restaurants/:restaurant_id/api-keys")
 			{
-				activity.GET("", activityH.GetActivityLogs)
+				apiKeys.POST("", apiKeyH.CreateKey)
+				apiKeys.GET("", apiKeyH.ListKeys)
+				apiKeys.DELETE("/:key_id", apiKeyH.RevokeKey)
 			}
 
+			owner.GET("/api-key-permissions", apiKeyH.ListPermissions)
+
 			payment := owner.Group("/payment")
 			{
 				payment.POST("/initiate", paymentH.InitiatePayment)
+				payment.POST("/preview", paymentH.PreviewPayment)
 			}
 		}
 
+		// Restaurant Routes for owners and staff, checked per permission
+		can := authMiddleware.RequirePermission
+		restaurant := protected.Group("/my-restaurants/:restaurant_id")
+		{
+			restaurant.POST("/categories", can(models.PermissionMenuEdit), menuH.CreateCategory)
+			restaurant.GET("/categories", can(models.PermissionMenuView), menuH.ListCategories)
+			restaurant.PATCH("/categories/:category_id", can(models.PermissionMenuEdit), menuH.UpdateCategory)
+			restaurant.DELETE("/categories/:category_id", can(models.PermissionMenuEdit), menuH.DeleteCategory)
+
+			restaurant.POST("/categories/:category_id/items", can(models.PermissionMenuEdit), menuH.CreateItem)
+			restaurant.GET("/categories/:category_id/items", can(models.PermissionMenuView), menuH.ListItems)
+			restaurant.PATCH("/categories/:category_id/items/:item_id", can(models.PermissionMenuEdit), menuH.UpdateItem)
+			restaurant.DELETE("/categories/:category_id/items/:item_id", can(models.PermissionMenuEdit), menuH.DeleteItem)
+
+			restaurant.GET("/inventory/low-stock", can(models.PermissionMenuAvailability), menuH.ListLowStockItems)
+			restaurant.PUT("/items/:item_id/stock", can(models.PermissionMenuAvailability), menuH.UpdateStock)
+			restaurant.POST("/items/:item_id/stock/adjustments", can(models.PermissionMenuAvailability), menuH.AdjustStock)
+			restaurant.POST("/items/:item_id/eighty-six", can(models.PermissionMenuAvailability), menuH.EightySixItem)
+			restaurant.DELETE("/items/:item_id/eighty-six", can(models.PermissionMenuAvailability), menuH.RestoreItem)
+
+			restaurant.GET("/analytics/overview", can(models.PermissionAnalyticsView), analyticsH.GetOverview)
+			restaurant.GET("/service-requests/stats", can(models.PermissionAnalyticsView), serviceReqH.GetStats)
+			restaurant.GET("/activity", can(models.PermissionActivityView), activityH.GetActivityLogs)
+
+			restaurant.POST("/tables", can(models.PermissionTablesManage), serviceReqH.CreateTable)
+			restaurant.GET("/tables", can(models.PermissionTablesManage), serviceReqH.ListTables)
+			restaurant.DELETE("/tables/:table_id", can(models.PermissionTablesManage), serviceReqH.DeleteTable)
+
+			restaurant.GET("/service-requests", can(models.PermissionServiceRequestsHandle), serviceReqH.ListOpenRequests)
+			restaurant.PATCH("/service-requests/:request_id/acknowledge", can(models.PermissionServiceRequestsHandle), serviceReqH.AcknowledgeRequest)
+
+			restaurant.GET("/reservation-settings", can(models.PermissionReservationSettings), reservationH.GetSettings)
+			restaurant.PUT("/reservation-settings", can(models.PermissionReservationSettings), reservationH.UpdateSettings)
+			restaurant.GET("/reservations", can(models.PermissionReservationsManage), reservationH.ListReservations)
+			restaurant.PATCH("/reservations/:reservation_id/seat", can(models.PermissionReservationsManage), reservationH.SeatGuests)
+			restaurant.PATCH("/reservations/:reservation_id/no-show", can(models.PermissionReservationsManage), reservationH.MarkNoShow)
+
+			restaurant.GET("/reviews", can(models.PermissionReviewsView), reviewH.ListMyRestaurantReviews)
+			restaurant.POST("/reviews/:review_id/reply", can(models.PermissionReviewsReply), reviewH.ReplyToReview)
+
+			restaurant.POST("/promotions", can(models.PermissionPromotionsManage), promotionH.CreatePromotion)
+			restaurant.GET("/promotions", can(models.PermissionPromotionsManage), promotionH.ListPromotions)
+			restaurant.GET("/promotions/:promotion_id", can(models.PermissionPromotionsManage), promotionH.GetPromotion)
+			restaurant.PUT("/promotions/:promotion_id", can(models.PermissionPromotionsManage), promotionH.UpdatePromotion)
+			restaurant.DELETE("/promotions/:promotion_id", can(models.PermissionPromotionsManage), promotionH.DeletePromotion)
+		}
+
 		// Admin Routes
 		admin := protected.Group("/admin")
 		admin.Use(authMiddleware.RequireRole("admin"))
@@ -180,8 +320,38 @@ func InitRouter(
 			admin.GET("/restaurants/:restaurant_id", adminH.GetRestaurantDetails)
 
 			admin.PATCH("/users/:user_id/status", adminH.UpdateUserStatus)
+			admin.POST("/users/:user_id/unlock", adminH.UnlockUser)
+			admin.GET("/users/:user_id/sessions", adminH.ListUserSessions)
+
+			admin.GET("/settings/security", adminH.GetSecuritySettings)
+			admin.PUT("/settings/security", adminH.UpdateSecuritySettings)
+
+			admin.GET("/reviews/reported", reviewH.ListReportedReviews)
+			admin.PATCH("/reviews/:review_id/moderation", reviewH.ModerateReview)
+
+			admin.POST("/coupons", couponH.CreateCoupon)
+			admin.GET("/coupons", couponH.ListCoupons)
+			admin.GET("/coupons/:coupon_id", couponH.GetCoupon)
+			admin.PUT("/coupons/:coupon_id", couponH.UpdateCoupon)
+			admin.DELETE("/coupons/:coupon_id", couponH.DeactivateCoupon)
+			admin.GET("/coupons/:coupon_id/redemptions", couponH.ListRedemptions)
+
+			admin.POST("/invoices/:invoice_id/refund", refundH.RefundInvoice)
+			admin.GET("/invoices/:invoice_id/credit-notes", refundH.ListCreditNotes)
 		}
 	}
 
 	return r
 }
+
+// trustedProxies reads the comma separated IPs or CIDRs of the proxies in front of the
+// API. With none set, the client IP is the address of the connection itself.
+func trustedProxies() []string {
+	var proxies []string
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return proxies
+}
@@ -1,10 +1,13 @@
 package middleware
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"menuvista/internal/models"
 	"menuvista/internal/services/sms"
 	"menuvista/internal/utils"
+	"menuvista/platform/cache"
 	"net/http"
 	"strings"
 	"time"
@@ -14,17 +17,39 @@ import (
 	"github.com/google/uuid"
 )
 
+// PermissionResolver looks up the restaurant a staff member works at and the
+// permissions granted by their role, and whether an owner owns a restaurant.
+type PermissionResolver interface {
+	StaffPermissions(ctx context.Context, userID uuid.UUID) (uuid.UUID, []string, error)
+	OwnsRestaurant(ctx context.Context, ownerID, restaurantID uuid.UUID) (bool, error)
+}
+
+// APIKeyAuthenticator resolves an integration API key to the restaurant and
+// permissions it was issued for.
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, key, ipAddress string) (*models.APIKeyPrincipal, error)
+}
+
+// RoleAPIKey is the role set for requests authenticated with an API key
+const RoleAPIKey = "api_key"
+
 type AuthMiddleware struct {
-	jwtSecret  []byte
-	logger     *log.Logger
-	smsService *sms.Service
+	jwtSecret   []byte
+	logger      *log.Logger
+	smsService  *sms.Service
+	redis       *cache.RedisClient
+	permissions PermissionResolver
+	apiKeys     APIKeyAuthenticator
 }
 
-func NewAuthMiddleware(secret string, logger *log.Logger, smsService *sms.Service) *AuthMiddleware {
+func NewAuthMiddleware(secret string, logger *log.Logger, smsService *sms.Service, redis *cache.RedisClient, permissions PermissionResolver, apiKeys APIKeyAuthenticator) *AuthMiddleware {
 	return &AuthMiddleware{
-		jwtSecret:  []byte(secret),
-		logger:     logger,
-		smsService: smsService,
+		jwtSecret:   []byte(secret),
+		logger:      logger,
+		smsService:  smsService,
+		redis:       redis,
+		permissions: permissions,
+		apiKeys:     apiKeys,
 	}
 }
 
@@ -35,11 +60,18 @@ func NewAuthMiddleware(secret string, logger *log.Logger, smsService *sms.Servic
 		RestaurantID string `json:"restaurant_id,omitempty"`
 		SubStatus    string `json:"sub_status,omitempty"`
 		SubEnd       int64  `json:"sub_end,omitempty"`
+		AccessUUID   string `json:"access_uuid"`
+		FamilyID     string `json:"family_id,omitempty"`
 		jwt.RegisteredClaims
 	}
 
 func (am *AuthMiddleware) AuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if key := am.extractAPIKey(c); key != "" {
+			am.authenticateAPIKey(c, key)
+			return
+		}
+
 		tokenString, err := am.extractToken(c)
 		if err != nil {
 			am.logger.Printf("[AuthMiddleware] Token extraction failed: %v", err)
@@ -56,6 +88,33 @@ func (am *AuthMiddleware) AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		// Access tokens stay valid only while their UUID is in Redis; logout, logout-all
+		// and account deactivation delete it. Without Redis revocation cannot be checked,
+		// so no token is accepted.
+		if claims.AccessUUID == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired token"})
+			c.Abort()
+			return
+		}
+		if am.redis == nil {
+			am.logger.Printf("[AuthMiddleware] Token revocation check failed: Redis is not configured")
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "authentication temporarily unavailable"})
+			c.Abort()
+			return
+		}
+		active, err := am.redis.Exists(c.Request.Context(), claims.AccessUUID)
+		if err != nil {
+			am.logger.Printf("[AuthMiddleware] Token revocation check failed: %v", err)
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "authentication temporarily unavailable"})
+			c.Abort()
+			return
+		}
+		if !active {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "token has been revoked"})
+			c.Abort()
+			return
+		}
+
 		am.setContext(c, claims)
 		c.Next()
 	}
@@ -75,6 +134,45 @@ func (am *AuthMiddleware) extractToken(c *gin.Context) (string, error) {
 	return parts[1], nil
 }
 
+// extractAPIKey accepts a key in the X-API-Key header or as a Bearer token; keys are
+// told apart from JWTs by their prefix.
+func (am *AuthMiddleware) extractAPIKey(c *gin.Context) string {
+	if key := c.GetHeader("X-API-Key"); key != "" {
+		return key
+	}
+	if token, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); found && utils.IsAPIKey(token) {
+		return token
+	}
+	return ""
+}
+
+// authenticateAPIKey acts on behalf of the owner who issued the key. Only routes guarded
+// by RequirePermission accept the api_key role, so a key cannot reach account or owner routes.
+func (am *AuthMiddleware) authenticateAPIKey(c *gin.Context, key string) {
+	if am.apiKeys == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "API keys are not accepted"})
+		c.Abort()
+		return
+	}
+
+	principal, err := am.apiKeys.Authenticate(c.Request.Context(), key, c.ClientIP())
+	if err != nil {
+		am.logger.Printf("[AuthMiddleware] API key rejected: %v", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired API key"})
+		c.Abort()
+		return
+	}
+
+	c.Set("user_id", principal.OwnerID)
+	c.Set("email", "")
+	c.Set("role", RoleAPIKey)
+	c.Set("owner_id", principal.OwnerID)
+	c.Set("restaurant_id", principal.RestaurantID)
+	c.Set("api_key_id", principal.KeyID)
+	c.Set("api_key_permissions", principal.Permissions)
+	c.Next()
+}
+
 func (am *AuthMiddleware) parseAndValidateToken(tokenString string) (*AuthClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &AuthClaims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
@@ -141,6 +239,8 @@ func (am *AuthMiddleware) setContext(c *gin.Context, claims *AuthClaims) {
 		}
 	}
 
+	c.Set("access_uuid", claims.AccessUUID)
+	c.Set("token_family", claims.FamilyID)
 	c.Set("auth_claims", claims)
 }
 
@@ -173,3 +273,69 @@ func (am *AuthMiddleware) RequireRole(roles ...string) gin.HandlerFunc {
 		c.Abort()
 	}
 }
+
+// RequirePermission lets owners through to the restaurant in the :restaurant_id path when
+// they own it, and checks that staff and API keys belong to that restaurant and hold the
+// named permission.
+func (am *AuthMiddleware) RequirePermission(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, _ := c.Get("role")
+		userID, _ := c.Get("user_id")
+		id, ok := userID.(uuid.UUID)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+			c.Abort()
+			return
+		}
+
+		var restaurantID uuid.UUID
+		var permissions []string
+		switch role {
+		case "owner":
+			owns, err := am.permissions.OwnsRestaurant(c.Request.Context(), id, utils.ParseUUID(c.Param("restaurant_id")))
+			if err != nil || !owns {
+				log.Printf("[RequirePermission] Access denied: owner %v does not own restaurant %s (err: %v)", id, c.Param("restaurant_id"), err)
+				c.JSON(http.StatusForbidden, gin.H{"error": "You do not own this restaurant"})
+				c.Abort()
+				return
+			}
+			c.Next()
+			return
+		case "staff":
+			var err error
+			restaurantID, permissions, err = am.permissions.StaffPermissions(c.Request.Context(), id)
+			if err != nil {
+				log.Printf("[RequirePermission] Failed to resolve permissions for %v: %v", id, err)
+				c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to perform this action"})
+				c.Abort()
+				return
+			}
+		case RoleAPIKey:
+			restaurantID, _ = c.MustGet("restaurant_id").(uuid.UUID)
+			permissions, _ = c.MustGet("api_key_permissions").([]string)
+		default:
+			log.Printf("[RequirePermission] Access denied: user role %v, required permission %s", role, permission)
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to perform this action"})
+			c.Abort()
+			return
+		}
+
+		if restaurantID != utils.ParseUUID(c.Param("restaurant_id")) {
+			log.Printf("[RequirePermission] Access denied: %v %v is not assigned to restaurant %s", role, id, c.Param("restaurant_id"))
+			c.JSON(http.StatusForbidden, gin.H{"error": "You are not assigned to this restaurant"})
+			c.Abort()
+			return
+		}
+
+		for _, p := range permissions {
+			if p == permission {
+				c.Next()
+				return
+			}
+		}
+
+		log.Printf("[RequirePermission] Access denied: %v %v lacks %s", role, id, permission)
+		c.JSON(http.StatusForbidden, gin.H{"error": "Your role does not allow this action", "permission": permission})
+		c.Abort()
+	}
+}
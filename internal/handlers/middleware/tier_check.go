@@ -2,13 +2,12 @@ package middleware
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
-	"time"
 
 	"menuvista/internal/models"
+	"menuvista/internal/services/subscription"
 	"menuvista/internal/storage/persistence"
 	"menuvista/internal/utils"
 
@@ -71,38 +70,11 @@ func (tm *TierCheckMiddleware) CheckMenuLimit() gin.HandlerFunc {
 }
 
 func (tm *TierCheckMiddleware) getOwnerFeatures(ctx context.Context, ownerID uuid.UUID) (models.FeatureLimits, error) {
-	ownerIDStr := ownerID.String()
-	subRow, err := tm.queries.GetActiveSubscriptionByOwner(ctx, utils.ToUUID(&ownerIDStr))
+	features, err := subscription.EffectiveFeatures(ctx, tm.queries, ownerID)
 	if err != nil {
 		tm.logger.Printf("[TierCheck] Failed to get subscription: %v", err)
 		return models.FeatureLimits{}, fmt.Errorf("failed to check subscription")
 	}
-
-	// Check status and expiration
-	now := time.Now()
-	isActive := false
-
-	if string(subRow.Status) == string(models.SubscriptionStatusActive) {
-		if subRow.CurrentPeriodEnd.Time.After(now) {
-			isActive = true
-		}
-	} else if string(subRow.Status) == string(models.SubscriptionStatusTrialing) {
-		if subRow.TrialEnd.Valid && subRow.TrialEnd.Time.After(now) {
-			isActive = true
-		}
-	}
-
-	if !isActive {
-		return models.FeatureLimits{}, fmt.Errorf("subscription is inactive or expired")
-	}
-
-	var features models.FeatureLimits
-	if len(subRow.Features) > 0 {
-		if err := json.Unmarshal(subRow.Features, &features); err != nil {
-			tm.logger.Printf("[TierCheck] Failed to parse features: %v", err)
-			return models.FeatureLimits{}, fmt.Errorf("failed to parse subscription features")
-		}
-	}
 	return features, nil
 }
 
@@ -119,3 +119,68 @@ func (h *AdminHandler) UpdateUserStatus(c *gin.Context) {
 	}
 	RespondSuccess(c, http.StatusOK, user, nil)
 }
+
+func (h *AdminHandler) ListUserSessions(c *gin.Context) {
+	log.Printf("[AdminHandler] ListUserSessions request received")
+	userID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "Invalid user ID", "INVALID_ID")
+		return
+	}
+
+	sessions, err := h.service.ListUserSessions(c.Request.Context(), userID)
+	if err != nil {
+		log.Printf("[AdminHandler] ListUserSessions service error: %v", err)
+		RespondError(c, http.StatusInternalServerError, err.Error(), "INTERNAL_ERROR")
+		return
+	}
+	RespondSuccess(c, http.StatusOK, sessions, nil)
+}
+
+func (h *AdminHandler) UnlockUser(c *gin.Context) {
+	log.Printf("[AdminHandler] UnlockUser request received")
+	adminIDVal, _ := c.Get("user_id")
+
+	userID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "Invalid user ID", "INVALID_ID")
+		return
+	}
+
+	if err := h.service.UnlockUser(c.Request.Context(), userID, adminIDVal.(uuid.UUID)); err != nil {
+		log.Printf("[AdminHandler] UnlockUser service error: %v", err)
+		RespondError(c, http.StatusInternalServerError, err.Error(), "INTERNAL_ERROR")
+		return
+	}
+	RespondSuccess(c, http.StatusOK, gin.H{"message": "Account unlocked"}, nil)
+}
+
+func (h *AdminHandler) GetSecuritySettings(c *gin.Context) {
+	log.Printf("[AdminHandler] GetSecuritySettings request received")
+	settings, err := h.service.GetSecuritySettings(c.Request.Context())
+	if err != nil {
+		log.Printf("[AdminHandler] GetSecuritySettings service error: %v", err)
+		RespondError(c, http.StatusInternalServerError, err.Error(), "INTERNAL_ERROR")
+		return
+	}
+	RespondSuccess(c, http.StatusOK, settings, nil)
+}
+
+func (h *AdminHandler) UpdateSecuritySettings(c *gin.Context) {
+	log.Printf("[AdminHandler] UpdateSecuritySettings request received")
+	userIDVal, _ := c.Get("user_id")
+
+	var req models.UpdateSecuritySettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		return
+	}
+
+	settings, err := h.service.UpdateSecuritySettings(c.Request.Context(), userIDVal.(uuid.UUID), req)
+	if err != nil {
+		log.Printf("[AdminHandler] UpdateSecuritySettings service error: %v", err)
+		RespondError(c, http.StatusInternalServerError, err.Error(), "INTERNAL_ERROR")
+		return
+	}
+	RespondSuccess(c, http.StatusOK, settings, nil)
+}
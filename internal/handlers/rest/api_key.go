@@ -0,0 +1,127 @@
+package rest
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"menuvista/internal/models"
+	"menuvista/internal/services/apikey"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type APIKeyHandler struct {
+	service *apikey.Service
+}
+
+func NewAPIKeyHandler(service *apikey.Service) *APIKeyHandler {
+	return &APIKeyHandler{
+		service: service,
+	}
+}
+
+// CreateKey returns the full key once; only its prefix is shown afterwards
+func (h *APIKeyHandler) CreateKey(c *gin.Context) {
+	log.Printf("[APIKeyHandler] CreateKey request received")
+
+	userID, restaurantID, ok := h.parseContext(c)
+	if !ok {
+		return
+	}
+
+	var req models.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		return
+	}
+
+	key, err := h.service.CreateKey(c.Request.Context(), userID, restaurantID, req)
+	if err != nil {
+		h.respondServiceError(c, "CreateKey", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusCreated, key, nil)
+}
+
+func (h *APIKeyHandler) ListKeys(c *gin.Context) {
+	userID, restaurantID, ok := h.parseContext(c)
+	if !ok {
+		return
+	}
+
+	keys, err := h.service.ListKeys(c.Request.Context(), userID, restaurantID)
+	if err != nil {
+		h.respondServiceError(c, "ListKeys", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, keys, nil)
+}
+
+func (h *APIKeyHandler) RevokeKey(c *gin.Context) {
+	log.Printf("[APIKeyHandler] RevokeKey request received")
+
+	userID, restaurantID, ok := h.parseContext(c)
+	if !ok {
+		return
+	}
+
+	keyID, err := uuid.Parse(c.Param("key_id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "Invalid key ID", "INVALID_INPUT")
+		return
+	}
+
+	if err := h.service.RevokeKey(c.Request.Context(), userID, restaurantID, keyID); err != nil {
+		h.respondServiceError(c, "RevokeKey", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, gin.H{"message": "API key revoked"}, nil)
+}
+
+// ListPermissions returns the permissions that can be granted to a key
+func (h *APIKeyHandler) ListPermissions(c *gin.Context) {
+	var permissions []models.PermissionInfo
+	for _, p := range models.Permissions {
+		if models.IsValidAPIKeyPermission(p.Key) {
+			permissions = append(permissions, p)
+		}
+	}
+	RespondSuccess(c, http.StatusOK, permissions, nil)
+}
+
+func (h *APIKeyHandler) parseContext(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		RespondError(c, http.StatusUnauthorized, "Unauthorized", "UNAUTHORIZED")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	restaurantID, err := uuid.Parse(c.Param("restaurant_id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "Invalid restaurant ID", "INVALID_INPUT")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return userIDVal.(uuid.UUID), restaurantID, true
+}
+
+func (h *APIKeyHandler) respondServiceError(c *gin.Context, action string, err error) {
+	log.Printf("[APIKeyHandler] %s service error: %v", action, err)
+	switch {
+	case errors.Is(err, apikey.ErrRestaurantAccess):
+		RespondError(c, http.StatusForbidden, err.Error(), "FORBIDDEN")
+	case errors.Is(err, apikey.ErrKeyLimitReached):
+		RespondError(c, http.StatusForbidden, err.Error(), "LIMIT_REACHED")
+	case errors.Is(err, apikey.ErrKeyNotFound):
+		RespondError(c, http.StatusNotFound, err.Error(), "NOT_FOUND")
+	case errors.Is(err, apikey.ErrInvalidPermission):
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+	default:
+		RespondError(c, http.StatusInternalServerError, err.Error(), "INTERNAL_ERROR")
+	}
+}
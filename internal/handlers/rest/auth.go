@@ -1,9 +1,12 @@
 package rest
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"math"
 	"net/http"
+	"strconv"
 
 	"menuvista/internal/models"
 	"menuvista/internal/services/auth"
@@ -49,15 +52,35 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
+	input.Client = clientInfo(c)
 	response, err := h.service.Login(c.Request.Context(), input)
-	fmt.Println("this is the input", input)
 	fmt.Println("this is the response", response)
 	if err != nil {
+		var throttled *auth.ThrottledError
+		if errors.As(err, &throttled) {
+			h.respondThrottled(c, throttled)
+			return
+		}
 		if err == auth.ErrPaymentRequired {
 			log.Printf("[AuthHandler] Payment required for user: %s", input.Email)
 			RespondSuccess(c, http.StatusPaymentRequired, response, nil) // 402
 			return
 		}
+		if err == auth.ErrPasswordChangeRequired {
+			log.Printf("[AuthHandler] Password change required for user: %s", input.Email)
+			RespondSuccess(c, http.StatusForbidden, response, nil) // 403 with a reset token
+			return
+		}
+		if err == auth.ErrTwoFactorRequired {
+			log.Printf("[AuthHandler] Two-factor challenge issued for user: %s", input.Email)
+			RespondSuccess(c, http.StatusAccepted, response, nil) // 202, finish at /auth/login/2fa
+			return
+		}
+		if err == auth.ErrTwoFactorSetupRequired {
+			log.Printf("[AuthHandler] Two-factor setup required for user: %s", input.Email)
+			RespondSuccess(c, http.StatusForbidden, response, nil) // 403 with a setup token
+			return
+		}
 		if err == auth.ErrSubscriptionInactive {
 			log.Printf("[AuthHandler] Subscription inactive for user: %s", input.Email)
 			RespondError(c, http.StatusForbidden, "Restaurant subscription is inactive. Please contact the owner.", "SUBSCRIPTION_INACTIVE") // 403
@@ -73,6 +96,158 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	RespondSuccess(c, http.StatusOK, response, nil)
 }
 
+func (h *AuthHandler) RefreshToken(c *gin.Context) {
+	log.Printf("[AuthHandler] RefreshToken request received")
+	var input models.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		return
+	}
+
+	response, err := h.service.RefreshTokens(c.Request.Context(), input.RefreshToken, clientInfo(c))
+	if err != nil {
+		log.Printf("[AuthHandler] RefreshToken service error: %v", err)
+		switch {
+		case errors.Is(err, auth.ErrRefreshTokenReused):
+			RespondError(c, http.StatusUnauthorized, err.Error(), "REFRESH_TOKEN_REUSED")
+		case errors.Is(err, auth.ErrInvalidRefreshToken):
+			RespondError(c, http.StatusUnauthorized, err.Error(), "INVALID_REFRESH_TOKEN")
+		default:
+			RespondError(c, http.StatusInternalServerError, err.Error(), "INTERNAL_ERROR")
+		}
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, response, nil)
+}
+
+func (h *AuthHandler) Logout(c *gin.Context) {
+	log.Printf("[AuthHandler] Logout request received")
+	userIDVal, _ := c.Get("user_id")
+	familyID := c.GetString("token_family")
+
+	if err := h.service.Logout(c.Request.Context(), userIDVal.(uuid.UUID), familyID); err != nil {
+		log.Printf("[AuthHandler] Logout service error: %v", err)
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, gin.H{"message": "Logged out successfully"}, nil)
+}
+
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	log.Printf("[AuthHandler] LogoutAll request received")
+	userIDVal, _ := c.Get("user_id")
+
+	if err := h.service.RevokeAllSessions(c.Request.Context(), userIDVal.(uuid.UUID)); err != nil {
+		log.Printf("[AuthHandler] LogoutAll service error: %v", err)
+		RespondError(c, http.StatusInternalServerError, err.Error(), "INTERNAL_ERROR")
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, gin.H{"message": "Logged out from all devices"}, nil)
+}
+
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	log.Printf("[AuthHandler] ForgotPassword request received")
+	var input models.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		return
+	}
+
+	if err := h.service.ForgotPassword(c.Request.Context(), input.Email); err != nil {
+		log.Printf("[AuthHandler] ForgotPassword service error: %v", err)
+		RespondError(c, http.StatusInternalServerError, err.Error(), "INTERNAL_ERROR")
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, gin.H{"message": "If an account exists for this email, a password reset link has been sent"}, nil)
+}
+
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	log.Printf("[AuthHandler] ResetPassword request received")
+	var input models.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		return
+	}
+
+	if err := h.service.ResetPassword(c.Request.Context(), input.Token, input.NewPassword); err != nil {
+		log.Printf("[AuthHandler] ResetPassword service error: %v", err)
+		if errors.Is(err, auth.ErrInvalidResetToken) {
+			RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_RESET_TOKEN")
+			return
+		}
+		RespondError(c, http.StatusInternalServerError, err.Error(), "INTERNAL_ERROR")
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, gin.H{"message": "Password has been reset. Please log in with your new password"}, nil)
+}
+
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	log.Printf("[AuthHandler] ChangePassword request received")
+	userIDVal, _ := c.Get("user_id")
+	familyID := c.GetString("token_family")
+
+	var input models.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		return
+	}
+
+	err := h.service.ChangePassword(c.Request.Context(), userIDVal.(uuid.UUID), familyID, input.CurrentPassword, input.NewPassword)
+	if err != nil {
+		log.Printf("[AuthHandler] ChangePassword service error: %v", err)
+		if errors.Is(err, auth.ErrIncorrectPassword) {
+			RespondError(c, http.StatusBadRequest, err.Error(), "INCORRECT_PASSWORD")
+			return
+		}
+		RespondError(c, http.StatusInternalServerError, err.Error(), "INTERNAL_ERROR")
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, gin.H{"message": "Password changed successfully"}, nil)
+}
+
+func (h *AuthHandler) ListSessions(c *gin.Context) {
+	log.Printf("[AuthHandler] ListSessions request received")
+	userIDVal, _ := c.Get("user_id")
+
+	sessions, err := h.service.ListSessions(c.Request.Context(), userIDVal.(uuid.UUID), c.GetString("token_family"))
+	if err != nil {
+		log.Printf("[AuthHandler] ListSessions service error: %v", err)
+		RespondError(c, http.StatusInternalServerError, err.Error(), "INTERNAL_ERROR")
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, sessions, nil)
+}
+
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	log.Printf("[AuthHandler] RevokeSession request received")
+	userIDVal, _ := c.Get("user_id")
+
+	sessionID, err := uuid.Parse(c.Param("session_id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "Invalid session ID", "INVALID_INPUT")
+		return
+	}
+
+	if err := h.service.RevokeSession(c.Request.Context(), userIDVal.(uuid.UUID), sessionID); err != nil {
+		log.Printf("[AuthHandler] RevokeSession service error: %v", err)
+		if errors.Is(err, auth.ErrSessionNotFound) {
+			RespondError(c, http.StatusNotFound, err.Error(), "NOT_FOUND")
+			return
+		}
+		RespondError(c, http.StatusInternalServerError, err.Error(), "INTERNAL_ERROR")
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, gin.H{"message": "Session revoked"}, nil)
+}
+
 func (h *AuthHandler) GetProfile(c *gin.Context) {
 	log.Printf("[AuthHandler] GetProfile request received")
 	userIDVal, _ := c.Get("user_id")
@@ -96,7 +271,7 @@ func (h *AuthHandler) ActivateAccount(c *gin.Context) {
 		return
 	}
 
-	response, err := h.service.ActivateUser(c.Request.Context(), token)
+	response, err := h.service.ActivateUser(c.Request.Context(), token, clientInfo(c))
 	if err != nil {
 		log.Printf("[AuthHandler] Activation failed: %v", err)
 		RespondError(c, http.StatusBadRequest, err.Error(), "ACTIVATION_FAILED")
@@ -127,3 +302,190 @@ func (h *AuthHandler) UpdateProfile(c *gin.Context) {
 
 	RespondSuccess(c, http.StatusOK, user, nil)
 }
+
+func (h *AuthHandler) VerifyTwoFactorLogin(c *gin.Context) {
+	log.Printf("[AuthHandler] VerifyTwoFactorLogin request received")
+	var input models.TwoFactorLoginRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		return
+	}
+
+	response, err := h.service.VerifyTwoFactorLogin(c.Request.Context(), input.ChallengeToken, input.Code, clientInfo(c))
+	if err != nil {
+		h.respondLoginError(c, "VerifyTwoFactorLogin", response, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, response, nil)
+}
+
+func (h *AuthHandler) BeginRequiredTwoFactorSetup(c *gin.Context) {
+	log.Printf("[AuthHandler] BeginRequiredTwoFactorSetup request received")
+	var input models.TwoFactorChallengeRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		return
+	}
+
+	setup, err := h.service.BeginRequiredTwoFactorSetup(c.Request.Context(), input.ChallengeToken)
+	if err != nil {
+		h.respondTwoFactorError(c, "BeginRequiredTwoFactorSetup", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, setup, nil)
+}
+
+func (h *AuthHandler) CompleteRequiredTwoFactorSetup(c *gin.Context) {
+	log.Printf("[AuthHandler] CompleteRequiredTwoFactorSetup request received")
+	var input models.TwoFactorLoginRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		return
+	}
+
+	response, err := h.service.CompleteRequiredTwoFactorSetup(c.Request.Context(), input.ChallengeToken, input.Code, clientInfo(c))
+	if err != nil {
+		h.respondLoginError(c, "CompleteRequiredTwoFactorSetup", response, err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, response, nil)
+}
+
+func (h *AuthHandler) GetTwoFactorStatus(c *gin.Context) {
+	log.Printf("[AuthHandler] GetTwoFactorStatus request received")
+	userIDVal, _ := c.Get("user_id")
+
+	status, err := h.service.GetTwoFactorStatus(c.Request.Context(), userIDVal.(uuid.UUID))
+	if err != nil {
+		h.respondTwoFactorError(c, "GetTwoFactorStatus", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, status, nil)
+}
+
+func (h *AuthHandler) BeginTwoFactorSetup(c *gin.Context) {
+	log.Printf("[AuthHandler] BeginTwoFactorSetup request received")
+	userIDVal, _ := c.Get("user_id")
+
+	setup, err := h.service.BeginTwoFactorSetup(c.Request.Context(), userIDVal.(uuid.UUID))
+	if err != nil {
+		h.respondTwoFactorError(c, "BeginTwoFactorSetup", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, setup, nil)
+}
+
+func (h *AuthHandler) EnableTwoFactor(c *gin.Context) {
+	log.Printf("[AuthHandler] EnableTwoFactor request received")
+	userIDVal, _ := c.Get("user_id")
+
+	var input models.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		return
+	}
+
+	codes, err := h.service.EnableTwoFactor(c.Request.Context(), userIDVal.(uuid.UUID), input.Code)
+	if err != nil {
+		h.respondTwoFactorError(c, "EnableTwoFactor", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, models.RecoveryCodesResponse{RecoveryCodes: codes}, nil)
+}
+
+func (h *AuthHandler) DisableTwoFactor(c *gin.Context) {
+	log.Printf("[AuthHandler] DisableTwoFactor request received")
+	userIDVal, _ := c.Get("user_id")
+
+	var input models.DisableTwoFactorRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		return
+	}
+
+	if err := h.service.DisableTwoFactor(c.Request.Context(), userIDVal.(uuid.UUID), input.Password, input.Code); err != nil {
+		h.respondTwoFactorError(c, "DisableTwoFactor", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, gin.H{"message": "Two-factor authentication disabled"}, nil)
+}
+
+func (h *AuthHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	log.Printf("[AuthHandler] RegenerateRecoveryCodes request received")
+	userIDVal, _ := c.Get("user_id")
+
+	var input models.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		return
+	}
+
+	codes, err := h.service.RegenerateRecoveryCodes(c.Request.Context(), userIDVal.(uuid.UUID), input.Code)
+	if err != nil {
+		h.respondTwoFactorError(c, "RegenerateRecoveryCodes", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, models.RecoveryCodesResponse{RecoveryCodes: codes}, nil)
+}
+
+// respondLoginError handles errors from the steps that finish a login, which can still
+// end in the same payment and subscription outcomes as a password login.
+func (h *AuthHandler) respondLoginError(c *gin.Context, action string, response *models.AuthResponse, err error) {
+	var throttled *auth.ThrottledError
+	switch {
+	case errors.As(err, &throttled):
+		h.respondThrottled(c, throttled)
+	case errors.Is(err, auth.ErrPaymentRequired):
+		RespondSuccess(c, http.StatusPaymentRequired, response, nil)
+	case errors.Is(err, auth.ErrSubscriptionInactive):
+		RespondError(c, http.StatusForbidden, "Restaurant subscription is inactive. Please contact the owner.", "SUBSCRIPTION_INACTIVE")
+	default:
+		h.respondTwoFactorError(c, action, err)
+	}
+}
+
+func (h *AuthHandler) respondTwoFactorError(c *gin.Context, action string, err error) {
+	log.Printf("[AuthHandler] %s service error: %v", action, err)
+	switch {
+	case errors.Is(err, auth.ErrInvalidChallengeToken):
+		RespondError(c, http.StatusUnauthorized, err.Error(), "INVALID_CHALLENGE")
+	case errors.Is(err, auth.ErrInvalidTwoFactorCode):
+		RespondError(c, http.StatusUnauthorized, err.Error(), "INVALID_TWO_FACTOR_CODE")
+	case errors.Is(err, auth.ErrIncorrectPassword):
+		RespondError(c, http.StatusBadRequest, err.Error(), "INCORRECT_PASSWORD")
+	case errors.Is(err, auth.ErrTwoFactorNotAllowed),
+		errors.Is(err, auth.ErrTwoFactorMandatory):
+		RespondError(c, http.StatusForbidden, err.Error(), "FORBIDDEN")
+	case errors.Is(err, auth.ErrTwoFactorAlreadyEnabled),
+		errors.Is(err, auth.ErrTwoFactorNotEnabled),
+		errors.Is(err, auth.ErrTwoFactorSetupNotFound):
+		RespondError(c, http.StatusConflict, err.Error(), "CONFLICT")
+	default:
+		RespondError(c, http.StatusInternalServerError, err.Error(), "INTERNAL_ERROR")
+	}
+}
+
+func (h *AuthHandler) respondThrottled(c *gin.Context, err *auth.ThrottledError) {
+	log.Printf("[AuthHandler] Login throttled: %v", err)
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(err.RetryAfter.Seconds()))))
+	if err.Locked {
+		RespondError(c, http.StatusTooManyRequests, err.Error(), "ACCOUNT_LOCKED")
+		return
+	}
+	RespondError(c, http.StatusTooManyRequests, err.Error(), "TOO_MANY_ATTEMPTS")
+}
+
+func clientInfo(c *gin.Context) models.ClientInfo {
+	return models.ClientInfo{
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+}
@@ -0,0 +1,153 @@
+package rest
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"menuvista/internal/models"
+	"menuvista/internal/services/coupon"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type CouponHandler struct {
+	service *coupon.Service
+}
+
+func NewCouponHandler(service *coupon.Service) *CouponHandler {
+	return &CouponHandler{
+		service: service,
+	}
+}
+
+func (h *CouponHandler) CreateCoupon(c *gin.Context) {
+	log.Printf("[CouponHandler] CreateCoupon request received")
+
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		RespondError(c, http.StatusUnauthorized, "Unauthorized", "UNAUTHORIZED")
+		return
+	}
+
+	var req models.CreateCouponRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		return
+	}
+
+	result, err := h.service.CreateCoupon(c.Request.Context(), userIDVal.(uuid.UUID), req)
+	if err != nil {
+		h.respondServiceError(c, "CreateCoupon", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusCreated, result, nil)
+}
+
+func (h *CouponHandler) ListCoupons(c *gin.Context) {
+	log.Printf("[CouponHandler] ListCoupons request received")
+
+	results, err := h.service.ListCoupons(c.Request.Context())
+	if err != nil {
+		h.respondServiceError(c, "ListCoupons", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, results, nil)
+}
+
+func (h *CouponHandler) GetCoupon(c *gin.Context) {
+	log.Printf("[CouponHandler] GetCoupon request received")
+
+	couponID, ok := parseCouponID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.service.GetCoupon(c.Request.Context(), couponID)
+	if err != nil {
+		h.respondServiceError(c, "GetCoupon", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, result, nil)
+}
+
+func (h *CouponHandler) UpdateCoupon(c *gin.Context) {
+	log.Printf("[CouponHandler] UpdateCoupon request received")
+
+	couponID, ok := parseCouponID(c)
+	if !ok {
+		return
+	}
+
+	var req models.CreateCouponRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		return
+	}
+
+	result, err := h.service.UpdateCoupon(c.Request.Context(), couponID, req)
+	if err != nil {
+		h.respondServiceError(c, "UpdateCoupon", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, result, nil)
+}
+
+func (h *CouponHandler) DeactivateCoupon(c *gin.Context) {
+	log.Printf("[CouponHandler] DeactivateCoupon request received")
+
+	couponID, ok := parseCouponID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeactivateCoupon(c.Request.Context(), couponID); err != nil {
+		h.respondServiceError(c, "DeactivateCoupon", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, gin.H{"message": "Coupon deactivated successfully"}, nil)
+}
+
+func (h *CouponHandler) ListRedemptions(c *gin.Context) {
+	log.Printf("[CouponHandler] ListRedemptions request received")
+
+	couponID, ok := parseCouponID(c)
+	if !ok {
+		return
+	}
+
+	results, err := h.service.ListRedemptions(c.Request.Context(), couponID)
+	if err != nil {
+		h.respondServiceError(c, "ListRedemptions", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, results, nil)
+}
+
+func parseCouponID(c *gin.Context) (uuid.UUID, bool) {
+	couponID, err := uuid.Parse(c.Param("coupon_id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "Invalid coupon ID", "INVALID_INPUT")
+		return uuid.Nil, false
+	}
+	return couponID, true
+}
+
+func (h *CouponHandler) respondServiceError(c *gin.Context, action string, err error) {
+	log.Printf("[CouponHandler] %s service error: %v", action, err)
+	switch {
+	case errors.Is(err, coupon.ErrCouponNotFound):
+		RespondError(c, http.StatusNotFound, err.Error(), "NOT_FOUND")
+	case errors.Is(err, coupon.ErrCodeTaken):
+		RespondError(c, http.StatusConflict, err.Error(), "CONFLICT")
+	default:
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+	}
+}
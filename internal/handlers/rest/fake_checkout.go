@@ -0,0 +1,78 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"menuvista/internal/services/payment"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FakeCheckoutHandler serves the local fake provider's checkout page. It is only routed
+// when PAYMENT_PROVIDER=fake.
+type FakeCheckoutHandler struct {
+	provider *payment.FakeProvider
+	webhook  *payment.WebhookService
+}
+
+func NewFakeCheckoutHandler(provider *payment.FakeProvider, webhook *payment.WebhookService) *FakeCheckoutHandler {
+	return &FakeCheckoutHandler{provider: provider, webhook: webhook}
+}
+
+func (h *FakeCheckoutHandler) Checkout(c *gin.Context) {
+	session, err := h.provider.Checkout(c.Param("tx_ref"))
+	if err != nil {
+		c.HTML(http.StatusNotFound, "payment_error.html", gin.H{
+			"error": "Checkout session not found. Sessions are lost when the server restarts.",
+		})
+		return
+	}
+
+	c.HTML(http.StatusOK, "payment_fake_checkout.html", gin.H{
+		"tx_ref":      session.TxRef,
+		"title":       session.Title,
+		"description": session.Description,
+		"amount":      fmt.Sprintf("%.2f", session.Amount),
+		"currency":    session.Currency,
+		"email":       session.Email,
+		"status":      session.Status,
+	})
+}
+
+// Submit settles the session with the outcome picked on the page. The signed webhook is
+// delivered in-process, then the customer returns to the success page like from a gateway.
+func (h *FakeCheckoutHandler) Submit(c *gin.Context) {
+	txRef := c.Param("tx_ref")
+	outcome := c.PostForm("outcome")
+	log.Printf("[FakeCheckoutHandler] Checkout %s submitted with outcome %q", txRef, outcome)
+
+	if outcome == "cancel" {
+		c.Redirect(http.StatusSeeOther, "/payment/cancel?trx_ref="+txRef)
+		return
+	}
+	if outcome != "success" && outcome != "failed" {
+		c.HTML(http.StatusBadRequest, "payment_error.html", gin.H{"error": "Unknown checkout outcome."})
+		return
+	}
+
+	body, header, err := h.provider.Resolve(txRef, outcome == "success")
+	switch {
+	case errors.Is(err, payment.ErrFakeCheckoutNotFound):
+		c.HTML(http.StatusNotFound, "payment_error.html", gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, payment.ErrFakeCheckoutResolved):
+		// Resubmitted form: show the result of the first submission
+	case err != nil:
+		c.HTML(http.StatusInternalServerError, "payment_error.html", gin.H{"error": err.Error()})
+		return
+	default:
+		if err := h.webhook.ProcessWebhook(c.Request.Context(), body, header); err != nil {
+			log.Printf("[FakeCheckoutHandler] Webhook processing failed for %s: %v", txRef, err)
+		}
+	}
+
+	c.Redirect(http.StatusSeeOther, h.provider.ReturnURL(txRef))
+}
@@ -0,0 +1,153 @@
+package rest
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"menuvista/internal/models"
+	"menuvista/internal/services/menu"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Inventory
+
+func (h *MenuHandler) UpdateStock(c *gin.Context) {
+	log.Printf("[MenuHandler] UpdateStock request received")
+
+	userID, restaurantID, itemID, ok := h.parseItemContext(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateStockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		return
+	}
+
+	result, err := h.service.UpdateStock(c.Request.Context(), userID, restaurantID, itemID, req)
+	if err != nil {
+		h.respondInventoryError(c, "UpdateStock", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, result, nil)
+}
+
+func (h *MenuHandler) AdjustStock(c *gin.Context) {
+	log.Printf("[MenuHandler] AdjustStock request received")
+
+	userID, restaurantID, itemID, ok := h.parseItemContext(c)
+	if !ok {
+		return
+	}
+
+	var req models.AdjustStockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		return
+	}
+
+	result, err := h.service.AdjustStock(c.Request.Context(), userID, restaurantID, itemID, req)
+	if err != nil {
+		h.respondInventoryError(c, "AdjustStock", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, result, nil)
+}
+
+func (h *MenuHandler) EightySixItem(c *gin.Context) {
+	log.Printf("[MenuHandler] EightySixItem request received")
+
+	userID, restaurantID, itemID, ok := h.parseItemContext(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.service.EightySixItem(c.Request.Context(), userID, restaurantID, itemID)
+	if err != nil {
+		h.respondInventoryError(c, "EightySixItem", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, result, nil)
+}
+
+func (h *MenuHandler) RestoreItem(c *gin.Context) {
+	log.Printf("[MenuHandler] RestoreItem request received")
+
+	userID, restaurantID, itemID, ok := h.parseItemContext(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.service.RestoreItem(c.Request.Context(), userID, restaurantID, itemID)
+	if err != nil {
+		h.respondInventoryError(c, "RestoreItem", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, result, nil)
+}
+
+func (h *MenuHandler) ListLowStockItems(c *gin.Context) {
+	log.Printf("[MenuHandler] ListLowStockItems request received")
+
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		RespondError(c, http.StatusUnauthorized, errUnauthorized, "UNAUTHORIZED")
+		return
+	}
+
+	restaurantID, err := uuid.Parse(c.Param("restaurant_id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "Invalid restaurant ID", "INVALID_INPUT")
+		return
+	}
+
+	items, err := h.service.ListLowStockItems(c.Request.Context(), userIDVal.(uuid.UUID), restaurantID)
+	if err != nil {
+		h.respondInventoryError(c, "ListLowStockItems", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, items, nil)
+}
+
+func (h *MenuHandler) parseItemContext(c *gin.Context) (uuid.UUID, uuid.UUID, uuid.UUID, bool) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		RespondError(c, http.StatusUnauthorized, errUnauthorized, "UNAUTHORIZED")
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+
+	restaurantID, err := uuid.Parse(c.Param("restaurant_id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "Invalid restaurant ID", "INVALID_INPUT")
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+
+	itemID, err := uuid.Parse(c.Param("item_id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, errInvalidItemID, "INVALID_INPUT")
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+
+	return userIDVal.(uuid.UUID), restaurantID, itemID, true
+}
+
+func (h *MenuHandler) respondInventoryError(c *gin.Context, action string, err error) {
+	log.Printf("[MenuHandler] %s service error: %v", action, err)
+	switch {
+	case errors.Is(err, menu.ErrMenuItemNotFound):
+		RespondError(c, http.StatusNotFound, err.Error(), "NOT_FOUND")
+	case errors.Is(err, menu.ErrStockNotTracked):
+		RespondError(c, http.StatusBadRequest, err.Error(), "STOCK_NOT_TRACKED")
+	default:
+		RespondError(c, http.StatusInternalServerError, err.Error(), "INTERNAL_ERROR")
+	}
+}
@@ -0,0 +1,90 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"menuvista/internal/services/invoice"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type InvoiceHandler struct {
+	service *invoice.Service
+}
+
+func NewInvoiceHandler(service *invoice.Service) *InvoiceHandler {
+	return &InvoiceHandler{
+		service: service,
+	}
+}
+
+// ListInvoices returns the owner's invoices. Supports status and subscription_id filters
+// and page/page_size pagination.
+func (h *InvoiceHandler) ListInvoices(c *gin.Context) {
+	log.Printf("[InvoiceHandler] ListInvoices request received")
+
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		RespondError(c, http.StatusUnauthorized, "Unauthorized", "UNAUTHORIZED")
+		return
+	}
+
+	filters, err := ParseFilterParams(c, "invoices")
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		return
+	}
+	pagination := ParsePaginationParams(c)
+
+	results, meta, err := h.service.ListInvoices(c.Request.Context(), userIDVal.(uuid.UUID), filters, pagination)
+	if err != nil {
+		h.respondServiceError(c, "ListInvoices", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, results, meta)
+}
+
+// DownloadInvoicePDF sends a paid invoice as a PDF document
+func (h *InvoiceHandler) DownloadInvoicePDF(c *gin.Context) {
+	log.Printf("[InvoiceHandler] DownloadInvoicePDF request received")
+
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		RespondError(c, http.StatusUnauthorized, "Unauthorized", "UNAUTHORIZED")
+		return
+	}
+
+	invoiceID, err := uuid.Parse(c.Param("invoice_id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "Invalid invoice ID", "INVALID_INPUT")
+		return
+	}
+
+	document, filename, err := h.service.GetInvoicePDF(c.Request.Context(), userIDVal.(uuid.UUID), invoiceID)
+	if err != nil {
+		h.respondServiceError(c, "DownloadInvoicePDF", err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Data(http.StatusOK, "application/pdf", document)
+}
+
+func (h *InvoiceHandler) respondServiceError(c *gin.Context, action string, err error) {
+	log.Printf("[InvoiceHandler] %s service error: %v", action, err)
+	switch {
+	case errors.Is(err, invoice.ErrInvoiceNotFound):
+		RespondError(c, http.StatusNotFound, err.Error(), "NOT_FOUND")
+	case errors.Is(err, invoice.ErrInvoiceNotIssued):
+		RespondError(c, http.StatusConflict, err.Error(), "CONFLICT")
+	case errors.Is(err, invoice.ErrInvalidStatus):
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+	default:
+		RespondError(c, http.StatusInternalServerError, err.Error(), "INTERNAL_ERROR")
+	}
+}
@@ -1,6 +1,7 @@
 package rest
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -123,6 +124,10 @@ func (h *MenuHandler) UpdateCategory(c *gin.Context) {
 	userID := userIDVal.(uuid.UUID)
 
 	result, err := h.service.UpdateCategory(c.Request.Context(), userID, categoryID, req)
+	if errors.Is(err, menu.ErrPlanLocked) {
+		RespondError(c, http.StatusForbidden, "This is hidden because it is above your plan's limits. Upgrade your plan to enable it again.", "LIMIT_REACHED")
+		return
+	}
 	if err != nil {
 		log.Printf("[MenuHandler] UpdateCategory service error: %v", err)
 		RespondError(c, http.StatusInternalServerError, err.Error(), "INTERNAL_ERROR")
@@ -219,6 +224,10 @@ func (h *MenuHandler) UpdateItem(c *gin.Context) {
 	userID := userIDVal.(uuid.UUID)
 
 	result, err := h.service.UpdateMenuItem(c.Request.Context(), userID, itemID, input)
+	if errors.Is(err, menu.ErrPlanLocked) {
+		RespondError(c, http.StatusForbidden, "This is hidden because it is above your plan's limits. Upgrade your plan to enable it again.", "LIMIT_REACHED")
+		return
+	}
 	if err != nil {
 		log.Printf("[MenuHandler] UpdateItem service error: %v", err)
 		RespondError(c, http.StatusInternalServerError, err.Error(), "INTERNAL_ERROR")
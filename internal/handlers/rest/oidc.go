@@ -0,0 +1,95 @@
+package rest
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"menuvista/internal/models"
+	"menuvista/internal/services/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+// oidcStateCookie ties a sign-in to the browser that started it
+const oidcStateCookie = "oidc_state"
+
+func (h *AuthHandler) ListOIDCProviders(c *gin.Context) {
+	RespondSuccess(c, http.StatusOK, gin.H{"providers": h.service.OIDCProviders()}, nil)
+}
+
+// BeginOIDCLogin returns the provider URL the client should redirect the user to, and
+// sets the state in an HttpOnly cookie that the callback must present
+func (h *AuthHandler) BeginOIDCLogin(c *gin.Context) {
+	log.Printf("[AuthHandler] BeginOIDCLogin request received")
+	authorization, err := h.service.BeginOIDCLogin(c.Request.Context(), c.Param("provider"))
+	if err != nil {
+		h.respondOIDCError(c, "BeginOIDCLogin", nil, err)
+		return
+	}
+
+	setOIDCStateCookie(c, authorization.State, auth.OIDCStateTTL)
+
+	RespondSuccess(c, http.StatusOK, authorization, nil)
+}
+
+// CompleteOIDCLogin takes the code and state the provider sent back to the frontend
+// callback page. It ends like a password login, including the 2FA and payment outcomes.
+func (h *AuthHandler) CompleteOIDCLogin(c *gin.Context) {
+	log.Printf("[AuthHandler] CompleteOIDCLogin request received")
+	var input models.OIDCCallbackRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		return
+	}
+
+	input.Client = clientInfo(c)
+	input.BrowserState, _ = c.Cookie(oidcStateCookie)
+	// The state is single use, so the cookie is cleared whatever the outcome
+	setOIDCStateCookie(c, "", -1)
+
+	response, err := h.service.CompleteOIDCLogin(c.Request.Context(), c.Param("provider"), input)
+	if err != nil {
+		h.respondOIDCError(c, "CompleteOIDCLogin", response, err)
+		return
+	}
+
+	log.Printf("[AuthHandler] User logged in with %s: %v", c.Param("provider"), response.User.ID)
+	RespondSuccess(c, http.StatusOK, response, nil)
+}
+
+// setOIDCStateCookie scopes the cookie to the OIDC routes. SameSite=None lets the frontend
+// send it when it is served from another site than the API.
+func setOIDCStateCookie(c *gin.Context, state string, maxAge int) {
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    state,
+		Path:     "/api/v1/auth/oidc",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteNoneMode,
+	})
+}
+
+func (h *AuthHandler) respondOIDCError(c *gin.Context, action string, response *models.AuthResponse, err error) {
+	switch {
+	case errors.Is(err, auth.ErrOIDCProviderNotFound):
+		RespondError(c, http.StatusNotFound, err.Error(), "NOT_FOUND")
+	case errors.Is(err, auth.ErrOIDCInvalidState):
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_STATE")
+	case errors.Is(err, auth.ErrOIDCEmailNotVerified):
+		RespondError(c, http.StatusForbidden, err.Error(), "EMAIL_NOT_VERIFIED")
+	case errors.Is(err, auth.ErrAccountDisabled):
+		RespondError(c, http.StatusForbidden, err.Error(), "ACCOUNT_DISABLED")
+	case errors.Is(err, auth.ErrOIDCLoginFailed):
+		log.Printf("[AuthHandler] %s service error: %v", action, err)
+		RespondError(c, http.StatusBadGateway, err.Error(), "PROVIDER_ERROR")
+	case errors.Is(err, auth.ErrTwoFactorRequired):
+		RespondSuccess(c, http.StatusAccepted, response, nil) // 202, finish at /auth/login/2fa
+	case errors.Is(err, auth.ErrTwoFactorSetupRequired):
+		RespondSuccess(c, http.StatusForbidden, response, nil) // 403 with a setup token
+	default:
+		h.respondLoginError(c, action, response, err)
+	}
+}
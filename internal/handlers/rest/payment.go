@@ -1,10 +1,12 @@
 package rest
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 
+	"menuvista/internal/services/coupon"
 	"menuvista/internal/services/payment"
 
 	"github.com/gin-gonic/gin"
@@ -35,8 +37,10 @@ func (h *PaymentHandler) InitiatePayment(c *gin.Context) {
 	}
 
 	var req struct {
-		Plan string `json:"plan"`
-		Type string `json:"type"` // update, upgrade
+		Plan            string `json:"plan"`
+		Type            string `json:"type"`             // update, upgrade
+		BillingInterval string `json:"billing_interval"` // monthly (default) or annual
+		CouponCode      string `json:"coupon_code"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
@@ -48,15 +52,21 @@ func (h *PaymentHandler) InitiatePayment(c *gin.Context) {
 	// }
 
 	input := payment.InitiatePaymentInput{
-		OwnerID: userID,
-		Plan:    req.Plan,
-		Type:    req.Type,
+		OwnerID:         userID,
+		Plan:            req.Plan,
+		Type:            req.Type,
+		BillingInterval: req.BillingInterval,
+		CouponCode:      req.CouponCode,
 	}
 	fmt.Println("this is the payment input", input)
 
 	resp, err := h.service.InitiatePayment(c.Request.Context(), input)
 	if err != nil {
 		log.Printf("[PaymentHandler] InitiatePayment service error: %v", err)
+		if errors.Is(err, payment.ErrInvalidBillingInterval) || errors.Is(err, payment.ErrAnnualNotAvailable) || isCouponError(err) {
+			RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+			return
+		}
 		RespondError(c, http.StatusInternalServerError, err.Error(), "INTERNAL_ERROR")
 		return
 	}
@@ -64,6 +74,51 @@ func (h *PaymentHandler) InitiatePayment(c *gin.Context) {
 	RespondSuccess(c, http.StatusOK, resp, nil)
 }
 
+// PreviewPayment prices a plan change without creating anything, so the owner can review
+// the proration before being sent to checkout.
+func (h *PaymentHandler) PreviewPayment(c *gin.Context) {
+	log.Printf("[PaymentHandler] PreviewPayment request received")
+
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		RespondError(c, http.StatusUnauthorized, "User not authenticated", "UNAUTHORIZED")
+		return
+	}
+	userID := userIDVal.(uuid.UUID)
+
+	var req struct {
+		Plan            string `json:"plan" binding:"required"`
+		BillingInterval string `json:"billing_interval"`
+		CouponCode      string `json:"coupon_code"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		return
+	}
+
+	preview, err := h.service.PreviewPlanChange(c.Request.Context(), userID, req.Plan, req.BillingInterval, req.CouponCode)
+	if err != nil {
+		log.Printf("[PaymentHandler] PreviewPayment service error: %v", err)
+		if errors.Is(err, payment.ErrInvalidBillingInterval) || errors.Is(err, payment.ErrAnnualNotAvailable) || isCouponError(err) {
+			RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+			return
+		}
+		RespondError(c, http.StatusInternalServerError, err.Error(), "INTERNAL_ERROR")
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, preview, nil)
+}
+
+// isCouponError reports whether the coupon code given at checkout was rejected
+func isCouponError(err error) bool {
+	return errors.Is(err, coupon.ErrCouponNotFound) ||
+		errors.Is(err, coupon.ErrCouponExpired) ||
+		errors.Is(err, coupon.ErrCouponNotApplicable) ||
+		errors.Is(err, coupon.ErrCouponExhausted) ||
+		errors.Is(err, coupon.ErrCouponAlreadyUsed)
+}
+
 func (h *PaymentHandler) PaymentSuccess(c *gin.Context) {
 	log.Printf("[PaymentHandler] 📥 Payment success callback received")
 	log.Printf("[PaymentHandler]    Full URL: %s", c.Request.URL.String())
@@ -91,7 +146,7 @@ func (h *PaymentHandler) PaymentSuccess(c *gin.Context) {
 	}
 
 	// Verify payment
-	log.Printf("[PaymentHandler] 🔍 Verifying payment with Chapa: %s", txRef)
+	log.Printf("[PaymentHandler] 🔍 Verifying payment: %s", txRef)
 	providerRef, verified, err := h.webhook.VerifyPayment(c.Request.Context(), txRef)
 	if err != nil {
 		log.Printf("[PaymentHandler] ❌ Payment verification failed: %v", err)
@@ -0,0 +1,165 @@
+package rest
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"menuvista/internal/models"
+	"menuvista/internal/services/promotion"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type PromotionHandler struct {
+	service *promotion.Service
+}
+
+func NewPromotionHandler(service *promotion.Service) *PromotionHandler {
+	return &PromotionHandler{
+		service: service,
+	}
+}
+
+func (h *PromotionHandler) CreatePromotion(c *gin.Context) {
+	log.Printf("[PromotionHandler] CreatePromotion request received")
+
+	userID, restaurantID, ok := h.parseContext(c)
+	if !ok {
+		return
+	}
+
+	var req models.CreatePromotionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		return
+	}
+
+	result, err := h.service.CreatePromotion(c.Request.Context(), userID, restaurantID, req)
+	if err != nil {
+		h.respondServiceError(c, "CreatePromotion", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusCreated, result, nil)
+}
+
+func (h *PromotionHandler) ListPromotions(c *gin.Context) {
+	log.Printf("[PromotionHandler] ListPromotions request received")
+
+	userID, restaurantID, ok := h.parseContext(c)
+	if !ok {
+		return
+	}
+
+	results, err := h.service.ListPromotions(c.Request.Context(), userID, restaurantID)
+	if err != nil {
+		h.respondServiceError(c, "ListPromotions", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, results, nil)
+}
+
+func (h *PromotionHandler) GetPromotion(c *gin.Context) {
+	log.Printf("[PromotionHandler] GetPromotion request received")
+
+	userID, restaurantID, ok := h.parseContext(c)
+	if !ok {
+		return
+	}
+
+	promotionID, err := uuid.Parse(c.Param("promotion_id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "Invalid promotion ID", "INVALID_INPUT")
+		return
+	}
+
+	result, err := h.service.GetPromotion(c.Request.Context(), userID, restaurantID, promotionID)
+	if err != nil {
+		h.respondServiceError(c, "GetPromotion", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, result, nil)
+}
+
+func (h *PromotionHandler) UpdatePromotion(c *gin.Context) {
+	log.Printf("[PromotionHandler] UpdatePromotion request received")
+
+	userID, restaurantID, ok := h.parseContext(c)
+	if !ok {
+		return
+	}
+
+	promotionID, err := uuid.Parse(c.Param("promotion_id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "Invalid promotion ID", "INVALID_INPUT")
+		return
+	}
+
+	var req models.CreatePromotionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		return
+	}
+
+	result, err := h.service.UpdatePromotion(c.Request.Context(), userID, restaurantID, promotionID, req)
+	if err != nil {
+		h.respondServiceError(c, "UpdatePromotion", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, result, nil)
+}
+
+func (h *PromotionHandler) DeletePromotion(c *gin.Context) {
+	log.Printf("[PromotionHandler] DeletePromotion request received")
+
+	userID, restaurantID, ok := h.parseContext(c)
+	if !ok {
+		return
+	}
+
+	promotionID, err := uuid.Parse(c.Param("promotion_id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "Invalid promotion ID", "INVALID_INPUT")
+		return
+	}
+
+	if err := h.service.DeletePromotion(c.Request.Context(), userID, restaurantID, promotionID); err != nil {
+		h.respondServiceError(c, "DeletePromotion", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, gin.H{"message": "Promotion deleted successfully"}, nil)
+}
+
+func (h *PromotionHandler) parseContext(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		RespondError(c, http.StatusUnauthorized, "Unauthorized", "UNAUTHORIZED")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	restaurantID, err := uuid.Parse(c.Param("restaurant_id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "Invalid restaurant ID", "INVALID_INPUT")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return userIDVal.(uuid.UUID), restaurantID, true
+}
+
+func (h *PromotionHandler) respondServiceError(c *gin.Context, action string, err error) {
+	log.Printf("[PromotionHandler] %s service error: %v", action, err)
+	switch {
+	case errors.Is(err, promotion.ErrRestaurantAccess):
+		RespondError(c, http.StatusForbidden, err.Error(), "FORBIDDEN")
+	case errors.Is(err, promotion.ErrPromotionNotFound):
+		RespondError(c, http.StatusNotFound, err.Error(), "NOT_FOUND")
+	default:
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+	}
+}
@@ -0,0 +1,97 @@
+package rest
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"menuvista/internal/models"
+	"menuvista/internal/services/invoice"
+	"menuvista/internal/services/payment"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type RefundHandler struct {
+	service *payment.RefundService
+}
+
+func NewRefundHandler(service *payment.RefundService) *RefundHandler {
+	return &RefundHandler{
+		service: service,
+	}
+}
+
+// RefundInvoice refunds a paid invoice, in full or in part, and issues a credit note
+func (h *RefundHandler) RefundInvoice(c *gin.Context) {
+	log.Printf("[RefundHandler] RefundInvoice request received")
+
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		RespondError(c, http.StatusUnauthorized, "Unauthorized", "UNAUTHORIZED")
+		return
+	}
+
+	invoiceID, ok := parseInvoiceID(c)
+	if !ok {
+		return
+	}
+
+	var req models.RefundInvoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		return
+	}
+
+	result, err := h.service.RefundInvoice(c.Request.Context(), userIDVal.(uuid.UUID), invoiceID, req)
+	if err != nil {
+		h.respondServiceError(c, "RefundInvoice", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusCreated, result, nil)
+}
+
+// ListCreditNotes returns the credit notes issued against an invoice
+func (h *RefundHandler) ListCreditNotes(c *gin.Context) {
+	log.Printf("[RefundHandler] ListCreditNotes request received")
+
+	invoiceID, ok := parseInvoiceID(c)
+	if !ok {
+		return
+	}
+
+	results, err := h.service.ListCreditNotes(c.Request.Context(), invoiceID)
+	if err != nil {
+		h.respondServiceError(c, "ListCreditNotes", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, results, nil)
+}
+
+func parseInvoiceID(c *gin.Context) (uuid.UUID, bool) {
+	invoiceID, err := uuid.Parse(c.Param("invoice_id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "Invalid invoice ID", "INVALID_INPUT")
+		return uuid.Nil, false
+	}
+	return invoiceID, true
+}
+
+func (h *RefundHandler) respondServiceError(c *gin.Context, action string, err error) {
+	log.Printf("[RefundHandler] %s service error: %v", action, err)
+	switch {
+	case errors.Is(err, invoice.ErrInvoiceNotFound):
+		RespondError(c, http.StatusNotFound, err.Error(), "NOT_FOUND")
+	case errors.Is(err, payment.ErrInvoiceNotRefundable), errors.Is(err, payment.ErrNothingToRefund):
+		RespondError(c, http.StatusConflict, err.Error(), "CONFLICT")
+	case errors.Is(err, payment.ErrRefundExceedsBalance):
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+	case errors.Is(err, payment.ErrRefundFailed):
+		RespondError(c, http.StatusBadGateway, err.Error(), "PROVIDER_ERROR")
+	default:
+		RespondError(c, http.StatusInternalServerError, err.Error(), "INTERNAL_ERROR")
+	}
+}
@@ -0,0 +1,299 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"menuvista/internal/models"
+	"menuvista/internal/services/reservation"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+)
+
+type ReservationHandler struct {
+	service *reservation.Service
+}
+
+func NewReservationHandler(service *reservation.Service) *ReservationHandler {
+	return &ReservationHandler{
+		service: service,
+	}
+}
+
+// Guest facing
+
+func (h *ReservationHandler) ListSlots(c *gin.Context) {
+	log.Printf("[ReservationHandler] ListSlots request received")
+
+	date := c.Query("date")
+	if date == "" {
+		RespondError(c, http.StatusBadRequest, "date is required", "INVALID_INPUT")
+		return
+	}
+	partySize, err := strconv.Atoi(c.DefaultQuery("party_size", "2"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "Invalid party size", "INVALID_INPUT")
+		return
+	}
+
+	slots, err := h.service.ListAvailableSlots(c.Request.Context(), c.Param("slug"), date, int32(partySize))
+	if err != nil {
+		h.respondServiceError(c, "ListSlots", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, slots, nil)
+}
+
+func (h *ReservationHandler) CreateReservation(c *gin.Context) {
+	log.Printf("[ReservationHandler] CreateReservation request received")
+
+	var req models.CreateReservationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		return
+	}
+
+	result, err := h.service.CreateReservation(c.Request.Context(), c.Param("slug"), req, c.ClientIP())
+	if err != nil {
+		h.respondServiceError(c, "CreateReservation", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusCreated, result, nil)
+}
+
+// ConfirmReservationPage is opened by the confirm link in the booking email. Opening it
+// changes nothing, so mail scanners and link prefetchers cannot confirm a booking; the
+// guest submits the page's form, which posts to ConfirmReservation.
+func (h *ReservationHandler) ConfirmReservationPage(c *gin.Context) {
+	h.renderActionPage(c, "confirm", "Confirm Your Reservation", "Confirm your reservation so the restaurant can hold your table.")
+}
+
+// CancelReservationPage is opened by the cancel link in the booking email, like
+// ConfirmReservationPage
+func (h *ReservationHandler) CancelReservationPage(c *gin.Context) {
+	h.renderActionPage(c, "cancel", "Cancel Your Reservation", "Plans changed? Cancel your reservation so the table can go to someone else.")
+}
+
+// ConfirmReservation takes the token from the email page's form, or from a JSON body
+func (h *ReservationHandler) ConfirmReservation(c *gin.Context) {
+	log.Printf("[ReservationHandler] ConfirmReservation request received")
+	h.applyAction(c, "ConfirmReservation", h.service.ConfirmReservation, "Reservation Confirmed", "Thank you, your table is held. See you soon!")
+}
+
+// CancelReservation takes the token from the email page's form, or from a JSON body
+func (h *ReservationHandler) CancelReservation(c *gin.Context) {
+	log.Printf("[ReservationHandler] CancelReservation request received")
+	h.applyAction(c, "CancelReservation", h.service.CancelReservation, "Reservation Cancelled", "Your reservation has been cancelled.")
+}
+
+func (h *ReservationHandler) renderActionPage(c *gin.Context, action, title, message string) {
+	token := c.Query("token")
+	if token == "" {
+		c.HTML(http.StatusBadRequest, "reservation_action.html", gin.H{
+			"title":   "Link Incomplete",
+			"message": "This link is missing its reservation token. Please use the link from your booking email.",
+		})
+		return
+	}
+
+	c.HTML(http.StatusOK, "reservation_action.html", gin.H{
+		"title":   title,
+		"message": message,
+		"action":  action,
+		"token":   token,
+	})
+}
+
+type reservationTokenRequest struct {
+	Token string `json:"token" form:"token"`
+}
+
+// applyAction runs a guest token action. Form posts from the email page get a page back;
+// API clients get the usual JSON envelope.
+func (h *ReservationHandler) applyAction(c *gin.Context, action string, apply func(context.Context, string) (*models.Reservation, error), title, message string) {
+	fromPage := c.ContentType() == binding.MIMEPOSTForm
+
+	var req reservationTokenRequest
+	if err := c.ShouldBind(&req); err != nil || req.Token == "" {
+		if fromPage {
+			c.HTML(http.StatusBadRequest, "reservation_action.html", gin.H{
+				"title":   "Link Incomplete",
+				"message": "Reservation token is required.",
+			})
+			return
+		}
+		RespondError(c, http.StatusBadRequest, "Reservation token is required", "MISSING_TOKEN")
+		return
+	}
+
+	result, err := apply(c.Request.Context(), req.Token)
+	if !fromPage {
+		if err != nil {
+			h.respondServiceError(c, action, err)
+			return
+		}
+		RespondSuccess(c, http.StatusOK, result, nil)
+		return
+	}
+
+	if err != nil {
+		log.Printf("[ReservationHandler] %s service error: %v", action, err)
+		status := http.StatusBadRequest
+		if errors.Is(err, reservation.ErrReservationNotFound) || errors.Is(err, reservation.ErrInvalidToken) {
+			status = http.StatusNotFound
+		}
+		c.HTML(status, "reservation_action.html", gin.H{
+			"title":   "Something Went Wrong",
+			"message": err.Error(),
+		})
+		return
+	}
+	c.HTML(http.StatusOK, "reservation_action.html", gin.H{
+		"title":       title,
+		"message":     message,
+		"reserved_at": result.ReservedAt.Format("Monday, January 2, 2006 at 3:04 PM"),
+		"party_size":  result.PartySize,
+	})
+}
+
+// Owner and staff
+
+func (h *ReservationHandler) GetSettings(c *gin.Context) {
+	log.Printf("[ReservationHandler] GetSettings request received")
+
+	userID, restaurantID, ok := h.parseContext(c)
+	if !ok {
+		return
+	}
+
+	settings, err := h.service.GetSettings(c.Request.Context(), userID, restaurantID)
+	if err != nil {
+		h.respondServiceError(c, "GetSettings", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, settings, nil)
+}
+
+func (h *ReservationHandler) UpdateSettings(c *gin.Context) {
+	log.Printf("[ReservationHandler] UpdateSettings request received")
+
+	userID, restaurantID, ok := h.parseContext(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateReservationSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		return
+	}
+
+	settings, err := h.service.UpdateSettings(c.Request.Context(), userID, restaurantID, req)
+	if err != nil {
+		h.respondServiceError(c, "UpdateSettings", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, settings, nil)
+}
+
+func (h *ReservationHandler) ListReservations(c *gin.Context) {
+	log.Printf("[ReservationHandler] ListReservations request received")
+
+	userID, restaurantID, ok := h.parseContext(c)
+	if !ok {
+		return
+	}
+
+	reservations, err := h.service.ListReservationsForDay(c.Request.Context(), userID, restaurantID, c.Query("date"))
+	if err != nil {
+		h.respondServiceError(c, "ListReservations", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, reservations, nil)
+}
+
+func (h *ReservationHandler) SeatGuests(c *gin.Context) {
+	log.Printf("[ReservationHandler] SeatGuests request received")
+
+	userID, restaurantID, ok := h.parseContext(c)
+	if !ok {
+		return
+	}
+	reservationID, err := uuid.Parse(c.Param("reservation_id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "Invalid reservation ID", "INVALID_INPUT")
+		return
+	}
+
+	result, err := h.service.SeatGuests(c.Request.Context(), userID, restaurantID, reservationID)
+	if err != nil {
+		h.respondServiceError(c, "SeatGuests", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, result, nil)
+}
+
+func (h *ReservationHandler) MarkNoShow(c *gin.Context) {
+	log.Printf("[ReservationHandler] MarkNoShow request received")
+
+	userID, restaurantID, ok := h.parseContext(c)
+	if !ok {
+		return
+	}
+	reservationID, err := uuid.Parse(c.Param("reservation_id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "Invalid reservation ID", "INVALID_INPUT")
+		return
+	}
+
+	result, err := h.service.MarkNoShow(c.Request.Context(), userID, restaurantID, reservationID)
+	if err != nil {
+		h.respondServiceError(c, "MarkNoShow", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, result, nil)
+}
+
+func (h *ReservationHandler) parseContext(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		RespondError(c, http.StatusUnauthorized, "Unauthorized", "UNAUTHORIZED")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	restaurantID, err := uuid.Parse(c.Param("restaurant_id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "Invalid restaurant ID", "INVALID_INPUT")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return userIDVal.(uuid.UUID), restaurantID, true
+}
+
+func (h *ReservationHandler) respondServiceError(c *gin.Context, action string, err error) {
+	log.Printf("[ReservationHandler] %s service error: %v", action, err)
+	switch {
+	case errors.Is(err, reservation.ErrRestaurantAccess):
+		RespondError(c, http.StatusForbidden, err.Error(), "FORBIDDEN")
+	case errors.Is(err, reservation.ErrRestaurantNotFound), errors.Is(err, reservation.ErrReservationNotFound), errors.Is(err, reservation.ErrInvalidToken):
+		RespondError(c, http.StatusNotFound, err.Error(), "NOT_FOUND")
+	case errors.Is(err, reservation.ErrSlotUnavailable):
+		RespondError(c, http.StatusConflict, err.Error(), "SLOT_UNAVAILABLE")
+	case errors.Is(err, reservation.ErrRateLimited):
+		RespondError(c, http.StatusTooManyRequests, err.Error(), "RATE_LIMITED")
+	default:
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+	}
+}
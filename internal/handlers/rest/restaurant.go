@@ -1,6 +1,7 @@
 package rest
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -110,6 +111,7 @@ func (h *RestaurantHandler) ListRestaurants(c *gin.Context) {
 		OwnerID: filters.OwnerID,
 		// Status:  filters.Status,
 		Search: filters.Search,
+		SortBy: &filters.SortBy,
 	}
 
 	results, meta, err := h.service.ListRestaurantsWithFilters(c.Request.Context(), restaurantFilters, pagination)
@@ -146,6 +148,14 @@ func (h *RestaurantHandler) UpdateRestaurant(c *gin.Context) {
 	isAdmin := role == "admin"
 
 	result, err := h.service.UpdateRestaurant(c.Request.Context(), restaurantID, ownerID, isAdmin, input)
+	if errors.Is(err, restaurant.ErrRestaurantPlanLocked) {
+		RespondError(c, http.StatusForbidden, "This restaurant is hidden because it is above your plan's limit. Upgrade your plan to publish it again.", "LIMIT_REACHED")
+		return
+	}
+	if errors.Is(err, restaurant.ErrInvalidTimezone) {
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		return
+	}
 	if err != nil {
 		log.Printf("[RestaurantHandler] UpdateRestaurant service error: %v", err)
 		RespondError(c, http.StatusInternalServerError, err.Error(), "INTERNAL_ERROR")
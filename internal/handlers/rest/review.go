@@ -0,0 +1,228 @@
+package rest
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"menuvista/internal/models"
+	"menuvista/internal/services/review"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ReviewHandler struct {
+	service *review.Service
+}
+
+func NewReviewHandler(service *review.Service) *ReviewHandler {
+	return &ReviewHandler{
+		service: service,
+	}
+}
+
+// Public
+
+func (h *ReviewHandler) CreateRestaurantReview(c *gin.Context) {
+	log.Printf("[ReviewHandler] CreateRestaurantReview request received")
+	h.createReview(c, nil)
+}
+
+func (h *ReviewHandler) CreateMenuItemReview(c *gin.Context) {
+	log.Printf("[ReviewHandler] CreateMenuItemReview request received")
+
+	itemID, err := uuid.Parse(c.Param("item_id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "Invalid item ID", "INVALID_INPUT")
+		return
+	}
+	h.createReview(c, &itemID)
+}
+
+func (h *ReviewHandler) ListRestaurantReviews(c *gin.Context) {
+	log.Printf("[ReviewHandler] ListRestaurantReviews request received")
+
+	pagination := ParsePaginationParams(c)
+	results, meta, err := h.service.ListRestaurantReviews(c.Request.Context(), c.Param("slug"), pagination)
+	if err != nil {
+		h.respondServiceError(c, "ListRestaurantReviews", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, results, meta)
+}
+
+func (h *ReviewHandler) ListMenuItemReviews(c *gin.Context) {
+	log.Printf("[ReviewHandler] ListMenuItemReviews request received")
+
+	itemID, err := uuid.Parse(c.Param("item_id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "Invalid item ID", "INVALID_INPUT")
+		return
+	}
+
+	pagination := ParsePaginationParams(c)
+	results, meta, err := h.service.ListMenuItemReviews(c.Request.Context(), c.Param("slug"), itemID, pagination)
+	if err != nil {
+		h.respondServiceError(c, "ListMenuItemReviews", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, results, meta)
+}
+
+func (h *ReviewHandler) ReportReview(c *gin.Context) {
+	log.Printf("[ReviewHandler] ReportReview request received")
+
+	reviewID, err := uuid.Parse(c.Param("review_id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "Invalid review ID", "INVALID_INPUT")
+		return
+	}
+
+	if err := h.service.ReportReview(c.Request.Context(), reviewID, c.ClientIP()); err != nil {
+		h.respondServiceError(c, "ReportReview", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, gin.H{"message": "Review reported, thank you"}, nil)
+}
+
+// Owner
+
+func (h *ReviewHandler) ListMyRestaurantReviews(c *gin.Context) {
+	log.Printf("[ReviewHandler] ListMyRestaurantReviews request received")
+
+	userID, restaurantID, ok := h.parseContext(c)
+	if !ok {
+		return
+	}
+
+	pagination := ParsePaginationParams(c)
+	results, meta, err := h.service.ListReviewsForOwner(c.Request.Context(), userID, restaurantID, pagination)
+	if err != nil {
+		h.respondServiceError(c, "ListMyRestaurantReviews", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, results, meta)
+}
+
+func (h *ReviewHandler) ReplyToReview(c *gin.Context) {
+	log.Printf("[ReviewHandler] ReplyToReview request received")
+
+	userID, restaurantID, ok := h.parseContext(c)
+	if !ok {
+		return
+	}
+
+	reviewID, err := uuid.Parse(c.Param("review_id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "Invalid review ID", "INVALID_INPUT")
+		return
+	}
+
+	var req models.ReplyToReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		return
+	}
+
+	result, err := h.service.ReplyToReview(c.Request.Context(), userID, restaurantID, reviewID, req)
+	if err != nil {
+		h.respondServiceError(c, "ReplyToReview", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, result, nil)
+}
+
+// Admin
+
+func (h *ReviewHandler) ListReportedReviews(c *gin.Context) {
+	log.Printf("[ReviewHandler] ListReportedReviews request received")
+
+	pagination := ParsePaginationParams(c)
+	results, meta, err := h.service.ListReportedReviews(c.Request.Context(), pagination)
+	if err != nil {
+		h.respondServiceError(c, "ListReportedReviews", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, results, meta)
+}
+
+func (h *ReviewHandler) ModerateReview(c *gin.Context) {
+	log.Printf("[ReviewHandler] ModerateReview request received")
+
+	reviewID, err := uuid.Parse(c.Param("review_id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "Invalid review ID", "INVALID_INPUT")
+		return
+	}
+
+	var req models.ModerateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		return
+	}
+
+	result, err := h.service.ModerateReview(c.Request.Context(), reviewID, req)
+	if err != nil {
+		h.respondServiceError(c, "ModerateReview", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, result, nil)
+}
+
+func (h *ReviewHandler) createReview(c *gin.Context, itemID *uuid.UUID) {
+	var req models.CreateReviewRequest
+	if err := c.ShouldBind(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		return
+	}
+
+	result, err := h.service.CreateReview(c.Request.Context(), c.Param("slug"), itemID, req, c.ClientIP())
+	if err != nil {
+		h.respondServiceError(c, "CreateReview", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusCreated, result, nil)
+}
+
+func (h *ReviewHandler) parseContext(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		RespondError(c, http.StatusUnauthorized, "Unauthorized", "UNAUTHORIZED")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	restaurantID, err := uuid.Parse(c.Param("restaurant_id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "Invalid restaurant ID", "INVALID_INPUT")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return userIDVal.(uuid.UUID), restaurantID, true
+}
+
+func (h *ReviewHandler) respondServiceError(c *gin.Context, action string, err error) {
+	log.Printf("[ReviewHandler] %s service error: %v", action, err)
+	switch {
+	case errors.Is(err, review.ErrRestaurantAccess):
+		RespondError(c, http.StatusForbidden, err.Error(), "FORBIDDEN")
+	case errors.Is(err, review.ErrRestaurantNotFound),
+		errors.Is(err, review.ErrMenuItemNotFound),
+		errors.Is(err, review.ErrReviewNotFound):
+		RespondError(c, http.StatusNotFound, err.Error(), "NOT_FOUND")
+	case errors.Is(err, review.ErrRateLimited):
+		RespondError(c, http.StatusTooManyRequests, err.Error(), "RATE_LIMITED")
+	case errors.Is(err, review.ErrInvalidPhoto):
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+	default:
+		RespondError(c, http.StatusInternalServerError, err.Error(), "INTERNAL_ERROR")
+	}
+}
@@ -0,0 +1,221 @@
+package rest
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"menuvista/internal/models"
+	"menuvista/internal/services/servicerequest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ServiceRequestHandler struct {
+	service *servicerequest.Service
+}
+
+func NewServiceRequestHandler(service *servicerequest.Service) *ServiceRequestHandler {
+	return &ServiceRequestHandler{
+		service: service,
+	}
+}
+
+// Guest facing (table QR)
+
+func (h *ServiceRequestHandler) GetTable(c *gin.Context) {
+	log.Printf("[ServiceRequestHandler] GetTable request received")
+
+	info, err := h.service.GetTableInfo(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		RespondError(c, http.StatusNotFound, err.Error(), "NOT_FOUND")
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, info, nil)
+}
+
+func (h *ServiceRequestHandler) RaiseRequest(c *gin.Context) {
+	log.Printf("[ServiceRequestHandler] RaiseRequest request received")
+
+	var req models.CreateServiceRequestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		return
+	}
+
+	result, err := h.service.RaiseRequest(c.Request.Context(), c.Param("token"), req)
+	if err != nil {
+		log.Printf("[ServiceRequestHandler] RaiseRequest service error: %v", err)
+		switch {
+		case errors.Is(err, servicerequest.ErrTableNotFound):
+			RespondError(c, http.StatusNotFound, err.Error(), "NOT_FOUND")
+		case errors.Is(err, servicerequest.ErrRateLimited):
+			RespondError(c, http.StatusTooManyRequests, err.Error(), "RATE_LIMITED")
+		default:
+			RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		}
+		return
+	}
+
+	RespondSuccess(c, http.StatusCreated, result, nil)
+}
+
+// Tables
+
+func (h *ServiceRequestHandler) CreateTable(c *gin.Context) {
+	log.Printf("[ServiceRequestHandler] CreateTable request received")
+
+	userID, restaurantID, ok := h.parseContext(c)
+	if !ok {
+		return
+	}
+
+	var req models.CreateRestaurantTableRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		return
+	}
+
+	table, err := h.service.CreateTable(c.Request.Context(), userID, restaurantID, req)
+	if err != nil {
+		h.respondServiceError(c, "CreateTable", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusCreated, table, nil)
+}
+
+func (h *ServiceRequestHandler) ListTables(c *gin.Context) {
+	log.Printf("[ServiceRequestHandler] ListTables request received")
+
+	userID, restaurantID, ok := h.parseContext(c)
+	if !ok {
+		return
+	}
+
+	tables, err := h.service.ListTables(c.Request.Context(), userID, restaurantID)
+	if err != nil {
+		h.respondServiceError(c, "ListTables", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, tables, nil)
+}
+
+func (h *ServiceRequestHandler) DeleteTable(c *gin.Context) {
+	log.Printf("[ServiceRequestHandler] DeleteTable request received")
+
+	userID, restaurantID, ok := h.parseContext(c)
+	if !ok {
+		return
+	}
+
+	tableID, err := uuid.Parse(c.Param("table_id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "Invalid table ID", "INVALID_INPUT")
+		return
+	}
+
+	if err := h.service.DeleteTable(c.Request.Context(), userID, restaurantID, tableID); err != nil {
+		h.respondServiceError(c, "DeleteTable", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, gin.H{"message": "Table deleted successfully"}, nil)
+}
+
+// Staff facing
+
+func (h *ServiceRequestHandler) ListOpenRequests(c *gin.Context) {
+	log.Printf("[ServiceRequestHandler] ListOpenRequests request received")
+
+	userID, restaurantID, ok := h.parseContext(c)
+	if !ok {
+		return
+	}
+
+	requests, err := h.service.ListOpenRequests(c.Request.Context(), userID, restaurantID)
+	if err != nil {
+		h.respondServiceError(c, "ListOpenRequests", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, requests, nil)
+}
+
+func (h *ServiceRequestHandler) AcknowledgeRequest(c *gin.Context) {
+	log.Printf("[ServiceRequestHandler] AcknowledgeRequest request received")
+
+	userID, restaurantID, ok := h.parseContext(c)
+	if !ok {
+		return
+	}
+
+	requestID, err := uuid.Parse(c.Param("request_id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "Invalid request ID", "INVALID_INPUT")
+		return
+	}
+
+	result, err := h.service.AcknowledgeRequest(c.Request.Context(), userID, restaurantID, requestID)
+	if err != nil {
+		h.respondServiceError(c, "AcknowledgeRequest", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, result, nil)
+}
+
+func (h *ServiceRequestHandler) GetStats(c *gin.Context) {
+	log.Printf("[ServiceRequestHandler] GetStats request received")
+
+	userID, restaurantID, ok := h.parseContext(c)
+	if !ok {
+		return
+	}
+
+	days, err := strconv.Atoi(c.DefaultQuery("days", "30"))
+	if err != nil || days < 1 || days > 365 {
+		RespondError(c, http.StatusBadRequest, "days must be between 1 and 365", "INVALID_INPUT")
+		return
+	}
+
+	stats, err := h.service.GetStats(c.Request.Context(), userID, restaurantID, days)
+	if err != nil {
+		h.respondServiceError(c, "GetStats", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, stats, nil)
+}
+
+func (h *ServiceRequestHandler) parseContext(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		RespondError(c, http.StatusUnauthorized, "Unauthorized", "UNAUTHORIZED")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	restaurantID, err := uuid.Parse(c.Param("restaurant_id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "Invalid restaurant ID", "INVALID_INPUT")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return userIDVal.(uuid.UUID), restaurantID, true
+}
+
+func (h *ServiceRequestHandler) respondServiceError(c *gin.Context, action string, err error) {
+	log.Printf("[ServiceRequestHandler] %s service error: %v", action, err)
+	switch {
+	case errors.Is(err, servicerequest.ErrRestaurantAccess):
+		RespondError(c, http.StatusForbidden, err.Error(), "FORBIDDEN")
+	case errors.Is(err, servicerequest.ErrRequestNotFound):
+		RespondError(c, http.StatusNotFound, err.Error(), "NOT_FOUND")
+	default:
+		RespondError(c, http.StatusInternalServerError, err.Error(), "INTERNAL_ERROR")
+	}
+}
@@ -1,6 +1,7 @@
 package rest
 
 import (
+	"errors"
 	"log"
 	"net/http"
 
@@ -48,33 +49,125 @@ func (h *StaffHandler) ListStaff(c *gin.Context) {
 	RespondSuccess(c, http.StatusOK, staffList, meta)
 }
 
+// AddStaff invites a staff member; the account is created when they accept
 func (h *StaffHandler) AddStaff(c *gin.Context) {
+	log.Printf("[StaffHandler] AddStaff request received")
 	var req models.CreateStaffRequest
 	if err := c.ShouldBind(&req); err != nil {
 		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
 		return
 	}
 
-	ownerID, exists := c.Get("owner_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+	ownerID, restaurantID, ok := h.parseOwnerContext(c)
+	if !ok {
+		return
+	}
+	req.OwnerID = ownerID
+	req.RestaurantID = restaurantID
+
+	invitation, err := h.service.InviteStaff(c.Request.Context(), req)
+	if err != nil {
+		h.respondServiceError(c, "AddStaff", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusCreated, invitation, nil)
+}
+
+func (h *StaffHandler) ListInvitations(c *gin.Context) {
+	log.Printf("[StaffHandler] ListInvitations request received")
+	ownerID, restaurantID, ok := h.parseOwnerContext(c)
+	if !ok {
+		return
+	}
+
+	pagination := ParsePaginationParams(c)
+	invitations, meta, err := h.service.ListInvitations(c.Request.Context(), ownerID, restaurantID, pagination)
+	if err != nil {
+		h.respondServiceError(c, "ListInvitations", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, invitations, meta)
+}
+
+func (h *StaffHandler) ResendInvitation(c *gin.Context) {
+	log.Printf("[StaffHandler] ResendInvitation request received")
+	ownerID, restaurantID, ok := h.parseOwnerContext(c)
+	if !ok {
+		return
+	}
+
+	invitationID, err := uuid.Parse(c.Param("invitation_id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "Invalid invitation ID", "INVALID_INPUT")
+		return
+	}
+
+	invitation, err := h.service.ResendInvitation(c.Request.Context(), ownerID, restaurantID, invitationID)
+	if err != nil {
+		h.respondServiceError(c, "ResendInvitation", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, invitation, nil)
+}
+
+func (h *StaffHandler) RevokeInvitation(c *gin.Context) {
+	log.Printf("[StaffHandler] RevokeInvitation request received")
+	ownerID, restaurantID, ok := h.parseOwnerContext(c)
+	if !ok {
+		return
+	}
+
+	invitationID, err := uuid.Parse(c.Param("invitation_id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "Invalid invitation ID", "INVALID_INPUT")
+		return
+	}
+
+	if err := h.service.RevokeInvitation(c.Request.Context(), ownerID, restaurantID, invitationID); err != nil {
+		h.respondServiceError(c, "RevokeInvitation", err)
 		return
 	}
 
-	req.OwnerID = ownerID.(uuid.UUID)
+	RespondSuccess(c, http.StatusOK, gin.H{"message": "Invitation revoked"}, nil)
+}
+
+// Public
+
+func (h *StaffHandler) GetInvitation(c *gin.Context) {
+	log.Printf("[StaffHandler] GetInvitation request received")
+	token := c.Query("token")
+	if token == "" {
+		RespondError(c, http.StatusBadRequest, "Invitation token is required", "MISSING_TOKEN")
+		return
+	}
 
-	user, err := h.service.CreateStaff(c, req.OwnerID, req.RestaurantID, models.CreateUserRequest{
-		Email:    req.Email,
-		FullName: req.FullName,
-		Role:     models.RoleStaff,
-		Phone:    req.Phone,
-	})
+	details, err := h.service.GetInvitationDetails(c.Request.Context(), token)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		h.respondServiceError(c, "GetInvitation", err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, user)
+	RespondSuccess(c, http.StatusOK, details, nil)
+}
+
+func (h *StaffHandler) AcceptInvitation(c *gin.Context) {
+	log.Printf("[StaffHandler] AcceptInvitation request received")
+	var req models.AcceptInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		return
+	}
+
+	user, err := h.service.AcceptInvitation(c.Request.Context(), req)
+	if err != nil {
+		h.respondServiceError(c, "AcceptInvitation", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusCreated, user, nil)
 }
 
 func (h *StaffHandler) UpdateStaff(c *gin.Context) {
@@ -139,3 +232,47 @@ func (h *StaffHandler) RemoveStaff(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "Staff removed"})
 }
+
+func (h *StaffHandler) parseOwnerContext(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	ownerIDVal, exists := c.Get("owner_id")
+	if !exists {
+		RespondError(c, http.StatusUnauthorized, "Unauthorized", "UNAUTHORIZED")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	restaurantID, err := uuid.Parse(c.Param("restaurant_id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "Invalid restaurant ID", "INVALID_INPUT")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return ownerIDVal.(uuid.UUID), restaurantID, true
+}
+
+func (h *StaffHandler) respondServiceError(c *gin.Context, action string, err error) {
+	log.Printf("[StaffHandler] %s service error: %v", action, err)
+	switch {
+	case errors.Is(err, staff.ErrRestaurantAccess):
+		RespondError(c, http.StatusForbidden, err.Error(), "FORBIDDEN")
+	case errors.Is(err, staff.ErrStaffLimitReached):
+		RespondError(c, http.StatusForbidden, err.Error(), "LIMIT_REACHED")
+	case errors.Is(err, staff.ErrRoleBuiltIn):
+		RespondError(c, http.StatusForbidden, err.Error(), "FORBIDDEN")
+	case errors.Is(err, staff.ErrEmailTaken),
+		errors.Is(err, staff.ErrInvitationPending),
+		errors.Is(err, staff.ErrInvitationNotAvailable),
+		errors.Is(err, staff.ErrRoleNameTaken),
+		errors.Is(err, staff.ErrRoleInUse):
+		RespondError(c, http.StatusConflict, err.Error(), "CONFLICT")
+	case errors.Is(err, staff.ErrInvalidPermission):
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+	case errors.Is(err, staff.ErrInvitationExpired):
+		RespondError(c, http.StatusGone, err.Error(), "INVITATION_EXPIRED")
+	case errors.Is(err, staff.ErrInvitationNotFound),
+		errors.Is(err, staff.ErrRoleNotFound),
+		errors.Is(err, staff.ErrStaffNotFound):
+		RespondError(c, http.StatusNotFound, err.Error(), "NOT_FOUND")
+	default:
+		RespondError(c, http.StatusInternalServerError, err.Error(), "INTERNAL_ERROR")
+	}
+}
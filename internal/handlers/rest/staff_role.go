@@ -0,0 +1,130 @@
+package rest
+
+import (
+	"log"
+	"net/http"
+
+	"menuvista/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ListPermissions returns the permission catalog used to build roles
+func (h *StaffHandler) ListPermissions(c *gin.Context) {
+	RespondSuccess(c, http.StatusOK, models.Permissions, nil)
+}
+
+func (h *StaffHandler) ListRoles(c *gin.Context) {
+	log.Printf("[StaffHandler] ListRoles request received")
+	ownerID, restaurantID, ok := h.parseOwnerContext(c)
+	if !ok {
+		return
+	}
+
+	roles, err := h.service.ListRoles(c.Request.Context(), ownerID, restaurantID)
+	if err != nil {
+		h.respondServiceError(c, "ListRoles", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, roles, nil)
+}
+
+func (h *StaffHandler) CreateRole(c *gin.Context) {
+	log.Printf("[StaffHandler] CreateRole request received")
+	var req models.CreateStaffRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		return
+	}
+
+	ownerID, restaurantID, ok := h.parseOwnerContext(c)
+	if !ok {
+		return
+	}
+
+	role, err := h.service.CreateRole(c.Request.Context(), ownerID, restaurantID, req)
+	if err != nil {
+		h.respondServiceError(c, "CreateRole", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusCreated, role, nil)
+}
+
+func (h *StaffHandler) UpdateRole(c *gin.Context) {
+	log.Printf("[StaffHandler] UpdateRole request received")
+	var req models.UpdateStaffRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		return
+	}
+
+	ownerID, restaurantID, ok := h.parseOwnerContext(c)
+	if !ok {
+		return
+	}
+
+	roleID, err := uuid.Parse(c.Param("role_id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "Invalid role ID", "INVALID_INPUT")
+		return
+	}
+
+	role, err := h.service.UpdateRole(c.Request.Context(), ownerID, restaurantID, roleID, req)
+	if err != nil {
+		h.respondServiceError(c, "UpdateRole", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, role, nil)
+}
+
+func (h *StaffHandler) DeleteRole(c *gin.Context) {
+	log.Printf("[StaffHandler] DeleteRole request received")
+	ownerID, restaurantID, ok := h.parseOwnerContext(c)
+	if !ok {
+		return
+	}
+
+	roleID, err := uuid.Parse(c.Param("role_id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "Invalid role ID", "INVALID_INPUT")
+		return
+	}
+
+	if err := h.service.DeleteRole(c.Request.Context(), ownerID, restaurantID, roleID); err != nil {
+		h.respondServiceError(c, "DeleteRole", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, gin.H{"message": "Role deleted"}, nil)
+}
+
+func (h *StaffHandler) AssignRole(c *gin.Context) {
+	log.Printf("[StaffHandler] AssignRole request received")
+	var req models.AssignStaffRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		return
+	}
+
+	ownerID, restaurantID, ok := h.parseOwnerContext(c)
+	if !ok {
+		return
+	}
+
+	staffID, err := uuid.Parse(c.Param("staff_id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "Invalid staff ID", "INVALID_INPUT")
+		return
+	}
+
+	if err := h.service.AssignRole(c.Request.Context(), ownerID, restaurantID, staffID, req.RoleID); err != nil {
+		h.respondServiceError(c, "AssignRole", err)
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, gin.H{"message": "Role assigned"}, nil)
+}
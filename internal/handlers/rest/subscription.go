@@ -1,9 +1,11 @@
 package rest
 
 import (
+	"errors"
 	"log"
 	"net/http"
 
+	"menuvista/internal/models"
 	"menuvista/internal/services/subscription"
 
 	"github.com/gin-gonic/gin"
@@ -63,3 +65,96 @@ func (h *SubscriptionHandler) GetSubscriptionDetails(c *gin.Context) {
 
 	RespondSuccess(c, http.StatusOK, details, nil)
 }
+
+// CancelScheduledChange drops a downgrade that was waiting for the end of the period
+func (h *SubscriptionHandler) CancelScheduledChange(c *gin.Context) {
+	log.Printf("[SubscriptionHandler] CancelScheduledChange request received")
+
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		RespondError(c, http.StatusUnauthorized, "User not authenticated", "UNAUTHORIZED")
+		return
+	}
+	userID := userIDVal.(uuid.UUID)
+
+	if err := h.service.CancelScheduledChange(c.Request.Context(), userID); err != nil {
+		if errors.Is(err, subscription.ErrNoScheduledChange) {
+			RespondError(c, http.StatusNotFound, err.Error(), "NOT_FOUND")
+			return
+		}
+		log.Printf("[SubscriptionHandler] Failed to cancel scheduled change: %v", err)
+		RespondError(c, http.StatusInternalServerError, "Failed to cancel scheduled change", "INTERNAL_ERROR")
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, gin.H{"message": "Scheduled plan change cancelled"}, nil)
+}
+
+// CancelSubscription cancels at the end of the current period; the plan stays until then
+func (h *SubscriptionHandler) CancelSubscription(c *gin.Context) {
+	h.cancel(c, false)
+}
+
+// CancelSubscriptionNow ends the subscription immediately and moves the owner to the free plan
+func (h *SubscriptionHandler) CancelSubscriptionNow(c *gin.Context) {
+	h.cancel(c, true)
+}
+
+func (h *SubscriptionHandler) cancel(c *gin.Context, immediate bool) {
+	log.Printf("[SubscriptionHandler] CancelSubscription request received (immediate: %v)", immediate)
+
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		RespondError(c, http.StatusUnauthorized, "User not authenticated", "UNAUTHORIZED")
+		return
+	}
+	userID := userIDVal.(uuid.UUID)
+
+	var req models.CancelSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, err.Error(), "INVALID_INPUT")
+		return
+	}
+
+	details, err := h.service.CancelSubscription(c.Request.Context(), userID, immediate, req)
+	switch {
+	case errors.Is(err, subscription.ErrNothingToCancel):
+		RespondError(c, http.StatusNotFound, err.Error(), "NOT_FOUND")
+		return
+	case errors.Is(err, subscription.ErrAlreadyCancelling),
+		errors.Is(err, subscription.ErrPastDueCancel):
+		RespondError(c, http.StatusConflict, err.Error(), "CONFLICT")
+		return
+	case err != nil:
+		log.Printf("[SubscriptionHandler] Failed to cancel subscription: %v", err)
+		RespondError(c, http.StatusInternalServerError, "Failed to cancel subscription", "INTERNAL_ERROR")
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, details, nil)
+}
+
+// ResumeSubscription takes back a cancellation at period end before the period is over
+func (h *SubscriptionHandler) ResumeSubscription(c *gin.Context) {
+	log.Printf("[SubscriptionHandler] ResumeSubscription request received")
+
+	userIDVal, exists := c.Get("user_id")
+	if !exists {
+		RespondError(c, http.StatusUnauthorized, "User not authenticated", "UNAUTHORIZED")
+		return
+	}
+	userID := userIDVal.(uuid.UUID)
+
+	details, err := h.service.ResumeSubscription(c.Request.Context(), userID)
+	if errors.Is(err, subscription.ErrNothingToResume) {
+		RespondError(c, http.StatusNotFound, err.Error(), "NOT_FOUND")
+		return
+	}
+	if err != nil {
+		log.Printf("[SubscriptionHandler] Failed to resume subscription: %v", err)
+		RespondError(c, http.StatusInternalServerError, "Failed to resume subscription", "INTERNAL_ERROR")
+		return
+	}
+
+	RespondSuccess(c, http.StatusOK, details, nil)
+}
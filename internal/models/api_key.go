@@ -0,0 +1,55 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKeyPermissions are the permissions an integration key may hold. Settings, staff and
+// anything financial stay with people.
+var APIKeyPermissions = []string{
+	PermissionMenuView,
+	PermissionMenuAvailability,
+	PermissionReservationsManage,
+	PermissionServiceRequestsHandle,
+	PermissionAnalyticsView,
+}
+
+func IsValidAPIKeyPermission(key string) bool {
+	return slices.Contains(APIKeyPermissions, key)
+}
+
+type APIKey struct {
+	ID           uuid.UUID  `json:"id"`
+	RestaurantID uuid.UUID  `json:"restaurant_id"`
+	Name         string     `json:"name"`
+	Prefix       string     `json:"prefix"`
+	Permissions  []string   `json:"permissions"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt   *time.Time `json:"last_used_at,omitempty"`
+	LastUsedIP   string     `json:"last_used_ip,omitempty"`
+	RevokedAt    *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// CreatedAPIKey carries the full key; it is returned once and cannot be retrieved again
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+type CreateAPIKeyRequest struct {
+	Name          string   `json:"name" binding:"required,max=100"`
+	Permissions   []string `json:"permissions" binding:"required,min=1"`
+	ExpiresInDays *int     `json:"expires_in_days,omitempty" binding:"omitempty,min=1,max=730"`
+}
+
+// APIKeyPrincipal is who a request authenticated with an API key acts as
+type APIKeyPrincipal struct {
+	KeyID        uuid.UUID
+	RestaurantID uuid.UUID
+	OwnerID      uuid.UUID
+	Permissions  []string
+}
@@ -15,6 +15,7 @@ type Category struct {
 	Icon         string    `json:"icon,omitempty"`
 	DisplayOrder int32     `json:"display_order"`
 	IsActive     bool      `json:"is_active"`
+	PlanLocked   bool      `json:"plan_locked"`
 	CreatedBy    uuid.UUID `json:"created_by"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
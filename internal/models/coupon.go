@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Coupon is a promo code owners can apply at subscription checkout
+type Coupon struct {
+	ID            uuid.UUID    `json:"id"`
+	Code          string       `json:"code"`
+	Description   string       `json:"description,omitempty"`
+	DiscountType  DiscountType `json:"discount_type"`
+	DiscountValue float64      `json:"discount_value"`
+	// PlanIDs restricts the coupon to these plans; empty means any plan
+	PlanIDs        []uuid.UUID `json:"plan_ids"`
+	MaxRedemptions *int32      `json:"max_redemptions,omitempty"`
+	// RedemptionCount is the number of owners who redeemed the coupon
+	RedemptionCount int32 `json:"redemption_count"`
+	// DurationCycles is how many billing cycles the discount lasts; nil means every cycle
+	DurationCycles *int32     `json:"duration_cycles,omitempty"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	IsActive       bool       `json:"is_active"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// CreateCouponRequest describes a coupon. It is also used for full updates.
+type CreateCouponRequest struct {
+	Code           string       `json:"code" binding:"required,min=3,max=50,alphanum"`
+	Description    string       `json:"description"`
+	DiscountType   DiscountType `json:"discount_type" binding:"required,oneof=percentage fixed"`
+	DiscountValue  float64      `json:"discount_value" binding:"required,gt=0"`
+	PlanIDs        []uuid.UUID  `json:"plan_ids"`
+	MaxRedemptions *int32       `json:"max_redemptions" binding:"omitempty,gt=0"`
+	DurationCycles *int32       `json:"duration_cycles" binding:"omitempty,gt=0"`
+	ExpiresAt      *time.Time   `json:"expires_at"`
+	IsActive       *bool        `json:"is_active"`
+}
+
+// CouponRedemption is one paid invoice a coupon discounted
+type CouponRedemption struct {
+	ID             uuid.UUID `json:"id"`
+	OwnerID        uuid.UUID `json:"owner_id"`
+	OwnerEmail     string    `json:"owner_email"`
+	OwnerName      string    `json:"owner_name"`
+	InvoiceID      uuid.UUID `json:"invoice_id"`
+	InvoiceNumber  string    `json:"invoice_number"`
+	DiscountAmount float64   `json:"discount_amount"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// CouponDiscount is a coupon applied to an amount due at checkout
+type CouponDiscount struct {
+	CouponID uuid.UUID `json:"-"`
+	Code     string    `json:"code"`
+	Amount   float64   `json:"amount"`
+}
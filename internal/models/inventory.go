@@ -0,0 +1,14 @@
+package models
+
+type UpdateStockRequest struct {
+	TrackStock        bool  `json:"track_stock"`
+	StockQuantity     int32 `json:"stock_quantity" binding:"min=0"`
+	LowStockThreshold int32 `json:"low_stock_threshold" binding:"min=0"`
+}
+
+// AdjustStockRequest changes the quantity on hand by Delta, e.g. -2 when two portions are sold
+// or +20 after a delivery.
+type AdjustStockRequest struct {
+	Delta  int32  `json:"delta" binding:"required"`
+	Reason string `json:"reason" binding:"max=255"`
+}
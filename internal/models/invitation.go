@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type InvitationStatus string
+
+const (
+	InvitationStatusPending  InvitationStatus = "pending"
+	InvitationStatusAccepted InvitationStatus = "accepted"
+	InvitationStatusRevoked  InvitationStatus = "revoked"
+	// InvitationStatusExpired is derived for pending invitations past their expiry
+	InvitationStatusExpired InvitationStatus = "expired"
+)
+
+type StaffInvitation struct {
+	ID           uuid.UUID        `json:"id"`
+	RestaurantID uuid.UUID        `json:"restaurant_id"`
+	Email        string           `json:"email"`
+	FullName     string           `json:"full_name"`
+	Phone        string           `json:"phone,omitempty"`
+	StaffRoleID  *uuid.UUID       `json:"staff_role_id,omitempty"`
+	Status       InvitationStatus `json:"status"`
+	ExpiresAt    time.Time        `json:"expires_at"`
+	AcceptedAt   *time.Time       `json:"accepted_at,omitempty"`
+	CreatedAt    time.Time        `json:"created_at"`
+}
+
+// InvitationDetails is what an invitee sees before accepting
+type InvitationDetails struct {
+	Email          string    `json:"email"`
+	FullName       string    `json:"full_name"`
+	RestaurantName string    `json:"restaurant_name"`
+	ExpiresAt      time.Time `json:"expires_at"`
+}
+
+type AcceptInvitationRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=8"`
+}
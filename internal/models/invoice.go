@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Invoice is a subscription charge. Amounts include VAT.
+type Invoice struct {
+	ID             uuid.UUID `json:"id"`
+	SubscriptionID uuid.UUID `json:"subscription_id"`
+	// InvoiceNumber is given when the invoice is paid; pending invoices have none
+	InvoiceNumber  string     `json:"invoice_number,omitempty"`
+	Status         string     `json:"status"`
+	Currency       string     `json:"currency"`
+	Amount         float64    `json:"amount"`
+	CreditAmount   float64    `json:"credit_amount"`
+	DiscountAmount float64    `json:"discount_amount"`
+	VATRate        float64    `json:"vat_rate"`
+	VATAmount      float64    `json:"vat_amount"`
+	RefundedAmount float64    `json:"refunded_amount"`
+	Prorated       bool       `json:"prorated"`
+	PeriodStart    time.Time  `json:"period_start"`
+	PeriodEnd      time.Time  `json:"period_end"`
+	PaidAt         *time.Time `json:"paid_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
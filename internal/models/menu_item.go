@@ -22,11 +22,23 @@ type MenuItem struct {
 	SpiceLevel   int32           `json:"spice_level"`
 	Calories     int32           `json:"calories,omitempty"`
 	IsAvailable  bool            `json:"is_available"`
+	PlanLocked   bool            `json:"plan_locked"`
 	DisplayOrder int32           `json:"display_order"`
 	ViewCount    int32           `json:"view_count"`
-	CreatedBy    uuid.UUID       `json:"created_by"`
-	CreatedAt    time.Time       `json:"created_at"`
-	UpdatedAt    time.Time       `json:"updated_at"`
+	RatingAvg    float64         `json:"rating_avg"`
+	RatingCount  int32           `json:"rating_count"`
+	// Inventory
+	TrackStock        bool       `json:"track_stock"`
+	StockQuantity     int32      `json:"stock_quantity,omitempty"`
+	LowStockThreshold int32      `json:"low_stock_threshold,omitempty"`
+	UnavailableUntil  *time.Time `json:"unavailable_until,omitempty"`
+	// Promotions (EffectivePrice is Price after the best currently running promotion)
+	EffectivePrice float64    `json:"effective_price"`
+	PromotionID    *uuid.UUID `json:"promotion_id,omitempty"`
+	PromotionLabel string     `json:"promotion_label,omitempty"`
+	CreatedBy      uuid.UUID  `json:"created_by"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
 }
 
 type CreateMenuItemRequest struct {
@@ -0,0 +1,17 @@
+package models
+
+// OIDCAuthorization is where the client sends the user to sign in with the provider
+type OIDCAuthorization struct {
+	Provider         string `json:"provider"`
+	AuthorizationURL string `json:"authorization_url"`
+	State            string `json:"-"` // Set as a cookie by the handler
+}
+
+// OIDCCallbackRequest carries the parameters the provider appended to the redirect URL
+type OIDCCallbackRequest struct {
+	Code   string     `json:"code" binding:"required"`
+	State  string     `json:"state" binding:"required"`
+	Client ClientInfo `json:"-"` // Set from the request
+	// BrowserState is the state from the cookie set when the sign-in started
+	BrowserState string `json:"-"`
+}
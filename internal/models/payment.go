@@ -12,6 +12,11 @@ const (
 	PaymentStatusPending   PaymentStatus = "pending"
 	PaymentStatusCompleted PaymentStatus = "completed"
 	PaymentStatusFailed    PaymentStatus = "failed"
+	// Cancelled checkouts were replaced by a newer one before they were paid
+	PaymentStatusCancelled PaymentStatus = "cancelled"
+	// Refunded transactions were completed first; partial refunds keep part of the payment
+	PaymentStatusRefunded          PaymentStatus = "refunded"
+	PaymentStatusPartiallyRefunded PaymentStatus = "partially_refunded"
 )
 
 type PaymentTransaction struct {
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PromotionScope string
+
+const (
+	PromotionScopeRestaurant PromotionScope = "restaurant"
+	PromotionScopeCategory   PromotionScope = "category"
+	PromotionScopeItem       PromotionScope = "item"
+)
+
+type DiscountType string
+
+const (
+	DiscountTypePercentage DiscountType = "percentage"
+	DiscountTypeFixed      DiscountType = "fixed"
+)
+
+// PromotionDateLayout is the format used for promotion start and end dates.
+const PromotionDateLayout = "2006-01-02"
+
+type Promotion struct {
+	ID            uuid.UUID      `json:"id"`
+	RestaurantID  uuid.UUID      `json:"restaurant_id"`
+	Name          string         `json:"name"`
+	Label         string         `json:"label"`
+	Scope         PromotionScope `json:"scope"`
+	CategoryID    *uuid.UUID     `json:"category_id,omitempty"`
+	MenuItemID    *uuid.UUID     `json:"menu_item_id,omitempty"`
+	DiscountType  DiscountType   `json:"discount_type"`
+	DiscountValue float64        `json:"discount_value"`
+	StartsOn      string         `json:"starts_on,omitempty"`
+	EndsOn        string         `json:"ends_on,omitempty"`
+	DaysOfWeek    []int32        `json:"days_of_week"`
+	StartTime     string         `json:"start_time,omitempty"`
+	EndTime       string         `json:"end_time,omitempty"`
+	IsActive      bool           `json:"is_active"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+}
+
+// CreatePromotionRequest describes a promotion. "2 for 1" deals are expressed as a
+// 50% percentage discount with a matching label. It is also used for full updates.
+type CreatePromotionRequest struct {
+	Name          string         `json:"name" binding:"required,max=255"`
+	Label         string         `json:"label" binding:"required,max=100"`
+	Scope         PromotionScope `json:"scope" binding:"required,oneof=restaurant category item"`
+	CategoryID    *uuid.UUID     `json:"category_id"`
+	MenuItemID    *uuid.UUID     `json:"menu_item_id"`
+	DiscountType  DiscountType   `json:"discount_type" binding:"required,oneof=percentage fixed"`
+	DiscountValue float64        `json:"discount_value" binding:"required,gt=0"`
+	StartsOn      string         `json:"starts_on"`
+	EndsOn        string         `json:"ends_on"`
+	DaysOfWeek    []int32        `json:"days_of_week" binding:"dive,min=0,max=6"`
+	StartTime     string         `json:"start_time"`
+	EndTime       string         `json:"end_time"`
+	IsActive      *bool          `json:"is_active"`
+}
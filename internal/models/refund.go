@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RefundInvoiceRequest asks for a paid invoice to be refunded. Without an amount the
+// rest of the invoice that has not been refunded yet is returned.
+type RefundInvoiceRequest struct {
+	Amount *float64 `json:"amount" binding:"omitempty,gt=0"`
+	Reason string   `json:"reason" binding:"required,max=500"`
+	// CancelSubscription ends the invoice's subscription now and moves the owner to the free plan
+	CancelSubscription bool `json:"cancel_subscription"`
+}
+
+// CreditNote records a refund against a paid invoice. Amounts include VAT.
+type CreditNote struct {
+	ID                uuid.UUID `json:"id"`
+	CreditNoteNumber  string    `json:"credit_note_number"`
+	InvoiceID         uuid.UUID `json:"invoice_id"`
+	OwnerID           uuid.UUID `json:"owner_id"`
+	Amount            float64   `json:"amount"`
+	Currency          string    `json:"currency"`
+	VATRate           float64   `json:"vat_rate"`
+	VATAmount         float64   `json:"vat_amount"`
+	Reason            string    `json:"reason"`
+	ProviderRefundRef string    `json:"provider_refund_ref,omitempty"`
+	// SubscriptionTerminated is set when the subscription was ended with the refund
+	SubscriptionTerminated bool      `json:"subscription_terminated"`
+	CreatedBy              uuid.UUID `json:"created_by"`
+	CreatedAt              time.Time `json:"created_at"`
+}
+
+// RefundResponse is the credit note issued and the invoice after the refund
+type RefundResponse struct {
+	CreditNote *CreditNote `json:"credit_note"`
+	Invoice    *Invoice    `json:"invoice"`
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReservationStatus string
+
+const (
+	ReservationStatusPending   ReservationStatus = "pending"
+	ReservationStatusConfirmed ReservationStatus = "confirmed"
+	ReservationStatusSeated    ReservationStatus = "seated"
+	ReservationStatusNoShow    ReservationStatus = "no_show"
+	ReservationStatusCancelled ReservationStatus = "cancelled"
+)
+
+// ReservationTimeLayout is the restaurant-local format guests and staff use for booking times.
+const ReservationTimeLayout = "2006-01-02T15:04"
+
+type Reservation struct {
+	ID           uuid.UUID         `json:"id"`
+	RestaurantID uuid.UUID         `json:"restaurant_id"`
+	TableID      *uuid.UUID        `json:"table_id,omitempty"`
+	TableLabel   string            `json:"table_label,omitempty"`
+	GuestName    string            `json:"guest_name"`
+	GuestPhone   string            `json:"guest_phone"`
+	GuestEmail   string            `json:"guest_email"`
+	PartySize    int32             `json:"party_size"`
+	ReservedAt   time.Time         `json:"reserved_at"`
+	EndsAt       time.Time         `json:"ends_at"`
+	Notes        string            `json:"notes,omitempty"`
+	Status       ReservationStatus `json:"status"`
+	ConfirmedAt  *time.Time        `json:"confirmed_at,omitempty"`
+	SeatedAt     *time.Time        `json:"seated_at,omitempty"`
+	CancelledAt  *time.Time        `json:"cancelled_at,omitempty"`
+	CreatedAt    time.Time         `json:"created_at"`
+}
+
+type ReservationSlot struct {
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	Available bool      `json:"available"`
+}
+
+type OpeningHours struct {
+	DayOfWeek int32  `json:"day_of_week" binding:"min=0,max=6"`
+	OpensAt   string `json:"opens_at" binding:"required"`
+	ClosesAt  string `json:"closes_at" binding:"required"`
+}
+
+type ReservationSettings struct {
+	RestaurantID          uuid.UUID      `json:"restaurant_id"`
+	IsEnabled             bool           `json:"is_enabled"`
+	SlotIntervalMinutes   int32          `json:"slot_interval_minutes"`
+	DiningDurationMinutes int32          `json:"dining_duration_minutes"`
+	MaxPartySize          int32          `json:"max_party_size"`
+	BookingWindowDays     int32          `json:"booking_window_days"`
+	OpeningHours          []OpeningHours `json:"opening_hours"`
+}
+
+type UpdateReservationSettingsRequest struct {
+	IsEnabled             bool           `json:"is_enabled"`
+	SlotIntervalMinutes   int32          `json:"slot_interval_minutes" binding:"required,min=5,max=240"`
+	DiningDurationMinutes int32          `json:"dining_duration_minutes" binding:"required,min=15,max=480"`
+	MaxPartySize          int32          `json:"max_party_size" binding:"required,min=1,max=100"`
+	BookingWindowDays     int32          `json:"booking_window_days" binding:"required,min=1,max=365"`
+	OpeningHours          []OpeningHours `json:"opening_hours" binding:"dive"`
+}
+
+type CreateReservationRequest struct {
+	GuestName  string `json:"guest_name" binding:"required,max=255"`
+	GuestPhone string `json:"guest_phone" binding:"required,max=50"`
+	GuestEmail string `json:"guest_email" binding:"required,email"`
+	PartySize  int32  `json:"party_size" binding:"required,min=1"`
+	ReservedAt string `json:"reserved_at" binding:"required"`
+	Notes      string `json:"notes" binding:"max=1000"`
+}
@@ -21,13 +21,17 @@ type Restaurant struct {
 	Address       string          `json:"address,omitempty"`
 	City          string          `json:"city,omitempty"`
 	Country       string          `json:"country,omitempty"`
+	Timezone      string          `json:"timezone"`
 	LogoURL       string          `json:"logo_url,omitempty"`
 	CoverImageURL string          `json:"cover_image_url,omitempty"`
 	ThemeSettings json.RawMessage `json:"theme_settings"`
 	IsPublished   bool            `json:"is_published"`
+	PlanLocked    bool            `json:"plan_locked"`
 	Status        string          `json:"status"`
 	ViewCount     int32           `json:"view_count"`
 	RankScore     float64         `json:"rank_score"`
+	RatingAvg     float64         `json:"rating_avg"`
+	RatingCount   int32           `json:"rating_count"`
 	CreatedAt     time.Time       `json:"created_at"`
 	UpdatedAt     time.Time       `json:"updated_at"`
 }
@@ -58,6 +62,7 @@ type UpdateRestaurantRequest struct {
 	Address       *string               `form:"address,omitempty"`
 	City          *string               `form:"city,omitempty"`
 	Country       *string               `form:"country,omitempty"`
+	Timezone      *string               `form:"timezone,omitempty"` // IANA name, e.g. Africa/Addis_Ababa
 	ThemeSettings json.RawMessage       `form:"theme_settings,omitempty"`
 	IsPublished   *bool                 `form:"is_published,omitempty"`
 	Logo          *multipart.FileHeader `form:"logo,omitempty"`
@@ -72,4 +77,5 @@ type RestaurantFilter struct {
 	City        *string `json:"city,omitempty"`
 	Country     *string `json:"country,omitempty"`
 	IsPublished *bool   `json:"is_published,omitempty"`
+	SortBy      *string `json:"sort_by,omitempty"`
 }
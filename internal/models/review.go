@@ -0,0 +1,41 @@
+package models
+
+import (
+	"mime/multipart"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Review struct {
+	ID           uuid.UUID  `json:"id"`
+	RestaurantID uuid.UUID  `json:"restaurant_id"`
+	MenuItemID   *uuid.UUID `json:"menu_item_id,omitempty"`
+	MenuItemName string     `json:"menu_item_name,omitempty"`
+	ReviewerName string     `json:"reviewer_name"`
+	Rating       int32      `json:"rating"`
+	Comment      string     `json:"comment,omitempty"`
+	PhotoURL     string     `json:"photo_url,omitempty"`
+	OwnerReply   string     `json:"owner_reply,omitempty"`
+	RepliedAt    *time.Time `json:"replied_at,omitempty"`
+	IsHidden     bool       `json:"is_hidden"`
+	HiddenReason string     `json:"hidden_reason,omitempty"`
+	ReportCount  int32      `json:"report_count"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+type CreateReviewRequest struct {
+	ReviewerName string                `form:"reviewer_name" binding:"required,max=100"`
+	Rating       int32                 `form:"rating" binding:"required,min=1,max=5"`
+	Comment      string                `form:"comment" binding:"max=2000"`
+	Photo        *multipart.FileHeader `form:"photo,omitempty"`
+}
+
+type ReplyToReviewRequest struct {
+	Reply string `json:"reply" binding:"required,max=2000"`
+}
+
+type ModerateReviewRequest struct {
+	IsHidden bool   `json:"is_hidden"`
+	Reason   string `json:"reason" binding:"max=500"`
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ServiceRequestType string
+
+const (
+	ServiceRequestCallWaiter  ServiceRequestType = "call_waiter"
+	ServiceRequestRequestBill ServiceRequestType = "request_bill"
+	ServiceRequestCustom      ServiceRequestType = "custom"
+)
+
+type RestaurantTable struct {
+	ID           uuid.UUID `json:"id"`
+	RestaurantID uuid.UUID `json:"restaurant_id"`
+	Label        string    `json:"label"`
+	Token        string    `json:"token"`
+	Seats        int32     `json:"seats"`
+	IsActive     bool      `json:"is_active"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type ServiceRequest struct {
+	ID             uuid.UUID          `json:"id"`
+	RestaurantID   uuid.UUID          `json:"restaurant_id"`
+	TableID        uuid.UUID          `json:"table_id"`
+	TableLabel     string             `json:"table_label,omitempty"`
+	RequestType    ServiceRequestType `json:"request_type"`
+	Note           string             `json:"note,omitempty"`
+	Status         string             `json:"status"`
+	AcknowledgedBy *uuid.UUID         `json:"acknowledged_by,omitempty"`
+	AcknowledgedAt *time.Time         `json:"acknowledged_at,omitempty"`
+	CreatedAt      time.Time          `json:"created_at"`
+}
+
+type ServiceRequestStats struct {
+	PeriodDays            int     `json:"period_days"`
+	AcknowledgedCount     int64   `json:"acknowledged_count"`
+	AverageAckLatencySecs float64 `json:"average_ack_latency_seconds"`
+}
+
+type CreateRestaurantTableRequest struct {
+	Label string `json:"label" binding:"required"`
+	Seats int32  `json:"seats" binding:"omitempty,min=1,max=50"`
+}
+
+type CreateServiceRequestRequest struct {
+	RequestType ServiceRequestType `json:"request_type" binding:"required,oneof=call_waiter request_bill custom"`
+	Note        string             `json:"note" binding:"max=500"`
+}
+
+// TableInfo is what a guest sees after scanning a table QR code.
+type TableInfo struct {
+	TableLabel     string    `json:"table_label"`
+	RestaurantID   uuid.UUID `json:"restaurant_id"`
+	RestaurantName string    `json:"restaurant_name"`
+	RestaurantSlug string    `json:"restaurant_slug"`
+}
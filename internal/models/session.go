@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session is one login on one device. It lives as long as its refresh token chain,
+// and LastSeenAt moves forward each time the access token is refreshed.
+type Session struct {
+	ID         uuid.UUID `json:"id"`
+	DeviceType string    `json:"device_type"`
+	Browser    string    `json:"browser"`
+	OS         string    `json:"os"`
+	IPAddress  string    `json:"ip_address"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"`
+}
@@ -0,0 +1,121 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Permissions checked per route for staff. Owners hold every permission on their restaurants.
+const (
+	PermissionMenuView              = "menu.view"
+	PermissionMenuEdit              = "menu.edit"
+	PermissionMenuAvailability      = "menu.availability"
+	PermissionAnalyticsView         = "analytics.view"
+	PermissionActivityView          = "activity.view"
+	PermissionTablesManage          = "tables.manage"
+	PermissionServiceRequestsHandle = "service_requests.handle"
+	PermissionReservationsManage    = "reservations.manage"
+	PermissionReservationSettings   = "reservations.settings"
+	PermissionReviewsView           = "reviews.view"
+	PermissionReviewsReply          = "reviews.reply"
+	PermissionPromotionsManage      = "promotions.manage"
+)
+
+type PermissionInfo struct {
+	Key         string `json:"key"`
+	Description string `json:"description"`
+}
+
+// Permissions is the catalog owners pick from when building a role
+var Permissions = []PermissionInfo{
+	{PermissionMenuView, "View categories and items in the dashboard"},
+	{PermissionMenuEdit, "Create, update and delete categories and items"},
+	{PermissionMenuAvailability, "Update stock levels and 86 or restore items"},
+	{PermissionAnalyticsView, "View analytics and service request statistics"},
+	{PermissionActivityView, "View the restaurant activity log"},
+	{PermissionTablesManage, "Create and delete tables and their QR codes"},
+	{PermissionServiceRequestsHandle, "See and acknowledge table service requests"},
+	{PermissionReservationsManage, "View reservations, seat guests and mark no-shows"},
+	{PermissionReservationSettings, "Change reservation settings"},
+	{PermissionReviewsView, "Read reviews, including hidden ones"},
+	{PermissionReviewsReply, "Reply to reviews"},
+	{PermissionPromotionsManage, "Create and manage promotions"},
+}
+
+func IsValidPermission(key string) bool {
+	for _, p := range Permissions {
+		if p.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// StaffRolePreset is a built-in role seeded for every restaurant
+type StaffRolePreset struct {
+	Key         string
+	Name        string
+	Description string
+	Permissions []string
+}
+
+// StaffRolePresetFloor is given to staff invited without a role; it matches what staff
+// could do before roles existed. Keep the presets in sync with migration 015.
+const StaffRolePresetFloor = "floor_staff"
+
+var StaffRolePresets = []StaffRolePreset{
+	{
+		Key:         StaffRolePresetFloor,
+		Name:        "Floor staff",
+		Description: "Handles table service requests, reservations and item availability",
+		Permissions: []string{PermissionMenuView, PermissionMenuAvailability, PermissionServiceRequestsHandle, PermissionReservationsManage},
+	},
+	{
+		Key:         "menu_editor",
+		Name:        "Menu editor",
+		Description: "Manages categories, items and availability",
+		Permissions: []string{PermissionMenuView, PermissionMenuEdit, PermissionMenuAvailability},
+	},
+	{
+		Key:         "availability_only",
+		Name:        "Availability only",
+		Description: "Updates stock levels and 86s items",
+		Permissions: []string{PermissionMenuView, PermissionMenuAvailability},
+	},
+	{
+		Key:         "analytics_viewer",
+		Name:        "Analytics viewer",
+		Description: "Read-only access to analytics, activity and reviews",
+		Permissions: []string{PermissionAnalyticsView, PermissionActivityView, PermissionReviewsView},
+	},
+}
+
+type StaffRole struct {
+	ID           uuid.UUID `json:"id"`
+	RestaurantID uuid.UUID `json:"restaurant_id"`
+	Name         string    `json:"name"`
+	Description  string    `json:"description,omitempty"`
+	Permissions  []string  `json:"permissions"`
+	Preset       string    `json:"preset,omitempty"`
+	IsBuiltIn    bool      `json:"is_built_in"`
+	StaffCount   int64     `json:"staff_count"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type CreateStaffRoleRequest struct {
+	Name        string   `json:"name" binding:"required,max=100"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions" binding:"required,min=1"`
+}
+
+type UpdateStaffRoleRequest struct {
+	Name        *string  `json:"name,omitempty" binding:"omitempty,min=1,max=100"`
+	Description *string  `json:"description,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+}
+
+type AssignStaffRoleRequest struct {
+	RoleID uuid.UUID `json:"role_id" binding:"required"`
+}
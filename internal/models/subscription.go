@@ -15,15 +15,24 @@ const (
 	SubscriptionStatusTrialing  SubscriptionStatus = "trialing"
 )
 
+type BillingInterval string
+
+const (
+	BillingIntervalMonthly BillingInterval = "monthly"
+	BillingIntervalAnnual  BillingInterval = "annual"
+)
+
 type Subscription struct {
 	ID                            uuid.UUID          `json:"id"`
 	OwnerID                       uuid.UUID          `json:"owner_id"`
 	PlanID                        uuid.UUID          `json:"plan_id"`
 	Status                        SubscriptionStatus `json:"status"`
+	BillingInterval               BillingInterval    `json:"billing_interval"`
 	CurrentPeriodStart            time.Time          `json:"current_period_start"`
 	CurrentPeriodEnd              time.Time          `json:"current_period_end"`
 	TrialEnd                      *time.Time         `json:"trial_end,omitempty"`
 	CancelledAt                   *time.Time         `json:"cancelled_at,omitempty"`
+	CancelAtPeriodEnd             bool               `json:"cancel_at_period_end"`
 	PaymentProviderSubscriptionID string             `json:"payment_provider_subscription_id,omitempty"`
 	CreatedAt                     time.Time          `json:"created_at"`
 	UpdatedAt                     time.Time          `json:"updated_at"`
@@ -50,14 +59,65 @@ type SubscriptionPlan struct {
 }
 
 type SubscriptionDetailsResponse struct {
-	PlanName      string             `json:"plan_name"`
-	PlanSlug      string             `json:"plan_slug"`
-	Price         int32              `json:"price"`
-	Currency      string             `json:"currency"`
-	Status        SubscriptionStatus `json:"status"`
-	StartDate     time.Time          `json:"start_date"`
-	EndDate       time.Time          `json:"end_date"`
-	TrialEnd      *time.Time         `json:"trial_end,omitempty"`
-	DaysRemaining int                `json:"days_remaining"`
-	Features      FeatureLimits      `json:"features"`
+	PlanName        string             `json:"plan_name"`
+	PlanSlug        string             `json:"plan_slug"`
+	BillingInterval BillingInterval    `json:"billing_interval"`
+	Price           int32              `json:"price"` // For one billing interval
+	Currency        string             `json:"currency"`
+	Status          SubscriptionStatus `json:"status"`
+	StartDate       time.Time          `json:"start_date"`
+	EndDate         time.Time          `json:"end_date"`
+	TrialEnd        *time.Time         `json:"trial_end,omitempty"`
+	DaysRemaining   int                `json:"days_remaining"`
+	Features        FeatureLimits      `json:"features"`
+	// CancelAtPeriodEnd is set when the owner cancelled and the plan ends at EndDate
+	CancelAtPeriodEnd bool `json:"cancel_at_period_end"`
+	// ScheduledChange is set when a downgrade takes effect at the end of the period
+	ScheduledChange *ScheduledPlanChange `json:"scheduled_change,omitempty"`
+}
+
+// CancelSubscriptionRequest captures why an owner leaves, for churn analysis
+type CancelSubscriptionRequest struct {
+	Reason   string `json:"reason" binding:"required,oneof=too_expensive missing_features switched_service not_using technical_issues other"`
+	Feedback string `json:"feedback" binding:"max=2000"`
+}
+
+// Plan change kinds returned by the proration preview
+const (
+	PlanChangeNew       = "new"       // No paid period to credit, the new plan starts now
+	PlanChangeRenewal   = "renewal"   // Same plan and interval, the next period follows the current one
+	PlanChangeUpgrade   = "upgrade"   // Takes effect on payment, unused time on the current plan is credited
+	PlanChangeDowngrade = "downgrade" // Scheduled for the end of the current period, nothing to pay now
+)
+
+// ProrationPreview is what a plan change costs right now and when it takes effect
+type ProrationPreview struct {
+	Kind            string          `json:"kind"`
+	CurrentPlan     string          `json:"current_plan,omitempty"`
+	CurrentInterval BillingInterval `json:"current_interval,omitempty"`
+	NewPlan         string          `json:"new_plan"`
+	NewInterval     BillingInterval `json:"new_interval"`
+	Currency        string          `json:"currency"`
+	// Price is the full price of the new plan for one interval
+	Price float64 `json:"price"`
+	// Charge is the new plan's price for the period being paid for
+	Charge float64 `json:"charge"`
+	// Credit is the unused time left on the current plan
+	Credit float64 `json:"credit"`
+	// Discount is taken off by the coupon in CouponCode
+	Discount      float64   `json:"discount"`
+	CouponCode    string    `json:"coupon_code,omitempty"`
+	AmountDue     float64   `json:"amount_due"`
+	RemainingDays int       `json:"remaining_days"`
+	EffectiveAt   time.Time `json:"effective_at"`
+	PeriodStart   time.Time `json:"period_start"`
+	PeriodEnd     time.Time `json:"period_end"`
+}
+
+// ScheduledPlanChange is a downgrade waiting for the end of the current period
+type ScheduledPlanChange struct {
+	PlanName        string          `json:"plan_name"`
+	PlanSlug        string          `json:"plan_slug"`
+	BillingInterval BillingInterval `json:"billing_interval"`
+	EffectiveAt     time.Time       `json:"effective_at"`
 }
@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+type TwoFactorStatus struct {
+	Enabled                bool       `json:"enabled"`
+	EnabledAt              *time.Time `json:"enabled_at,omitempty"`
+	RecoveryCodesRemaining int        `json:"recovery_codes_remaining"`
+	// Required is true when platform settings make 2FA mandatory for this account
+	Required bool `json:"required"`
+}
+
+// TwoFactorSetupResponse carries the secret to enroll in an authenticator app. The
+// provisioning URI is what the client renders as a QR code.
+type TwoFactorSetupResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// TwoFactorCodeRequest accepts either an authenticator code or a recovery code
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type DisableTwoFactorRequest struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+type TwoFactorChallengeRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+}
+
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+}
+
+type SecuritySettings struct {
+	RequireAdminTwoFactor bool      `json:"require_admin_two_factor"`
+	UpdatedAt             time.Time `json:"updated_at"`
+}
+
+type UpdateSecuritySettingsRequest struct {
+	RequireAdminTwoFactor *bool `json:"require_admin_two_factor" binding:"required"`
+}
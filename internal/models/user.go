@@ -30,6 +30,9 @@ type User struct {
 	IsActive      bool       `json:"is_active"`
 	CreatedAt     time.Time  `json:"created_at"`
 	UpdatedAt     time.Time  `json:"updated_at"`
+
+	MustChangePassword bool       `json:"must_change_password"`
+	StaffRoleID        *uuid.UUID `json:"staff_role_id,omitempty"`
 }
 
 type CreateUserRequest struct {
@@ -42,11 +45,12 @@ type CreateUserRequest struct {
 }
 
 type CreateStaffRequest struct {
-	Email        string    `json:"email" binding:"required,email"`
-	FullName     string    `json:"full_name" binding:"required"`
-	Phone        string    `json:"phone,omitempty"`
-	RestaurantID uuid.UUID `json:"restaurant_id" binding:"required"`
-	OwnerID      uuid.UUID `json:"-"` // Set from context
+	Email        string     `json:"email" binding:"required,email"`
+	FullName     string     `json:"full_name" binding:"required"`
+	Phone        string     `json:"phone,omitempty"`
+	RoleID       *uuid.UUID `json:"role_id,omitempty"` // Defaults to the floor staff role
+	RestaurantID uuid.UUID  `json:"-"`                 // Set from path
+	OwnerID      uuid.UUID  `json:"-"`                 // Set from context
 }
 
 type UpdateUserRequest struct {
@@ -57,8 +61,33 @@ type UpdateUserRequest struct {
 }
 
 type LoginRequest struct {
-	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required"`
+	Email    string     `json:"email" binding:"required,email"`
+	Password string     `json:"password" binding:"required"`
+	Client   ClientInfo `json:"-"` // Set from the request
+}
+
+// ClientInfo identifies where an authentication request came from
+type ClientInfo struct {
+	IPAddress string
+	UserAgent string
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,min=8"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=8"`
 }
 
 type AuthResponse struct {
@@ -66,4 +95,10 @@ type AuthResponse struct {
 	AccessToken  string `json:"access_token"`
 	RefreshToken string `json:"refresh_token"`
 	CheckoutURL  string `json:"checkout_url,omitempty"`
+	// Set instead of tokens when the user must choose a new password before logging in
+	PasswordResetToken string `json:"password_reset_token,omitempty"`
+	// Set instead of tokens when a second factor is needed to finish logging in
+	TwoFactorToken      string   `json:"two_factor_token,omitempty"`
+	TwoFactorSetupToken string   `json:"two_factor_setup_token,omitempty"`
+	RecoveryCodes       []string `json:"recovery_codes,omitempty"`
 }
@@ -13,12 +13,28 @@ import (
 )
 
 type Service struct {
-	queries *persistence.Queries
+	queries  *persistence.Queries
+	sessions SessionManager
+	accounts AccountUnlocker
 }
 
-func NewService(queries *persistence.Queries) *Service {
+// SessionManager lists a user's sessions and logs them out everywhere, so deactivation
+// takes effect immediately
+type SessionManager interface {
+	ListSessions(ctx context.Context, userID uuid.UUID, currentFamilyID string) ([]*models.Session, error)
+	RevokeAllSessions(ctx context.Context, userID uuid.UUID) error
+}
+
+// AccountUnlocker lifts a login lockout caused by repeated failed attempts
+type AccountUnlocker interface {
+	UnlockAccount(ctx context.Context, userID, adminID uuid.UUID) error
+}
+
+func NewService(queries *persistence.Queries, sessions SessionManager, accounts AccountUnlocker) *Service {
 	return &Service{
-		queries: queries,
+		queries:  queries,
+		sessions: sessions,
+		accounts: accounts,
 	}
 }
 
@@ -94,5 +110,61 @@ func (s *Service) UpdateUserStatus(ctx context.Context, userID uuid.UUID, isActi
 		log.Printf("[AdminService] UpdateUserStatus error: %v", err)
 		return nil, err
 	}
+
+	if !isActive && s.sessions != nil {
+		if err := s.sessions.RevokeAllSessions(ctx, userID); err != nil {
+			log.Printf("[AdminService] Failed to revoke sessions for deactivated user %v: %v", userID, err)
+		}
+	}
 	return &user, nil
 }
+
+func (s *Service) ListUserSessions(ctx context.Context, userID uuid.UUID) ([]*models.Session, error) {
+	log.Printf("[AdminService] Listing sessions for user: %v", userID)
+	sessions, err := s.sessions.ListSessions(ctx, userID, "")
+	if err != nil {
+		log.Printf("[AdminService] ListUserSessions error: %v", err)
+		return nil, err
+	}
+	return sessions, nil
+}
+
+func (s *Service) UnlockUser(ctx context.Context, userID, adminID uuid.UUID) error {
+	log.Printf("[AdminService] Unlocking user: %v", userID)
+	if err := s.accounts.UnlockAccount(ctx, userID, adminID); err != nil {
+		log.Printf("[AdminService] UnlockUser error: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (s *Service) GetSecuritySettings(ctx context.Context) (*models.SecuritySettings, error) {
+	settings, err := s.queries.GetPlatformSettings(ctx)
+	if err != nil {
+		log.Printf("[AdminService] GetPlatformSettings error: %v", err)
+		return nil, err
+	}
+	return mapSecuritySettings(settings), nil
+}
+
+// UpdateSecuritySettings changes platform-wide security policy. Requiring 2FA for admins
+// takes effect at their next login; admins without 2FA are asked to enroll first.
+func (s *Service) UpdateSecuritySettings(ctx context.Context, adminID uuid.UUID, input models.UpdateSecuritySettingsRequest) (*models.SecuritySettings, error) {
+	log.Printf("[AdminService] Updating security settings: require_admin_two_factor=%v", *input.RequireAdminTwoFactor)
+	settings, err := s.queries.UpdatePlatformSettings(ctx, persistence.UpdatePlatformSettingsParams{
+		RequireAdminTwoFactor: *input.RequireAdminTwoFactor,
+		UpdatedBy:             adminID,
+	})
+	if err != nil {
+		log.Printf("[AdminService] UpdatePlatformSettings error: %v", err)
+		return nil, err
+	}
+	return mapSecuritySettings(settings), nil
+}
+
+func mapSecuritySettings(row persistence.PlatformSetting) *models.SecuritySettings {
+	return &models.SecuritySettings{
+		RequireAdminTwoFactor: row.RequireAdminTwoFactor,
+		UpdatedAt:             row.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,209 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"menuvista/internal/models"
+	"menuvista/internal/storage/persistence"
+	"menuvista/internal/utils"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const maxKeysPerRestaurant = 20
+
+var (
+	ErrRestaurantAccess  = errors.New("unauthorized: you do not own this restaurant")
+	ErrKeyNotFound       = errors.New("API key not found or already revoked")
+	ErrKeyLimitReached   = fmt.Errorf("a restaurant can have at most %d active API keys", maxKeysPerRestaurant)
+	ErrInvalidPermission = errors.New("permission not available to API keys")
+	ErrInvalidAPIKey     = errors.New("invalid API key")
+	ErrAPIKeyExpired     = errors.New("API key has expired")
+)
+
+type Service struct {
+	queries *persistence.Queries
+}
+
+func NewService(queries *persistence.Queries) *Service {
+	return &Service{queries: queries}
+}
+
+// CreateKey issues a new key. The full key is only in the response; MenuVista keeps its hash.
+func (s *Service) CreateKey(ctx context.Context, ownerID, restaurantID uuid.UUID, input models.CreateAPIKeyRequest) (*models.CreatedAPIKey, error) {
+	if err := s.verifyOwnership(ctx, ownerID, restaurantID); err != nil {
+		return nil, err
+	}
+
+	var permissions []string
+	for _, p := range input.Permissions {
+		if !models.IsValidAPIKeyPermission(p) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidPermission, p)
+		}
+		if !slices.Contains(permissions, p) {
+			permissions = append(permissions, p)
+		}
+	}
+
+	existing, err := s.queries.ListAPIKeysByRestaurant(ctx, restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+	active := 0
+	for _, k := range existing {
+		if isUsable(k) {
+			active++
+		}
+	}
+	if active >= maxKeysPerRestaurant {
+		return nil, ErrKeyLimitReached
+	}
+
+	key, prefix, err := utils.GenerateAPIKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate API key: %w", err)
+	}
+
+	var expiresAt pgtype.Timestamp
+	if input.ExpiresInDays != nil {
+		expiresAt = pgtype.Timestamp{Time: time.Now().AddDate(0, 0, *input.ExpiresInDays), Valid: true}
+	}
+
+	row, err := s.queries.CreateAPIKey(ctx, persistence.CreateAPIKeyParams{
+		RestaurantID: restaurantID,
+		CreatedBy:    ownerID,
+		Name:         input.Name,
+		KeyPrefix:    prefix,
+		KeyHash:      utils.HashToken(key),
+		Permissions:  permissions,
+		ExpiresAt:    expiresAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	log.Printf("[APIKeyService] Key %s created for restaurant %v", prefix, restaurantID)
+	return &models.CreatedAPIKey{APIKey: *mapAPIKey(row), Key: key}, nil
+}
+
+func (s *Service) ListKeys(ctx context.Context, ownerID, restaurantID uuid.UUID) ([]*models.APIKey, error) {
+	if err := s.verifyOwnership(ctx, ownerID, restaurantID); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.ListAPIKeysByRestaurant(ctx, restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+
+	keys := make([]*models.APIKey, len(rows))
+	for i, row := range rows {
+		keys[i] = mapAPIKey(row)
+	}
+	return keys, nil
+}
+
+func (s *Service) RevokeKey(ctx context.Context, ownerID, restaurantID, keyID uuid.UUID) error {
+	if err := s.verifyOwnership(ctx, ownerID, restaurantID); err != nil {
+		return err
+	}
+
+	rows, err := s.queries.RevokeAPIKey(ctx, persistence.RevokeAPIKeyParams{
+		ID:           keyID,
+		RestaurantID: restaurantID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+	if rows == 0 {
+		return ErrKeyNotFound
+	}
+
+	log.Printf("[APIKeyService] Key %v revoked by %v", keyID, ownerID)
+	return nil
+}
+
+// Authenticate resolves a presented key. Keys stop working when revoked, when they expire
+// or when the owner who created them is deactivated.
+func (s *Service) Authenticate(ctx context.Context, key, ipAddress string) (*models.APIKeyPrincipal, error) {
+	row, err := s.queries.GetAPIKeyByHash(ctx, utils.HashToken(key))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrInvalidAPIKey
+		}
+		return nil, fmt.Errorf("failed to fetch API key: %w", err)
+	}
+	if row.RevokedAt.Valid {
+		return nil, ErrInvalidAPIKey
+	}
+	if row.ExpiresAt.Valid && !row.ExpiresAt.Time.After(time.Now()) {
+		return nil, ErrAPIKeyExpired
+	}
+
+	owner, err := s.queries.GetUserByID(ctx, row.CreatedBy)
+	if err != nil || !owner.IsActive {
+		return nil, ErrInvalidAPIKey
+	}
+
+	// Recorded at most once a minute per key, see TouchAPIKey
+	if err := s.queries.TouchAPIKey(ctx, persistence.TouchAPIKeyParams{
+		ID:         row.ID,
+		LastUsedIp: pgtype.Text{String: ipAddress, Valid: ipAddress != ""},
+	}); err != nil {
+		log.Printf("[APIKeyService] Warning: Failed to record use of key %s: %v", row.KeyPrefix, err)
+	}
+
+	return &models.APIKeyPrincipal{
+		KeyID:        row.ID,
+		RestaurantID: row.RestaurantID,
+		OwnerID:      row.CreatedBy,
+		Permissions:  row.Permissions,
+	}, nil
+}
+
+func (s *Service) verifyOwnership(ctx context.Context, ownerID, restaurantID uuid.UUID) error {
+	restaurant, err := s.queries.GetRestaurantByID(ctx, restaurantID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch restaurant: %w", err)
+	}
+	if restaurant.OwnerID != ownerID {
+		return ErrRestaurantAccess
+	}
+	return nil
+}
+
+func isUsable(row persistence.ApiKey) bool {
+	return !row.RevokedAt.Valid && (!row.ExpiresAt.Valid || row.ExpiresAt.Time.After(time.Now()))
+}
+
+func mapAPIKey(row persistence.ApiKey) *models.APIKey {
+	key := &models.APIKey{
+		ID:           row.ID,
+		RestaurantID: row.RestaurantID,
+		Name:         row.Name,
+		Prefix:       row.KeyPrefix,
+		Permissions:  row.Permissions,
+		LastUsedIP:   row.LastUsedIp.String,
+		CreatedAt:    row.CreatedAt.Time,
+	}
+	if key.Permissions == nil {
+		key.Permissions = []string{}
+	}
+	if row.ExpiresAt.Valid {
+		key.ExpiresAt = &row.ExpiresAt.Time
+	}
+	if row.LastUsedAt.Valid {
+		key.LastUsedAt = &row.LastUsedAt.Time
+	}
+	if row.RevokedAt.Valid {
+		key.RevokedAt = &row.RevokedAt.Time
+	}
+	return key
+}
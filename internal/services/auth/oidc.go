@@ -0,0 +1,292 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+
+	"menuvista/internal/models"
+	"menuvista/internal/storage/persistence"
+	"menuvista/internal/utils"
+	"menuvista/platform/oidc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrOIDCProviderNotFound = errors.New("sign-in provider not found")
+	ErrOIDCInvalidState     = errors.New("invalid or expired sign-in request, please start again")
+	ErrOIDCEmailNotVerified = errors.New("the provider did not confirm your email address")
+	ErrOIDCLoginFailed      = errors.New("sign-in with the provider failed")
+	ErrAccountDisabled      = errors.New("account is disabled")
+
+	redisKeyOIDCState = "oidc_state:"
+)
+
+// OIDCStateTTL is how long the user has to finish signing in at the provider, in seconds
+const OIDCStateTTL = 10 * 60
+
+// oidcLoginState is kept in Redis between the redirect to the provider and the callback
+type oidcLoginState struct {
+	Provider     string `json:"provider"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+}
+
+// OIDCProviders lists the configured sign-in providers by name.
+func (s *Service) OIDCProviders() []string {
+	names := make([]string, 0, len(s.oidcProviders))
+	for name := range s.oidcProviders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// BeginOIDCLogin starts an authorization-code flow with PKCE. The nonce and code verifier
+// stay server side; the client gets the URL to redirect to, and the state is also returned
+// so the handler can bind it to the browser with a cookie.
+func (s *Service) BeginOIDCLogin(ctx context.Context, providerName string) (*models.OIDCAuthorization, error) {
+	provider, ok := s.oidcProviders[providerName]
+	if !ok {
+		return nil, ErrOIDCProviderNotFound
+	}
+
+	state, err := oidc.RandomString()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate state: %w", err)
+	}
+	nonce, err := oidc.RandomString()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	verifier, err := oidc.RandomString()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate code verifier: %w", err)
+	}
+
+	authURL, err := provider.AuthCodeURL(ctx, state, nonce, verifier)
+	if err != nil {
+		log.Printf("[AuthService] OIDC discovery for %s failed: %v", providerName, err)
+		return nil, ErrOIDCLoginFailed
+	}
+
+	payload, err := json.Marshal(oidcLoginState{Provider: providerName, Nonce: nonce, CodeVerifier: verifier})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.redis.Set(ctx, redisKeyOIDCState+state, string(payload), OIDCStateTTL); err != nil {
+		return nil, fmt.Errorf("failed to store sign-in state: %w", err)
+	}
+
+	return &models.OIDCAuthorization{
+		Provider:         providerName,
+		AuthorizationURL: authURL,
+		State:            state,
+	}, nil
+}
+
+// CompleteOIDCLogin redeems the code returned to the callback, verifies the ID token and
+// logs the matching user in. Identities are linked to existing accounts by verified email;
+// unknown emails get a new owner account on the free trial.
+func (s *Service) CompleteOIDCLogin(ctx context.Context, providerName string, input models.OIDCCallbackRequest) (*models.AuthResponse, error) {
+	provider, ok := s.oidcProviders[providerName]
+	if !ok {
+		return nil, ErrOIDCProviderNotFound
+	}
+
+	// The state must come back to the browser that started the sign-in, otherwise an
+	// attacker could complete their own sign-in in the victim's browser
+	if input.BrowserState == "" || subtle.ConstantTimeCompare([]byte(input.BrowserState), []byte(input.State)) != 1 {
+		return nil, ErrOIDCInvalidState
+	}
+
+	loginState, err := s.consumeOIDCState(ctx, input.State)
+	if err != nil {
+		return nil, err
+	}
+	if loginState.Provider != providerName {
+		return nil, ErrOIDCInvalidState
+	}
+
+	rawIDToken, err := provider.Exchange(ctx, input.Code, loginState.CodeVerifier)
+	if err != nil {
+		log.Printf("[AuthService] OIDC code exchange with %s failed: %v", providerName, err)
+		return nil, ErrOIDCLoginFailed
+	}
+	claims, err := provider.VerifyIDToken(ctx, rawIDToken, loginState.Nonce)
+	if err != nil {
+		log.Printf("[AuthService] OIDC ID token from %s rejected: %v", providerName, err)
+		return nil, ErrOIDCLoginFailed
+	}
+
+	userRow, err := s.resolveOIDCUser(ctx, providerName, claims)
+	if err != nil {
+		return nil, err
+	}
+	if !userRow.IsActive {
+		s.writeAuthAudit(ctx, userRow.ID, userRow.Email, persistence.AuthEventLoginFailure, providerName+" sign-in to disabled account", input.Client, uuid.Nil)
+		return nil, ErrAccountDisabled
+	}
+
+	if challenge, err := s.checkTwoFactor(ctx, userRow); err != nil {
+		return challenge, err
+	}
+
+	return s.completeLogin(ctx, userRow, input.Client)
+}
+
+func (s *Service) consumeOIDCState(ctx context.Context, state string) (*oidcLoginState, error) {
+	payload, err := s.redis.Get(ctx, redisKeyOIDCState+state)
+	if err != nil {
+		return nil, ErrOIDCInvalidState
+	}
+	// Only the request that deletes the key may use it, so a state cannot be replayed
+	deleted, err := s.redis.Del(ctx, redisKeyOIDCState+state)
+	if err != nil || deleted == 0 {
+		return nil, ErrOIDCInvalidState
+	}
+
+	var loginState oidcLoginState
+	if err := json.Unmarshal([]byte(payload), &loginState); err != nil {
+		return nil, ErrOIDCInvalidState
+	}
+	return &loginState, nil
+}
+
+// resolveOIDCUser finds the account for a provider identity, linking or creating it on
+// first sign-in.
+func (s *Service) resolveOIDCUser(ctx context.Context, providerName string, claims *oidc.Claims) (persistence.User, error) {
+	identity, err := s.queries.GetUserIdentity(ctx, persistence.GetUserIdentityParams{
+		Provider: providerName,
+		Subject:  claims.Subject,
+	})
+	if err == nil {
+		_ = s.queries.TouchUserIdentity(ctx, identity.ID)
+		userRow, err := s.queries.GetUserByID(ctx, identity.UserID)
+		if err != nil {
+			return persistence.User{}, fmt.Errorf("failed to fetch linked user: %w", err)
+		}
+		return userRow, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return persistence.User{}, fmt.Errorf("failed to fetch identity: %w", err)
+	}
+
+	// Linking by email is only safe when the provider vouches for the address
+	if claims.Email == "" || !claims.EmailVerified {
+		return persistence.User{}, ErrOIDCEmailNotVerified
+	}
+
+	userRow, err := s.queries.GetUserByEmail(ctx, claims.Email)
+	switch {
+	case err == nil:
+		if !userRow.EmailVerified {
+			// A registration still waiting for its activation email: the provider proved
+			// ownership of the address, so activate it the same way the email link would.
+			// Whoever registered it may not own the address, so their password and any
+			// sessions stop working; the owner can set a password with forgot-password.
+			if err := s.replaceUnverifiedPassword(ctx, userRow.ID); err != nil {
+				return persistence.User{}, err
+			}
+			userRow, err = s.queries.UpdateUser(ctx, persistence.UpdateUserParams{
+				ID:            userRow.ID,
+				IsActive:      pgtype.Bool{Bool: true, Valid: true},
+				EmailVerified: pgtype.Bool{Bool: true, Valid: true},
+			})
+			if err != nil {
+				return persistence.User{}, fmt.Errorf("failed to activate user: %w", err)
+			}
+			if userRow.Role == persistence.UserRoleOwner {
+				s.startFreeTrial(ctx, userRow.ID)
+			}
+		}
+		log.Printf("[AuthService] Linking %s identity to existing user %v", providerName, userRow.ID)
+	case errors.Is(err, pgx.ErrNoRows):
+		if userRow, err = s.createOIDCOwner(ctx, claims); err != nil {
+			return persistence.User{}, err
+		}
+	default:
+		return persistence.User{}, fmt.Errorf("failed to fetch user: %w", err)
+	}
+
+	if _, err := s.queries.CreateUserIdentity(ctx, persistence.CreateUserIdentityParams{
+		UserID:   userRow.ID,
+		Provider: providerName,
+		Subject:  claims.Subject,
+		Email:    pgtype.Text{String: claims.Email, Valid: true},
+	}); err != nil {
+		return persistence.User{}, fmt.Errorf("failed to link identity: %w", err)
+	}
+	return userRow, nil
+}
+
+// replaceUnverifiedPassword gives an account a random password and logs it out everywhere
+func (s *Service) replaceUnverifiedPassword(ctx context.Context, userID uuid.UUID) error {
+	password, err := oidc.RandomString()
+	if err != nil {
+		return fmt.Errorf("failed to generate password: %w", err)
+	}
+	if err := s.setPassword(ctx, userID, password); err != nil {
+		return err
+	}
+	if err := s.RevokeAllSessions(ctx, userID); err != nil {
+		log.Printf("[AuthService] Failed to revoke sessions for user %v: %v", userID, err)
+	}
+	return nil
+}
+
+// createOIDCOwner registers a new owner for a provider identity. The account is active
+// straight away because the provider verified the email, and it gets a random password
+// the owner can replace through the forgot-password flow.
+func (s *Service) createOIDCOwner(ctx context.Context, claims *oidc.Claims) (persistence.User, error) {
+	log.Printf("[AuthService] Registering owner %s from OIDC sign-in", claims.Email)
+
+	password, err := oidc.RandomString()
+	if err != nil {
+		return persistence.User{}, fmt.Errorf("failed to generate password: %w", err)
+	}
+	hashedPassword, err := utils.HashPassword(password)
+	if err != nil {
+		return persistence.User{}, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	fullName := claims.Name
+	if fullName == "" {
+		fullName = claims.Email
+	}
+
+	id := uuid.New()
+	userRow, err := s.queries.CreateUser(ctx, persistence.CreateUserParams{
+		ID:           id,
+		Email:        claims.Email,
+		PasswordHash: hashedPassword,
+		FullName:     fullName,
+		Role:         persistence.UserRoleOwner,
+		OwnerID:      id,
+		AvatarUrl:    pgtype.Text{String: claims.Picture, Valid: claims.Picture != ""},
+	})
+	if err != nil {
+		return persistence.User{}, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	userRow, err = s.queries.UpdateUser(ctx, persistence.UpdateUserParams{
+		ID:            userRow.ID,
+		IsActive:      pgtype.Bool{Bool: true, Valid: true},
+		EmailVerified: pgtype.Bool{Bool: true, Valid: true},
+	})
+	if err != nil {
+		return persistence.User{}, fmt.Errorf("failed to activate user: %w", err)
+	}
+
+	s.startFreeTrial(ctx, userRow.ID)
+	s.sendWelcomeEmailAsync(s.mapToDomainUser(userRow))
+
+	return userRow, nil
+}
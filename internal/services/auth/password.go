@@ -0,0 +1,150 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+
+	"menuvista/internal/storage/persistence"
+	"menuvista/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidResetToken      = errors.New("invalid or expired password reset token")
+	ErrPasswordChangeRequired = errors.New("password change required")
+	ErrIncorrectPassword      = errors.New("current password is incorrect")
+
+	redisKeyPasswordReset     = "password_reset:"
+	redisKeyUserPasswordReset = "user_password_reset:"
+)
+
+const (
+	passwordResetTTL      = 60 * 60 // 1 hour
+	firstLoginPasswordTTL = 15 * 60 // 15 minutes
+)
+
+// ForgotPassword emails a reset link to the account owner. It always succeeds so the
+// endpoint cannot be used to find out which emails are registered.
+func (s *Service) ForgotPassword(ctx context.Context, email string) error {
+	userRow, err := s.queries.GetUserByEmail(ctx, email)
+	if err != nil {
+		log.Printf("[AuthService] Password reset requested for unknown email: %s", email)
+		return nil
+	}
+	if !userRow.IsActive {
+		log.Printf("[AuthService] Password reset requested for inactive user %v, ignoring", userRow.ID)
+		return nil
+	}
+
+	token, err := s.createPasswordResetToken(ctx, userRow.ID, passwordResetTTL)
+	if err != nil {
+		return fmt.Errorf("failed to create reset token: %w", err)
+	}
+
+	domainUser := s.mapToDomainUser(userRow)
+	resetURL := os.Getenv("APP_BASE_URL") + "/reset-password?token=" + token
+	go func() {
+		if err := s.emailService.SendPasswordResetEmail(context.Background(), domainUser, resetURL); err != nil {
+			log.Printf("[AuthService] Failed to send password reset email: %v", err)
+		}
+	}()
+
+	return nil
+}
+
+// ResetPassword sets a new password using a token from ForgotPassword or a first login.
+// Tokens are single use and every existing session is logged out.
+func (s *Service) ResetPassword(ctx context.Context, token, newPassword string) error {
+	userIDStr, err := s.redis.Get(ctx, redisKeyPasswordReset+token)
+	if err != nil {
+		return ErrInvalidResetToken
+	}
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return ErrInvalidResetToken
+	}
+
+	// Deleting the token claims it; a second request with the same token gets nothing.
+	deleted, err := s.redis.Del(ctx, redisKeyPasswordReset+token)
+	if err != nil {
+		return fmt.Errorf("failed to consume reset token: %w", err)
+	}
+	if deleted == 0 {
+		return ErrInvalidResetToken
+	}
+	_, _ = s.redis.Del(ctx, redisKeyUserPasswordReset+userIDStr)
+
+	if err := s.setPassword(ctx, userID, newPassword); err != nil {
+		return err
+	}
+
+	log.Printf("[AuthService] Password reset for user %v", userID)
+	return s.RevokeAllSessions(ctx, userID)
+}
+
+// ChangePassword updates the password of a logged-in user after checking the current one.
+// Other sessions are logged out; the session making the change stays signed in.
+func (s *Service) ChangePassword(ctx context.Context, userID uuid.UUID, familyID, currentPassword, newPassword string) error {
+	userRow, err := s.queries.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("user not found: %w", err)
+	}
+
+	if !utils.CheckPasswordHash(currentPassword, userRow.PasswordHash) {
+		return ErrIncorrectPassword
+	}
+
+	if err := s.setPassword(ctx, userID, newPassword); err != nil {
+		return err
+	}
+
+	families, err := s.redis.SMembers(ctx, redisKeyUserFamilies+utils.UUIDToString(userID))
+	if err != nil {
+		return fmt.Errorf("failed to list sessions: %w", err)
+	}
+	for _, f := range families {
+		if f != familyID {
+			s.revokeTokenFamily(ctx, userID, f)
+		}
+	}
+
+	log.Printf("[AuthService] Password changed for user %v", userID)
+	return nil
+}
+
+func (s *Service) setPassword(ctx context.Context, userID uuid.UUID, password string) error {
+	hash, err := utils.HashPassword(password)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	err = s.queries.UpdateUserPassword(ctx, persistence.UpdateUserPasswordParams{
+		ID:           userID,
+		PasswordHash: hash,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+	return nil
+}
+
+// createPasswordResetToken issues a reset token, replacing any token the user already had.
+func (s *Service) createPasswordResetToken(ctx context.Context, userID uuid.UUID, ttl int) (string, error) {
+	userKey := redisKeyUserPasswordReset + utils.UUIDToString(userID)
+	if previous, err := s.redis.Get(ctx, userKey); err == nil {
+		_, _ = s.redis.Del(ctx, redisKeyPasswordReset+previous)
+	}
+
+	token := uuid.New().String()
+	if err := s.redis.Set(ctx, redisKeyPasswordReset+token, utils.UUIDToString(userID), ttl); err != nil {
+		return "", err
+	}
+	if err := s.redis.Set(ctx, userKey, token, ttl); err != nil {
+		return "", err
+	}
+	return token, nil
+}
@@ -10,9 +10,11 @@ import (
 
 	"menuvista/internal/models"
 	"menuvista/internal/services/payment"
+	"menuvista/internal/services/subscription"
 	"menuvista/internal/storage/persistence"
 	"menuvista/internal/utils"
 	"menuvista/platform/cache"
+	"menuvista/platform/oidc"
 	"menuvista/platform/storage"
 
 	"github.com/google/uuid"
@@ -25,12 +27,15 @@ type Service struct {
 	r2             *storage.R2Client
 	emailService   EmailService
 	paymentService PaymentService
+	oidcProviders  map[string]*oidc.Provider
 }
 
 // EmailService interface for sending emails
 type EmailService interface {
 	SendWelcomeEmail(ctx context.Context, user *models.User) error
 	SendVerificationEmail(ctx context.Context, user *models.User, token string) error
+	SendPasswordResetEmail(ctx context.Context, user *models.User, resetURL string) error
+	SendAccountLockedEmail(ctx context.Context, user *models.User, ipAddress, unlockAt string) error
 }
 
 // PaymentService interface for initiating payments
@@ -45,6 +50,7 @@ func NewService(queries *persistence.Queries, redis *cache.RedisClient, r2 *stor
 		r2:             r2,
 		emailService:   emailService,
 		paymentService: paymentService,
+		oidcProviders:  oidc.LoadProviders(),
 	}
 }
 
@@ -118,7 +124,7 @@ func (s *Service) GenerateActivationToken(ctx context.Context, userID uuid.UUID)
 	return token, nil
 }
 
-func (s *Service) ActivateUser(ctx context.Context, token string) (*models.AuthResponse, error) {
+func (s *Service) ActivateUser(ctx context.Context, token string, client models.ClientInfo) (*models.AuthResponse, error) {
 	userIDStr, err := s.redis.Get(ctx, redisKeyActivationToken+token)
 	if err != nil {
 		return nil, errors.New("invalid or expired activation token")
@@ -138,26 +144,7 @@ func (s *Service) ActivateUser(ctx context.Context, token string) (*models.AuthR
 		return nil, fmt.Errorf("failed to activate user: %w", err)
 	}
 
-	// Create Free Trial Subscription
-	// 1. Get Free Plan
-	freePlan, err := s.queries.GetSubscriptionPlanBySlug(ctx, "free-trial")
-	if err != nil {
-		log.Printf("[AuthService] Warning: Free plan not found, skipping trial creation: %v", err)
-	} else {
-		// 2. Create Subscription
-		trialEnd := time.Now().AddDate(0, 0, 14) // 14 days trial
-		_, err = s.queries.CreateSubscription(ctx, persistence.CreateSubscriptionParams{
-			OwnerID:            userID,
-			PlanID:             freePlan.ID,
-			Status:             persistence.SubscriptionStatusTrialing,
-			CurrentPeriodStart: pgtype.Timestamp{Time: time.Now(), Valid: true},
-			CurrentPeriodEnd:   pgtype.Timestamp{Time: trialEnd, Valid: true},
-			TrialEnd:           pgtype.Timestamp{Time: trialEnd, Valid: true},
-		})
-		if err != nil {
-			log.Printf("[AuthService] Warning: Failed to create trial subscription: %v", err)
-		}
-	}
+	s.startFreeTrial(ctx, userID)
 
 	// Delete tokens from Redis
 	// s.redis.Del(ctx, redisKeyActivationToken+token) // Need Del method in RedisClient
@@ -166,7 +153,31 @@ func (s *Service) ActivateUser(ctx context.Context, token string) (*models.AuthR
 	domainUser := s.mapToDomainUser(userRow)
 	s.sendWelcomeEmailAsync(domainUser)
 
-	return s.generateAuthResponse(ctx, userRow, "")
+	return s.generateAuthResponse(ctx, userRow, "", "", client)
+}
+
+// startFreeTrial gives a newly activated owner the 14 day free trial. Failures are only
+// logged so activation still succeeds.
+func (s *Service) startFreeTrial(ctx context.Context, userID uuid.UUID) {
+	freePlan, err := s.queries.GetSubscriptionPlanBySlug(ctx, "free-trial")
+	if err != nil {
+		log.Printf("[AuthService] Warning: Free plan not found, skipping trial creation: %v", err)
+		return
+	}
+
+	trialEnd := time.Now().AddDate(0, 0, 14) // 14 days trial
+	_, err = s.queries.CreateSubscription(ctx, persistence.CreateSubscriptionParams{
+		OwnerID:            userID,
+		PlanID:             freePlan.ID,
+		Status:             persistence.SubscriptionStatusTrialing,
+		CurrentPeriodStart: pgtype.Timestamp{Time: time.Now(), Valid: true},
+		CurrentPeriodEnd:   pgtype.Timestamp{Time: trialEnd, Valid: true},
+		TrialEnd:           pgtype.Timestamp{Time: trialEnd, Valid: true},
+		BillingInterval:    persistence.BillingIntervalMonthly,
+	})
+	if err != nil {
+		log.Printf("[AuthService] Warning: Failed to create trial subscription: %v", err)
+	}
 }
 
 func (s *Service) ResendActivationEmail(ctx context.Context, email string) error {
@@ -227,7 +238,8 @@ func (s *Service) handleOwnerSubscription(ctx context.Context, user persistence.
 		PlanID:             plan.ID,
 		Status:             status,
 		CurrentPeriodStart: pgtype.Timestamp{Time: time.Now(), Valid: true},
-		CurrentPeriodEnd:   pgtype.Timestamp{Time: time.Now().AddDate(0, 1, 0), Valid: true},
+		CurrentPeriodEnd:   pgtype.Timestamp{Time: payment.PeriodEnd(time.Now(), persistence.BillingIntervalMonthly), Valid: true},
+		BillingInterval:    persistence.BillingIntervalMonthly,
 	})
 	if err != nil {
 		log.Printf("[AuthService] Warning: Failed to create initial subscription: %v", err)
@@ -261,7 +273,7 @@ func (s *Service) sendWelcomeEmailAsync(user *models.User) {
 	}
 }
 
-func (s *Service) generateAuthResponse(ctx context.Context, user persistence.User, checkoutURL string) (*models.AuthResponse, error) {
+func (s *Service) generateAuthResponse(ctx context.Context, user persistence.User, checkoutURL string, familyID string, client models.ClientInfo) (*models.AuthResponse, error) {
 	var subStatus string
 	var subEnd *time.Time
 
@@ -276,14 +288,12 @@ func (s *Service) generateAuthResponse(ctx context.Context, user persistence.Use
 		}
 	}
 
-	tokenDetails, err := utils.CreateToken(user.ID, string(user.Role), &user.OwnerID, &user.RestaurantID, subStatus, subEnd)
+	tokenDetails, err := utils.CreateToken(user.ID, string(user.Role), &user.OwnerID, &user.RestaurantID, subStatus, subEnd, familyID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create tokens: %w", err)
 	}
 
-	// Store token metadata in Redis
-	_ = s.redis.Set(ctx, tokenDetails.AccessUUID, utils.UUIDToString(user.ID), int(tokenDetails.AtExpires-time.Now().Unix()))
-	_ = s.redis.Set(ctx, tokenDetails.RefreshUUID, utils.UUIDToString(user.ID), int(tokenDetails.RtExpires-time.Now().Unix()))
+	s.storeTokenMetadata(ctx, user.ID, tokenDetails, client)
 
 	return &models.AuthResponse{
 		User:         *s.mapToDomainUser(user),
@@ -296,25 +306,128 @@ func (s *Service) generateAuthResponse(ctx context.Context, user persistence.Use
 var (
 	ErrPaymentRequired      = errors.New("payment required")
 	ErrSubscriptionInactive = errors.New("subscription inactive")
+	ErrInvalidRefreshToken  = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused   = errors.New("refresh token reuse detected, please log in again")
 
 	// Redis keys
 	redisKeyActivationToken = "activation_token:"
 	redisKeyUserActivation  = "user_activation:"
+	redisKeyRefreshFamily   = "refresh_family:"
+	redisKeyUserFamilies    = "user_token_families:"
 )
 
+// RefreshTokens exchanges a refresh token for a new token pair. Each refresh token is single use:
+// presenting one that was already rotated revokes every token issued from the same login.
+func (s *Service) RefreshTokens(ctx context.Context, refreshToken string, client models.ClientInfo) (*models.AuthResponse, error) {
+	claims, err := utils.ParseRefreshToken(refreshToken)
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	familyKey := redisKeyRefreshFamily + claims.FamilyID
+	currentAccessUUID, err := s.redis.Get(ctx, familyKey)
+	if err != nil {
+		// The family was revoked or has expired.
+		return nil, ErrInvalidRefreshToken
+	}
+
+	// Deleting the refresh UUID is the rotation itself; only one caller can win it.
+	deleted, err := s.redis.Del(ctx, claims.RefreshUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+	if deleted == 0 {
+		log.Printf("[AuthService] Refresh token reuse detected for user %v, revoking family %s", claims.UserID, claims.FamilyID)
+		s.revokeTokenFamily(ctx, claims.UserID, claims.FamilyID)
+		return nil, ErrRefreshTokenReused
+	}
+
+	userRow, err := s.queries.GetUserByID(ctx, claims.UserID)
+	if err != nil || !userRow.IsActive {
+		s.revokeTokenFamily(ctx, claims.UserID, claims.FamilyID)
+		return nil, ErrInvalidRefreshToken
+	}
+
+	// The access token paired with the rotated refresh token is retired with it.
+	_, _ = s.redis.Del(ctx, currentAccessUUID)
+
+	log.Printf("[AuthService] Rotating refresh token for user %v", userRow.ID)
+	return s.generateAuthResponse(ctx, userRow, "", claims.FamilyID, client)
+}
+
+// Logout revokes the access and refresh tokens of the current login only.
+func (s *Service) Logout(ctx context.Context, userID uuid.UUID, familyID string) error {
+	if familyID == "" {
+		return errors.New("token does not belong to a session")
+	}
+	log.Printf("[AuthService] Logging out user %v (family %s)", userID, familyID)
+	s.revokeTokenFamily(ctx, userID, familyID)
+	return nil
+}
+
+// RevokeAllSessions logs the user out on every device. It backs logout-all and
+// takes effect immediately when an admin deactivates an account.
+func (s *Service) RevokeAllSessions(ctx context.Context, userID uuid.UUID) error {
+	families, err := s.redis.SMembers(ctx, redisKeyUserFamilies+utils.UUIDToString(userID))
+	if err != nil {
+		return fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	log.Printf("[AuthService] Revoking %d sessions for user %v", len(families), userID)
+	for _, familyID := range families {
+		s.revokeTokenFamily(ctx, userID, familyID)
+	}
+	if _, err := s.redis.Del(ctx, redisKeyUserFamilies+utils.UUIDToString(userID)); err != nil {
+		return fmt.Errorf("failed to clear sessions: %w", err)
+	}
+	return nil
+}
+
+// storeTokenMetadata records the issued token UUIDs in Redis. The family key points at the
+// family's current access UUID and lives as long as its newest refresh token.
+func (s *Service) storeTokenMetadata(ctx context.Context, userID uuid.UUID, td *utils.TokenDetails, client models.ClientInfo) {
+	rtTTL := int(td.RtExpires - time.Now().Unix())
+	_ = s.redis.Set(ctx, td.AccessUUID, utils.UUIDToString(userID), int(td.AtExpires-time.Now().Unix()))
+	_ = s.redis.Set(ctx, td.RefreshUUID, utils.UUIDToString(userID), rtTTL)
+	_ = s.redis.Set(ctx, redisKeyRefreshFamily+td.FamilyID, td.AccessUUID, rtTTL)
+	_ = s.redis.SAdd(ctx, redisKeyUserFamilies+utils.UUIDToString(userID), td.FamilyID, rtTTL)
+	s.recordSession(ctx, userID, td, client)
+}
+
+func (s *Service) revokeTokenFamily(ctx context.Context, userID uuid.UUID, familyID string) {
+	familyKey := redisKeyRefreshFamily + familyID
+	keys := []string{familyKey}
+	if accessUUID, err := s.redis.Get(ctx, familyKey); err == nil {
+		keys = append(keys, accessUUID)
+	}
+	if _, err := s.redis.Del(ctx, keys...); err != nil {
+		log.Printf("[AuthService] Failed to revoke token family %s: %v", familyID, err)
+	}
+	_ = s.redis.SRem(ctx, redisKeyUserFamilies+utils.UUIDToString(userID), familyID)
+
+	if sessionID, err := uuid.Parse(familyID); err == nil {
+		if err := s.queries.RevokeUserSession(ctx, sessionID); err != nil {
+			log.Printf("[AuthService] Failed to mark session %s revoked: %v", familyID, err)
+		}
+	}
+}
+
 func (s *Service) Login(ctx context.Context, input models.LoginRequest) (*models.AuthResponse, error) {
 	log.Printf("[AuthService] Login attempt for: %s", input.Email)
 
-	userRow, err := s.queries.GetUserByEmail(ctx, input.Email)
-	fmt.Println("this is the input", input)
-	fmt.Println("this is the user row", userRow)
-	fmt.Println("this is the error", err)
+	email := normalizeEmail(input.Email)
+	if err := s.checkLoginThrottle(ctx, email, input.Client.IPAddress); err != nil {
+		return nil, err
+	}
 
+	userRow, err := s.queries.GetUserByEmail(ctx, email)
 	if err != nil {
+		s.recordLoginFailure(ctx, email, nil, input.Client, "unknown email")
 		return nil, errors.New("invalid credentials")
 	}
 
 	if !utils.CheckPasswordHash(input.Password, userRow.PasswordHash) {
+		s.recordLoginFailure(ctx, email, &userRow, input.Client, "wrong password")
 		return nil, errors.New("invalid credentials")
 	}
 
@@ -341,6 +454,29 @@ func (s *Service) Login(ctx context.Context, input models.LoginRequest) (*models
 		return nil, errors.New("account is not activated. please check your email")
 	}
 
+	// Accounts created with a temporary password must set their own before getting tokens
+	if userRow.MustChangePassword {
+		token, err := s.createPasswordResetToken(ctx, userRow.ID, firstLoginPasswordTTL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create reset token: %w", err)
+		}
+		return &models.AuthResponse{
+			PasswordResetToken: token,
+		}, ErrPasswordChangeRequired
+	}
+
+	if challenge, err := s.checkTwoFactor(ctx, userRow); err != nil {
+		return challenge, err
+	}
+
+	return s.completeLogin(ctx, userRow, input.Client)
+}
+
+// completeLogin finishes a login once every factor has been verified: it enforces the
+// subscription rules and issues the token pair.
+func (s *Service) completeLogin(ctx context.Context, userRow persistence.User, client models.ClientInfo) (*models.AuthResponse, error) {
+	s.recordLoginSuccess(ctx, userRow, client)
+
 	// Update last login
 	_, _ = s.queries.UpdateUser(ctx, persistence.UpdateUserParams{
 		ID:          userRow.ID,
@@ -370,12 +506,13 @@ func (s *Service) Login(ctx context.Context, input models.LoginRequest) (*models
 				_, err := s.queries.GetSubscriptionPlanBySlug(ctx, sub.PlanSlug)
 				if err == nil && s.paymentService != nil {
 					payResp, err := s.paymentService.InitiatePayment(ctx, payment.InitiatePaymentInput{
-						OwnerID:        userID,
-						SubscriptionID: sub.ID,
-						Plan:           sub.PlanSlug,
-						Email:          userRow.Email,
-						Name:           userRow.FullName,
-						Type:           "renewal", // or activation
+						OwnerID:         userID,
+						SubscriptionID:  sub.ID,
+						Plan:            sub.PlanSlug,
+						Email:           userRow.Email,
+						Name:            userRow.FullName,
+						Type:            "renewal", // or activation
+						BillingInterval: string(sub.BillingInterval),
 					})
 					if err == nil {
 						checkoutURL = payResp.CheckoutURL
@@ -399,10 +536,10 @@ func (s *Service) Login(ctx context.Context, input models.LoginRequest) (*models
 	} else if userRow.Role == persistence.UserRoleStaff {
 		// Check owner's subscription
 		if &ownerID != nil {
-			sub, err := s.queries.GetActiveSubscriptionByOwner(ctx, ownerID)
+			sub, err := s.queries.GetEffectiveSubscriptionByOwner(ctx, ownerID)
 			if err == nil {
-				isActive := sub.Status == persistence.SubscriptionStatusActive || sub.Status == persistence.SubscriptionStatusTrialing
-				if !isActive {
+				// Staff accounts are above the free plan's limits
+				if sub.PlanSlug == subscription.FreePlanSlug {
 					return nil, ErrSubscriptionInactive
 				}
 			} else {
@@ -413,14 +550,12 @@ func (s *Service) Login(ctx context.Context, input models.LoginRequest) (*models
 		}
 	}
 
-	tokenDetails, err := utils.CreateToken(userID, string(userRow.Role), &ownerID, &restaurantID, subStatus, subEnd)
+	tokenDetails, err := utils.CreateToken(userID, string(userRow.Role), &ownerID, &restaurantID, subStatus, subEnd, "")
 	if err != nil {
 		return nil, fmt.Errorf("failed to create tokens: %w", err)
 	}
 
-	// Store token metadata in Redis
-	_ = s.redis.Set(ctx, tokenDetails.AccessUUID, utils.UUIDToString(userID), int(tokenDetails.AtExpires-time.Now().Unix()))
-	_ = s.redis.Set(ctx, tokenDetails.RefreshUUID, utils.UUIDToString(userID), int(tokenDetails.RtExpires-time.Now().Unix()))
+	s.storeTokenMetadata(ctx, userID, tokenDetails, client)
 
 	return &models.AuthResponse{
 		User:         *s.mapToDomainUser(userRow),
@@ -442,6 +577,11 @@ func (s *Service) mapToDomainUser(row persistence.User) *models.User {
 	ownerID := row.OwnerID
 	restaurantID := row.RestaurantID
 
+	var staffRoleID *uuid.UUID
+	if row.StaffRoleID != uuid.Nil {
+		staffRoleID = &row.StaffRoleID
+	}
+
 	var lastLogin *time.Time
 	if row.LastLoginAt.Valid {
 		lastLogin = &row.LastLoginAt.Time
@@ -461,6 +601,9 @@ func (s *Service) mapToDomainUser(row persistence.User) *models.User {
 		IsActive:      row.IsActive,
 		CreatedAt:     row.CreatedAt.Time,
 		UpdatedAt:     row.UpdatedAt.Time,
+
+		MustChangePassword: row.MustChangePassword,
+		StaffRoleID:        staffRoleID,
 	}
 }
 func (s *Service) UpdateUser(ctx context.Context, id uuid.UUID, input models.UpdateUserRequest) (*models.User, error) {
@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"menuvista/internal/models"
+	"menuvista/internal/storage/persistence"
+	"menuvista/internal/utils"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var ErrSessionNotFound = errors.New("session not found")
+
+// recordSession creates the session row for a new token family, or refreshes its
+// device details and last-seen time when the family is rotated.
+func (s *Service) recordSession(ctx context.Context, userID uuid.UUID, td *utils.TokenDetails, client models.ClientInfo) {
+	sessionID, err := uuid.Parse(td.FamilyID)
+	if err != nil {
+		log.Printf("[AuthService] Invalid token family %q, session not recorded", td.FamilyID)
+		return
+	}
+
+	ua := utils.ParseUserAgent(client.UserAgent)
+	err = s.queries.UpsertUserSession(ctx, persistence.UpsertUserSessionParams{
+		ID:         sessionID,
+		UserID:     userID,
+		IpAddress:  pgtype.Text{String: client.IPAddress, Valid: client.IPAddress != ""},
+		UserAgent:  pgtype.Text{String: client.UserAgent, Valid: client.UserAgent != ""},
+		DeviceType: pgtype.Text{String: ua.DeviceType, Valid: true},
+		Browser:    pgtype.Text{String: ua.Browser, Valid: true},
+		Os:         pgtype.Text{String: ua.OS, Valid: true},
+		ExpiresAt:  pgtype.Timestamp{Time: time.Unix(td.RtExpires, 0), Valid: true},
+	})
+	if err != nil {
+		log.Printf("[AuthService] Failed to record session %s: %v", td.FamilyID, err)
+	}
+}
+
+// ListSessions returns the user's active sessions, flagging the one making the request.
+func (s *Service) ListSessions(ctx context.Context, userID uuid.UUID, currentFamilyID string) ([]*models.Session, error) {
+	rows, err := s.queries.ListActiveUserSessions(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	sessions := make([]*models.Session, len(rows))
+	for i, row := range rows {
+		sessions[i] = &models.Session{
+			ID:         row.ID,
+			DeviceType: row.DeviceType.String,
+			Browser:    row.Browser.String,
+			OS:         row.Os.String,
+			IPAddress:  row.IpAddress.String,
+			CreatedAt:  row.CreatedAt.Time,
+			LastSeenAt: row.LastSeenAt.Time,
+			ExpiresAt:  row.ExpiresAt.Time,
+			Current:    row.ID.String() == currentFamilyID,
+		}
+	}
+	return sessions, nil
+}
+
+// RevokeSession logs out a single session belonging to the user.
+func (s *Service) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	session, err := s.queries.GetUserSession(ctx, persistence.GetUserSessionParams{
+		ID:     sessionID,
+		UserID: userID,
+	})
+	if err != nil || session.RevokedAt.Valid {
+		return ErrSessionNotFound
+	}
+
+	log.Printf("[AuthService] Revoking session %v for user %v", sessionID, userID)
+	s.revokeTokenFamily(ctx, userID, sessionID.String())
+	return nil
+}
@@ -0,0 +1,177 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"menuvista/internal/models"
+	"menuvista/internal/storage/persistence"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrTooManyAttempts = errors.New("too many login attempts, please try again later")
+	ErrAccountLocked   = errors.New("account temporarily locked after too many failed logins")
+
+	redisKeyLoginFailuresEmail = "login_failures:email:"
+	redisKeyLoginFailuresIP    = "login_failures:ip:"
+	redisKeyLoginBackoffEmail  = "login_backoff:email:"
+	redisKeyLoginBackoffIP     = "login_backoff:ip:"
+	redisKeyLoginLockout       = "login_lockout:"
+)
+
+const (
+	loginFailureWindow   = 15 * 60 // failures are counted over 15 minutes
+	loginLockoutDuration = 30 * 60 // 30 minutes
+	maxEmailFailures     = 5       // failures before the account is locked
+	maxIPFailures        = 20      // failures before the address is blocked for the window
+	emailBackoffAfter    = 2       // failures before per-account backoff starts
+	ipBackoffAfter       = 5       // failures before per-address backoff starts
+	maxBackoffSeconds    = 5 * 60
+)
+
+// ThrottledError tells the client how long to wait before trying to log in again.
+type ThrottledError struct {
+	RetryAfter time.Duration
+	Locked     bool
+}
+
+func (e *ThrottledError) Error() string {
+	return fmt.Sprintf("%s (retry in %ds)", e.Unwrap().Error(), int(e.RetryAfter.Seconds()))
+}
+
+func (e *ThrottledError) Unwrap() error {
+	if e.Locked {
+		return ErrAccountLocked
+	}
+	return ErrTooManyAttempts
+}
+
+// checkLoginThrottle rejects attempts for locked accounts and while a backoff is running.
+func (s *Service) checkLoginThrottle(ctx context.Context, email, ip string) error {
+	if ttl, err := s.redis.TTL(ctx, redisKeyLoginLockout+email); err == nil && ttl > 0 {
+		return &ThrottledError{RetryAfter: ttl, Locked: true}
+	}
+
+	var wait time.Duration
+	for _, key := range []string{redisKeyLoginBackoffEmail + email, redisKeyLoginBackoffIP + ip} {
+		if ttl, err := s.redis.TTL(ctx, key); err == nil && ttl > wait {
+			wait = ttl
+		}
+	}
+	if wait > 0 {
+		return &ThrottledError{RetryAfter: wait}
+	}
+	return nil
+}
+
+// recordLoginFailure counts a failed attempt against the email and the IP address. Each
+// failure doubles the wait before the next attempt; enough failures lock the account.
+func (s *Service) recordLoginFailure(ctx context.Context, email string, userRow *persistence.User, client models.ClientInfo, reason string) {
+	var userID uuid.UUID
+	if userRow != nil {
+		userID = userRow.ID
+	}
+	s.writeAuthAudit(ctx, userID, email, persistence.AuthEventLoginFailure, reason, client, uuid.Nil)
+
+	ipFailures, err := s.redis.Incr(ctx, redisKeyLoginFailuresIP+client.IPAddress, loginFailureWindow)
+	if err != nil {
+		log.Printf("[AuthService] Failed to count login failure for IP %s: %v", client.IPAddress, err)
+	} else if ipFailures >= maxIPFailures {
+		_ = s.redis.Set(ctx, redisKeyLoginBackoffIP+client.IPAddress, ipFailures, loginFailureWindow)
+	} else if delay := backoffSeconds(ipFailures, ipBackoffAfter); delay > 0 {
+		_ = s.redis.Set(ctx, redisKeyLoginBackoffIP+client.IPAddress, ipFailures, delay)
+	}
+
+	emailFailures, err := s.redis.Incr(ctx, redisKeyLoginFailuresEmail+email, loginFailureWindow)
+	if err != nil {
+		log.Printf("[AuthService] Failed to count login failure for %s: %v", email, err)
+		return
+	}
+
+	if emailFailures < maxEmailFailures {
+		if delay := backoffSeconds(emailFailures, emailBackoffAfter); delay > 0 {
+			_ = s.redis.Set(ctx, redisKeyLoginBackoffEmail+email, emailFailures, delay)
+		}
+		return
+	}
+
+	// Lock the account; the counters restart once the lock is lifted
+	if err := s.redis.Set(ctx, redisKeyLoginLockout+email, emailFailures, loginLockoutDuration); err != nil {
+		log.Printf("[AuthService] Failed to lock account %s: %v", email, err)
+		return
+	}
+	_, _ = s.redis.Del(ctx, redisKeyLoginFailuresEmail+email, redisKeyLoginBackoffEmail+email)
+
+	log.Printf("[AuthService] Account %s locked after %d failed logins", email, emailFailures)
+	s.writeAuthAudit(ctx, userID, email, persistence.AuthEventAccountLocked, fmt.Sprintf("%d failed logins", emailFailures), client, uuid.Nil)
+
+	if userRow != nil {
+		domainUser := s.mapToDomainUser(*userRow)
+		unlockAt := time.Now().Add(loginLockoutDuration * time.Second).Format("Jan 2, 2006 15:04 MST")
+		go func() {
+			if err := s.emailService.SendAccountLockedEmail(context.Background(), domainUser, client.IPAddress, unlockAt); err != nil {
+				log.Printf("[AuthService] Failed to send account locked email: %v", err)
+			}
+		}()
+	}
+}
+
+// recordLoginSuccess clears the account's failure history. The IP counter is left alone so
+// an attacker cannot reset it by logging into an account of their own.
+func (s *Service) recordLoginSuccess(ctx context.Context, userRow persistence.User, client models.ClientInfo) {
+	email := normalizeEmail(userRow.Email)
+	_, _ = s.redis.Del(ctx, redisKeyLoginFailuresEmail+email, redisKeyLoginBackoffEmail+email)
+	s.writeAuthAudit(ctx, userRow.ID, email, persistence.AuthEventLoginSuccess, "", client, uuid.Nil)
+}
+
+// UnlockAccount lifts a lockout early. It is used by admins and recorded in the audit log.
+func (s *Service) UnlockAccount(ctx context.Context, userID, adminID uuid.UUID) error {
+	userRow, err := s.queries.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("user not found: %w", err)
+	}
+
+	email := normalizeEmail(userRow.Email)
+	if _, err := s.redis.Del(ctx, redisKeyLoginLockout+email, redisKeyLoginFailuresEmail+email, redisKeyLoginBackoffEmail+email); err != nil {
+		return fmt.Errorf("failed to unlock account: %w", err)
+	}
+
+	log.Printf("[AuthService] Account %v unlocked by admin %v", userID, adminID)
+	s.writeAuthAudit(ctx, userID, email, persistence.AuthEventAccountUnlocked, "unlocked by admin", models.ClientInfo{}, adminID)
+	return nil
+}
+
+func (s *Service) writeAuthAudit(ctx context.Context, userID uuid.UUID, email string, event persistence.AuthEvent, reason string, client models.ClientInfo, actorID uuid.UUID) {
+	err := s.queries.CreateAuthAuditLog(ctx, persistence.CreateAuthAuditLogParams{
+		UserID:    userID,
+		Email:     email,
+		Event:     event,
+		Reason:    pgtype.Text{String: reason, Valid: reason != ""},
+		IpAddress: pgtype.Text{String: client.IPAddress, Valid: client.IPAddress != ""},
+		UserAgent: pgtype.Text{String: client.UserAgent, Valid: client.UserAgent != ""},
+		ActorID:   actorID,
+	})
+	if err != nil {
+		log.Printf("[AuthService] Failed to write auth audit log: %v", err)
+	}
+}
+
+// backoffSeconds doubles the delay for every failure past the threshold: 1s, 2s, 4s...
+func backoffSeconds(failures int64, after int64) int {
+	if failures < after {
+		return 0
+	}
+	delay := 1 << min(failures-after, 16)
+	return int(min(delay, maxBackoffSeconds))
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,392 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"menuvista/internal/models"
+	"menuvista/internal/storage/persistence"
+	"menuvista/internal/utils"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrTwoFactorRequired       = errors.New("two-factor authentication required")
+	ErrTwoFactorSetupRequired  = errors.New("two-factor authentication must be set up before logging in")
+	ErrInvalidTwoFactorCode    = errors.New("invalid two-factor code")
+	ErrInvalidChallengeToken   = errors.New("invalid or expired two-factor challenge")
+	ErrTwoFactorNotAllowed     = errors.New("two-factor authentication is only available to owners and admins")
+	ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnabled     = errors.New("two-factor authentication is not enabled")
+	ErrTwoFactorSetupNotFound  = errors.New("no two-factor setup in progress, start setup again")
+	ErrTwoFactorMandatory      = errors.New("two-factor authentication is mandatory for your role")
+
+	redisKeyTwoFactorSetup          = "two_factor_setup:"
+	redisKeyTwoFactorChallenge      = "two_factor_challenge:"
+	redisKeyTwoFactorSetupChallenge = "two_factor_setup_challenge:"
+	redisKeyTwoFactorAttempts       = "two_factor_attempts:"
+	redisKeyTOTPUsed                = "totp_used:"
+)
+
+const (
+	twoFactorIssuer            = "MenuVista"
+	twoFactorSetupTTL          = 10 * 60 // 10 minutes to scan and confirm
+	twoFactorChallengeTTL      = 5 * 60  // 5 minutes to enter the code
+	twoFactorSetupChallengeTTL = 15 * 60 // 15 minutes to enroll during a mandatory setup
+	twoFactorMaxAttempts       = 5
+	recoveryCodeCount          = 10
+)
+
+// checkTwoFactor decides whether a password-verified login needs a second step. It returns
+// a challenge for users with 2FA enabled, or a setup challenge for admins who must enroll.
+func (s *Service) checkTwoFactor(ctx context.Context, userRow persistence.User) (*models.AuthResponse, error) {
+	_, err := s.queries.GetUserTwoFactor(ctx, userRow.ID)
+	if err == nil {
+		token, err := s.issueChallenge(ctx, redisKeyTwoFactorChallenge, userRow.ID, twoFactorChallengeTTL)
+		if err != nil {
+			return nil, err
+		}
+		return &models.AuthResponse{TwoFactorToken: token}, ErrTwoFactorRequired
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to check two-factor status: %w", err)
+	}
+
+	if s.twoFactorMandatory(ctx, userRow.Role) {
+		token, err := s.issueChallenge(ctx, redisKeyTwoFactorSetupChallenge, userRow.ID, twoFactorSetupChallengeTTL)
+		if err != nil {
+			return nil, err
+		}
+		return &models.AuthResponse{TwoFactorSetupToken: token}, ErrTwoFactorSetupRequired
+	}
+
+	return nil, nil
+}
+
+// VerifyTwoFactorLogin completes a login with an authenticator or recovery code.
+// Wrong codes count as failed logins, so guessing codes leads to the same lockout as guessing passwords.
+func (s *Service) VerifyTwoFactorLogin(ctx context.Context, challengeToken, code string, client models.ClientInfo) (*models.AuthResponse, error) {
+	userID, err := s.resolveChallenge(ctx, redisKeyTwoFactorChallenge, challengeToken)
+	if err != nil {
+		return nil, err
+	}
+
+	userRow, err := s.queries.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, ErrInvalidChallengeToken
+	}
+	email := normalizeEmail(userRow.Email)
+	if err := s.checkLoginThrottle(ctx, email, client.IPAddress); err != nil {
+		return nil, err
+	}
+
+	twoFactor, err := s.queries.GetUserTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, ErrInvalidChallengeToken
+	}
+
+	if !s.verifySecondFactor(ctx, userID, twoFactor.Secret, code) {
+		s.recordLoginFailure(ctx, email, &userRow, client, "invalid two-factor code")
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	userRow, err = s.consumeChallenge(ctx, redisKeyTwoFactorChallenge, challengeToken, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[AuthService] Two-factor login verified for user %v", userID)
+	return s.completeLogin(ctx, userRow, client)
+}
+
+// BeginRequiredTwoFactorSetup starts enrollment for an admin who was stopped at login.
+func (s *Service) BeginRequiredTwoFactorSetup(ctx context.Context, challengeToken string) (*models.TwoFactorSetupResponse, error) {
+	userID, err := s.resolveChallenge(ctx, redisKeyTwoFactorSetupChallenge, challengeToken)
+	if err != nil {
+		return nil, err
+	}
+	return s.BeginTwoFactorSetup(ctx, userID)
+}
+
+// CompleteRequiredTwoFactorSetup enables 2FA from a setup challenge and logs the user in.
+// The recovery codes are returned alongside the tokens since this is the only time they are shown.
+func (s *Service) CompleteRequiredTwoFactorSetup(ctx context.Context, challengeToken, code string, client models.ClientInfo) (*models.AuthResponse, error) {
+	userID, err := s.resolveChallenge(ctx, redisKeyTwoFactorSetupChallenge, challengeToken)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, err := s.EnableTwoFactor(ctx, userID, code)
+	if err != nil {
+		return nil, err
+	}
+
+	userRow, err := s.consumeChallenge(ctx, redisKeyTwoFactorSetupChallenge, challengeToken, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.completeLogin(ctx, userRow, client)
+	if response != nil {
+		response.RecoveryCodes = codes
+	}
+	return response, err
+}
+
+func (s *Service) GetTwoFactorStatus(ctx context.Context, userID uuid.UUID) (*models.TwoFactorStatus, error) {
+	userRow, err := s.queries.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	status := &models.TwoFactorStatus{
+		Required: s.twoFactorMandatory(ctx, userRow.Role),
+	}
+
+	twoFactor, err := s.queries.GetUserTwoFactor(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return status, nil
+		}
+		return nil, fmt.Errorf("failed to fetch two-factor status: %w", err)
+	}
+
+	codes, err := s.queries.ListUnusedRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count recovery codes: %w", err)
+	}
+
+	status.Enabled = true
+	status.EnabledAt = &twoFactor.EnabledAt.Time
+	status.RecoveryCodesRemaining = len(codes)
+	return status, nil
+}
+
+// BeginTwoFactorSetup generates a new secret. It is kept in Redis until EnableTwoFactor
+// confirms the user's authenticator produces matching codes.
+func (s *Service) BeginTwoFactorSetup(ctx context.Context, userID uuid.UUID) (*models.TwoFactorSetupResponse, error) {
+	userRow, err := s.queries.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+	if userRow.Role != persistence.UserRoleOwner && userRow.Role != persistence.UserRoleAdmin {
+		return nil, ErrTwoFactorNotAllowed
+	}
+	if _, err := s.queries.GetUserTwoFactor(ctx, userID); err == nil {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate secret: %w", err)
+	}
+	if err := s.redis.Set(ctx, redisKeyTwoFactorSetup+utils.UUIDToString(userID), secret, twoFactorSetupTTL); err != nil {
+		return nil, fmt.Errorf("failed to store setup secret: %w", err)
+	}
+
+	return &models.TwoFactorSetupResponse{
+		Secret:          secret,
+		ProvisioningURI: utils.TOTPProvisioningURI(twoFactorIssuer, userRow.Email, secret),
+	}, nil
+}
+
+// EnableTwoFactor verifies the first code from the authenticator, stores the secret and
+// returns a fresh set of recovery codes.
+func (s *Service) EnableTwoFactor(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	setupKey := redisKeyTwoFactorSetup + utils.UUIDToString(userID)
+	secret, err := s.redis.Get(ctx, setupKey)
+	if err != nil {
+		return nil, ErrTwoFactorSetupNotFound
+	}
+
+	if !s.verifyTOTP(ctx, userID, secret, code) {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	if _, err := s.queries.UpsertUserTwoFactor(ctx, persistence.UpsertUserTwoFactorParams{
+		UserID: userID,
+		Secret: secret,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to enable two-factor: %w", err)
+	}
+	_, _ = s.redis.Del(ctx, setupKey)
+
+	log.Printf("[AuthService] Two-factor enabled for user %v", userID)
+	return s.replaceRecoveryCodes(ctx, userID)
+}
+
+// DisableTwoFactor turns 2FA off after checking both the password and a second factor.
+func (s *Service) DisableTwoFactor(ctx context.Context, userID uuid.UUID, password, code string) error {
+	userRow, err := s.queries.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("user not found: %w", err)
+	}
+	if !utils.CheckPasswordHash(password, userRow.PasswordHash) {
+		return ErrIncorrectPassword
+	}
+	if s.twoFactorMandatory(ctx, userRow.Role) {
+		return ErrTwoFactorMandatory
+	}
+
+	twoFactor, err := s.queries.GetUserTwoFactor(ctx, userID)
+	if err != nil {
+		return ErrTwoFactorNotEnabled
+	}
+	if !s.verifySecondFactor(ctx, userID, twoFactor.Secret, code) {
+		return ErrInvalidTwoFactorCode
+	}
+
+	if err := s.queries.DeleteUserTwoFactor(ctx, userID); err != nil {
+		return fmt.Errorf("failed to disable two-factor: %w", err)
+	}
+	if err := s.queries.DeleteRecoveryCodes(ctx, userID); err != nil {
+		log.Printf("[AuthService] Warning: Failed to delete recovery codes for user %v: %v", userID, err)
+	}
+
+	log.Printf("[AuthService] Two-factor disabled for user %v", userID)
+	return nil
+}
+
+// RegenerateRecoveryCodes invalidates all existing recovery codes and issues new ones.
+func (s *Service) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	twoFactor, err := s.queries.GetUserTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, ErrTwoFactorNotEnabled
+	}
+	if !s.verifyTOTP(ctx, userID, twoFactor.Secret, code) {
+		return nil, ErrInvalidTwoFactorCode
+	}
+	return s.replaceRecoveryCodes(ctx, userID)
+}
+
+func (s *Service) replaceRecoveryCodes(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	if err := s.queries.DeleteRecoveryCodes(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to clear recovery codes: %w", err)
+	}
+
+	codes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		code, err := utils.GenerateRecoveryCode()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+		hash, err := utils.HashPassword(code)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.queries.CreateRecoveryCode(ctx, persistence.CreateRecoveryCodeParams{
+			UserID:   userID,
+			CodeHash: hash,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to store recovery code: %w", err)
+		}
+		codes[i] = code
+	}
+	return codes, nil
+}
+
+// verifySecondFactor accepts a current authenticator code or an unused recovery code.
+func (s *Service) verifySecondFactor(ctx context.Context, userID uuid.UUID, secret, code string) bool {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if s.verifyTOTP(ctx, userID, secret, code) {
+		return true
+	}
+
+	codes, err := s.queries.ListUnusedRecoveryCodes(ctx, userID)
+	if err != nil {
+		log.Printf("[AuthService] Failed to list recovery codes for user %v: %v", userID, err)
+		return false
+	}
+	for _, rc := range codes {
+		if utils.CheckPasswordHash(code, rc.CodeHash) {
+			used, err := s.queries.MarkRecoveryCodeUsed(ctx, rc.ID)
+			if err != nil || used == 0 {
+				return false
+			}
+			log.Printf("[AuthService] Recovery code used by user %v", userID)
+			return true
+		}
+	}
+	return false
+}
+
+// verifyTOTP validates an authenticator code and records its time step so it cannot be replayed.
+func (s *Service) verifyTOTP(ctx context.Context, userID uuid.UUID, secret, code string) bool {
+	step, ok := utils.ValidateTOTP(secret, strings.TrimSpace(code), time.Now())
+	if !ok {
+		return false
+	}
+
+	usedKey := fmt.Sprintf("%s%s:%d", redisKeyTOTPUsed, utils.UUIDToString(userID), step)
+	count, err := s.redis.Incr(ctx, usedKey, 90) // outlives the three accepted 30s steps
+	if err != nil || count > 1 {
+		return false
+	}
+	return true
+}
+
+func (s *Service) twoFactorMandatory(ctx context.Context, role persistence.UserRole) bool {
+	if role != persistence.UserRoleAdmin {
+		return false
+	}
+	settings, err := s.queries.GetPlatformSettings(ctx)
+	if err != nil {
+		log.Printf("[AuthService] Warning: Failed to load platform settings: %v", err)
+		return false
+	}
+	return settings.RequireAdminTwoFactor
+}
+
+func (s *Service) issueChallenge(ctx context.Context, prefix string, userID uuid.UUID, ttl int) (string, error) {
+	token := uuid.New().String()
+	if err := s.redis.Set(ctx, prefix+token, utils.UUIDToString(userID), ttl); err != nil {
+		return "", fmt.Errorf("failed to create two-factor challenge: %w", err)
+	}
+	return token, nil
+}
+
+// resolveChallenge looks up a challenge and counts the attempt; too many wrong codes burn it.
+func (s *Service) resolveChallenge(ctx context.Context, prefix, token string) (uuid.UUID, error) {
+	userIDStr, err := s.redis.Get(ctx, prefix+token)
+	if err != nil {
+		return uuid.Nil, ErrInvalidChallengeToken
+	}
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, ErrInvalidChallengeToken
+	}
+
+	attempts, err := s.redis.Incr(ctx, redisKeyTwoFactorAttempts+token, twoFactorSetupChallengeTTL)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to track two-factor attempts: %w", err)
+	}
+	if attempts > twoFactorMaxAttempts {
+		_, _ = s.redis.Del(ctx, prefix+token, redisKeyTwoFactorAttempts+token)
+		return uuid.Nil, ErrInvalidChallengeToken
+	}
+	return userID, nil
+}
+
+// consumeChallenge deletes a challenge so it can finish only one login, then reloads the user.
+func (s *Service) consumeChallenge(ctx context.Context, prefix, token string, userID uuid.UUID) (persistence.User, error) {
+	deleted, err := s.redis.Del(ctx, prefix+token)
+	if err != nil {
+		return persistence.User{}, fmt.Errorf("failed to consume two-factor challenge: %w", err)
+	}
+	if deleted == 0 {
+		return persistence.User{}, ErrInvalidChallengeToken
+	}
+	_, _ = s.redis.Del(ctx, redisKeyTwoFactorAttempts+token)
+
+	userRow, err := s.queries.GetUserByID(ctx, userID)
+	if err != nil || !userRow.IsActive {
+		return persistence.User{}, ErrInvalidChallengeToken
+	}
+	return userRow, nil
+}
@@ -0,0 +1,289 @@
+package coupon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"menuvista/internal/models"
+	"menuvista/internal/storage/persistence"
+	"menuvista/internal/utils"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrCouponExpired       = errors.New("coupon has expired")
+	ErrCouponNotApplicable = errors.New("coupon does not apply to this plan")
+	ErrCouponExhausted     = errors.New("coupon has reached its maximum number of redemptions")
+	ErrCouponAlreadyUsed   = errors.New("you have already used this coupon")
+)
+
+// ReservationTTL is how long a checkout holds a coupon redemption before it is given back
+const ReservationTTL = 24 * time.Hour
+
+// Discount works out what a coupon takes off an amount due for a plan. Each owner can
+// redeem a coupon once; the discount then applies to that many paid billing cycles
+// (every cycle when the coupon has no duration), so renewals and plan changes keep it
+// without the code being entered again. An empty code continues the owner's latest
+// coupon if it has cycles left. Expiry and the redemption cap only stop new owners; an
+// owner whose checkout already reserved a redemption keeps it. A nil discount means
+// nothing applies.
+func Discount(ctx context.Context, queries *persistence.Queries, ownerID uuid.UUID, code string, planID uuid.UUID, amount float64) (*models.CouponDiscount, error) {
+	if amount <= 0 {
+		return nil, nil
+	}
+
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return continuedDiscount(ctx, queries, ownerID, planID, amount)
+	}
+
+	coupon, err := queries.GetCouponByCode(ctx, code)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && !coupon.IsActive) {
+		return nil, ErrCouponNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch coupon: %w", err)
+	}
+	if !appliesToPlan(coupon, planID) {
+		return nil, ErrCouponNotApplicable
+	}
+
+	used, err := queries.CountCouponCyclesByOwner(ctx, persistence.CountCouponCyclesByOwnerParams{
+		CouponID: coupon.ID,
+		OwnerID:  ownerID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count coupon redemptions: %w", err)
+	}
+	if used > 0 {
+		if cyclesUsedUp(coupon, used) {
+			return nil, ErrCouponAlreadyUsed
+		}
+		return discountFor(coupon, amount), nil
+	}
+
+	if coupon.ExpiresAt.Valid && time.Now().After(coupon.ExpiresAt.Time) {
+		return nil, ErrCouponExpired
+	}
+	if coupon.MaxRedemptions.Valid && coupon.RedemptionCount >= coupon.MaxRedemptions.Int32 {
+		reserved, err := queries.HasCouponReservation(ctx, persistence.HasCouponReservationParams{
+			CouponID: coupon.ID,
+			OwnerID:  ownerID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to check coupon reservation: %w", err)
+		}
+		if !reserved {
+			return nil, ErrCouponExhausted
+		}
+	}
+	return discountFor(coupon, amount), nil
+}
+
+// Reserve holds one of the coupon's redemptions for the owner's checkout txRef, counting it
+// against max_redemptions straight away so concurrent and pending checkouts cannot hand out
+// more discounts than the cap. Owners who already paid with the coupon need no reservation,
+// and a new checkout by the same owner moves their reservation to it. ErrCouponExhausted
+// is returned when no redemption is left.
+func Reserve(ctx context.Context, queries *persistence.Queries, ownerID uuid.UUID, discount *models.CouponDiscount, txRef string) error {
+	if discount == nil {
+		return nil
+	}
+
+	used, err := queries.CountCouponCyclesByOwner(ctx, persistence.CountCouponCyclesByOwnerParams{
+		CouponID: discount.CouponID,
+		OwnerID:  ownerID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to count coupon redemptions: %w", err)
+	}
+	if used > 0 {
+		return nil
+	}
+
+	tx, err := queries.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := queries.WithTx(tx)
+
+	reservation := persistence.CreateCouponReservationParams{
+		CouponID:  discount.CouponID,
+		OwnerID:   ownerID,
+		TxRef:     txRef,
+		ExpiresAt: pgtype.Timestamp{Time: time.Now().Add(ReservationTTL), Valid: true},
+	}
+	moved, err := qtx.ExtendCouponReservation(ctx, persistence.ExtendCouponReservationParams(reservation))
+	if err != nil {
+		return fmt.Errorf("failed to extend coupon reservation: %w", err)
+	}
+	if moved == 0 {
+		counted, err := qtx.IncrementCouponRedemptions(ctx, discount.CouponID)
+		if err != nil {
+			return fmt.Errorf("failed to reserve coupon redemption: %w", err)
+		}
+		if counted == 0 {
+			return ErrCouponExhausted
+		}
+		created, err := qtx.CreateCouponReservation(ctx, reservation)
+		if err != nil {
+			return fmt.Errorf("failed to reserve coupon redemption: %w", err)
+		}
+		if created == 0 {
+			// Another checkout of the owner reserved it at the same time; keep that one
+			return nil
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to reserve coupon redemption: %w", err)
+	}
+	return nil
+}
+
+// Release gives back the redemption reserved for checkout txRef, if it still holds one
+func Release(ctx context.Context, queries *persistence.Queries, txRef string) error {
+	released, err := queries.ReleaseCouponReservation(ctx, txRef)
+	if err != nil {
+		return fmt.Errorf("failed to release coupon reservation: %w", err)
+	}
+	if released > 0 {
+		log.Printf("[CouponService] Released the coupon reservation of checkout %s", txRef)
+	}
+	return nil
+}
+
+// Redeem records a paid invoice's discount in the coupon ledger. The first time an owner
+// pays with a coupon, the redemption their checkout reserved becomes theirs; when the
+// reservation had already been given back it is counted again if the cap allows. Later
+// cycles only add ledger rows. Invoices are recorded at most once, so replayed payments
+// are harmless.
+func Redeem(ctx context.Context, queries *persistence.Queries, invoice persistence.Invoice) error {
+	if invoice.CouponID == uuid.Nil {
+		return nil
+	}
+
+	used, err := queries.CountCouponCyclesByOwner(ctx, persistence.CountCouponCyclesByOwnerParams{
+		CouponID: invoice.CouponID,
+		OwnerID:  invoice.OwnerID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to count coupon redemptions: %w", err)
+	}
+
+	inserted, err := queries.CreateCouponRedemption(ctx, persistence.CreateCouponRedemptionParams{
+		CouponID:       invoice.CouponID,
+		OwnerID:        invoice.OwnerID,
+		InvoiceID:      invoice.ID,
+		DiscountAmount: invoice.DiscountAmount,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record coupon redemption: %w", err)
+	}
+	if inserted == 0 {
+		return nil
+	}
+
+	if used == 0 {
+		consumed, err := queries.ConsumeCouponReservation(ctx, persistence.ConsumeCouponReservationParams{
+			CouponID: invoice.CouponID,
+			OwnerID:  invoice.OwnerID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to consume coupon reservation: %w", err)
+		}
+		if consumed == 0 {
+			counted, err := queries.IncrementCouponRedemptions(ctx, invoice.CouponID)
+			if err != nil {
+				return fmt.Errorf("failed to count coupon redemption: %w", err)
+			}
+			if counted == 0 {
+				// Paid after its reservation expired; the discount was already paid for
+				log.Printf("[CouponService] Warning: Coupon %v was used past its redemption cap by owner %v on invoice %s", invoice.CouponID, invoice.OwnerID, invoice.InvoiceNumber.String)
+			}
+		}
+	}
+	log.Printf("[CouponService] Coupon %v redeemed by owner %v on invoice %s", invoice.CouponID, invoice.OwnerID, invoice.InvoiceNumber.String)
+	return nil
+}
+
+// ReleaseInterval is how often ReleaseExpiredReservations should be scheduled
+const ReleaseInterval = 15 * time.Minute
+
+// ReleaseExpiredReservations gives back the redemptions held by checkouts that were not
+// paid within ReservationTTL
+func (s *Service) ReleaseExpiredReservations(ctx context.Context) {
+	released, err := s.queries.ReleaseExpiredCouponReservations(ctx)
+	if err != nil {
+		log.Printf("[CouponService] Failed to release expired coupon reservations: %v", err)
+		return
+	}
+	if released > 0 {
+		log.Printf("[CouponService] Released expired reservations on %d coupons", released)
+	}
+}
+
+// continuedDiscount applies the owner's most recent coupon again while it has cycles left
+func continuedDiscount(ctx context.Context, queries *persistence.Queries, ownerID, planID uuid.UUID, amount float64) (*models.CouponDiscount, error) {
+	latest, err := queries.GetLatestCouponRedemptionByOwner(ctx, ownerID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch coupon redemption: %w", err)
+	}
+
+	coupon, err := queries.GetCouponByID(ctx, latest.CouponID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch coupon: %w", err)
+	}
+	if !coupon.IsActive || !appliesToPlan(coupon, planID) {
+		return nil, nil
+	}
+
+	used, err := queries.CountCouponCyclesByOwner(ctx, persistence.CountCouponCyclesByOwnerParams{
+		CouponID: coupon.ID,
+		OwnerID:  ownerID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count coupon redemptions: %w", err)
+	}
+	if cyclesUsedUp(coupon, used) {
+		return nil, nil
+	}
+	return discountFor(coupon, amount), nil
+}
+
+func appliesToPlan(coupon persistence.Coupon, planID uuid.UUID) bool {
+	return len(coupon.PlanIds) == 0 || slices.Contains(coupon.PlanIds, planID)
+}
+
+func cyclesUsedUp(coupon persistence.Coupon, used int64) bool {
+	return coupon.DurationCycles.Valid && used >= int64(coupon.DurationCycles.Int32)
+}
+
+// discountFor prices the coupon against amount; the discount never exceeds it
+func discountFor(coupon persistence.Coupon, amount float64) *models.CouponDiscount {
+	value, _ := utils.NumericToFloat(coupon.DiscountValue)
+	discount := value
+	if coupon.DiscountType == persistence.DiscountTypePercentage {
+		discount = amount * value / 100
+	}
+	discount = math.Min(amount, math.Round(discount*100)/100)
+
+	return &models.CouponDiscount{
+		CouponID: coupon.ID,
+		Code:     coupon.Code,
+		Amount:   discount,
+	}
+}
@@ -0,0 +1,222 @@
+package coupon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"menuvista/internal/models"
+	"menuvista/internal/storage/persistence"
+	"menuvista/internal/utils"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrCouponNotFound = errors.New("coupon not found")
+	ErrCodeTaken      = errors.New("a coupon with this code already exists")
+	ErrInvalidPlan    = errors.New("coupon is restricted to a plan that does not exist")
+)
+
+type Service struct {
+	queries *persistence.Queries
+}
+
+func NewService(queries *persistence.Queries) *Service {
+	return &Service{
+		queries: queries,
+	}
+}
+
+// Admin management
+
+func (s *Service) CreateCoupon(ctx context.Context, adminID uuid.UUID, input models.CreateCouponRequest) (*models.Coupon, error) {
+	fields, err := s.buildFields(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[CouponService] Creating coupon %s", fields.Code)
+
+	row, err := s.queries.CreateCoupon(ctx, persistence.CreateCouponParams{
+		Code:           fields.Code,
+		Description:    fields.Description,
+		DiscountType:   fields.DiscountType,
+		DiscountValue:  fields.DiscountValue,
+		PlanIds:        fields.PlanIds,
+		MaxRedemptions: fields.MaxRedemptions,
+		DurationCycles: fields.DurationCycles,
+		ExpiresAt:      fields.ExpiresAt,
+		IsActive:       fields.IsActive,
+		CreatedBy:      adminID,
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrCodeTaken
+		}
+		return nil, fmt.Errorf("failed to create coupon: %w", err)
+	}
+	return mapCoupon(row), nil
+}
+
+func (s *Service) UpdateCoupon(ctx context.Context, couponID uuid.UUID, input models.CreateCouponRequest) (*models.Coupon, error) {
+	fields, err := s.buildFields(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	fields.ID = couponID
+
+	row, err := s.queries.UpdateCoupon(ctx, *fields)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCouponNotFound
+		}
+		if isUniqueViolation(err) {
+			return nil, ErrCodeTaken
+		}
+		return nil, fmt.Errorf("failed to update coupon: %w", err)
+	}
+	return mapCoupon(row), nil
+}
+
+func (s *Service) GetCoupon(ctx context.Context, couponID uuid.UUID) (*models.Coupon, error) {
+	row, err := s.queries.GetCouponByID(ctx, couponID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCouponNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch coupon: %w", err)
+	}
+	return mapCoupon(row), nil
+}
+
+func (s *Service) ListCoupons(ctx context.Context) ([]*models.Coupon, error) {
+	rows, err := s.queries.ListCoupons(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list coupons: %w", err)
+	}
+
+	coupons := make([]*models.Coupon, len(rows))
+	for i, row := range rows {
+		coupons[i] = mapCoupon(row)
+	}
+	return coupons, nil
+}
+
+// DeactivateCoupon stops a coupon from being applied. Coupons are kept rather than deleted
+// because the redemption ledger and invoices refer to them; owners already part way
+// through its duration stop getting the discount from their next payment.
+func (s *Service) DeactivateCoupon(ctx context.Context, couponID uuid.UUID) error {
+	updated, err := s.queries.DeactivateCoupon(ctx, couponID)
+	if err != nil {
+		return fmt.Errorf("failed to deactivate coupon: %w", err)
+	}
+	if updated == 0 {
+		return ErrCouponNotFound
+	}
+	log.Printf("[CouponService] Deactivated coupon %v", couponID)
+	return nil
+}
+
+// ListRedemptions returns the ledger of paid invoices the coupon discounted, newest first
+func (s *Service) ListRedemptions(ctx context.Context, couponID uuid.UUID) ([]*models.CouponRedemption, error) {
+	if _, err := s.GetCoupon(ctx, couponID); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.ListCouponRedemptions(ctx, couponID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list coupon redemptions: %w", err)
+	}
+
+	redemptions := make([]*models.CouponRedemption, len(rows))
+	for i, row := range rows {
+		amount, _ := utils.NumericToFloat(row.DiscountAmount)
+		redemptions[i] = &models.CouponRedemption{
+			ID:             row.ID,
+			OwnerID:        row.OwnerID,
+			OwnerEmail:     row.OwnerEmail,
+			OwnerName:      row.OwnerName,
+			InvoiceID:      row.InvoiceID,
+			InvoiceNumber:  row.InvoiceNumber.String,
+			DiscountAmount: amount,
+			CreatedAt:      row.CreatedAt.Time,
+		}
+	}
+	return redemptions, nil
+}
+
+// Helpers
+
+// buildFields validates the request and converts it to column values shared by create and update.
+func (s *Service) buildFields(ctx context.Context, input models.CreateCouponRequest) (*persistence.UpdateCouponParams, error) {
+	if input.DiscountType == models.DiscountTypePercentage && input.DiscountValue > 100 {
+		return nil, errors.New("percentage discount cannot exceed 100")
+	}
+
+	fields := &persistence.UpdateCouponParams{
+		Code:          strings.ToUpper(strings.TrimSpace(input.Code)),
+		Description:   pgtype.Text{String: input.Description, Valid: input.Description != ""},
+		DiscountType:  persistence.DiscountType(input.DiscountType),
+		DiscountValue: utils.ToNumeric(input.DiscountValue),
+		PlanIds:       input.PlanIDs,
+		IsActive:      input.IsActive == nil || *input.IsActive,
+	}
+	if fields.PlanIds == nil {
+		fields.PlanIds = []uuid.UUID{}
+	}
+	for _, planID := range fields.PlanIds {
+		if _, err := s.queries.GetSubscriptionPlanByID(ctx, planID); err != nil {
+			return nil, ErrInvalidPlan
+		}
+	}
+	if input.MaxRedemptions != nil {
+		fields.MaxRedemptions = pgtype.Int4{Int32: *input.MaxRedemptions, Valid: true}
+	}
+	if input.DurationCycles != nil {
+		fields.DurationCycles = pgtype.Int4{Int32: *input.DurationCycles, Valid: true}
+	}
+	if input.ExpiresAt != nil {
+		fields.ExpiresAt = pgtype.Timestamp{Time: *input.ExpiresAt, Valid: true}
+	}
+	return fields, nil
+}
+
+func mapCoupon(row persistence.Coupon) *models.Coupon {
+	value, _ := utils.NumericToFloat(row.DiscountValue)
+	coupon := &models.Coupon{
+		ID:              row.ID,
+		Code:            row.Code,
+		Description:     row.Description.String,
+		DiscountType:    models.DiscountType(row.DiscountType),
+		DiscountValue:   value,
+		PlanIDs:         row.PlanIds,
+		RedemptionCount: row.RedemptionCount,
+		IsActive:        row.IsActive,
+		CreatedAt:       row.CreatedAt.Time,
+		UpdatedAt:       row.UpdatedAt.Time,
+	}
+	if coupon.PlanIDs == nil {
+		coupon.PlanIDs = []uuid.UUID{}
+	}
+	if row.MaxRedemptions.Valid {
+		coupon.MaxRedemptions = &row.MaxRedemptions.Int32
+	}
+	if row.DurationCycles.Valid {
+		coupon.DurationCycles = &row.DurationCycles.Int32
+	}
+	if row.ExpiresAt.Valid {
+		coupon.ExpiresAt = &row.ExpiresAt.Time
+	}
+	return coupon
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
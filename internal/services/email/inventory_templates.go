@@ -0,0 +1,52 @@
+package email
+
+import "fmt"
+
+const lowStockSubject = "Low Stock Alert - MenuVista"
+
+// LowStockTemplate generates the alert sent to owners when a tracked menu item runs low
+func LowStockTemplate(ownerName, restaurantName, itemName string, quantity, threshold int32) string {
+	status := fmt.Sprintf("Only <strong>%d</strong> left (alert threshold: %d).", quantity, threshold)
+	if quantity == 0 {
+		status = "It is <strong>sold out</strong> and has been hidden from your menu until you restock it."
+	}
+
+	return fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; background-color: #f3f4f6;">
+    <table role="presentation" width="100%%" cellspacing="0" cellpadding="0" border="0" style="background-color: #f3f4f6;">
+        <tr>
+            <td style="padding: 40px 20px;">
+                <table role="presentation" width="600" cellspacing="0" cellpadding="0" border="0" style="margin: 0 auto; max-width: 600px;">
+                    <tr>
+                        <td style="text-align: center; padding-bottom: 32px;">
+                            <h1 style="color: #667eea; font-size: 32px; margin: 0; font-weight: 700;">🍽️ MenuVista</h1>
+                        </td>
+                    </tr>
+                    <tr>
+                        <td style="background: #ffffff; border-radius: 12px; padding: 40px; box-shadow: 0 4px 6px rgba(0,0,0,0.1);">
+                            <h2 style="color: #1f2937; margin: 0 0 16px 0; font-size: 24px; font-weight: 600;">Running Low 📦</h2>
+                            <p style="color: #4b5563; margin: 0 0 16px 0; font-size: 16px; line-height: 1.6;">Hi <strong>%s</strong>,</p>
+                            <p style="color: #4b5563; margin: 0 0 16px 0; font-size: 16px; line-height: 1.6;">Stock for <strong>%s</strong> at <strong>%s</strong> is running low.</p>
+
+                            <div style="background: #fef3c7; border-left: 4px solid #f59e0b; padding: 16px 20px; border-radius: 4px; margin: 24px 0;">
+                                <p style="color: #92400e; margin: 0; font-size: 14px;">%s</p>
+                            </div>
+
+                            <p style="color: #6b7280; margin: 24px 0 0 0; font-size: 14px;">Update the stock level from your dashboard once you have restocked.</p>
+                        </td>
+                    </tr>
+                    <tr>
+                        <td style="padding-top: 32px; text-align: center;">
+                            <p style="color: #9ca3af; font-size: 12px; margin: 0;">© 2026 MenuVista. All rights reserved.</p>
+                        </td>
+                    </tr>
+                </table>
+            </td>
+        </tr>
+    </table>
+</body>
+</html>
+`, ownerName, itemName, restaurantName, status)
+}
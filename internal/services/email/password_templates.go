@@ -0,0 +1,109 @@
+package email
+
+import "fmt"
+
+const passwordResetSubject = "Reset your password - MenuVista"
+
+// PasswordResetTemplate generates the email carrying a single-use password reset link
+func PasswordResetTemplate(name, resetURL, expiresIn string) string {
+	return fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; background-color: #f3f4f6;">
+    <table role="presentation" width="100%%" cellspacing="0" cellpadding="0" border="0" style="background-color: #f3f4f6;">
+        <tr>
+            <td style="padding: 40px 20px;">
+                <table role="presentation" width="600" cellspacing="0" cellpadding="0" border="0" style="margin: 0 auto; max-width: 600px;">
+                    <tr>
+                        <td style="text-align: center; padding-bottom: 32px;">
+                            <h1 style="color: #667eea; font-size: 32px; margin: 0; font-weight: 700;">🍽️ MenuVista</h1>
+                        </td>
+                    </tr>
+                    <tr>
+                        <td style="background: #ffffff; border-radius: 12px; padding: 40px; box-shadow: 0 4px 6px rgba(0,0,0,0.1);">
+                            <h2 style="color: #1f2937; margin: 0 0 16px 0; font-size: 24px; font-weight: 600;">Reset Your Password 🔑</h2>
+                            <p style="color: #4b5563; margin: 0 0 16px 0; font-size: 16px; line-height: 1.6;">Hi <strong>%s</strong>,</p>
+                            <p style="color: #4b5563; margin: 0 0 24px 0; font-size: 16px; line-height: 1.6;">We received a request to reset the password for your MenuVista account. Click the button below to choose a new one:</p>
+
+                            <table role="presentation" cellspacing="0" cellpadding="0" border="0" style="margin: 0 auto;">
+                                <tr>
+                                    <td style="border-radius: 8px; background: linear-gradient(135deg, #667eea 0%%, #764ba2 100%%);">
+                                        <a href="%s" style="display: inline-block; padding: 14px 32px; color: #ffffff; text-decoration: none; font-weight: 600; font-size: 16px;">Reset Password</a>
+                                    </td>
+                                </tr>
+                            </table>
+
+                            <div style="background: #fef3c7; border-left: 4px solid #f59e0b; padding: 16px 20px; border-radius: 4px; margin: 24px 0;">
+                                <p style="color: #92400e; margin: 0; font-size: 14px;">This link expires in <strong>%s</strong> and can only be used once.</p>
+                            </div>
+
+                            <p style="color: #6b7280; margin: 24px 0 0 0; font-size: 14px;">If you didn't request a password reset, you can safely ignore this email. Your password will not change.</p>
+                        </td>
+                    </tr>
+                    <tr>
+                        <td style="padding-top: 32px; text-align: center;">
+                            <p style="color: #9ca3af; font-size: 12px; margin: 0;">© 2026 MenuVista. All rights reserved.</p>
+                        </td>
+                    </tr>
+                </table>
+            </td>
+        </tr>
+    </table>
+</body>
+</html>
+`, name, resetURL, expiresIn)
+}
+
+const accountLockedSubject = "Your account has been temporarily locked - MenuVista"
+
+// AccountLockedTemplate warns a user that repeated failed logins locked their account
+func AccountLockedTemplate(name, ipAddress, unlockAt, resetURL string) string {
+	return fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; background-color: #f3f4f6;">
+    <table role="presentation" width="100%%" cellspacing="0" cellpadding="0" border="0" style="background-color: #f3f4f6;">
+        <tr>
+            <td style="padding: 40px 20px;">
+                <table role="presentation" width="600" cellspacing="0" cellpadding="0" border="0" style="margin: 0 auto; max-width: 600px;">
+                    <tr>
+                        <td style="text-align: center; padding-bottom: 32px;">
+                            <h1 style="color: #667eea; font-size: 32px; margin: 0; font-weight: 700;">🍽️ MenuVista</h1>
+                        </td>
+                    </tr>
+                    <tr>
+                        <td style="background: #ffffff; border-radius: 12px; padding: 40px; box-shadow: 0 4px 6px rgba(0,0,0,0.1);">
+                            <h2 style="color: #1f2937; margin: 0 0 16px 0; font-size: 24px; font-weight: 600;">Account Temporarily Locked 🔒</h2>
+                            <p style="color: #4b5563; margin: 0 0 16px 0; font-size: 16px; line-height: 1.6;">Hi <strong>%s</strong>,</p>
+                            <p style="color: #4b5563; margin: 0 0 16px 0; font-size: 16px; line-height: 1.6;">We locked your MenuVista account after several failed login attempts. The last attempt came from IP address <strong>%s</strong>.</p>
+
+                            <div style="background: #fef2f2; border-left: 4px solid #ef4444; padding: 16px 20px; border-radius: 4px; margin: 24px 0;">
+                                <p style="color: #991b1b; margin: 0; font-size: 14px;">You can try again after <strong>%s</strong>.</p>
+                            </div>
+
+                            <p style="color: #4b5563; margin: 0 0 24px 0; font-size: 16px; line-height: 1.6;">If this wasn't you, someone may be trying to guess your password. We recommend choosing a new one:</p>
+
+                            <table role="presentation" width="100%%" cellspacing="0" cellpadding="0" border="0">
+                                <tr>
+                                    <td align="center" style="padding: 24px 0;">
+                                        <a href="%s" style="display: inline-block; background: #667eea; color: #ffffff; padding: 16px 40px; border-radius: 8px; text-decoration: none; font-weight: 600; font-size: 16px;">Reset Password</a>
+                                    </td>
+                                </tr>
+                            </table>
+
+                            <p style="color: #6b7280; margin: 24px 0 0 0; font-size: 14px;">Contact support if you need your account unlocked sooner.</p>
+                        </td>
+                    </tr>
+                    <tr>
+                        <td style="padding-top: 32px; text-align: center;">
+                            <p style="color: #9ca3af; font-size: 12px; margin: 0;">© 2026 MenuVista. All rights reserved.</p>
+                        </td>
+                    </tr>
+                </table>
+            </td>
+        </tr>
+    </table>
+</body>
+</html>
+`, name, ipAddress, unlockAt, resetURL)
+}
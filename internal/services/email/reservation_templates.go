@@ -0,0 +1,60 @@
+package email
+
+import (
+	"fmt"
+	"html"
+)
+
+const reservationConfirmationSubject = "Your Table Reservation - Please Confirm"
+
+// ReservationConfirmationTemplate generates the booking email sent to guests with confirm and cancel links.
+// The guest and restaurant names are typed in by users, so they are escaped.
+func ReservationConfirmationTemplate(guestName, restaurantName, reservedAt string, partySize int32, confirmURL, cancelURL string) string {
+	return fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; background-color: #f3f4f6;">
+    <table role="presentation" width="100%%" cellspacing="0" cellpadding="0" border="0" style="background-color: #f3f4f6;">
+        <tr>
+            <td style="padding: 40px 20px;">
+                <table role="presentation" width="600" cellspacing="0" cellpadding="0" border="0" style="margin: 0 auto; max-width: 600px;">
+                    <tr>
+                        <td style="text-align: center; padding-bottom: 32px;">
+                            <h1 style="color: #667eea; font-size: 32px; margin: 0; font-weight: 700;">🍽️ MenuVista</h1>
+                        </td>
+                    </tr>
+                    <tr>
+                        <td style="background: #ffffff; border-radius: 12px; padding: 40px; box-shadow: 0 4px 6px rgba(0,0,0,0.1);">
+                            <h2 style="color: #1f2937; margin: 0 0 16px 0; font-size: 24px; font-weight: 600;">Your Table is Reserved 🪑</h2>
+                            <p style="color: #4b5563; margin: 0 0 16px 0; font-size: 16px; line-height: 1.6;">Hi <strong>%s</strong>,</p>
+                            <p style="color: #4b5563; margin: 0 0 16px 0; font-size: 16px; line-height: 1.6;">Thanks for booking with <strong>%s</strong>. Please confirm your reservation so we can hold your table.</p>
+
+                            <div style="background: #f3f4f6; padding: 20px; border-radius: 8px; margin: 24px 0;">
+                                <p style="color: #4b5563; margin: 0 0 8px 0; font-size: 14px;"><strong>When:</strong> %s</p>
+                                <p style="color: #4b5563; margin: 0; font-size: 14px;"><strong>Guests:</strong> %d</p>
+                            </div>
+
+                            <table role="presentation" width="100%%" cellspacing="0" cellpadding="0" border="0">
+                                <tr>
+                                    <td align="center" style="padding: 24px 0;">
+                                        <a href="%s" style="display: inline-block; background: linear-gradient(135deg, #667eea 0%%, #764ba2 100%%); color: #ffffff; padding: 16px 40px; border-radius: 8px; text-decoration: none; font-weight: 600; font-size: 16px;">Confirm Reservation</a>
+                                    </td>
+                                </tr>
+                            </table>
+
+                            <p style="color: #6b7280; margin: 24px 0 0 0; font-size: 14px; text-align: center;">Plans changed? <a href="%s" style="color: #dc2626;">Cancel your reservation</a></p>
+                        </td>
+                    </tr>
+                    <tr>
+                        <td style="padding-top: 32px; text-align: center;">
+                            <p style="color: #9ca3af; font-size: 12px; margin: 0;">© 2026 MenuVista. All rights reserved.</p>
+                        </td>
+                    </tr>
+                </table>
+            </td>
+        </tr>
+    </table>
+</body>
+</html>
+`, html.EscapeString(guestName), html.EscapeString(restaurantName), reservedAt, partySize, confirmURL, cancelURL)
+}
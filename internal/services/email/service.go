@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"os"
 	"strings"
 
 	"menuvista/internal/models"
@@ -193,8 +194,8 @@ func (s *Service) SendWelcomeEmail(ctx context.Context, user *models.User) error
 	return nil
 }
 
-// SendPaymentSuccessEmail sends payment confirmation email
-func (s *Service) SendPaymentSuccessEmail(ctx context.Context, email, firstName, invoiceNumber string, amount float64, currency string) error {
+// SendPaymentSuccessEmail sends payment confirmation email, with the invoice PDF attached when one is given
+func (s *Service) SendPaymentSuccessEmail(ctx context.Context, email, firstName, invoiceNumber string, amount float64, currency string, invoicePDF []byte, filename string) error {
 	log.Printf("[EmailService] Sending payment success email to: %s", email)
 
 	htmlContent := PaymentSuccessTemplate(firstName, invoiceNumber, amount, currency)
@@ -205,6 +206,13 @@ func (s *Service) SendPaymentSuccessEmail(ctx context.Context, email, firstName,
 		Subject: paymentSuccessSubject,
 		Html:    htmlContent,
 	}
+	if len(invoicePDF) > 0 {
+		params.Attachments = []*resend.Attachment{{
+			Content:     invoicePDF,
+			Filename:    filename,
+			ContentType: "application/pdf",
+		}}
+	}
 
 	_, err := s.client.Emails.Send(params)
 	if err != nil {
@@ -216,6 +224,36 @@ func (s *Service) SendPaymentSuccessEmail(ctx context.Context, email, firstName,
 	return nil
 }
 
+// SendRefundIssuedEmail tells the owner about a refund, with the credit note attached
+func (s *Service) SendRefundIssuedEmail(ctx context.Context, email, firstName, invoiceNumber, creditNoteNumber string, amount float64, currency, reason string, terminated bool, creditNotePDF []byte, filename string) error {
+	log.Printf("[EmailService] Sending refund issued email to: %s", email)
+
+	htmlContent := RefundIssuedTemplate(firstName, invoiceNumber, creditNoteNumber, amount, currency, reason, terminated)
+
+	params := &resend.SendEmailRequest{
+		From:    senderEmail,
+		To:      []string{email},
+		Subject: refundIssuedSubject,
+		Html:    htmlContent,
+	}
+	if len(creditNotePDF) > 0 {
+		params.Attachments = []*resend.Attachment{{
+			Content:     creditNotePDF,
+			Filename:    filename,
+			ContentType: "application/pdf",
+		}}
+	}
+
+	_, err := s.client.Emails.Send(params)
+	if err != nil {
+		log.Printf("[EmailService] Failed to send refund issued email: %v", err)
+		return fmt.Errorf("failed to send refund issued email: %w", err)
+	}
+
+	log.Printf("[EmailService] Refund issued email sent successfully")
+	return nil
+}
+
 // SendPaymentFailedEmail sends payment failed email
 func (s *Service) SendPaymentFailedEmail(ctx context.Context, email, firstName, updatePaymentURL string) error {
 	log.Printf("[EmailService] Sending payment failed email to: %s", email)
@@ -262,26 +300,141 @@ func (s *Service) SendPaymentPendingEmail(ctx context.Context, email, firstName
 	return nil
 }
 
-// SendStaffWelcomeEmail sends welcome email to new staff with credentials
-func (s *Service) SendStaffWelcomeEmail(ctx context.Context, user *models.User, password string) error {
-	log.Printf("[EmailService] Sending staff welcome email to: %s", user.Email)
+// SendSubscriptionCancelledEmail tells an owner their unpaid subscription was cancelled
+func (s *Service) SendSubscriptionCancelledEmail(ctx context.Context, email, firstName, planName, resubscribeURL string) error {
+	log.Printf("[EmailService] Sending subscription cancelled email to: %s", email)
 
-	htmlContent := StaffWelcomeTemplate(user.FullName, user.Email, password)
+	htmlContent := SubscriptionCancelledTemplate(firstName, planName, resubscribeURL)
 
 	params := &resend.SendEmailRequest{
 		From:    senderEmail,
-		To:      []string{user.Email},
-		Subject: "Welcome to MenuVista - Your Credentials",
+		To:      []string{email},
+		Subject: subscriptionCancelledSubject,
+		Html:    htmlContent,
+	}
+
+	_, err := s.client.Emails.Send(params)
+	if err != nil {
+		log.Printf("[EmailService] Failed to send subscription cancelled email: %v", err)
+		return fmt.Errorf("failed to send subscription cancelled email: %w", err)
+	}
+
+	log.Printf("[EmailService] Subscription cancelled email sent successfully")
+	return nil
+}
+
+// SendCancellationConfirmedEmail confirms an owner's own cancellation, see CancellationConfirmedTemplate
+func (s *Service) SendCancellationConfirmedEmail(ctx context.Context, email, firstName, planName, endDate, actionURL string, immediate bool) error {
+	log.Printf("[EmailService] Sending cancellation confirmation email to: %s", email)
+
+	htmlContent := CancellationConfirmedTemplate(firstName, planName, endDate, actionURL, immediate)
+
+	params := &resend.SendEmailRequest{
+		From:    senderEmail,
+		To:      []string{email},
+		Subject: cancellationConfirmedSubject,
+		Html:    htmlContent,
+	}
+
+	_, err := s.client.Emails.Send(params)
+	if err != nil {
+		log.Printf("[EmailService] Failed to send cancellation confirmation email: %v", err)
+		return fmt.Errorf("failed to send cancellation confirmation email: %w", err)
+	}
+
+	log.Printf("[EmailService] Cancellation confirmation email sent successfully")
+	return nil
+}
+
+// SendTrialWarningEmail reminds an owner that their free trial ends in daysLeft days
+func (s *Service) SendTrialWarningEmail(ctx context.Context, email, name, trialEndDate string, daysLeft int, upgradeBronzeURL, upgradeSilverURL, upgradeGoldURL string) error {
+	log.Printf("[EmailService] Sending trial warning email to: %s", email)
+
+	htmlContent := TrialWarningEmailTemplate(name, trialEndDate, daysLeft, upgradeBronzeURL, upgradeSilverURL, upgradeGoldURL)
+
+	params := &resend.SendEmailRequest{
+		From:    senderEmail,
+		To:      []string{email},
+		Subject: trialWarningSubject,
+		Html:    htmlContent,
+	}
+
+	_, err := s.client.Emails.Send(params)
+	if err != nil {
+		log.Printf("[EmailService] Failed to send trial warning email: %v", err)
+		return fmt.Errorf("failed to send trial warning email: %w", err)
+	}
+
+	log.Printf("[EmailService] Trial warning email sent successfully")
+	return nil
+}
+
+// SendTrialExpiredEmail tells an owner their free trial has ended
+func (s *Service) SendTrialExpiredEmail(ctx context.Context, email, name, upgradeURL string) error {
+	log.Printf("[EmailService] Sending trial expired email to: %s", email)
+
+	htmlContent := TrialExpiredEmailTemplate(name, upgradeURL)
+
+	params := &resend.SendEmailRequest{
+		From:    senderEmail,
+		To:      []string{email},
+		Subject: trialExpiredSubject,
+		Html:    htmlContent,
+	}
+
+	_, err := s.client.Emails.Send(params)
+	if err != nil {
+		log.Printf("[EmailService] Failed to send trial expired email: %v", err)
+		return fmt.Errorf("failed to send trial expired email: %w", err)
+	}
+
+	log.Printf("[EmailService] Trial expired email sent successfully")
+	return nil
+}
+
+// SendSubscriptionExpiringEmail reminds an owner that their paid period ends in daysLeft days
+func (s *Service) SendSubscriptionExpiringEmail(ctx context.Context, email, name, planName, expiryDate string, daysLeft int, renewURL string) error {
+	log.Printf("[EmailService] Sending subscription expiring email to: %s", email)
+
+	htmlContent := SubscriptionExpiringEmailTemplate(name, planName, expiryDate, daysLeft, renewURL)
+
+	params := &resend.SendEmailRequest{
+		From:    senderEmail,
+		To:      []string{email},
+		Subject: subscriptionExpiringSubject,
+		Html:    htmlContent,
+	}
+
+	_, err := s.client.Emails.Send(params)
+	if err != nil {
+		log.Printf("[EmailService] Failed to send subscription expiring email: %v", err)
+		return fmt.Errorf("failed to send subscription expiring email: %w", err)
+	}
+
+	log.Printf("[EmailService] Subscription expiring email sent successfully")
+	return nil
+}
+
+// SendStaffInvitationEmail invites a new staff member to set their password and join a restaurant
+func (s *Service) SendStaffInvitationEmail(ctx context.Context, email, name, restaurantName, acceptURL, expiresIn string) error {
+	log.Printf("[EmailService] Sending staff invitation email to: %s", email)
+
+	htmlContent := StaffInvitationTemplate(name, restaurantName, acceptURL, expiresIn)
+
+	params := &resend.SendEmailRequest{
+		From:    senderEmail,
+		To:      []string{email},
+		Subject: fmt.Sprintf(staffInvitationSubject, restaurantName),
 		Html:    htmlContent,
 	}
 
 	_, err := s.client.Emails.Send(params)
 	if err != nil {
-		log.Printf("[EmailService] Failed to send staff welcome email: %v", err)
-		return fmt.Errorf("failed to send staff welcome email: %w", err)
+		log.Printf("[EmailService] Failed to send staff invitation email: %v", err)
+		return fmt.Errorf("failed to send staff invitation email: %w", err)
 	}
 
-	log.Printf("[EmailService] Staff welcome email sent successfully")
+	log.Printf("[EmailService] Staff invitation email sent successfully")
 	return nil
 }
 
@@ -312,3 +465,96 @@ func (s *Service) SendVerificationEmail(ctx context.Context, user *models.User,
 	log.Printf("[EmailService] Verification email sent successfully")
 	return nil
 }
+
+// SendReservationConfirmationEmail sends the booking confirmation and cancellation links to a guest
+func (s *Service) SendReservationConfirmationEmail(ctx context.Context, email, guestName, restaurantName, reservedAt string, partySize int32, confirmURL, cancelURL string) error {
+	log.Printf("[EmailService] Sending reservation confirmation email to: %s", email)
+
+	htmlContent := ReservationConfirmationTemplate(guestName, restaurantName, reservedAt, partySize, confirmURL, cancelURL)
+
+	params := &resend.SendEmailRequest{
+		From:    senderEmail,
+		To:      []string{email},
+		Subject: reservationConfirmationSubject,
+		Html:    htmlContent,
+	}
+
+	_, err := s.client.Emails.Send(params)
+	if err != nil {
+		log.Printf("[EmailService] Failed to send reservation confirmation email: %v", err)
+		return fmt.Errorf("failed to send reservation confirmation email: %w", err)
+	}
+
+	log.Printf("[EmailService] Reservation confirmation email sent successfully")
+	return nil
+}
+
+// SendLowStockEmail alerts a restaurant owner that a tracked menu item is running low
+func (s *Service) SendLowStockEmail(ctx context.Context, email, ownerName, restaurantName, itemName string, quantity, threshold int32) error {
+	log.Printf("[EmailService] Sending low stock email to: %s", email)
+
+	htmlContent := LowStockTemplate(ownerName, restaurantName, itemName, quantity, threshold)
+
+	params := &resend.SendEmailRequest{
+		From:    senderEmail,
+		To:      []string{email},
+		Subject: lowStockSubject,
+		Html:    htmlContent,
+	}
+
+	_, err := s.client.Emails.Send(params)
+	if err != nil {
+		log.Printf("[EmailService] Failed to send low stock email: %v", err)
+		return fmt.Errorf("failed to send low stock email: %w", err)
+	}
+
+	log.Printf("[EmailService] Low stock email sent successfully")
+	return nil
+}
+
+// SendPasswordResetEmail sends a single-use password reset link to a user
+func (s *Service) SendPasswordResetEmail(ctx context.Context, user *models.User, resetURL string) error {
+	log.Printf("[EmailService] Sending password reset email to: %s", user.Email)
+
+	htmlContent := PasswordResetTemplate(user.FullName, resetURL, "1 hour")
+
+	params := &resend.SendEmailRequest{
+		From:    senderEmail,
+		To:      []string{user.Email},
+		Subject: passwordResetSubject,
+		Html:    htmlContent,
+	}
+
+	_, err := s.client.Emails.Send(params)
+	if err != nil {
+		log.Printf("[EmailService] Failed to send password reset email: %v", err)
+		return fmt.Errorf("failed to send password reset email: %w", err)
+	}
+
+	log.Printf("[EmailService] Password reset email sent successfully")
+	return nil
+}
+
+// SendAccountLockedEmail tells a user their account was locked after repeated failed logins
+func (s *Service) SendAccountLockedEmail(ctx context.Context, user *models.User, ipAddress, unlockAt string) error {
+	log.Printf("[EmailService] Sending account locked email to: %s", user.Email)
+
+	resetURL := os.Getenv("APP_BASE_URL") + "/forgot-password"
+	htmlContent := AccountLockedTemplate(user.FullName, ipAddress, unlockAt, resetURL)
+
+	params := &resend.SendEmailRequest{
+		From:    senderEmail,
+		To:      []string{user.Email},
+		Subject: accountLockedSubject,
+		Html:    htmlContent,
+	}
+
+	_, err := s.client.Emails.Send(params)
+	if err != nil {
+		log.Printf("[EmailService] Failed to send account locked email: %v", err)
+		return fmt.Errorf("failed to send account locked email: %w", err)
+	}
+
+	log.Printf("[EmailService] Account locked email sent successfully")
+	return nil
+}
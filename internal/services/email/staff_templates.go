@@ -0,0 +1,56 @@
+package email
+
+import "fmt"
+
+const staffInvitationSubject = "You're invited to join %s on MenuVista"
+
+// StaffInvitationTemplate generates the invitation sent to new staff. The link lets them
+// choose their own password, so no credentials are ever sent by email.
+func StaffInvitationTemplate(name, restaurantName, acceptURL, expiresIn string) string {
+	return fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; background-color: #f3f4f6;">
+    <table role="presentation" width="100%%" cellspacing="0" cellpadding="0" border="0" style="background-color: #f3f4f6;">
+        <tr>
+            <td style="padding: 40px 20px;">
+                <table role="presentation" width="600" cellspacing="0" cellpadding="0" border="0" style="margin: 0 auto; max-width: 600px;">
+                    <tr>
+                        <td style="text-align: center; padding-bottom: 32px;">
+                            <h1 style="color: #667eea; font-size: 32px; margin: 0; font-weight: 700;">🍽️ MenuVista</h1>
+                        </td>
+                    </tr>
+                    <tr>
+                        <td style="background: #ffffff; border-radius: 12px; padding: 40px; box-shadow: 0 4px 6px rgba(0,0,0,0.1);">
+                            <h2 style="color: #1f2937; margin: 0 0 16px 0; font-size: 24px; font-weight: 600;">You're Invited! 👋</h2>
+                            <p style="color: #4b5563; margin: 0 0 16px 0; font-size: 16px; line-height: 1.6;">Hi <strong>%s</strong>,</p>
+                            <p style="color: #4b5563; margin: 0 0 24px 0; font-size: 16px; line-height: 1.6;">You have been invited to join the team at <strong>%s</strong> on MenuVista. Click the button below to set your password and activate your account:</p>
+
+                            <table role="presentation" width="100%%" cellspacing="0" cellpadding="0" border="0">
+                                <tr>
+                                    <td align="center" style="padding: 24px 0;">
+                                        <a href="%s" style="display: inline-block; background: #667eea; color: #ffffff; padding: 16px 40px; border-radius: 8px; text-decoration: none; font-weight: 600; font-size: 16px;">Accept Invitation</a>
+                                    </td>
+                                </tr>
+                            </table>
+
+                            <div style="background: #fef3c7; border-left: 4px solid #f59e0b; padding: 16px 20px; border-radius: 4px; margin: 24px 0;">
+                                <p style="color: #92400e; margin: 0; font-size: 14px;">This invitation expires in <strong>%s</strong>. Ask the restaurant owner to resend it if it has expired.</p>
+                            </div>
+
+                            <p style="color: #6b7280; margin: 24px 0 0 0; font-size: 14px;">If you weren't expecting this invitation, you can safely ignore this email.</p>
+                        </td>
+                    </tr>
+                    <tr>
+                        <td style="padding-top: 32px; text-align: center;">
+                            <p style="color: #9ca3af; font-size: 12px; margin: 0;">© 2026 MenuVista. All rights reserved.</p>
+                        </td>
+                    </tr>
+                </table>
+            </td>
+        </tr>
+    </table>
+</body>
+</html>
+`, name, restaurantName, acceptURL, expiresIn)
+}
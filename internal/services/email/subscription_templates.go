@@ -0,0 +1,177 @@
+package email
+
+import (
+	"fmt"
+	"html"
+)
+
+const (
+	subscriptionCancelledSubject = "Your MenuVista Subscription Has Been Cancelled"
+	cancellationConfirmedSubject = "Your MenuVista Cancellation Is Confirmed"
+	refundIssuedSubject          = "Your MenuVista Refund Has Been Issued"
+)
+
+// SubscriptionCancelledTemplate generates the notice sent when a subscription is cancelled
+// after its renewal stayed unpaid
+func SubscriptionCancelledTemplate(firstName, planName, resubscribeURL string) string {
+	return fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; background-color: #f3f4f6;">
+    <table role="presentation" width="100%%" cellspacing="0" cellpadding="0" border="0" style="background-color: #f3f4f6;">
+        <tr>
+            <td style="padding: 40px 20px;">
+                <table role="presentation" width="600" cellspacing="0" cellpadding="0" border="0" style="margin: 0 auto; max-width: 600px;">
+                    <tr>
+                        <td style="text-align: center; padding-bottom: 32px;">
+                            <h1 style="color: #667eea; font-size: 32px; margin: 0; font-weight: 700;">🍽️ MenuVista</h1>
+                        </td>
+                    </tr>
+                    <tr>
+                        <td style="background: #ffffff; border-radius: 12px; padding: 40px; box-shadow: 0 4px 6px rgba(0,0,0,0.1);">
+                            <h2 style="color: #1f2937; margin: 0 0 16px 0; font-size: 24px; font-weight: 600;">Subscription Cancelled</h2>
+                            <p style="color: #4b5563; margin: 0 0 16px 0; font-size: 16px; line-height: 1.6;">Hi <strong>%s</strong>,</p>
+                            <p style="color: #4b5563; margin: 0 0 16px 0; font-size: 16px; line-height: 1.6;">We could not collect the renewal payment for your <strong>%s</strong> plan after several reminders, so your subscription has been cancelled.</p>
+
+                            <div style="background: #f9fafb; border-left: 4px solid #6b7280; padding: 20px; border-radius: 4px; margin: 24px 0;">
+                                <p style="color: #374151; margin: 0; font-size: 14px;">Your restaurants and menus are kept. Subscribe again at any time to restore full access.</p>
+                            </div>
+
+                            <table role="presentation" width="100%%" cellspacing="0" cellpadding="0" border="0">
+                                <tr>
+                                    <td align="center" style="padding: 24px 0;">
+                                        <a href="%s" style="display: inline-block; background: #667eea; color: #ffffff; padding: 16px 40px; border-radius: 8px; text-decoration: none; font-weight: 600; font-size: 16px;">Choose a Plan</a>
+                                    </td>
+                                </tr>
+                            </table>
+                        </td>
+                    </tr>
+                    <tr>
+                        <td style="padding-top: 32px; text-align: center;">
+                            <p style="color: #9ca3af; font-size: 12px; margin: 0;">© 2026 MenuVista. All rights reserved.</p>
+                        </td>
+                    </tr>
+                </table>
+            </td>
+        </tr>
+    </table>
+</body>
+</html>
+`, firstName, planName, resubscribeURL)
+}
+
+// CancellationConfirmedTemplate generates the confirmation sent when an owner cancels. A
+// cancellation at period end keeps the plan until endDate and links to resume it; an
+// immediate one has already moved the owner to the free plan and links to the plans.
+func CancellationConfirmedTemplate(firstName, planName, endDate, actionURL string, immediate bool) string {
+	summary := fmt.Sprintf("Your <strong>%s</strong> plan stays active until <strong>%s</strong>. You will not be charged again.", planName, endDate)
+	notice := "Changed your mind? You can resume your subscription any time before it ends and nothing will change."
+	action := "Resume Subscription"
+	if immediate {
+		summary = fmt.Sprintf("Your <strong>%s</strong> plan has ended and your account is now on the Free plan.", planName)
+		notice = "Your restaurants and menus are kept. Anything above the Free plan's limits is hidden until you subscribe again."
+		action = "Choose a Plan"
+	}
+
+	return fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; background-color: #f3f4f6;">
+    <table role="presentation" width="100%%" cellspacing="0" cellpadding="0" border="0" style="background-color: #f3f4f6;">
+        <tr>
+            <td style="padding: 40px 20px;">
+                <table role="presentation" width="600" cellspacing="0" cellpadding="0" border="0" style="margin: 0 auto; max-width: 600px;">
+                    <tr>
+                        <td style="text-align: center; padding-bottom: 32px;">
+                            <h1 style="color: #667eea; font-size: 32px; margin: 0; font-weight: 700;">🍽️ MenuVista</h1>
+                        </td>
+                    </tr>
+                    <tr>
+                        <td style="background: #ffffff; border-radius: 12px; padding: 40px; box-shadow: 0 4px 6px rgba(0,0,0,0.1);">
+                            <h2 style="color: #1f2937; margin: 0 0 16px 0; font-size: 24px; font-weight: 600;">Cancellation Confirmed</h2>
+                            <p style="color: #4b5563; margin: 0 0 16px 0; font-size: 16px; line-height: 1.6;">Hi <strong>%s</strong>,</p>
+                            <p style="color: #4b5563; margin: 0 0 16px 0; font-size: 16px; line-height: 1.6;">%s</p>
+
+                            <div style="background: #f9fafb; border-left: 4px solid #6b7280; padding: 20px; border-radius: 4px; margin: 24px 0;">
+                                <p style="color: #374151; margin: 0; font-size: 14px;">%s</p>
+                            </div>
+
+                            <table role="presentation" width="100%%" cellspacing="0" cellpadding="0" border="0">
+                                <tr>
+                                    <td align="center" style="padding: 24px 0;">
+                                        <a href="%s" style="display: inline-block; background: #667eea; color: #ffffff; padding: 16px 40px; border-radius: 8px; text-decoration: none; font-weight: 600; font-size: 16px;">%s</a>
+                                    </td>
+                                </tr>
+                            </table>
+
+                            <p style="color: #6b7280; margin: 0; font-size: 14px; line-height: 1.6;">Thank you for using MenuVista.</p>
+                        </td>
+                    </tr>
+                    <tr>
+                        <td style="padding-top: 32px; text-align: center;">
+                            <p style="color: #9ca3af; font-size: 12px; margin: 0;">© 2026 MenuVista. All rights reserved.</p>
+                        </td>
+                    </tr>
+                </table>
+            </td>
+        </tr>
+    </table>
+</body>
+</html>
+`, firstName, summary, notice, actionURL, action)
+}
+
+// RefundIssuedTemplate generates the notice sent when a payment is refunded. The credit
+// note is attached to the email; terminated says the subscription was ended with the refund.
+func RefundIssuedTemplate(firstName, invoiceNumber, creditNoteNumber string, amount float64, currency, reason string, terminated bool) string {
+	notice := "Your subscription continues unchanged."
+	if terminated {
+		notice = "Your subscription has been ended and your account is now on the Free plan. Anything above the Free plan's limits is hidden until you subscribe again."
+	}
+
+	return fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; background-color: #f3f4f6;">
+    <table role="presentation" width="100%%" cellspacing="0" cellpadding="0" border="0" style="background-color: #f3f4f6;">
+        <tr>
+            <td style="padding: 40px 20px;">
+                <table role="presentation" width="600" cellspacing="0" cellpadding="0" border="0" style="margin: 0 auto; max-width: 600px;">
+                    <tr>
+                        <td style="text-align: center; padding-bottom: 32px;">
+                            <h1 style="color: #667eea; font-size: 32px; margin: 0; font-weight: 700;">🍽️ MenuVista</h1>
+                        </td>
+                    </tr>
+                    <tr>
+                        <td style="background: #ffffff; border-radius: 12px; padding: 40px; box-shadow: 0 4px 6px rgba(0,0,0,0.1);">
+                            <h2 style="color: #1f2937; margin: 0 0 16px 0; font-size: 24px; font-weight: 600;">Refund Issued</h2>
+                            <p style="color: #4b5563; margin: 0 0 16px 0; font-size: 16px; line-height: 1.6;">Hi <strong>%s</strong>,</p>
+                            <p style="color: #4b5563; margin: 0 0 16px 0; font-size: 16px; line-height: 1.6;">We have refunded part or all of your payment for invoice <strong>%s</strong>. The money is returned to the account you paid from; depending on your bank or wallet it can take a few days to arrive.</p>
+
+                            <div style="background: #f3f4f6; padding: 20px; border-radius: 8px; margin: 24px 0;">
+                                <h3 style="color: #374151; margin: 0 0 12px 0; font-size: 18px;">Refund Details:</h3>
+                                <p style="color: #4b5563; margin: 0 0 8px 0;"><strong>Credit note:</strong> %s</p>
+                                <p style="color: #4b5563; margin: 0 0 8px 0;"><strong>Amount:</strong> %.2f %s</p>
+                                <p style="color: #4b5563; margin: 0;"><strong>Reason:</strong> %s</p>
+                            </div>
+
+                            <div style="background: #f9fafb; border-left: 4px solid #6b7280; padding: 20px; border-radius: 4px; margin: 24px 0;">
+                                <p style="color: #374151; margin: 0; font-size: 14px;">%s</p>
+                            </div>
+
+                            <p style="color: #6b7280; margin: 0; font-size: 14px; line-height: 1.6;">The credit note is attached to this email for your records.</p>
+                        </td>
+                    </tr>
+                    <tr>
+                        <td style="padding-top: 32px; text-align: center;">
+                            <p style="color: #9ca3af; font-size: 12px; margin: 0;">© 2026 MenuVista. All rights reserved.</p>
+                        </td>
+                    </tr>
+                </table>
+            </td>
+        </tr>
+    </table>
+</body>
+</html>
+`, firstName, invoiceNumber, creditNoteNumber, amount, currency, html.EscapeString(reason), notice)
+}
@@ -356,7 +356,7 @@ func PaymentFailedTemplate(firstName, updatePaymentURL string) string {
                                 </tr>
                             </table>
                             
-                            <p style="color: #6b7280; margin: 24px 0 0 0; font-size: 14px; text-align: center;">We will retry the payment automatically in 24 hours.</p>
+                            <p style="color: #6b7280; margin: 24px 0 0 0; font-size: 14px; text-align: center;">We will send you a new payment link again in a few days if the payment is still outstanding.</p>
                         </td>
                     </tr>
                     <tr>
@@ -413,55 +413,3 @@ func PaymentPendingTemplate(firstName string) string {
 </html>
 `, firstName)
 }
-
-// StaffWelcomeTemplate generates welcome email for new staff
-func StaffWelcomeTemplate(firstName, email, password string) string {
-	return fmt.Sprintf(`
-<!DOCTYPE html>
-<html>
-<body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; background-color: #f3f4f6;">
-    <table role="presentation" width="100%%" cellspacing="0" cellpadding="0" border="0" style="background-color: #f3f4f6;">
-        <tr>
-            <td style="padding: 40px 20px;">
-                <table role="presentation" width="600" cellspacing="0" cellpadding="0" border="0" style="margin: 0 auto; max-width: 600px;">
-                    <tr>
-                        <td style="text-align: center; padding-bottom: 32px;">
-                            <h1 style="color: #667eea; font-size: 32px; margin: 0; font-weight: 700;">🍽️ MenuVista</h1>
-                        </td>
-                    </tr>
-                    <tr>
-                        <td style="background: #ffffff; border-radius: 12px; padding: 40px; box-shadow: 0 4px 6px rgba(0,0,0,0.1);">
-                            <h2 style="color: #1f2937; margin: 0 0 16px 0; font-size: 24px; font-weight: 600;">Welcome to the Team! 👋</h2>
-                            <p style="color: #4b5563; margin: 0 0 16px 0; font-size: 16px; line-height: 1.6;">Hi <strong>%s</strong>,</p>
-                            <p style="color: #4b5563; margin: 0 0 16px 0; font-size: 16px; line-height: 1.6;">You have been added as a staff member to MenuVista.</p>
-                            
-                            <div style="background: #f3f4f6; padding: 20px; border-radius: 8px; margin: 24px 0;">
-                                <h3 style="color: #374151; margin: 0 0 12px 0; font-size: 18px;">Your Login Credentials:</h3>
-                                <p style="color: #4b5563; margin: 0 0 8px 0;"><strong>Email:</strong> %s</p>
-                                <p style="color: #4b5563; margin: 0;"><strong>Password:</strong> <code style="background: #e5e7eb; padding: 2px 6px; border-radius: 4px; font-family: monospace;">%s</code></p>
-                            </div>
-                            
-                            <p style="color: #ef4444; margin: 0 0 24px 0; font-size: 14px;">⚠️ Please log in and change your password immediately.</p>
-                            
-                            <table role="presentation" width="100%%" cellspacing="0" cellpadding="0" border="0">
-                                <tr>
-                                    <td align="center" style="padding: 24px 0;">
-                                        <a href="http://localhost:8080/login" style="display: inline-block; background: #667eea; color: #ffffff; padding: 16px 40px; border-radius: 8px; text-decoration: none; font-weight: 600; font-size: 16px;">Log In Now</a>
-                                    </td>
-                                </tr>
-                            </table>
-                        </td>
-                    </tr>
-                    <tr>
-                        <td style="padding-top: 32px; text-align: center;">
-                            <p style="color: #9ca3af; font-size: 12px; margin: 0;">© 2026 MenuVista. All rights reserved.</p>
-                        </td>
-                    </tr>
-                </table>
-            </td>
-        </tr>
-    </table>
-</body>
-</html>
-`, firstName, email, password)
-}
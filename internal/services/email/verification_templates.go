@@ -2,6 +2,12 @@ package email
 
 import "fmt"
 
+const (
+	trialWarningSubject         = "Your MenuVista Free Trial Is Ending Soon"
+	trialExpiredSubject         = "Your MenuVista Free Trial Has Ended"
+	subscriptionExpiringSubject = "Your MenuVista Subscription Renews Soon"
+)
+
 // VerificationEmailTemplate generates email verification email
 func VerificationEmailTemplate(name, verificationURL, expiresIn string) string {
 	return fmt.Sprintf(`
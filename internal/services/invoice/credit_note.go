@@ -0,0 +1,102 @@
+package invoice
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"menuvista/internal/storage/persistence"
+	"menuvista/internal/utils"
+)
+
+// reasonLineLength is how many characters of the refund reason fit on one line
+const reasonLineLength = 95
+
+// renderCreditNote lays a credit note against a paid invoice out on an A4 page. The
+// refund carries the invoice's VAT rate, so it reverses the VAT charged on that part.
+func renderCreditNote(seller Seller, inv persistence.GetInvoiceDocumentRow, note persistence.CreditNote) []byte {
+	amount, _ := utils.NumericToFloat(note.Amount)
+	rate, _ := utils.NumericToFloat(note.VatRate)
+	vat := SplitVAT(amount, rate)
+
+	page := newPDFPage()
+	y := drawHeading(page, seller, "CREDIT NOTE", [][2]string{
+		{"Credit note no.", note.CreditNoteNumber},
+		{"Issued", formatDate(note.CreatedAt)},
+		{"Invoice no.", inv.InvoiceNumber.String},
+		{"Invoice date", formatDate(inv.PaidAt)},
+	}, inv)
+
+	// Lines
+	y -= 36
+	page.fillRect(left, y-6, right-left, 20, 0.93)
+	page.text(left+8, y, 9, true, "DESCRIPTION")
+	page.textRight(right-8, y, 9, true, fmt.Sprintf("AMOUNT (%s)", note.Currency))
+
+	y -= 26
+	page.text(left+8, y, 10, false, fmt.Sprintf("Refund of invoice %s, %s plan", inv.InvoiceNumber.String, inv.PlanName))
+	page.textRight(right-8, y, 10, false, formatMoney(-amount))
+	for _, line := range wrapText("Reason: "+note.Reason, reasonLineLength) {
+		y -= 12
+		page.text(left+8, y, 8, false, line)
+	}
+	y -= 20
+	page.line(left, y+8, right, y+8, 0.5)
+
+	// Totals
+	totals := []struct {
+		label  string
+		amount float64
+		bold   bool
+	}{
+		{"Subtotal (excl. VAT)", -(amount - vat), false},
+		{fmt.Sprintf("VAT %s%%", strconv.FormatFloat(rate, 'f', -1, 64)), -vat, false},
+		{"Total credited " + note.Currency, -amount, true},
+	}
+	y -= 10
+	for _, t := range totals {
+		size := 10.0
+		if t.bold {
+			size = 12
+			page.line(right-200, y+14, right, y+14, 0.5)
+		}
+		page.text(right-200, y, size, t.bold, t.label)
+		page.textRight(right-8, y, size, t.bold, formatMoney(t.amount))
+		y -= 18
+	}
+
+	// Footer
+	page.line(left, 80, right, 80, 0.5)
+	page.text(left, 64, 8, false, fmt.Sprintf("This credit note reduces invoice %s by %s %s. The amount is returned to the original payment method.", inv.InvoiceNumber.String, formatMoney(amount), note.Currency))
+	page.text(left, 52, 8, false, "Refund ref. "+note.ProviderRefundRef.String)
+
+	return page.bytes()
+}
+
+// wrapText splits s into lines of at most width characters, breaking between words.
+// At most four lines are kept.
+func wrapText(s string, width int) []string {
+	const maxLines = 4
+
+	var lines []string
+	current := ""
+	for _, word := range strings.Fields(s) {
+		if current != "" && utf8.RuneCountInString(current)+1+utf8.RuneCountInString(word) > width {
+			lines = append(lines, current)
+			current = ""
+		}
+		if current != "" {
+			current += " "
+		}
+		current += word
+	}
+	if current != "" {
+		lines = append(lines, current)
+	}
+	if len(lines) > maxLines {
+		lines = lines[:maxLines]
+		lines[maxLines-1] += " ..."
+	}
+	return lines
+}
@@ -0,0 +1,216 @@
+package invoice
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+
+	"menuvista/internal/storage/persistence"
+	"menuvista/internal/utils"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// defaultVATRate is the Ethiopian standard VAT rate, in percent
+const defaultVATRate = 15.0
+
+// Seller is the business named as the issuer on invoices
+type Seller struct {
+	Name    string
+	Address string
+	TIN     string
+	Email   string
+}
+
+// SellerFromEnv reads the INVOICE_SELLER_* settings. The name defaults to MenuVista.
+func SellerFromEnv() Seller {
+	name := os.Getenv("INVOICE_SELLER_NAME")
+	if name == "" {
+		name = "MenuVista"
+	}
+	return Seller{
+		Name:    name,
+		Address: os.Getenv("INVOICE_SELLER_ADDRESS"),
+		TIN:     os.Getenv("INVOICE_SELLER_TIN"),
+		Email:   os.Getenv("INVOICE_SELLER_EMAIL"),
+	}
+}
+
+// VATRateFromEnv returns the VAT rate (percent) new invoices are issued with, from
+// INVOICE_VAT_RATE. Plan prices include VAT; the rate only splits them on the invoice.
+func VATRateFromEnv() float64 {
+	if rate, err := strconv.ParseFloat(os.Getenv("INVOICE_VAT_RATE"), 64); err == nil && rate >= 0 {
+		return rate
+	}
+	return defaultVATRate
+}
+
+// SplitVAT returns the VAT included in a gross amount
+func SplitVAT(gross, rate float64) float64 {
+	net := math.Round(gross/(1+rate/100)*100) / 100
+	return math.Round((gross-net)*100) / 100
+}
+
+// Page margins shared by invoices and credit notes
+const (
+	left  = 50.0
+	right = pageWidth - 50
+)
+
+// documentLine is a row of the invoice table; negative amounts are deductions
+type documentLine struct {
+	description string
+	amount      float64
+}
+
+// renderDocument lays the invoice out on an A4 page
+func renderDocument(seller Seller, inv persistence.GetInvoiceDocumentRow) []byte {
+	amount, _ := utils.NumericToFloat(inv.Amount)
+	credit, _ := utils.NumericToFloat(inv.CreditAmount)
+	discount, _ := utils.NumericToFloat(inv.DiscountAmount)
+	rate, _ := utils.NumericToFloat(inv.VatRate)
+	refunded, _ := utils.NumericToFloat(inv.RefundedAmount)
+	vat := SplitVAT(amount, rate)
+
+	details := [][2]string{
+		{"Invoice no.", inv.InvoiceNumber.String},
+		{"Issued", formatDate(inv.PaidAt)},
+		{"Payment ref.", inv.TxRef},
+	}
+	switch {
+	case inv.Status == persistence.InvoiceStatusRefunded:
+		details = append(details, [2]string{"Status", "Refunded"})
+	case refunded > 0:
+		details = append(details, [2]string{"Refunded", formatMoney(refunded) + " " + inv.Currency})
+	}
+	page := newPDFPage()
+	y := drawHeading(page, seller, "INVOICE", details, inv)
+
+	// Lines
+	y -= 36
+	page.fillRect(left, y-6, right-left, 20, 0.93)
+	page.text(left+8, y, 9, true, "DESCRIPTION")
+	page.textRight(right-8, y, 9, true, fmt.Sprintf("AMOUNT (%s)", inv.Currency))
+
+	interval := "monthly"
+	if inv.BillingInterval == persistence.BillingIntervalAnnual {
+		interval = "annual"
+	}
+	lines := []documentLine{
+		{fmt.Sprintf("%s plan, %s subscription", inv.PlanName, interval), amount + credit + discount},
+	}
+	if credit > 0 {
+		lines = append(lines, documentLine{"Credit for unused time on the previous plan", -credit})
+	}
+	if discount > 0 {
+		lines = append(lines, documentLine{"Discount, coupon " + inv.CouponCode.String, -discount})
+	}
+
+	y -= 26
+	for i, line := range lines {
+		page.text(left+8, y, 10, false, line.description)
+		page.textRight(right-8, y, 10, false, formatMoney(line.amount))
+		if i == 0 {
+			y -= 12
+			page.text(left+8, y, 8, false, fmt.Sprintf("Service period %s – %s", formatDate(inv.BillingPeriodStart), formatDate(inv.BillingPeriodEnd)))
+		}
+		y -= 20
+	}
+	page.line(left, y+8, right, y+8, 0.5)
+
+	// Totals
+	totals := []struct {
+		label  string
+		amount float64
+		bold   bool
+	}{
+		{"Subtotal (excl. VAT)", amount - vat, false},
+		{fmt.Sprintf("VAT %s%%", strconv.FormatFloat(rate, 'f', -1, 64)), vat, false},
+		{"Total " + inv.Currency, amount, true},
+	}
+	y -= 10
+	for _, t := range totals {
+		size := 10.0
+		if t.bold {
+			size = 12
+			page.line(right-200, y+14, right, y+14, 0.5)
+		}
+		page.text(right-200, y, size, t.bold, t.label)
+		page.textRight(right-8, y, size, t.bold, formatMoney(t.amount))
+		y -= 18
+	}
+
+	// Footer
+	page.line(left, 80, right, 80, 0.5)
+	page.text(left, 64, 8, false, fmt.Sprintf("Prices include VAT at %s%%. This invoice was paid in full on %s.", strconv.FormatFloat(rate, 'f', -1, 64), formatDate(inv.PaidAt)))
+	page.text(left, 52, 8, false, "Thank you for your business.")
+
+	return page.bytes()
+}
+
+// drawHeading puts the seller, the document title with its details, and the buyer at the
+// top of the page, returning where the body starts
+func drawHeading(page *pdfPage, seller Seller, title string, details [][2]string, inv persistence.GetInvoiceDocumentRow) float64 {
+	// Seller
+	y := pageHeight - 70
+	page.text(left, y, 20, true, seller.Name)
+	y -= 18
+	for _, line := range []string{seller.Address, labelled("TIN", seller.TIN), seller.Email} {
+		if line == "" {
+			continue
+		}
+		page.text(left, y, 9, false, line)
+		y -= 12
+	}
+
+	// Document details
+	page.textRight(right, pageHeight-70, 22, true, title)
+	dy := pageHeight - 88
+	for _, d := range details {
+		page.text(right-200, dy, 9, true, d[0])
+		page.text(right-125, dy, 9, false, d[1])
+		dy -= 12
+	}
+
+	// Buyer
+	y = math.Min(y, dy) - 24
+	page.text(left, y, 9, true, "BILL TO")
+	y -= 14
+	page.text(left, y, 11, true, inv.OwnerName)
+	y -= 13
+	page.text(left, y, 9, false, inv.OwnerEmail)
+	if inv.OwnerPhone.Valid && inv.OwnerPhone.String != "" {
+		y -= 12
+		page.text(left, y, 9, false, inv.OwnerPhone.String)
+	}
+	return y
+}
+
+func formatDate(t pgtype.Timestamp) string {
+	if !t.Valid {
+		return ""
+	}
+	return t.Time.Format("January 2, 2006")
+}
+
+func labelled(label, value string) string {
+	if value == "" {
+		return ""
+	}
+	return label + ": " + value
+}
+
+// formatMoney writes an amount with thousands separators, e.g. 1,234.50
+func formatMoney(amount float64) string {
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	s := strconv.FormatFloat(amount, 'f', 2, 64)
+	whole, cents := s[:len(s)-3], s[len(s)-3:]
+	for i := len(whole) - 3; i > 0; i -= 3 {
+		whole = whole[:i] + "," + whole[i:]
+	}
+	return sign + whole + cents
+}
@@ -0,0 +1,220 @@
+package invoice
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// ttfFont is a TrueType font embedded in documents for text the standard fonts cannot
+// print, such as Amharic. Only what the PDF needs is read: glyph IDs, advance widths and
+// the metrics for the font descriptor.
+type ttfFont struct {
+	name       string
+	data       []byte
+	unitsPerEm float64
+	glyphs     map[rune]uint16
+	advances   []uint16
+	bbox       [4]int16
+	ascent     int16
+	descent    int16
+}
+
+var (
+	documentFontsOnce sync.Once
+	documentFonts     *pdfFonts
+
+	nonNameChars = regexp.MustCompile(`[^A-Za-z0-9-]`)
+)
+
+// loadDocumentFonts reads the fonts in INVOICE_FONT_PATH and INVOICE_BOLD_FONT_PATH once.
+// Without them, or when they cannot be used, documents keep to the standard fonts.
+func loadDocumentFonts() *pdfFonts {
+	documentFontsOnce.Do(func() {
+		path := os.Getenv("INVOICE_FONT_PATH")
+		if path == "" {
+			return
+		}
+		regular, err := loadTTF(path)
+		if err != nil {
+			log.Printf("[InvoiceService] Failed to load INVOICE_FONT_PATH, non Latin-1 text will print as \"?\": %v", err)
+			return
+		}
+
+		bold := regular
+		if boldPath := os.Getenv("INVOICE_BOLD_FONT_PATH"); boldPath != "" {
+			if bold, err = loadTTF(boldPath); err != nil {
+				log.Printf("[InvoiceService] Failed to load INVOICE_BOLD_FONT_PATH, using the regular font for bold text: %v", err)
+				bold = regular
+			}
+		}
+		documentFonts = &pdfFonts{regular: regular, bold: bold}
+	})
+	return documentFonts
+}
+
+func loadTTF(path string) (*ttfFont, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	name := nonNameChars.ReplaceAllString(strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)), "")
+	if name == "" {
+		name = "DocumentFont"
+	}
+	return parseTTF(name, data)
+}
+
+// parseTTF reads a TrueType font. CFF based OpenType fonts (.otf) are not supported.
+func parseTTF(name string, data []byte) (*ttfFont, error) {
+	if len(data) < 12 {
+		return nil, errors.New("not a TrueType font")
+	}
+	tables := make(map[string][]byte)
+	numTables := int(binary.BigEndian.Uint16(data[4:]))
+	for i := 0; i < numTables; i++ {
+		record := 12 + 16*i
+		if record+16 > len(data) {
+			return nil, errors.New("truncated table directory")
+		}
+		offset := int(binary.BigEndian.Uint32(data[record+8:]))
+		length := int(binary.BigEndian.Uint32(data[record+12:]))
+		if offset < 0 || length < 0 || offset+length > len(data) {
+			return nil, errors.New("table outside the font file")
+		}
+		tables[string(data[record:record+4])] = data[offset : offset+length]
+	}
+	for _, tag := range []string{"head", "hhea", "hmtx", "maxp", "cmap", "glyf"} {
+		if _, ok := tables[tag]; !ok {
+			return nil, fmt.Errorf("font has no %s table, only TrueType outlines are supported", tag)
+		}
+	}
+
+	// Fonts whose licence forbids embedding say so in OS/2 fsType
+	if os2 := tables["OS/2"]; len(os2) >= 10 && binary.BigEndian.Uint16(os2[8:])&0x000f == 0x0002 {
+		return nil, errors.New("font licence does not allow embedding")
+	}
+
+	head, hhea, maxp := tables["head"], tables["hhea"], tables["maxp"]
+	if len(head) < 54 || len(hhea) < 36 || len(maxp) < 6 {
+		return nil, errors.New("truncated font header")
+	}
+	font := &ttfFont{
+		name:       name,
+		data:       data,
+		unitsPerEm: float64(binary.BigEndian.Uint16(head[18:])),
+		ascent:     int16(binary.BigEndian.Uint16(hhea[4:])),
+		descent:    int16(binary.BigEndian.Uint16(hhea[6:])),
+	}
+	if font.unitsPerEm == 0 {
+		return nil, errors.New("font has no units per em")
+	}
+	for i := range font.bbox {
+		font.bbox[i] = int16(binary.BigEndian.Uint16(head[36+2*i:]))
+	}
+
+	numGlyphs := int(binary.BigEndian.Uint16(maxp[4:]))
+	numMetrics := int(binary.BigEndian.Uint16(hhea[34:]))
+	hmtx := tables["hmtx"]
+	if numMetrics == 0 || len(hmtx) < 4*numMetrics {
+		return nil, errors.New("truncated horizontal metrics")
+	}
+	font.advances = make([]uint16, numGlyphs)
+	for gid := range font.advances {
+		font.advances[gid] = binary.BigEndian.Uint16(hmtx[4*min(gid, numMetrics-1):])
+	}
+
+	glyphs, err := parseCmap(tables["cmap"], numGlyphs)
+	if err != nil {
+		return nil, err
+	}
+	font.glyphs = glyphs
+	return font, nil
+}
+
+// parseCmap maps code points to glyphs from the Unicode subtable, preferring the full
+// repertoire (format 12) over the Basic Multilingual Plane one (format 4)
+func parseCmap(cmap []byte, numGlyphs int) (map[rune]uint16, error) {
+	if len(cmap) < 4 {
+		return nil, errors.New("truncated cmap table")
+	}
+	var format4, format12 []byte
+	numSubtables := int(binary.BigEndian.Uint16(cmap[2:]))
+	for i := 0; i < numSubtables; i++ {
+		record := 4 + 8*i
+		if record+8 > len(cmap) {
+			break
+		}
+		platform := binary.BigEndian.Uint16(cmap[record:])
+		encoding := binary.BigEndian.Uint16(cmap[record+2:])
+		offset := int(binary.BigEndian.Uint32(cmap[record+4:]))
+		if offset+2 > len(cmap) || !(platform == 0 || (platform == 3 && (encoding == 1 || encoding == 10))) {
+			continue
+		}
+		switch binary.BigEndian.Uint16(cmap[offset:]) {
+		case 4:
+			format4 = cmap[offset:]
+		case 12:
+			format12 = cmap[offset:]
+		}
+	}
+
+	glyphs := make(map[rune]uint16)
+	valid := func(gid int) bool { return gid > 0 && gid < numGlyphs }
+	switch {
+	case len(format12) >= 16:
+		groups := int(binary.BigEndian.Uint32(format12[12:]))
+		for g := 0; g < groups && 16+12*g+12 <= len(format12); g++ {
+			group := format12[16+12*g:]
+			start, end := binary.BigEndian.Uint32(group), binary.BigEndian.Uint32(group[4:])
+			gid := int(binary.BigEndian.Uint32(group[8:]))
+			for c := start; c <= end && c <= 0x10ffff; c, gid = c+1, gid+1 {
+				if valid(gid) {
+					glyphs[rune(c)] = uint16(gid)
+				}
+			}
+		}
+	case len(format4) >= 14:
+		segments := int(binary.BigEndian.Uint16(format4[6:])) / 2
+		ends, starts := 14, 16+2*segments
+		deltas, rangeOffsets := starts+2*segments, starts+4*segments
+		if rangeOffsets+2*segments > len(format4) {
+			return nil, errors.New("truncated cmap subtable")
+		}
+		for s := 0; s < segments; s++ {
+			end := int(binary.BigEndian.Uint16(format4[ends+2*s:]))
+			start := int(binary.BigEndian.Uint16(format4[starts+2*s:]))
+			delta := int(binary.BigEndian.Uint16(format4[deltas+2*s:]))
+			rangeOffset := int(binary.BigEndian.Uint16(format4[rangeOffsets+2*s:]))
+			for c := start; c <= end && c != 0xffff; c++ {
+				gid := (c + delta) & 0xffff
+				if rangeOffset != 0 {
+					at := rangeOffsets + 2*s + rangeOffset + 2*(c-start)
+					if at+2 > len(format4) {
+						continue
+					}
+					if gid = int(binary.BigEndian.Uint16(format4[at:])); gid != 0 {
+						gid = (gid + delta) & 0xffff
+					}
+				}
+				if valid(gid) {
+					glyphs[rune(c)] = uint16(gid)
+				}
+			}
+		}
+	default:
+		return nil, errors.New("font has no Unicode cmap")
+	}
+	return glyphs, nil
+}
+
+// scale converts font units to the 1000 unit text space PDF widths use
+func (f *ttfFont) scale(units float64) int {
+	return int(units * 1000 / f.unitsPerEm)
+}
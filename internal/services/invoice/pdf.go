@@ -0,0 +1,282 @@
+package invoice
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf16"
+)
+
+// A4 in PDF points
+const (
+	pageWidth  = 595.0
+	pageHeight = 842.0
+)
+
+// pdfPage is a single-page PDF drawn with the standard Helvetica fonts. Standard fonts
+// need no embedding, which keeps the writer small; the catch is that they only cover
+// Latin-1. Other characters, such as Amharic names, are drawn with the TrueType fonts
+// configured for documents, which are embedded only when a page uses them. Without
+// those fonts they are printed as "?".
+type pdfPage struct {
+	content bytes.Buffer
+	fonts   *pdfFonts
+	// used records the glyphs drawn with each embedded font and the characters they show
+	used map[*ttfFont]map[uint16]rune
+}
+
+// pdfFonts are the embedded fonts for regular and bold text. They may be the same font.
+type pdfFonts struct {
+	regular *ttfFont
+	bold    *ttfFont
+}
+
+// textRun is a stretch of text drawn with one font
+type textRun struct {
+	text     string
+	embedded bool
+}
+
+func newPDFPage() *pdfPage {
+	return &pdfPage{fonts: loadDocumentFonts()}
+}
+
+// text draws s with its baseline starting at x, y (from the bottom left of the page).
+// Runs in different fonts follow each other within one text object, so the viewer
+// places each run after the previous one.
+func (p *pdfPage) text(x, y, size float64, bold bool, s string) {
+	standard, embedded, font := p.fontFor(bold)
+	fmt.Fprintf(&p.content, "BT %.2f %.2f Td", x, y)
+	for _, run := range splitRuns(s, font) {
+		if run.embedded {
+			fmt.Fprintf(&p.content, " /%s %.1f Tf <%s> Tj", embedded, size, p.glyphHex(font, run.text))
+		} else {
+			fmt.Fprintf(&p.content, " /%s %.1f Tf (%s) Tj", standard, size, escapePDFText(run.text))
+		}
+	}
+	p.content.WriteString(" ET\n")
+}
+
+// textRight draws s so that it ends at x. Widths in the standard fonts are only known
+// for the characters amounts are written with, so this is meant for numbers.
+func (p *pdfPage) textRight(x, y, size float64, bold bool, s string) {
+	_, _, font := p.fontFor(bold)
+	var width float64
+	for _, run := range splitRuns(s, font) {
+		if !run.embedded {
+			width += numberWidth(run.text, size)
+			continue
+		}
+		for _, r := range run.text {
+			width += float64(font.advances[font.glyphs[r]]) * size / font.unitsPerEm
+		}
+	}
+	p.text(x-width, y, size, bold, s)
+}
+
+// fontFor names the standard and embedded font resources for regular or bold text
+func (p *pdfPage) fontFor(bold bool) (string, string, *ttfFont) {
+	var font *ttfFont
+	if bold {
+		if p.fonts != nil {
+			font = p.fonts.bold
+		}
+		return "F2", "F4", font
+	}
+	if p.fonts != nil {
+		font = p.fonts.regular
+	}
+	return "F1", "F3", font
+}
+
+// glyphHex encodes s as the two-byte glyph IDs the embedded font is written with
+func (p *pdfPage) glyphHex(font *ttfFont, s string) string {
+	if p.used == nil {
+		p.used = make(map[*ttfFont]map[uint16]rune)
+	}
+	if p.used[font] == nil {
+		p.used[font] = make(map[uint16]rune)
+	}
+	var b strings.Builder
+	for _, r := range s {
+		gid := font.glyphs[r]
+		p.used[font][gid] = r
+		fmt.Fprintf(&b, "%04X", gid)
+	}
+	return b.String()
+}
+
+// splitRuns keeps everything the standard fonts can print in them, so Latin text looks
+// the same with or without embedded fonts, and hands the rest to font where it has a glyph
+func splitRuns(s string, font *ttfFont) []textRun {
+	var runs []textRun
+	for _, r := range s {
+		embedded := false
+		if font != nil && !isWinAnsi(r) {
+			_, embedded = font.glyphs[r]
+		}
+		if n := len(runs); n > 0 && runs[n-1].embedded == embedded {
+			runs[n-1].text += string(r)
+			continue
+		}
+		runs = append(runs, textRun{text: string(r), embedded: embedded})
+	}
+	return runs
+}
+
+func (p *pdfPage) line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(&p.content, "%.2f w %.2f %.2f m %.2f %.2f l S\n", width, x1, y1, x2, y2)
+}
+
+// fillRect paints a rectangle in a shade of gray (0 black, 1 white)
+func (p *pdfPage) fillRect(x, y, w, h, gray float64) {
+	fmt.Fprintf(&p.content, "q %.2f g %.2f %.2f %.2f %.2f re f Q\n", gray, x, y, w, h)
+}
+
+// bytes serializes the page as a complete PDF document
+func (p *pdfPage) bytes() []byte {
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"", // the page, once the fonts are known
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", p.content.Len(), p.content.String()),
+	}
+
+	fonts := "/F1 4 0 R /F2 5 0 R"
+	if p.fonts != nil {
+		written := make(map[*ttfFont]int)
+		for _, f := range []struct {
+			resource string
+			font     *ttfFont
+		}{{"F3", p.fonts.regular}, {"F4", p.fonts.bold}} {
+			if len(p.used[f.font]) == 0 {
+				continue
+			}
+			id, ok := written[f.font]
+			if !ok {
+				id = len(objects) + 1
+				objects = append(objects, embeddedFontObjects(id, f.font, p.used[f.font])...)
+				written[f.font] = id
+			}
+			fonts += fmt.Sprintf(" /%s %d 0 R", f.resource, id)
+		}
+	}
+	objects[2] = fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << %s >> >> /Contents 6 0 R >>", pageWidth, pageHeight, fonts)
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return out.Bytes()
+}
+
+// embeddedFontObjects writes font as a composite font starting at object id: the Type0
+// font, its CID font, descriptor, the whole TrueType file and a ToUnicode map so the
+// text can be searched and copied. Glyph IDs are used as character codes (Identity-H).
+func embeddedFontObjects(id int, font *ttfFont, used map[uint16]rune) []string {
+	gids := make([]uint16, 0, len(used))
+	for gid := range used {
+		gids = append(gids, gid)
+	}
+	slices.Sort(gids)
+
+	var widths, unicode strings.Builder
+	for i, gid := range gids {
+		fmt.Fprintf(&widths, "%d [%d] ", gid, font.scale(float64(font.advances[gid])))
+		// bfchar blocks hold at most 100 entries
+		if i%100 == 0 {
+			if i > 0 {
+				unicode.WriteString("endbfchar\n")
+			}
+			fmt.Fprintf(&unicode, "%d beginbfchar\n", min(100, len(gids)-i))
+		}
+		fmt.Fprintf(&unicode, "<%04X> <", gid)
+		for _, unit := range utf16.Encode([]rune{used[gid]}) {
+			fmt.Fprintf(&unicode, "%04X", unit)
+		}
+		unicode.WriteString(">\n")
+	}
+	unicode.WriteString("endbfchar\n")
+
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	_, _ = zw.Write(font.data)
+	_ = zw.Close()
+
+	cmap := "/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n" +
+		"/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n" +
+		"/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n" +
+		"1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n" +
+		unicode.String() +
+		"endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend\n"
+
+	return []string{
+		fmt.Sprintf("<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /Identity-H /DescendantFonts [%d 0 R] /ToUnicode %d 0 R >>", font.name, id+1, id+4),
+		fmt.Sprintf("<< /Type /Font /Subtype /CIDFontType2 /BaseFont /%s /CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> /FontDescriptor %d 0 R /W [%s] /CIDToGIDMap /Identity >>", font.name, id+2, strings.TrimSpace(widths.String())),
+		fmt.Sprintf("<< /Type /FontDescriptor /FontName /%s /Flags 32 /FontBBox [%d %d %d %d] /ItalicAngle 0 /Ascent %d /Descent %d /CapHeight %d /StemV 80 /FontFile2 %d 0 R >>",
+			font.name, font.scale(float64(font.bbox[0])), font.scale(float64(font.bbox[1])), font.scale(float64(font.bbox[2])), font.scale(float64(font.bbox[3])),
+			font.scale(float64(font.ascent)), font.scale(float64(font.descent)), font.scale(float64(font.ascent)), id+3),
+		fmt.Sprintf("<< /Length %d /Length1 %d /Filter /FlateDecode >>\nstream\n%s\nendstream", compressed.Len(), len(font.data), compressed.String()),
+		fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(cmap), cmap),
+	}
+}
+
+// isWinAnsi reports whether the standard fonts can print r
+func isWinAnsi(r rune) bool {
+	return r == '–' || (r >= 0x20 && r < 0x7f) || (r >= 0xa0 && r <= 0xff)
+}
+
+// escapePDFText converts s to WinAnsi bytes for a PDF string literal
+func escapePDFText(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '–':
+			b.WriteString(`\226`) // en dash
+		case r >= 0x20 && r < 0x7f:
+			b.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+// numberWidth measures s in Helvetica (regular and bold share these digit widths)
+func numberWidth(s string, size float64) float64 {
+	var units float64
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			units += 556
+		case r == '.' || r == ',' || r == ' ':
+			units += 278
+		case r == '-':
+			units += 333
+		case r == '%':
+			units += 889
+		default:
+			units += 556
+		}
+	}
+	return units * size / 1000
+}
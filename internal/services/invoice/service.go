@@ -0,0 +1,156 @@
+package invoice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"menuvista/internal/models"
+	"menuvista/internal/storage/persistence"
+	"menuvista/internal/utils"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrInvoiceNotFound  = errors.New("invoice not found")
+	ErrInvoiceNotIssued = errors.New("invoice has not been paid, so there is no document for it yet")
+	ErrInvalidStatus    = errors.New("status must be pending, paid, failed, refunded or cancelled")
+)
+
+type Service struct {
+	queries *persistence.Queries
+	seller  Seller
+}
+
+func NewService(queries *persistence.Queries, seller Seller) *Service {
+	return &Service{
+		queries: queries,
+		seller:  seller,
+	}
+}
+
+// ListInvoices returns the owner's invoices, newest first, optionally filtered by
+// subscription and status
+func (s *Service) ListInvoices(ctx context.Context, ownerID uuid.UUID, filters models.FilterParams, pagination models.PaginationParams) ([]*models.Invoice, *models.Meta, error) {
+	var status persistence.NullInvoiceStatus
+	if value, ok := utils.ToInvoiceStatus(filters.Status); ok {
+		switch value {
+		case persistence.InvoiceStatusPending, persistence.InvoiceStatusPaid, persistence.InvoiceStatusFailed, persistence.InvoiceStatusRefunded, persistence.InvoiceStatusCancelled:
+			status = persistence.NullInvoiceStatus{InvoiceStatus: value, Valid: true}
+		default:
+			return nil, nil, ErrInvalidStatus
+		}
+	}
+	subscriptionID := utils.ToUUID(filters.SubscriptionID)
+
+	rows, err := s.queries.ListInvoicesWithFilters(ctx, persistence.ListInvoicesWithFiltersParams{
+		OwnerID:        ownerID,
+		SubscriptionID: subscriptionID,
+		Status:         status,
+		Limit:          int32(pagination.PageSize),
+		Offset:         int32(pagination.Offset),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list invoices: %w", err)
+	}
+
+	total, err := s.queries.CountInvoicesWithFilters(ctx, persistence.CountInvoicesWithFiltersParams{
+		OwnerID:        ownerID,
+		SubscriptionID: subscriptionID,
+		Status:         status,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to count invoices: %w", err)
+	}
+
+	invoices := make([]*models.Invoice, len(rows))
+	for i, row := range rows {
+		invoices[i] = mapInvoice(row)
+	}
+	return invoices, models.CalculateMeta(pagination.Page, pagination.PageSize, int(total)), nil
+}
+
+// GetInvoicePDF renders one of the owner's paid invoices, returning the document and
+// a file name for it
+func (s *Service) GetInvoicePDF(ctx context.Context, ownerID, invoiceID uuid.UUID) ([]byte, string, error) {
+	inv, err := s.queries.GetInvoiceDocument(ctx, invoiceID)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && inv.OwnerID != ownerID) {
+		return nil, "", ErrInvoiceNotFound
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch invoice: %w", err)
+	}
+	return s.render(inv)
+}
+
+// RenderPDF renders a paid invoice for attaching to emails
+func (s *Service) RenderPDF(ctx context.Context, invoiceID uuid.UUID) ([]byte, string, error) {
+	inv, err := s.queries.GetInvoiceDocument(ctx, invoiceID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch invoice: %w", err)
+	}
+	return s.render(inv)
+}
+
+// GetInvoice returns any invoice, for admin use
+func (s *Service) GetInvoice(ctx context.Context, invoiceID uuid.UUID) (*models.Invoice, error) {
+	row, err := s.queries.GetInvoiceByID(ctx, invoiceID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrInvoiceNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch invoice: %w", err)
+	}
+	return mapInvoice(row), nil
+}
+
+// RenderCreditNotePDF renders a credit note for attaching to emails
+func (s *Service) RenderCreditNotePDF(ctx context.Context, note persistence.CreditNote) ([]byte, string, error) {
+	inv, err := s.queries.GetInvoiceDocument(ctx, note.InvoiceID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch invoice: %w", err)
+	}
+	log.Printf("[InvoiceService] Rendering credit note %s", note.CreditNoteNumber)
+	return renderCreditNote(s.seller, inv, note), note.CreditNoteNumber + ".pdf", nil
+}
+
+func (s *Service) render(inv persistence.GetInvoiceDocumentRow) ([]byte, string, error) {
+	if !inv.InvoiceNumber.Valid {
+		return nil, "", ErrInvoiceNotIssued
+	}
+	log.Printf("[InvoiceService] Rendering invoice %s", inv.InvoiceNumber.String)
+	return renderDocument(s.seller, inv), inv.InvoiceNumber.String + ".pdf", nil
+}
+
+func mapInvoice(row persistence.Invoice) *models.Invoice {
+	amount, _ := utils.NumericToFloat(row.Amount)
+	credit, _ := utils.NumericToFloat(row.CreditAmount)
+	discount, _ := utils.NumericToFloat(row.DiscountAmount)
+	rate, _ := utils.NumericToFloat(row.VatRate)
+	refunded, _ := utils.NumericToFloat(row.RefundedAmount)
+
+	invoice := &models.Invoice{
+		ID:             row.ID,
+		SubscriptionID: row.SubscriptionID,
+		InvoiceNumber:  row.InvoiceNumber.String,
+		Status:         string(row.Status),
+		Currency:       row.Currency,
+		Amount:         amount,
+		CreditAmount:   credit,
+		DiscountAmount: discount,
+		VATRate:        rate,
+		VATAmount:      SplitVAT(amount, rate),
+		RefundedAmount: refunded,
+		Prorated:       row.Prorated,
+		PeriodStart:    row.BillingPeriodStart.Time,
+		PeriodEnd:      row.BillingPeriodEnd.Time,
+		CreatedAt:      row.CreatedAt.Time,
+	}
+	if row.PaidAt.Valid {
+		invoice.PaidAt = &row.PaidAt.Time
+	}
+	return invoice
+}
@@ -0,0 +1,195 @@
+package menu
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"menuvista/internal/models"
+	"menuvista/internal/storage/persistence"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrMenuItemNotFound = errors.New("menu item not found")
+	ErrStockNotTracked  = errors.New("stock tracking is not enabled for this item")
+)
+
+// EmailService is the subset of the email service used for inventory alerts.
+type EmailService interface {
+	SendLowStockEmail(ctx context.Context, email, ownerName, restaurantName, itemName string, quantity, threshold int32) error
+}
+
+// OpeningHours resolves when a restaurant next opens, used to restore 86'd items.
+type OpeningHours interface {
+	NextOpening(ctx context.Context, restaurantID uuid.UUID, after time.Time) (time.Time, bool, error)
+}
+
+// UpdateStock sets the stock level and alert threshold of an item and turns tracking on or off.
+func (s *Service) UpdateStock(ctx context.Context, userID, restaurantID, itemID uuid.UUID, input models.UpdateStockRequest) (*models.MenuItem, error) {
+	if _, err := s.getAccessibleItem(ctx, userID, restaurantID, itemID); err != nil {
+		return nil, err
+	}
+
+	log.Printf("[MenuService] Setting stock for item %v: tracked=%v quantity=%d threshold=%d", itemID, input.TrackStock, input.StockQuantity, input.LowStockThreshold)
+
+	row, err := s.queries.UpdateMenuItemStock(ctx, persistence.UpdateMenuItemStockParams{
+		ID:                itemID,
+		TrackStock:        input.TrackStock,
+		StockQuantity:     input.StockQuantity,
+		LowStockThreshold: input.LowStockThreshold,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update stock: %w", err)
+	}
+
+	s.notifyLowStock(ctx, row)
+	return s.mapToDomainMenuItem(row), nil
+}
+
+// AdjustStock changes the quantity on hand by a delta. Items are hidden automatically
+// when stock reaches zero and shown again when it is replenished.
+func (s *Service) AdjustStock(ctx context.Context, userID, restaurantID, itemID uuid.UUID, input models.AdjustStockRequest) (*models.MenuItem, error) {
+	if _, err := s.getAccessibleItem(ctx, userID, restaurantID, itemID); err != nil {
+		return nil, err
+	}
+
+	log.Printf("[MenuService] Adjusting stock for item %v by %d (reason: %s)", itemID, input.Delta, input.Reason)
+
+	row, err := s.queries.AdjustMenuItemStock(ctx, persistence.AdjustMenuItemStockParams{
+		Delta: input.Delta,
+		ID:    itemID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrStockNotTracked
+		}
+		return nil, fmt.Errorf("failed to adjust stock: %w", err)
+	}
+
+	s.notifyLowStock(ctx, row)
+	return s.mapToDomainMenuItem(row), nil
+}
+
+// EightySixItem marks an item unavailable until the restaurant next opens, or until
+// midnight when no opening hours are configured.
+func (s *Service) EightySixItem(ctx context.Context, userID, restaurantID, itemID uuid.UUID) (*models.MenuItem, error) {
+	item, err := s.getAccessibleItem(ctx, userID, restaurantID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	// unavailable_until holds restaurant-local wall-clock time, like the opening hours
+	now := s.localNow(ctx, item.RestaurantID)
+	until := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+	if s.openingHours != nil {
+		next, ok, err := s.openingHours.NextOpening(ctx, item.RestaurantID, time.Now())
+		if err != nil {
+			log.Printf("[MenuService] Failed to resolve next opening for restaurant %v: %v", item.RestaurantID, err)
+		} else if ok {
+			until = next
+		}
+	}
+
+	log.Printf("[MenuService] 86'ing item %v until %s", itemID, until.Format("2006-01-02 15:04"))
+
+	row, err := s.queries.EightySixMenuItem(ctx, persistence.EightySixMenuItemParams{
+		ID:               itemID,
+		UnavailableUntil: pgtype.Timestamp{Time: until, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark item unavailable: %w", err)
+	}
+	return s.mapToDomainMenuItem(row), nil
+}
+
+// RestoreItem lifts an 86 early. Tracked items stay hidden while out of stock.
+func (s *Service) RestoreItem(ctx context.Context, userID, restaurantID, itemID uuid.UUID) (*models.MenuItem, error) {
+	if _, err := s.getAccessibleItem(ctx, userID, restaurantID, itemID); err != nil {
+		return nil, err
+	}
+
+	row, err := s.queries.RestoreMenuItemAvailability(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore item: %w", err)
+	}
+	return s.mapToDomainMenuItem(row), nil
+}
+
+func (s *Service) ListLowStockItems(ctx context.Context, userID, restaurantID uuid.UUID) ([]*models.MenuItem, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.verifyAccess(ctx, user, restaurantID); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.ListLowStockMenuItems(ctx, restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list low stock items: %w", err)
+	}
+
+	items := make([]*models.MenuItem, len(rows))
+	for i, row := range rows {
+		items[i] = s.mapToDomainMenuItem(row)
+	}
+	return items, nil
+}
+
+func (s *Service) getAccessibleItem(ctx context.Context, userID, restaurantID, itemID uuid.UUID) (persistence.MenuItem, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return persistence.MenuItem{}, err
+	}
+
+	item, err := s.queries.GetMenuItemByID(ctx, itemID)
+	if err != nil || item.RestaurantID != restaurantID {
+		return persistence.MenuItem{}, ErrMenuItemNotFound
+	}
+
+	if err := s.verifyAccess(ctx, user, item.RestaurantID); err != nil {
+		return persistence.MenuItem{}, err
+	}
+	return item, nil
+}
+
+// notifyLowStock emails the owner the first time a tracked item drops to its threshold.
+// The flag is cleared again once the item is restocked above the threshold.
+func (s *Service) notifyLowStock(ctx context.Context, item persistence.MenuItem) {
+	if !item.TrackStock || item.StockQuantity > item.LowStockThreshold || item.LowStockNotifiedAt.Valid {
+		return
+	}
+
+	// Only the caller that flips the flag sends the email, so concurrent sales alert once.
+	marked, err := s.queries.MarkLowStockNotified(ctx, item.ID)
+	if err != nil || marked == 0 {
+		return
+	}
+
+	if s.emailService == nil {
+		return
+	}
+
+	restaurant, err := s.queries.GetRestaurantByID(ctx, item.RestaurantID)
+	if err != nil {
+		log.Printf("[MenuService] Failed to load restaurant for low stock alert: %v", err)
+		return
+	}
+	owner, err := s.queries.GetUserByID(ctx, restaurant.OwnerID)
+	if err != nil {
+		log.Printf("[MenuService] Failed to load owner for low stock alert: %v", err)
+		return
+	}
+
+	go func() {
+		if err := s.emailService.SendLowStockEmail(context.Background(), owner.Email, owner.FullName, restaurant.Name, item.Name, item.StockQuantity, item.LowStockThreshold); err != nil {
+			log.Printf("[MenuService] Failed to send low stock email: %v", err)
+		}
+	}()
+}
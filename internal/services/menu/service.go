@@ -2,12 +2,14 @@ package menu
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"mime/multipart"
 	"time"
 
 	"menuvista/internal/models"
+	"menuvista/internal/services/subscription"
 	"menuvista/internal/storage/persistence"
 	"menuvista/internal/utils"
 	"menuvista/platform/storage"
@@ -17,14 +19,25 @@ import (
 )
 
 type Service struct {
-	queries *persistence.Queries
-	r2      *storage.R2Client
+	queries      *persistence.Queries
+	r2           *storage.R2Client
+	emailService EmailService
+	openingHours OpeningHours
+	promotions   Promotions
 }
 
-func NewService(queries *persistence.Queries, r2 *storage.R2Client) *Service {
+// Promotions prices menu items according to the promotions running right now.
+type Promotions interface {
+	ApplyPromotions(ctx context.Context, restaurantID uuid.UUID, items []*models.MenuItem) error
+}
+
+func NewService(queries *persistence.Queries, r2 *storage.R2Client, emailService EmailService, openingHours OpeningHours, promotions Promotions) *Service {
 	return &Service{
-		queries: queries,
-		r2:      r2,
+		queries:      queries,
+		r2:           r2,
+		emailService: emailService,
+		openingHours: openingHours,
+		promotions:   promotions,
 	}
 }
 
@@ -33,6 +46,10 @@ const (
 	errMenuItemNotFound = "menu item not found: %w"
 )
 
+// ErrPlanLocked is returned when re-enabling a category or item that was hidden because it
+// is above the owner's plan limits; it comes back when the owner upgrades
+var ErrPlanLocked = errors.New("this is above your plan's limits")
+
 // Categories
 
 // Categories
@@ -55,32 +72,9 @@ func (s *Service) CreateCategory(ctx context.Context, userID uuid.UUID, restaura
 	if user.Role == models.RoleStaff {
 		ownerID = *user.OwnerID
 	}
-	ownerIDStr := ownerID.String()
-	sub, err := s.queries.GetActiveSubscriptionByOwner(ctx, utils.ToUUID(&ownerIDStr))
+	features, err := subscription.EffectiveFeatures(ctx, s.queries, ownerID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch subscription: %w", err)
-	}
-
-	// Check status and expiration
-	now := time.Now()
-	isSubActive := false
-	if string(sub.Status) == string(models.SubscriptionStatusActive) {
-		if sub.CurrentPeriodEnd.Time.After(now) {
-			isSubActive = true
-		}
-	} else if string(sub.Status) == string(models.SubscriptionStatusTrialing) {
-		if sub.TrialEnd.Valid && sub.TrialEnd.Time.After(now) {
-			isSubActive = true
-		}
-	}
-
-	if !isSubActive {
-		return nil, fmt.Errorf("subscription is inactive or expired")
-	}
-
-	var features models.FeatureLimits
-	if err := utils.UnmarshalJSON(sub.Features, &features); err != nil {
-		log.Printf("[MenuService] Warning: Failed to unmarshal features: %v", err)
+		return nil, err
 	}
 
 	restaurantIDStr := restaurantID.String()
@@ -161,6 +155,9 @@ func (s *Service) UpdateCategory(ctx context.Context, userID uuid.UUID, id uuid.
 	if err := s.verifyAccess(ctx, user, category.RestaurantID); err != nil {
 		return nil, err
 	}
+	if category.PlanLocked && utils.DerefBool(input.IsActive) {
+		return nil, ErrPlanLocked
+	}
 
 	params := persistence.UpdateCategoryParams{
 		ID:           utils.ToUUID(&idStr),
@@ -225,32 +222,9 @@ func (s *Service) CreateMenuItem(ctx context.Context, userID uuid.UUID, restaura
 	if user.Role == models.RoleStaff {
 		ownerID = *user.OwnerID
 	}
-	ownerIDStr := ownerID.String()
-	sub, err := s.queries.GetActiveSubscriptionByOwner(ctx, utils.ToUUID(&ownerIDStr))
+	features, err := subscription.EffectiveFeatures(ctx, s.queries, ownerID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch subscription: %w", err)
-	}
-
-	// Check status and expiration
-	now := time.Now()
-	isSubActive := false
-	if string(sub.Status) == string(models.SubscriptionStatusActive) {
-		if sub.CurrentPeriodEnd.Time.After(now) {
-			isSubActive = true
-		}
-	} else if string(sub.Status) == string(models.SubscriptionStatusTrialing) {
-		if sub.TrialEnd.Valid && sub.TrialEnd.Time.After(now) {
-			isSubActive = true
-		}
-	}
-
-	if !isSubActive {
-		return nil, fmt.Errorf("subscription is inactive or expired")
-	}
-
-	var features models.FeatureLimits
-	if err := utils.UnmarshalJSON(sub.Features, &features); err != nil {
-		log.Printf("[MenuService] Warning: Failed to unmarshal features: %v", err)
+		return nil, err
 	}
 
 	restaurantIDStr := restaurantID.String()
@@ -303,6 +277,8 @@ func (s *Service) GetMenuItemByID(ctx context.Context, id uuid.UUID) (*models.Me
 }
 
 func (s *Service) ListMenuItems(ctx context.Context, restaurantID uuid.UUID) ([]*models.MenuItem, *models.Meta, error) {
+	s.restoreExpiredItems(ctx, restaurantID)
+
 	rows, err := s.queries.ListMenuItemsByRestaurant(ctx, restaurantID)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to list menu items: %w", err)
@@ -317,6 +293,7 @@ func (s *Service) ListMenuItems(ctx context.Context, restaurantID uuid.UUID) ([]
 	for i, row := range rows {
 		items[i] = s.mapToDomainMenuItem(row)
 	}
+	s.applyPromotions(ctx, restaurantID, items)
 
 	meta := models.CalculateMeta(1, len(rows), int(totalRecords))
 
@@ -338,6 +315,9 @@ func (s *Service) UpdateMenuItem(ctx context.Context, userID uuid.UUID, id uuid.
 	if err := s.verifyAccess(ctx, user, item.RestaurantID); err != nil {
 		return nil, err
 	}
+	if item.PlanLocked && utils.DerefBool(input.IsAvailable) {
+		return nil, ErrPlanLocked
+	}
 
 	params := persistence.UpdateMenuItemParams{
 		ID:           utils.ToUUID(&idStr),
@@ -365,6 +345,13 @@ func (s *Service) UpdateMenuItem(ctx context.Context, userID uuid.UUID, id uuid.
 		return nil, fmt.Errorf("failed to update menu item: %w", err)
 	}
 
+	// Switching off a locked item keeps it off when the plan lock is lifted
+	if item.PlanLocked && input.IsAvailable != nil && !*input.IsAvailable {
+		if err := s.queries.KeepLockedMenuItemUnavailable(ctx, item.ID); err != nil {
+			return nil, fmt.Errorf("failed to update menu item: %w", err)
+		}
+	}
+
 	return s.mapToDomainMenuItem(itemRow), nil
 }
 
@@ -388,6 +375,14 @@ func (s *Service) DeleteMenuItem(ctx context.Context, userID uuid.UUID, id uuid.
 }
 
 func (s *Service) ListItems(ctx context.Context, restaurantID uuid.UUID, categoryID uuid.UUID, pagination models.PaginationParams) ([]*models.MenuItem, *models.Meta, error) {
+	// The public menu route only carries the category, so resolve the restaurant from it.
+	if restaurantID == uuid.Nil {
+		if category, err := s.queries.GetCategoryByID(ctx, categoryID); err == nil {
+			restaurantID = category.RestaurantID
+		}
+	}
+	s.restoreExpiredItems(ctx, restaurantID)
+
 	rows, err := s.queries.ListMenuItemsByCategory(ctx, categoryID)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to list menu items: %w", err)
@@ -402,6 +397,7 @@ func (s *Service) ListItems(ctx context.Context, restaurantID uuid.UUID, categor
 	for i, row := range rows {
 		items[i] = s.mapToDomainMenuItem(row)
 	}
+	s.applyPromotions(ctx, restaurantID, items)
 
 	meta := models.CalculateMeta(pagination.Page, pagination.PageSize, int(totalRecords))
 
@@ -410,6 +406,46 @@ func (s *Service) ListItems(ctx context.Context, restaurantID uuid.UUID, categor
 
 // Helpers
 
+// restoreExpiredItems brings 86'd items back once their restore time has passed.
+// It runs before every menu read so no background job is needed.
+func (s *Service) restoreExpiredItems(ctx context.Context, restaurantID uuid.UUID) {
+	restored, err := s.queries.RestoreExpiredEightySixedItems(ctx, persistence.RestoreExpiredEightySixedItemsParams{
+		RestaurantID:     restaurantID,
+		UnavailableUntil: pgtype.Timestamp{Time: s.localNow(ctx, restaurantID), Valid: true},
+	})
+	if err != nil {
+		log.Printf("[MenuService] Warning: Failed to restore 86'd items for restaurant %v: %v", restaurantID, err)
+		return
+	}
+	if restored > 0 {
+		log.Printf("[MenuService] Restored %d 86'd items for restaurant %v", restored, restaurantID)
+	}
+}
+
+// localNow returns the current wall-clock time in the restaurant's timezone, which is how
+// unavailable_until is stored
+func (s *Service) localNow(ctx context.Context, restaurantID uuid.UUID) time.Time {
+	timezone := utils.DefaultTimezone
+	restaurant, err := s.queries.GetRestaurantByID(ctx, restaurantID)
+	if err != nil {
+		log.Printf("[MenuService] Failed to load timezone of restaurant %v, using %s: %v", restaurantID, timezone, err)
+	} else {
+		timezone = restaurant.Timezone
+	}
+	return utils.LocalWallClock(time.Now(), timezone)
+}
+
+// applyPromotions fills in effective prices. Pricing failures fall back to list prices
+// rather than failing the menu.
+func (s *Service) applyPromotions(ctx context.Context, restaurantID uuid.UUID, items []*models.MenuItem) {
+	if s.promotions == nil {
+		return
+	}
+	if err := s.promotions.ApplyPromotions(ctx, restaurantID, items); err != nil {
+		log.Printf("[MenuService] Warning: Failed to apply promotions for restaurant %v: %v", restaurantID, err)
+	}
+}
+
 func (s *Service) getUser(ctx context.Context, userID uuid.UUID) (*models.User, error) {
 	userIDStr := userID.String()
 	userRow, err := s.queries.GetUserByID(ctx, utils.ToUUID(&userIDStr))
@@ -493,6 +529,7 @@ func (s *Service) mapToDomainCategory(row persistence.Category) *models.Category
 		Icon:         row.Icon.String,
 		DisplayOrder: row.DisplayOrder,
 		IsActive:     row.IsActive,
+		PlanLocked:   row.PlanLocked,
 		CreatedBy:    createdBy,
 		CreatedAt:    row.CreatedAt.Time,
 		UpdatedAt:    row.UpdatedAt.Time,
@@ -506,27 +543,40 @@ func (s *Service) mapToDomainMenuItem(row persistence.MenuItem) *models.MenuItem
 	createdBy := row.CreatedBy
 
 	price, _ := row.Price.Float64Value()
-
-	return &models.MenuItem{
-		ID:           id,
-		RestaurantID: restaurantID,
-		CategoryID:   categoryID,
-		Name:         row.Name,
-		Description:  row.Description.String,
-		Price:        price.Float64,
-		Currency:     row.Currency,
-		Images:       row.Images,
-		Allergens:    row.Allergens,
-		DietaryTags:  row.DietaryTags,
-		SpiceLevel:   row.SpiceLevel.Int32,
-		Calories:     row.Calories.Int32,
-		IsAvailable:  row.IsAvailable,
-		DisplayOrder: row.DisplayOrder,
-		ViewCount:    row.ViewCount.Int32,
-		CreatedBy:    createdBy,
-		CreatedAt:    row.CreatedAt.Time,
-		UpdatedAt:    row.UpdatedAt.Time,
-	}
+	ratingAvg, _ := row.RatingAvg.Float64Value()
+
+	item := &models.MenuItem{
+		ID:                id,
+		RestaurantID:      restaurantID,
+		CategoryID:        categoryID,
+		Name:              row.Name,
+		Description:       row.Description.String,
+		Price:             price.Float64,
+		EffectivePrice:    price.Float64,
+		Currency:          row.Currency,
+		Images:            row.Images,
+		Allergens:         row.Allergens,
+		DietaryTags:       row.DietaryTags,
+		SpiceLevel:        row.SpiceLevel.Int32,
+		Calories:          row.Calories.Int32,
+		IsAvailable:       row.IsAvailable,
+		PlanLocked:        row.PlanLocked,
+		DisplayOrder:      row.DisplayOrder,
+		ViewCount:         row.ViewCount.Int32,
+		RatingAvg:         ratingAvg.Float64,
+		RatingCount:       row.RatingCount,
+		TrackStock:        row.TrackStock,
+		StockQuantity:     row.StockQuantity,
+		LowStockThreshold: row.LowStockThreshold,
+		CreatedBy:         createdBy,
+		CreatedAt:         row.CreatedAt.Time,
+		UpdatedAt:         row.UpdatedAt.Time,
+	}
+	if row.UnavailableUntil.Valid {
+		unavailableUntil := row.UnavailableUntil.Time
+		item.UnavailableUntil = &unavailableUntil
+	}
+	return item
 }
 func (s *Service) uploadFile(ctx context.Context, key string, file *multipart.FileHeader) (string, error) {
 	if s.r2 == nil {
@@ -0,0 +1,226 @@
+package payment
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"menuvista/internal/models"
+)
+
+const providerChapa = "chapa"
+
+type ChapaConfig struct {
+	APIURL        string
+	SecretKey     string
+	WebhookSecret string
+	CallbackURL   string
+	ReturnURL     string
+}
+
+// ChapaConfigFromEnv reads the CHAPA_* settings. CHAPA_API_URL defaults to the live API.
+func ChapaConfigFromEnv() ChapaConfig {
+	apiURL := os.Getenv("CHAPA_API_URL")
+	if apiURL == "" {
+		apiURL = "https://api.chapa.co/v1"
+	}
+	return ChapaConfig{
+		APIURL:        strings.TrimRight(apiURL, "/"),
+		SecretKey:     os.Getenv("CHAPA_SECRET_KEY"),
+		WebhookSecret: os.Getenv("CHAPA_WEBHOOK_SECRET"),
+		CallbackURL:   os.Getenv("CHAPA_CALLBACK_URL"),
+		ReturnURL:     os.Getenv("CHAPA_RETURN_URL"),
+	}
+}
+
+// ChapaProvider takes payments through Chapa's hosted checkout
+type ChapaProvider struct {
+	config ChapaConfig
+	client *http.Client
+}
+
+func NewChapaProvider(config ChapaConfig) *ChapaProvider {
+	if config.SecretKey == "" {
+		log.Printf("[ChapaProvider] WARNING: CHAPA_SECRET_KEY not set, payments will fail")
+	}
+	return &ChapaProvider{
+		config: config,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (p *ChapaProvider) Name() string {
+	return providerChapa
+}
+
+func (p *ChapaProvider) InitializeCheckout(ctx context.Context, req CheckoutRequest) (string, error) {
+	payload := map[string]interface{}{
+		"amount":       fmt.Sprintf("%.2f", req.Amount),
+		"currency":     req.Currency,
+		"email":        req.Email,
+		"first_name":   req.Name,
+		"tx_ref":       req.TxRef,
+		"callback_url": p.config.CallbackURL,
+		"return_url":   fmt.Sprintf("%s?tx_ref=%s", p.config.ReturnURL, req.TxRef),
+		"customization": map[string]string{
+			"title":       req.Title,
+			"description": req.Description,
+		},
+	}
+
+	body, _ := json.Marshal(payload)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.config.APIURL+"/transaction/initialize", bytes.NewBuffer(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to build payment request: %w", err)
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+p.config.SecretKey)
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		log.Printf("[ChapaProvider] Chapa request failed: %v", err)
+		return "", fmt.Errorf("failed to initiate payment: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var chapaResp map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&chapaResp); err != nil {
+		log.Printf("[ChapaProvider] Failed to decode Chapa response: %v", err)
+		return "", fmt.Errorf("failed to decode payment response")
+	}
+
+	if status, ok := chapaResp["status"].(string); !ok || status != "success" {
+		log.Printf("[ChapaProvider] Chapa returned error: %v", chapaResp)
+		return "", fmt.Errorf("payment initiation failed")
+	}
+
+	data, ok := chapaResp["data"].(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("invalid chapa response data")
+	}
+
+	checkoutURL, ok := data["checkout_url"].(string)
+	if !ok {
+		return "", fmt.Errorf("checkout_url not found")
+	}
+
+	return checkoutURL, nil
+}
+
+func (p *ChapaProvider) VerifyPayment(ctx context.Context, txRef string) (string, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.config.APIURL+"/transaction/verify/"+txRef, nil)
+	if err != nil {
+		return "", false, err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.config.SecretKey)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", false, err
+	}
+	defer resp.Body.Close()
+
+	var verifyResp map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&verifyResp); err != nil {
+		return "", false, err
+	}
+
+	if status, ok := verifyResp["status"].(string); !ok || status != "success" {
+		return "", false, nil
+	}
+
+	data, ok := verifyResp["data"].(map[string]interface{})
+	if !ok {
+		return "", false, nil
+	}
+
+	providerRef, _ := data["reference"].(string)
+	return providerRef, data["status"] == "success", nil
+}
+
+func (p *ChapaProvider) Refund(ctx context.Context, req RefundRequest) (string, error) {
+	payload := map[string]interface{}{
+		"amount":    fmt.Sprintf("%.2f", req.Amount),
+		"reason":    req.Reason,
+		"reference": req.Reference,
+	}
+
+	body, _ := json.Marshal(payload)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.config.APIURL+"/refund/"+req.TxRef, bytes.NewBuffer(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to build refund request: %w", err)
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+p.config.SecretKey)
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		log.Printf("[ChapaProvider] Chapa refund request failed: %v", err)
+		return "", fmt.Errorf("failed to request refund: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var refundResp map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&refundResp); err != nil {
+		log.Printf("[ChapaProvider] Failed to decode Chapa refund response: %v", err)
+		return "", fmt.Errorf("failed to decode refund response")
+	}
+
+	if status, ok := refundResp["status"].(string); !ok || status != "success" {
+		log.Printf("[ChapaProvider] Chapa rejected refund for %s: %v", req.TxRef, refundResp)
+		return "", fmt.Errorf("%w: %v", ErrRefundRejected, refundResp["message"])
+	}
+
+	// Chapa does not always return its own id for the refund; fall back to ours
+	providerRef := req.Reference
+	if data, ok := refundResp["data"].(map[string]interface{}); ok {
+		if ref, ok := data["ref_id"].(string); ok && ref != "" {
+			providerRef = ref
+		}
+	}
+	return providerRef, nil
+}
+
+// ParseWebhook checks the HMAC-SHA256 of the body, keyed with CHAPA_WEBHOOK_SECRET
+func (p *ChapaProvider) ParseWebhook(body []byte, header http.Header) (*WebhookEvent, error) {
+	signature := header.Get("Chapa-Signature")
+	if signature == "" {
+		signature = header.Get("X-Chapa-Signature")
+	}
+	if p.config.WebhookSecret == "" {
+		log.Printf("[ChapaProvider] CHAPA_WEBHOOK_SECRET not set")
+		return nil, ErrInvalidWebhookSignature
+	}
+	if signature == "" || !hmac.Equal([]byte(signHMAC(p.config.WebhookSecret, body)), []byte(signature)) {
+		return nil, ErrInvalidWebhookSignature
+	}
+
+	var payload models.ChapaWebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("failed to parse webhook payload: %w", err)
+	}
+
+	return &WebhookEvent{
+		Type:        payload.Event,
+		TxRef:       payload.Data.TxRef,
+		ProviderRef: payload.Data.Reference,
+	}, nil
+}
+
+// signHMAC returns the hex HMAC-SHA256 of body
+func signHMAC(secret string, body []byte) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
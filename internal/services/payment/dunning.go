@@ -0,0 +1,251 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"menuvista/internal/models"
+	"menuvista/internal/services/coupon"
+	"menuvista/internal/services/email"
+	"menuvista/internal/services/subscription"
+	"menuvista/internal/storage/persistence"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Retry job statuses, see payment_retry_jobs.status
+const (
+	retryJobRetrying  = "retrying"
+	retryJobCompleted = "completed"
+	retryJobFailed    = "failed"
+)
+
+const (
+	defaultDunningInterval = time.Hour
+	// dunningBatchSize caps the jobs handled per run so one run cannot take too long
+	dunningBatchSize = 100
+	// dunningLease keeps other instances off a claimed job; a crashed run retries after it
+	dunningLease = 15 * time.Minute
+	// dunningFirstBackoff is the wait after the first reminder; it doubles with each one
+	dunningFirstBackoff = 24 * time.Hour
+)
+
+// DunningWorker chases unpaid renewals. Subscriptions that pass current_period_end go
+// past_due and get a retry job; each attempt emails the owner a fresh checkout link and
+// backs off, and once max_retries is used up the subscription is cancelled.
+type DunningWorker struct {
+	queries      *persistence.Queries
+	payments     *Service
+	emailService *email.Service
+	interval     time.Duration
+}
+
+func NewDunningWorker(queries *persistence.Queries, payments *Service, emailService *email.Service) *DunningWorker {
+	interval := defaultDunningInterval
+	if raw := os.Getenv("DUNNING_INTERVAL"); raw != "" {
+		if parsed, err := time.ParseDuration(raw); err == nil && parsed > 0 {
+			interval = parsed
+		} else {
+			log.Printf("[DunningWorker] Invalid DUNNING_INTERVAL %q, using %v", raw, defaultDunningInterval)
+		}
+	}
+
+	return &DunningWorker{
+		queries:      queries,
+		payments:     payments,
+		emailService: emailService,
+		interval:     interval,
+	}
+}
+
+// Interval is how often RunOnce should be scheduled, from DUNNING_INTERVAL
+func (w *DunningWorker) Interval() time.Duration {
+	return w.interval
+}
+
+// RunOnce moves lapsed subscriptions into dunning and works through the due retry jobs
+func (w *DunningWorker) RunOnce(ctx context.Context) {
+	if err := w.markLapsedSubscriptions(ctx); err != nil {
+		log.Printf("[DunningWorker] Failed to check lapsed subscriptions: %v", err)
+	}
+
+	jobs, err := w.queries.ListDuePaymentRetryJobs(ctx, dunningBatchSize)
+	if err != nil {
+		log.Printf("[DunningWorker] Failed to list due retry jobs: %v", err)
+		return
+	}
+
+	for _, job := range jobs {
+		if err := w.processJob(ctx, job); err != nil {
+			log.Printf("[DunningWorker] Retry job %v failed: %v", job.ID, err)
+		}
+	}
+}
+
+func (w *DunningWorker) markLapsedSubscriptions(ctx context.Context) error {
+	// Downgrades due at the end of the period are renewed on the new plan
+	if applied, err := w.queries.ApplyDueScheduledSubscriptionChanges(ctx); err != nil {
+		log.Printf("[DunningWorker] Warning: Failed to apply scheduled plan changes: %v", err)
+	} else if applied > 0 {
+		log.Printf("[DunningWorker] Applied %d scheduled plan changes", applied)
+	}
+
+	lapsed, err := w.queries.MarkExpiredSubscriptionsPastDue(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, sub := range lapsed {
+		log.Printf("[DunningWorker] Subscription %v of owner %v lapsed, now past_due", sub.ID, sub.OwnerID)
+		// A downgrade applied above takes effect now, renewed or not
+		if err := subscription.EnforcePlanLimits(ctx, w.queries, sub.OwnerID); err != nil {
+			log.Printf("[DunningWorker] Warning: Failed to enforce plan limits for owner %v: %v", sub.OwnerID, err)
+		}
+		if err := scheduleRetry(ctx, w.queries, sub.ID, time.Now()); err != nil {
+			log.Printf("[DunningWorker] Warning: Failed to schedule retry for %v: %v", sub.ID, err)
+		}
+	}
+	return nil
+}
+
+func (w *DunningWorker) processJob(ctx context.Context, job persistence.PaymentRetryJob) error {
+	// Claim the job first so a second instance does not email the owner twice
+	job, err := w.queries.ClaimPaymentRetryJob(ctx, persistence.ClaimPaymentRetryJobParams{
+		ID:           job.ID,
+		ScheduledFor: pgtype.Timestamp{Time: time.Now().Add(dunningLease), Valid: true},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to claim job: %w", err)
+	}
+
+	sub, err := w.queries.GetSubscriptionByID(ctx, job.SubscriptionID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch subscription: %w", err)
+	}
+
+	// Paid in the meantime, or resolved some other way
+	if _, err := w.queries.GetActiveSubscriptionByOwner(ctx, sub.OwnerID); err == nil || sub.Status != persistence.SubscriptionStatusPastDue {
+		log.Printf("[DunningWorker] Subscription %v no longer past due, closing job %v", sub.ID, job.ID)
+		return w.setJobStatus(ctx, job.ID, retryJobCompleted)
+	}
+
+	user, err := w.queries.GetUserByID(ctx, sub.OwnerID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch owner: %w", err)
+	}
+	plan, err := w.queries.GetSubscriptionPlanByID(ctx, sub.PlanID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch plan: %w", err)
+	}
+
+	maxRetries := int32(3)
+	if job.MaxRetries.Valid {
+		maxRetries = job.MaxRetries.Int32
+	}
+	if job.RetryCount.Int32 >= maxRetries {
+		return w.cancel(ctx, job, user, plan)
+	}
+
+	// Each reminder carries a fresh checkout; the ones sent before are closed so only one
+	// can be paid
+	cancelled, err := w.queries.CancelPendingRenewalCheckouts(ctx, sub.OwnerID)
+	if err != nil {
+		return fmt.Errorf("failed to cancel earlier checkouts: %w", err)
+	}
+	for _, txRef := range cancelled {
+		if err := coupon.Release(ctx, w.queries, txRef); err != nil {
+			log.Printf("[DunningWorker] Warning: %v", err)
+		}
+	}
+	if len(cancelled) > 0 {
+		log.Printf("[DunningWorker] Cancelled %d earlier checkouts of owner %v", len(cancelled), sub.OwnerID)
+	}
+
+	checkoutURL := os.Getenv("APP_BASE_URL") + "/billing"
+	checkout, err := w.payments.InitiatePayment(ctx, InitiatePaymentInput{
+		OwnerID:         sub.OwnerID,
+		Plan:            plan.Slug,
+		Email:           user.Email,
+		Name:            user.FullName,
+		Type:            models.PlanChangeRenewal,
+		BillingInterval: string(sub.BillingInterval),
+	})
+	if err != nil {
+		log.Printf("[DunningWorker] Warning: Failed to create checkout for owner %v, linking to billing page: %v", sub.OwnerID, err)
+	} else if checkout.Completed {
+		// A coupon covered the renewal, which also closed this job
+		log.Printf("[DunningWorker] Renewal of owner %v paid in full by coupon", sub.OwnerID)
+		return nil
+	} else if checkout.CheckoutURL != "" {
+		checkoutURL = checkout.CheckoutURL
+	}
+
+	if err := w.emailService.SendPaymentFailedEmail(ctx, user.Email, user.FullName, checkoutURL); err != nil {
+		log.Printf("[DunningWorker] Warning: Failed to send payment failed email: %v", err)
+	}
+
+	next := time.Now().Add(dunningFirstBackoff << job.RetryCount.Int32)
+	if _, err := w.queries.UpdatePaymentRetryJob(ctx, persistence.UpdatePaymentRetryJobParams{
+		ID:           job.ID,
+		Status:       retryJobRetrying,
+		ScheduledFor: pgtype.Timestamp{Time: next, Valid: true},
+	}); err != nil {
+		return fmt.Errorf("failed to reschedule job: %w", err)
+	}
+
+	log.Printf("[DunningWorker] Reminder %d/%d sent to owner %v, next at %v", job.RetryCount.Int32+1, maxRetries, sub.OwnerID, next)
+	return nil
+}
+
+// cancel ends dunning for good: the owner's past-due subscriptions are cancelled
+func (w *DunningWorker) cancel(ctx context.Context, job persistence.PaymentRetryJob, user persistence.User, plan persistence.SubscriptionPlan) error {
+	cancelled, err := w.queries.CancelPastDueSubscriptionsByOwner(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to cancel subscription: %w", err)
+	}
+	if err := w.setJobStatus(ctx, job.ID, retryJobFailed); err != nil {
+		return err
+	}
+	log.Printf("[DunningWorker] Retries exhausted, cancelled %d subscriptions of owner %v", cancelled, user.ID)
+
+	resubscribeURL := os.Getenv("APP_BASE_URL") + "/billing"
+	if err := w.emailService.SendSubscriptionCancelledEmail(ctx, user.Email, user.FullName, plan.Name, resubscribeURL); err != nil {
+		log.Printf("[DunningWorker] Warning: Failed to send subscription cancelled email: %v", err)
+	}
+	return nil
+}
+
+func (w *DunningWorker) setJobStatus(ctx context.Context, jobID uuid.UUID, status string) error {
+	if err := w.queries.SetPaymentRetryJobStatus(ctx, persistence.SetPaymentRetryJobStatusParams{
+		ID:     jobID,
+		Status: status,
+	}); err != nil {
+		return fmt.Errorf("failed to mark job %s: %w", status, err)
+	}
+	return nil
+}
+
+// scheduleRetry starts dunning for a subscription unless its owner is already in dunning
+func scheduleRetry(ctx context.Context, queries *persistence.Queries, subscriptionID uuid.UUID, at time.Time) error {
+	job, err := queries.CreatePaymentRetryJob(ctx, persistence.CreatePaymentRetryJobParams{
+		SubscriptionID: subscriptionID,
+		ScheduledFor:   pgtype.Timestamp{Time: at, Valid: true},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	log.Printf("[DunningWorker] Scheduled retry job %v for subscription %v at %v", job.ID, subscriptionID, at)
+	return nil
+}
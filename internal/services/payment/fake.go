@@ -0,0 +1,237 @@
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	providerFake = "fake"
+
+	fakeSignatureHeader = "X-Fake-Signature"
+
+	FakeCheckoutPending = "pending"
+	FakeCheckoutPaid    = "paid"
+	FakeCheckoutFailed  = "failed"
+)
+
+var (
+	ErrFakeCheckoutNotFound = errors.New("checkout session not found")
+	ErrFakeCheckoutResolved = errors.New("checkout session already completed")
+	ErrFakeCheckoutUnpaid   = errors.New("checkout session was not paid")
+)
+
+type FakeConfig struct {
+	// BaseURL is where this API is reachable from the browser; the checkout page and
+	// the return page are served from it
+	BaseURL       string
+	WebhookSecret string
+}
+
+// FakeConfigFromEnv reads PAYMENT_FAKE_BASE_URL, defaulting to localhost on PORT, and
+// PAYMENT_FAKE_WEBHOOK_SECRET, which gets a random value when unset.
+func FakeConfigFromEnv() FakeConfig {
+	baseURL := os.Getenv("PAYMENT_FAKE_BASE_URL")
+	if baseURL == "" {
+		port := os.Getenv("PORT")
+		if port == "" {
+			port = "8080"
+		}
+		baseURL = "http://localhost:" + port
+	}
+	return FakeConfig{
+		BaseURL:       strings.TrimRight(baseURL, "/"),
+		WebhookSecret: os.Getenv("PAYMENT_FAKE_WEBHOOK_SECRET"),
+	}
+}
+
+// FakeCheckout is a checkout session on the local payment page
+type FakeCheckout struct {
+	CheckoutRequest
+	Status      string
+	ProviderRef string
+	Refunded    float64
+	CreatedAt   time.Time
+}
+
+// FakeProvider takes payments on a checkout page served by the API itself, so the whole
+// subscription flow runs in development and integration tests without a gateway.
+// Sessions are kept in memory and are lost on restart.
+type FakeProvider struct {
+	config FakeConfig
+
+	mu       sync.Mutex
+	sessions map[string]*FakeCheckout
+	// refunds maps refund references to the provider reference they were issued under
+	refunds map[string]string
+}
+
+func NewFakeProvider(config FakeConfig) (*FakeProvider, error) {
+	if config.WebhookSecret == "" {
+		secret, err := fakeRandomHex(32)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+		}
+		config.WebhookSecret = secret
+	}
+	log.Printf("[FakeProvider] Using the local fake payment provider, checkout at %s/payment/fake/checkout", config.BaseURL)
+	return &FakeProvider{
+		config:   config,
+		sessions: make(map[string]*FakeCheckout),
+		refunds:  make(map[string]string),
+	}, nil
+}
+
+func (p *FakeProvider) Name() string {
+	return providerFake
+}
+
+func (p *FakeProvider) InitializeCheckout(ctx context.Context, req CheckoutRequest) (string, error) {
+	p.mu.Lock()
+	p.sessions[req.TxRef] = &FakeCheckout{
+		CheckoutRequest: req,
+		Status:          FakeCheckoutPending,
+		CreatedAt:       time.Now(),
+	}
+	p.mu.Unlock()
+
+	return p.config.BaseURL + "/payment/fake/checkout/" + req.TxRef, nil
+}
+
+func (p *FakeProvider) VerifyPayment(ctx context.Context, txRef string) (string, bool, error) {
+	session, err := p.Checkout(txRef)
+	if err != nil {
+		return "", false, err
+	}
+	return session.ProviderRef, session.Status == FakeCheckoutPaid, nil
+}
+
+// Refund always succeeds for sessions lost to a restart; known sessions must be paid
+// and cannot be refunded past what was charged. A reference seen before returns the
+// earlier refund, like a gateway deduplicating retries.
+func (p *FakeProvider) Refund(ctx context.Context, req RefundRequest) (string, error) {
+	reference, err := fakeRandomHex(8)
+	if err != nil {
+		return "", err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if issued, ok := p.refunds[req.Reference]; ok && req.Reference != "" {
+		return issued, nil
+	}
+
+	if session, ok := p.sessions[req.TxRef]; ok {
+		switch {
+		case session.Status != FakeCheckoutPaid:
+			return "", fmt.Errorf("%w: %v", ErrRefundRejected, ErrFakeCheckoutUnpaid)
+		case session.Refunded+req.Amount > session.Amount+0.005:
+			return "", fmt.Errorf("%w: refund exceeds the amount paid", ErrRefundRejected)
+		}
+		session.Refunded += req.Amount
+	}
+	if req.Reference != "" {
+		p.refunds[req.Reference] = "fake_refund_" + reference
+	}
+	return "fake_refund_" + reference, nil
+}
+
+// fakeWebhookPayload is what Resolve delivers and ParseWebhook accepts
+type fakeWebhookPayload struct {
+	Event     string `json:"event"`
+	TxRef     string `json:"tx_ref"`
+	Reference string `json:"reference"`
+}
+
+// ParseWebhook checks the hex HMAC-SHA256 of the body in X-Fake-Signature
+func (p *FakeProvider) ParseWebhook(body []byte, header http.Header) (*WebhookEvent, error) {
+	signature := header.Get(fakeSignatureHeader)
+	if signature == "" || !hmac.Equal([]byte(signHMAC(p.config.WebhookSecret, body)), []byte(signature)) {
+		return nil, ErrInvalidWebhookSignature
+	}
+
+	var payload fakeWebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("failed to parse webhook payload: %w", err)
+	}
+
+	return &WebhookEvent{
+		Type:        payload.Event,
+		TxRef:       payload.TxRef,
+		ProviderRef: payload.Reference,
+	}, nil
+}
+
+// Checkout returns a copy of the session for txRef
+func (p *FakeProvider) Checkout(txRef string) (FakeCheckout, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	session, ok := p.sessions[txRef]
+	if !ok {
+		return FakeCheckout{}, ErrFakeCheckoutNotFound
+	}
+	return *session, nil
+}
+
+// Resolve records the outcome chosen on the checkout page and returns the signed webhook
+// a real gateway would send for it, for the caller to deliver.
+func (p *FakeProvider) Resolve(txRef string, paid bool) ([]byte, http.Header, error) {
+	reference, err := fakeRandomHex(8)
+	if err != nil {
+		return nil, nil, err
+	}
+	reference = "fake_" + reference
+
+	p.mu.Lock()
+	session, ok := p.sessions[txRef]
+	switch {
+	case !ok:
+		p.mu.Unlock()
+		return nil, nil, ErrFakeCheckoutNotFound
+	case session.Status != FakeCheckoutPending:
+		p.mu.Unlock()
+		return nil, nil, ErrFakeCheckoutResolved
+	}
+	event := EventPaymentFailed
+	session.Status = FakeCheckoutFailed
+	if paid {
+		event = EventPaymentSuccess
+		session.Status = FakeCheckoutPaid
+	}
+	session.ProviderRef = reference
+	p.mu.Unlock()
+
+	body, err := json.Marshal(fakeWebhookPayload{Event: event, TxRef: txRef, Reference: reference})
+	if err != nil {
+		return nil, nil, err
+	}
+	header := http.Header{}
+	header.Set(fakeSignatureHeader, signHMAC(p.config.WebhookSecret, body))
+	return body, header, nil
+}
+
+// ReturnURL is where the customer lands after the checkout page, like a gateway's return_url
+func (p *FakeProvider) ReturnURL(txRef string) string {
+	return p.config.BaseURL + "/payment/success?tx_ref=" + txRef
+}
+
+func fakeRandomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
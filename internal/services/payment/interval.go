@@ -0,0 +1,48 @@
+package payment
+
+import (
+	"errors"
+	"time"
+
+	"menuvista/internal/storage/persistence"
+)
+
+var (
+	ErrInvalidBillingInterval = errors.New("billing interval must be monthly or annual")
+	ErrAnnualNotAvailable     = errors.New("this plan cannot be billed annually")
+)
+
+// resolveBillingInterval validates the requested interval. An empty value keeps the
+// fallback, which is the interval of the owner's current subscription or monthly.
+func resolveBillingInterval(requested string, fallback persistence.BillingInterval) (persistence.BillingInterval, error) {
+	switch persistence.BillingInterval(requested) {
+	case "":
+		if fallback == "" {
+			return persistence.BillingIntervalMonthly, nil
+		}
+		return fallback, nil
+	case persistence.BillingIntervalMonthly, persistence.BillingIntervalAnnual:
+		return persistence.BillingInterval(requested), nil
+	default:
+		return "", ErrInvalidBillingInterval
+	}
+}
+
+// PeriodEnd returns when a billing period of the given interval starting at start ends
+func PeriodEnd(start time.Time, interval persistence.BillingInterval) time.Time {
+	if interval == persistence.BillingIntervalAnnual {
+		return start.AddDate(1, 0, 0)
+	}
+	return start.AddDate(0, 1, 0)
+}
+
+// PlanPrice returns what the plan costs for one billing interval
+func PlanPrice(plan persistence.SubscriptionPlan, interval persistence.BillingInterval) (int32, error) {
+	if interval != persistence.BillingIntervalAnnual {
+		return plan.PriceMonthly, nil
+	}
+	if !plan.PriceAnnual.Valid {
+		return 0, ErrAnnualNotAvailable
+	}
+	return plan.PriceAnnual.Int32, nil
+}
@@ -0,0 +1,181 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"menuvista/internal/models"
+	"menuvista/internal/services/coupon"
+	"menuvista/internal/storage/persistence"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// Periods with less than a day left are not prorated; the new plan starts a fresh period.
+const minProratedPeriod = 24 * time.Hour
+
+// planChange is a priced move from the owner's current subscription to a plan and interval
+type planChange struct {
+	plan     persistence.SubscriptionPlan
+	interval persistence.BillingInterval
+	active   *persistence.GetActiveSubscriptionByOwnerRow
+	latest   *persistence.GetLatestSubscriptionByOwnerRow
+	quote    *models.ProrationPreview
+	coupon   *models.CouponDiscount
+}
+
+// PreviewPlanChange shows what moving to a plan would cost now and when it takes effect,
+// so the owner can confirm before being sent to checkout. It changes nothing.
+func (s *Service) PreviewPlanChange(ctx context.Context, ownerID uuid.UUID, planSlug, billingInterval, couponCode string) (*models.ProrationPreview, error) {
+	change, err := s.prepareChange(ctx, ownerID, planSlug, billingInterval, couponCode)
+	if err != nil {
+		return nil, err
+	}
+	return change.quote, nil
+}
+
+// prepareChange prices a plan change without writing anything
+func (s *Service) prepareChange(ctx context.Context, ownerID uuid.UUID, planSlug, billingInterval, couponCode string) (*planChange, error) {
+	change := &planChange{}
+
+	// Latest subscription (any status) for reuse logic, active one for proration
+	if latest, err := s.queries.GetLatestSubscriptionByOwner(ctx, ownerID); err == nil {
+		change.latest = &latest
+	}
+	if active, err := s.queries.GetActiveSubscriptionByOwner(ctx, ownerID); err == nil {
+		change.active = &active
+		// A downgrade whose period has ended is priced as the current plan, as it is applied
+		// before anything is charged
+		if active.ScheduledPlanID != uuid.Nil && !active.CurrentPeriodEnd.Time.After(time.Now()) {
+			change.active.PlanID = active.ScheduledPlanID
+			if active.ScheduledBillingInterval.Valid {
+				change.active.BillingInterval = active.ScheduledBillingInterval.BillingInterval
+			}
+			if change.latest != nil && change.latest.ID == active.ID {
+				change.latest.BillingInterval = change.active.BillingInterval
+			}
+		}
+	}
+
+	plan, err := s.queries.GetSubscriptionPlanBySlug(ctx, planSlug)
+	if err != nil || !plan.IsActive {
+		return nil, fmt.Errorf("invalid plan: %s", planSlug)
+	}
+	change.plan = plan
+
+	var currentInterval persistence.BillingInterval
+	if change.latest != nil {
+		currentInterval = change.latest.BillingInterval
+	}
+	if change.interval, err = resolveBillingInterval(billingInterval, currentInterval); err != nil {
+		return nil, err
+	}
+
+	var currentPlan persistence.SubscriptionPlan
+	if change.active != nil {
+		if currentPlan, err = s.queries.GetSubscriptionPlanByID(ctx, change.active.PlanID); err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("failed to fetch current plan: %w", err)
+		} else if err == nil {
+			change.active.PlanSlug = currentPlan.Slug
+		}
+	}
+
+	change.quote, err = quoteChange(change.active, currentPlan, plan, change.interval, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// Coupons come off what is left to pay after the credit
+	change.coupon, err = coupon.Discount(ctx, s.queries, ownerID, couponCode, plan.ID, change.quote.AmountDue)
+	if err != nil {
+		return nil, err
+	}
+	if change.coupon != nil {
+		change.quote.Discount = change.coupon.Amount
+		change.quote.CouponCode = change.coupon.Code
+		change.quote.AmountDue = roundMoney(change.quote.AmountDue - change.coupon.Amount)
+	}
+	return change, nil
+}
+
+// quoteChange prices a plan change. Upgrades take effect on payment: the unused part of the
+// current period is credited and the new plan is charged for the same remaining time, or
+// for a full year when switching to annual billing. Downgrades and moves to monthly billing
+// wait for the end of the period and cost nothing now.
+func quoteChange(current *persistence.GetActiveSubscriptionByOwnerRow, currentPlan, newPlan persistence.SubscriptionPlan, interval persistence.BillingInterval, now time.Time) (*models.ProrationPreview, error) {
+	price, err := PlanPrice(newPlan, interval)
+	if err != nil {
+		return nil, err
+	}
+
+	quote := &models.ProrationPreview{
+		Kind:        models.PlanChangeNew,
+		NewPlan:     newPlan.Slug,
+		NewInterval: models.BillingInterval(interval),
+		Currency:    newPlan.Currency,
+		Price:       float64(price),
+		Charge:      float64(price),
+		AmountDue:   float64(price),
+		EffectiveAt: now,
+		PeriodStart: now,
+		PeriodEnd:   PeriodEnd(now, interval),
+	}
+	if current == nil {
+		return quote, nil
+	}
+	quote.CurrentPlan = current.PlanSlug
+	quote.CurrentInterval = models.BillingInterval(current.BillingInterval)
+
+	oldPrice, _ := PlanPrice(currentPlan, current.BillingInterval)
+	start, end := current.CurrentPeriodStart.Time, current.CurrentPeriodEnd.Time
+	total, remaining := end.Sub(start), end.Sub(now)
+	if oldPrice == 0 || total <= 0 || remaining < minProratedPeriod {
+		// Nothing paid is left to credit
+		return quote, nil
+	}
+	if remaining > total {
+		// A renewal paid in advance has not started yet
+		remaining = total
+	}
+	ratio := remaining.Seconds() / total.Seconds()
+	credit := roundMoney(float64(oldPrice) * ratio)
+	quote.RemainingDays = int(math.Ceil(remaining.Hours() / 24))
+
+	sameInterval := interval == current.BillingInterval
+	switch {
+	case newPlan.ID == currentPlan.ID && sameInterval:
+		quote.Kind = models.PlanChangeRenewal
+		quote.EffectiveAt = end
+		quote.PeriodStart = end
+		quote.PeriodEnd = PeriodEnd(end, interval)
+
+	case sameInterval && price > oldPrice:
+		quote.Kind = models.PlanChangeUpgrade
+		quote.Charge = roundMoney(float64(price) * ratio)
+		quote.Credit = credit
+		quote.AmountDue = roundMoney(quote.Charge - credit)
+		quote.PeriodEnd = end
+
+	case interval == persistence.BillingIntervalAnnual && float64(price) > credit:
+		quote.Kind = models.PlanChangeUpgrade
+		quote.Credit = credit
+		quote.AmountDue = roundMoney(quote.Charge - credit)
+
+	default:
+		quote.Kind = models.PlanChangeDowngrade
+		quote.Charge = 0
+		quote.AmountDue = 0
+		quote.EffectiveAt = end
+		quote.PeriodStart = end
+		quote.PeriodEnd = PeriodEnd(end, interval)
+	}
+	return quote, nil
+}
+
+func roundMoney(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
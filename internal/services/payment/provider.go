@@ -0,0 +1,83 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// Webhook event types, shared by every provider
+const (
+	EventPaymentSuccess = "payment.success"
+	EventPaymentFailed  = "payment.failed"
+	EventPaymentPending = "payment.pending"
+)
+
+var (
+	ErrInvalidWebhookSignature = errors.New("invalid webhook signature")
+	ErrUnknownPaymentProvider  = errors.New("unknown payment provider")
+	ErrRefundRejected          = errors.New("payment provider rejected the refund")
+)
+
+// PaymentProvider is a hosted checkout gateway. Adapters hold their own credentials, so
+// the services never talk to a gateway API directly.
+type PaymentProvider interface {
+	// Name identifies the provider in logs and configuration
+	Name() string
+	// InitializeCheckout registers the transaction with the provider and returns the URL
+	// the customer pays at
+	InitializeCheckout(ctx context.Context, req CheckoutRequest) (string, error)
+	// VerifyPayment asks the provider whether the transaction was paid. It returns the
+	// provider's own reference for it when known.
+	VerifyPayment(ctx context.Context, txRef string) (providerRef string, paid bool, err error)
+	// ParseWebhook authenticates a webhook delivery and normalizes it
+	ParseWebhook(body []byte, header http.Header) (*WebhookEvent, error)
+	// Refund returns some or all of a paid transaction to the customer and returns the
+	// provider's reference for the refund
+	Refund(ctx context.Context, req RefundRequest) (providerRef string, err error)
+}
+
+// CheckoutRequest describes a payment the customer is sent to the provider for
+type CheckoutRequest struct {
+	TxRef       string
+	Amount      float64
+	Currency    string
+	Email       string
+	Name        string
+	Title       string
+	Description string
+}
+
+// RefundRequest asks the provider to return Amount of the transaction TxRef. Reference
+// is our own identifier for the refund. It is the same when a failed refund is retried,
+// so providers that deduplicate on it do not refund twice.
+type RefundRequest struct {
+	TxRef     string
+	Amount    float64
+	Currency  string
+	Reason    string
+	Reference string
+}
+
+// WebhookEvent is a provider notification about one transaction
+type WebhookEvent struct {
+	Type        string // one of the Event* constants
+	TxRef       string
+	ProviderRef string
+}
+
+// NewProviderFromEnv picks the provider named by PAYMENT_PROVIDER, defaulting to Chapa.
+// Set it to "fake" to take payments on a local checkout page without network access.
+func NewProviderFromEnv() (PaymentProvider, error) {
+	switch name := strings.ToLower(strings.TrimSpace(os.Getenv("PAYMENT_PROVIDER"))); name {
+	case "", providerChapa:
+		return NewChapaProvider(ChapaConfigFromEnv()), nil
+	case providerFake:
+		return NewFakeProvider(FakeConfigFromEnv())
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownPaymentProvider, name)
+	}
+}
@@ -0,0 +1,219 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+
+	"menuvista/internal/models"
+	"menuvista/internal/services/email"
+	"menuvista/internal/services/invoice"
+	"menuvista/internal/services/subscription"
+	"menuvista/internal/storage/persistence"
+	"menuvista/internal/utils"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrInvoiceNotRefundable = errors.New("only paid invoices that have not been refunded in full can be refunded")
+	ErrNothingToRefund      = errors.New("nothing was charged on this invoice, so there is nothing to refund")
+	ErrRefundExceedsBalance = errors.New("refund is more than what is left to refund on this invoice")
+	ErrRefundFailed         = errors.New("payment provider could not issue the refund")
+)
+
+// RefundService returns payments to owners through the payment provider and records a
+// credit note for every refund
+type RefundService struct {
+	queries       *persistence.Queries
+	provider      PaymentProvider
+	emailService  *email.Service
+	subscriptions *subscription.Service
+	invoices      *invoice.Service
+}
+
+func NewRefundService(queries *persistence.Queries, provider PaymentProvider, emailService *email.Service, subscriptions *subscription.Service, invoices *invoice.Service) *RefundService {
+	return &RefundService{
+		queries:       queries,
+		provider:      provider,
+		emailService:  emailService,
+		subscriptions: subscriptions,
+		invoices:      invoices,
+	}
+}
+
+// RefundInvoice refunds some or all of a paid invoice. The amount is reserved on the
+// invoice before the provider is asked, so concurrent refunds cannot return more than
+// was paid, and released again if the provider refuses. The invoice becomes refunded
+// once nothing is left to refund. The subscription is only ended when asked for.
+//
+// The provider reference is derived from the invoice and what had been refunded before
+// this refund. A refund that failed, for example on a timeout the provider went on to
+// process, is released, so retrying it sends the same reference again.
+func (s *RefundService) RefundInvoice(ctx context.Context, adminID, invoiceID uuid.UUID, input models.RefundInvoiceRequest) (*models.RefundResponse, error) {
+	inv, err := s.queries.GetInvoiceDocument(ctx, invoiceID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, invoice.ErrInvoiceNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch invoice: %w", err)
+	}
+	if inv.Status != persistence.InvoiceStatusPaid {
+		return nil, ErrInvoiceNotRefundable
+	}
+
+	paid, _ := utils.NumericToFloat(inv.Amount)
+	refunded, _ := utils.NumericToFloat(inv.RefundedAmount)
+	remaining := math.Round((paid-refunded)*100) / 100
+	if remaining <= 0 {
+		return nil, ErrNothingToRefund
+	}
+	amount := remaining
+	if input.Amount != nil {
+		amount = math.Round(*input.Amount*100) / 100
+	}
+	if amount <= 0 {
+		return nil, ErrNothingToRefund
+	}
+	if amount > remaining {
+		return nil, fmt.Errorf("%w (%.2f %s left)", ErrRefundExceedsBalance, remaining, inv.Currency)
+	}
+
+	if _, err := s.queries.ReserveInvoiceRefund(ctx, persistence.ReserveInvoiceRefundParams{
+		Amount: utils.ToNumeric(amount),
+		ID:     inv.ID,
+	}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// Another refund got there first
+			return nil, ErrInvoiceNotRefundable
+		}
+		return nil, fmt.Errorf("failed to reserve refund: %w", err)
+	}
+
+	log.Printf("[RefundService] Refunding %.2f %s of invoice %s via %s", amount, inv.Currency, inv.InvoiceNumber.String, s.provider.Name())
+	providerRef, err := s.provider.Refund(ctx, RefundRequest{
+		TxRef:     inv.TxRef,
+		Amount:    amount,
+		Currency:  inv.Currency,
+		Reason:    input.Reason,
+		Reference: refundReference(inv.ID, refunded, amount),
+	})
+	if err != nil {
+		if releaseErr := s.queries.ReleaseInvoiceRefund(context.Background(), persistence.ReleaseInvoiceRefundParams{
+			Amount: utils.ToNumeric(amount),
+			ID:     inv.ID,
+		}); releaseErr != nil {
+			log.Printf("[RefundService] ERROR: Failed to release refund reserved on invoice %v: %v", inv.ID, releaseErr)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrRefundFailed, err)
+	}
+
+	// The money has been returned from here on, so failures are logged rather than
+	// undoing the refund
+	if _, err := s.queries.AddPaymentTransactionRefund(ctx, persistence.AddPaymentTransactionRefundParams{
+		Amount: utils.ToNumeric(amount),
+		TxRef:  inv.TxRef,
+	}); err != nil {
+		log.Printf("[RefundService] Warning: Failed to record refund on transaction %s: %v", inv.TxRef, err)
+	}
+
+	terminated := false
+	if input.CancelSubscription {
+		switch err := s.subscriptions.TerminateSubscription(ctx, inv.OwnerID, inv.SubscriptionID); {
+		case err == nil:
+			terminated = true
+		case errors.Is(err, subscription.ErrNothingToCancel):
+			log.Printf("[RefundService] Subscription %v of invoice %s has already ended", inv.SubscriptionID, inv.InvoiceNumber.String)
+		default:
+			log.Printf("[RefundService] Warning: Failed to end subscription %v: %v", inv.SubscriptionID, err)
+		}
+	}
+
+	note, err := s.queries.CreateCreditNote(ctx, persistence.CreateCreditNoteParams{
+		InvoiceID:              inv.ID,
+		OwnerID:                inv.OwnerID,
+		Amount:                 utils.ToNumeric(amount),
+		Currency:               inv.Currency,
+		VatRate:                inv.VatRate,
+		Reason:                 input.Reason,
+		ProviderRefundRef:      pgtype.Text{String: providerRef, Valid: providerRef != ""},
+		SubscriptionTerminated: terminated,
+		CreatedBy:              adminID,
+	})
+	if err != nil {
+		log.Printf("[RefundService] ERROR: Refund %s of invoice %s was issued but its credit note was not recorded: %v", providerRef, inv.InvoiceNumber.String, err)
+		return nil, fmt.Errorf("refund %s was issued but the credit note could not be recorded: %w", providerRef, err)
+	}
+	log.Printf("[RefundService] Issued credit note %s for %.2f %s against invoice %s", note.CreditNoteNumber, amount, note.Currency, inv.InvoiceNumber.String)
+
+	s.sendRefundNotice(inv, note, amount)
+
+	updated, err := s.invoices.GetInvoice(ctx, inv.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.RefundResponse{
+		CreditNote: mapCreditNote(note),
+		Invoice:    updated,
+	}, nil
+}
+
+// ListCreditNotes returns the credit notes issued against an invoice, oldest first
+func (s *RefundService) ListCreditNotes(ctx context.Context, invoiceID uuid.UUID) ([]*models.CreditNote, error) {
+	if _, err := s.invoices.GetInvoice(ctx, invoiceID); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.ListCreditNotesByInvoice(ctx, invoiceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list credit notes: %w", err)
+	}
+
+	notes := make([]*models.CreditNote, len(rows))
+	for i, row := range rows {
+		notes[i] = mapCreditNote(row)
+	}
+	return notes, nil
+}
+
+func (s *RefundService) sendRefundNotice(inv persistence.GetInvoiceDocumentRow, note persistence.CreditNote, amount float64) {
+	go func() {
+		ctx := context.Background()
+		document, filename, err := s.invoices.RenderCreditNotePDF(ctx, note)
+		if err != nil {
+			log.Printf("[RefundService] Failed to render credit note %s: %v", note.CreditNoteNumber, err)
+		}
+		if err := s.emailService.SendRefundIssuedEmail(ctx, inv.OwnerEmail, inv.OwnerName, inv.InvoiceNumber.String, note.CreditNoteNumber, amount, note.Currency, note.Reason, note.SubscriptionTerminated, document, filename); err != nil {
+			log.Printf("[RefundService] Failed to send refund email for credit note %s: %v", note.CreditNoteNumber, err)
+		}
+	}()
+}
+
+// refundReference identifies a refund of amount after refunded had already been returned
+func refundReference(invoiceID uuid.UUID, refunded, amount float64) string {
+	return fmt.Sprintf("refund-%s-%.0f-%.0f", invoiceID, refunded*100, amount*100)
+}
+
+func mapCreditNote(row persistence.CreditNote) *models.CreditNote {
+	amount, _ := utils.NumericToFloat(row.Amount)
+	rate, _ := utils.NumericToFloat(row.VatRate)
+	return &models.CreditNote{
+		ID:                     row.ID,
+		CreditNoteNumber:       row.CreditNoteNumber,
+		InvoiceID:              row.InvoiceID,
+		OwnerID:                row.OwnerID,
+		Amount:                 amount,
+		Currency:               row.Currency,
+		VATRate:                rate,
+		VATAmount:              invoice.SplitVAT(amount, rate),
+		Reason:                 row.Reason,
+		ProviderRefundRef:      row.ProviderRefundRef.String,
+		SubscriptionTerminated: row.SubscriptionTerminated,
+		CreatedBy:              row.CreatedBy,
+		CreatedAt:              row.CreatedAt.Time,
+	}
+}
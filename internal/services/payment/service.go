@@ -1,15 +1,13 @@
 package payment
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"log"
-	"net/http"
-	"os"
-	"time"
 
+	"menuvista/internal/models"
+	"menuvista/internal/services/coupon"
+	"menuvista/internal/services/invoice"
 	"menuvista/internal/storage/persistence"
 	"menuvista/internal/utils"
 
@@ -18,12 +16,17 @@ import (
 )
 
 type Service struct {
-	queries *persistence.Queries
+	queries  *persistence.Queries
+	provider PaymentProvider
+	// webhooks completes payments that coupons bring down to nothing
+	webhooks *WebhookService
 }
 
-func NewService(queries *persistence.Queries) *Service {
+func NewService(queries *persistence.Queries, provider PaymentProvider, webhooks *WebhookService) *Service {
 	return &Service{
-		queries: queries,
+		queries:  queries,
+		provider: provider,
+		webhooks: webhooks,
 	}
 }
 
@@ -34,57 +37,92 @@ type InitiatePaymentInput struct {
 	Email          string
 	Name           string
 	Type           string // update, upgrade
+	// BillingInterval is monthly or annual; empty keeps the interval of the current subscription
+	BillingInterval string
+	// CouponCode is a promo code to apply; empty continues a coupon the owner already redeemed
+	CouponCode string
 }
 
 type InitiatePaymentResponse struct {
 	CheckoutURL string `json:"checkout_url"`
+	// Proration explains the amount charged, or when a scheduled downgrade takes effect
+	Proration *models.ProrationPreview `json:"proration,omitempty"`
+	// Completed is set when a coupon covered the whole amount and the plan is already active
+	Completed bool `json:"completed,omitempty"`
 }
 
 func (s *Service) InitiatePayment(ctx context.Context, input InitiatePaymentInput) (*InitiatePaymentResponse, error) {
 	log.Printf("[PaymentService] Initiating payment for owner: %v, plan: %s, type: %s", utils.UUIDToString(input.OwnerID), input.Plan, input.Type)
 
-	var latestSub persistence.GetLatestSubscriptionByOwnerRow
-	var activeSub persistence.GetActiveSubscriptionByOwnerRow
-	var err error
-
-	// 1. Get latest subscription (any status) for reuse logic
-	latestSub, err = s.queries.GetLatestSubscriptionByOwner(ctx, input.OwnerID)
-	hasLatest := err == nil
-
-	// 2. Get active subscription for amount calculation (upgrades/renewals)
-	activeSub, err = s.queries.GetActiveSubscriptionByOwner(ctx, input.OwnerID)
-	hasActive := err == nil
+	// A downgrade whose period has ended is applied before the owner is charged again
+	if applied, err := s.queries.ApplyScheduledSubscriptionChange(ctx, input.OwnerID); err != nil {
+		log.Printf("[PaymentService] Warning: Failed to apply scheduled plan change: %v", err)
+	} else if applied > 0 {
+		log.Printf("[PaymentService] Applied scheduled plan change for owner %v", input.OwnerID)
+	}
 
-	newPlan, err := s.queries.GetSubscriptionPlanBySlug(ctx, input.Plan)
+	change, err := s.prepareChange(ctx, input.OwnerID, input.Plan, input.BillingInterval, input.CouponCode)
 	if err != nil {
-		return nil, fmt.Errorf("invalid plan: %s", input.Plan)
+		return nil, err
+	}
+	newPlan, interval, quote := change.plan, change.interval, change.quote
+	if input.Type == "" {
+		input.Type = quote.Kind
 	}
 
-	// 3. Reuse incomplete subscription if it matches the plan
-	if hasLatest && latestSub.Status == persistence.SubscriptionStatusIncomplete && latestSub.PlanSlug == newPlan.Slug {
-		input.SubscriptionID = latestSub.ID
-		log.Printf("[PaymentService] Reusing incomplete subscription: %v", latestSub.ID)
-	} else {
-		// 4. Create a new subscription
-		createSubParams := persistence.CreateSubscriptionParams{
-			OwnerID:            input.OwnerID,
-			PlanID:             newPlan.ID,
-			Status:             persistence.SubscriptionStatusIncomplete,
-			CurrentPeriodStart: pgtype.Timestamp{Time: time.Now(), Valid: true},
+	// Downgrades are not charged; they replace the plan when the paid period ends
+	if quote.Kind == models.PlanChangeDowngrade {
+		err := s.queries.ScheduleSubscriptionChange(ctx, persistence.ScheduleSubscriptionChangeParams{
+			ID:                       change.active.ID,
+			ScheduledPlanID:          newPlan.ID,
+			ScheduledBillingInterval: interval,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to schedule plan change: %w", err)
 		}
+		log.Printf("[PaymentService] Scheduled change to %s (%s) for owner %v at %v", newPlan.Slug, interval, input.OwnerID, quote.EffectiveAt)
+		return &InitiatePaymentResponse{Proration: quote}, nil
+	}
 
-		// If there's an active subscription, use its end date if it's close to expiring
-		if hasActive {
-			if (time.Until(activeSub.CurrentPeriodEnd.Time).Hours() / 24) <= 5 {
-				createSubParams.CurrentPeriodEnd = pgtype.Timestamp{Time: time.Now().AddDate(0, 1, 0), Valid: true}
-			} else {
-				createSubParams.CurrentPeriodEnd = activeSub.CurrentPeriodEnd
+	// A new owner's coupon redemption is held for this checkout before anything is created,
+	// and given back if no checkout comes of it
+	txRef := "tx_" + uuid.New().String()
+	if err := coupon.Reserve(ctx, s.queries, input.OwnerID, change.coupon, txRef); err != nil {
+		return nil, err
+	}
+	started := false
+	defer func() {
+		if change.coupon != nil && !started {
+			if err := coupon.Release(context.WithoutCancel(ctx), s.queries, txRef); err != nil {
+				log.Printf("[PaymentService] Warning: %v", err)
 			}
-		} else {
-			createSubParams.CurrentPeriodEnd = pgtype.Timestamp{Time: time.Now().AddDate(0, 1, 0), Valid: true}
 		}
-
-		newSub, err := s.queries.CreateSubscription(ctx, createSubParams)
+	}()
+
+	periodStart := pgtype.Timestamp{Time: quote.PeriodStart, Valid: true}
+	periodEnd := pgtype.Timestamp{Time: quote.PeriodEnd, Valid: true}
+
+	// Reuse incomplete subscription if it matches the plan and interval
+	if change.latest != nil && change.latest.Status == persistence.SubscriptionStatusIncomplete &&
+		change.latest.PlanSlug == newPlan.Slug && change.latest.BillingInterval == interval {
+		input.SubscriptionID = change.latest.ID
+		if _, err := s.queries.UpdateSubscription(ctx, persistence.UpdateSubscriptionParams{
+			ID:                 change.latest.ID,
+			CurrentPeriodStart: periodStart,
+			CurrentPeriodEnd:   periodEnd,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to update subscription: %w", err)
+		}
+		log.Printf("[PaymentService] Reusing incomplete subscription: %v", change.latest.ID)
+	} else {
+		newSub, err := s.queries.CreateSubscription(ctx, persistence.CreateSubscriptionParams{
+			OwnerID:            input.OwnerID,
+			PlanID:             newPlan.ID,
+			Status:             persistence.SubscriptionStatusIncomplete,
+			CurrentPeriodStart: periodStart,
+			CurrentPeriodEnd:   periodEnd,
+			BillingInterval:    interval,
+		})
 		if err != nil {
 			return nil, fmt.Errorf("failed to create subscription: %w", err)
 		}
@@ -97,55 +135,36 @@ func (s *Service) InitiatePayment(ctx context.Context, input InitiatePaymentInpu
 		return nil, err
 	}
 
-	amount, err := s.calculateAmount(ctx, input.Type, activeSub, newPlan)
-	if err != nil {
-		return nil, err
-	}
+	amount := quote.AmountDue
+	log.Printf("[PaymentService] %s %s -> %s (%s): charge %.2f, credit %.2f, discount %.2f, due %.2f", quote.Kind, quote.CurrentPlan, newPlan.Slug, interval, quote.Charge, quote.Credit, quote.Discount, amount)
 
-	txRef := "tx_" + uuid.New().String()
 	if err := s.createTransactionRecord(ctx, input, newPlan, amount, txRef); err != nil {
 		return nil, err
 	}
 
-	checkoutURL, err := s.initializeChapaTransaction(newPlan, email, name, fmt.Sprintf("%.2f", amount), txRef)
-	if err != nil {
-		return nil, err
-	}
-
-	s.createInvoiceRecord(ctx, input, newPlan, amount, txRef)
-
-	return &InitiatePaymentResponse{CheckoutURL: checkoutURL}, nil
-}
-
-func (s *Service) calculateAmount(ctx context.Context, newPlanType string, currentPlan persistence.GetActiveSubscriptionByOwnerRow, newPlan persistence.SubscriptionPlan) (float64, error) {
-	amount := float64(newPlan.PriceMonthly)
-	planType := "update"
-	if currentPlan.PlanSlug != newPlan.Slug {
-		planType = "upgrade"
-	}
-
-	remainingDays := time.Until(currentPlan.CurrentPeriodEnd.Time).Hours() / 24
-	if remainingDays <= 5 {
-		log.Println(planType, " plan", currentPlan.PlanSlug, "tfb", newPlan.Slug, "==>", amount)
-		return amount, nil
+	// Nothing is left to pay, so there is no checkout; the payment is completed here
+	if amount <= 0 && change.coupon != nil {
+		if err := s.createInvoiceRecord(ctx, input, newPlan, quote, change.coupon, txRef); err != nil {
+			return nil, err
+		}
+		if err := s.webhooks.CompletePayment(ctx, txRef, "coupon:"+change.coupon.Code); err != nil {
+			return nil, err
+		}
+		started = true
+		return &InitiatePaymentResponse{Proration: quote, Completed: true}, nil
 	}
 
-	totalDays := currentPlan.CurrentPeriodEnd.Time.Sub(currentPlan.CurrentPeriodStart.Time).Hours() / 24
-	if totalDays <= 0 {
-		log.Println(planType, "plan", currentPlan.PlanSlug, "-->", newPlan.Slug, "==>", amount)
-		return amount, nil
+	checkoutURL, err := s.initializeCheckout(ctx, newPlan, interval, email, name, amount, txRef)
+	if err != nil {
+		return nil, err
 	}
 
-	oldPlan, _ := s.queries.GetSubscriptionPlanBySlug(ctx, currentPlan.PlanSlug)
-	priceDiff := float64(newPlan.PriceMonthly - oldPlan.PriceMonthly)
-	if priceDiff > 0 {
-		if newPlanType != "update" {
-			amount = float64(newPlan.PriceMonthly)
-			log.Println(planType, "exisitng plan", oldPlan.Slug, "-->", newPlan.Slug, "==>", amount)
-		}
+	if err := s.createInvoiceRecord(ctx, input, newPlan, quote, change.coupon, txRef); err != nil {
+		log.Printf("[PaymentService] Failed to save invoice record: %v", err)
 	}
 
-	return amount, nil
+	started = true
+	return &InitiatePaymentResponse{CheckoutURL: checkoutURL, Proration: quote}, nil
 }
 
 func (s *Service) createTransactionRecord(ctx context.Context, input InitiatePaymentInput, plan persistence.SubscriptionPlan, amount float64, txRef string) error {
@@ -160,20 +179,31 @@ func (s *Service) createTransactionRecord(ctx context.Context, input InitiatePay
 	return err
 }
 
-func (s *Service) createInvoiceRecord(ctx context.Context, input InitiatePaymentInput, plan persistence.SubscriptionPlan, amount float64, txRef string) {
-	_, err := s.queries.CreateInvoice(ctx, persistence.CreateInvoiceParams{
+// createInvoiceRecord bills the period from the quote. Invoices that credit unused time are
+// marked prorated so completing the payment keeps the quoted period end. The coupon, if
+// any, is kept on the invoice and goes into the redemption ledger once it is paid.
+func (s *Service) createInvoiceRecord(ctx context.Context, input InitiatePaymentInput, plan persistence.SubscriptionPlan, quote *models.ProrationPreview, discount *models.CouponDiscount, txRef string) error {
+	params := persistence.CreateInvoiceParams{
 		OwnerID:            input.OwnerID,
-		InvoiceNumber:      txRef,
-		Amount:             utils.ToNumeric(amount),
+		TxRef:              txRef,
+		Amount:             utils.ToNumeric(quote.AmountDue),
 		Currency:           plan.Currency,
 		Status:             persistence.InvoiceStatusPending,
 		SubscriptionID:     input.SubscriptionID,
-		BillingPeriodStart: pgtype.Timestamp{Time: time.Now(), Valid: true},
-		BillingPeriodEnd:   pgtype.Timestamp{Time: time.Now().AddDate(0, 1, 0), Valid: true},
-	})
-	if err != nil {
-		log.Printf("[PaymentService] Failed to save invoice record: %v", err)
+		BillingPeriodStart: pgtype.Timestamp{Time: quote.PeriodStart, Valid: true},
+		BillingPeriodEnd:   pgtype.Timestamp{Time: quote.PeriodEnd, Valid: true},
+		Prorated:           quote.Credit > 0,
+		CreditAmount:       utils.ToNumeric(quote.Credit),
+		DiscountAmount:     utils.ToNumeric(quote.Discount),
+		VatRate:            utils.ToNumeric(invoice.VATRateFromEnv()),
+	}
+	if discount != nil {
+		params.CouponID = discount.CouponID
 	}
+	if _, err := s.queries.CreateInvoice(ctx, params); err != nil {
+		return fmt.Errorf("failed to save invoice record: %w", err)
+	}
+	return nil
 }
 
 func (s *Service) getUserDetails(ctx context.Context, input InitiatePaymentInput) (string, string, error) {
@@ -200,61 +230,24 @@ func (s *Service) getUserDetails(ctx context.Context, input InitiatePaymentInput
 	return email, name, nil
 }
 
-func (s *Service) initializeChapaTransaction(plan persistence.SubscriptionPlan, email, name, amountStr, txRef string) (string, error) {
-	payload := map[string]interface{}{
-		"amount":       amountStr,
-		"currency":     plan.Currency,
-		"email":        email,
-		"first_name":   name,
-		"tx_ref":       txRef,
-		"callback_url": os.Getenv("CHAPA_CALLBACK_URL"),
-		"return_url":   fmt.Sprintf("%s?tx_ref=%s", os.Getenv("CHAPA_RETURN_URL"), txRef),
-		// "cancel_url":   os.Getenv("CHAPA_CANCEL_URL"), // Uncomment if Chapa supports it directly or handle via return_url logic
-		"customization": map[string]string{
-			"title":       plan.Name + " Plan",
-			"description": "Monthly subscription",
-		},
+func (s *Service) initializeCheckout(ctx context.Context, plan persistence.SubscriptionPlan, interval persistence.BillingInterval, email, name string, amount float64, txRef string) (string, error) {
+	description := "Monthly subscription"
+	if interval == persistence.BillingIntervalAnnual {
+		description = "Annual subscription"
 	}
 
-	body, _ := json.Marshal(payload)
-
-	reqHttp, _ := http.NewRequest(
-		"POST",
-		"https://api.chapa.co/v1/transaction/initialize",
-		bytes.NewBuffer(body),
-	)
-
-	reqHttp.Header.Set("Authorization", "Bearer "+os.Getenv("CHAPA_SECRET_KEY"))
-	reqHttp.Header.Set("Content-Type", "application/json")
-
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Do(reqHttp)
+	checkoutURL, err := s.provider.InitializeCheckout(ctx, CheckoutRequest{
+		TxRef:       txRef,
+		Amount:      amount,
+		Currency:    plan.Currency,
+		Email:       email,
+		Name:        name,
+		Title:       plan.Name + " Plan",
+		Description: description,
+	})
 	if err != nil {
-		log.Printf("[PaymentService] Chapa request failed: %v", err)
-		return "", fmt.Errorf("failed to initiate payment: %w", err)
-	}
-	defer resp.Body.Close()
-
-	var chapaResp map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&chapaResp); err != nil {
-		log.Printf("[PaymentService] Failed to decode Chapa response: %v", err)
-		return "", fmt.Errorf("failed to decode payment response")
+		log.Printf("[PaymentService] %s checkout failed for tx %s: %v", s.provider.Name(), txRef, err)
+		return "", err
 	}
-
-	if status, ok := chapaResp["status"].(string); !ok || status != "success" {
-		log.Printf("[PaymentService] Chapa returned error: %v", chapaResp)
-		return "", fmt.Errorf("payment initiation failed")
-	}
-
-	data, ok := chapaResp["data"].(map[string]interface{})
-	if !ok {
-		return "", fmt.Errorf("invalid chapa response data")
-	}
-
-	checkoutURL, ok := data["checkout_url"].(string)
-	if !ok {
-		return "", fmt.Errorf("checkout_url not found")
-	}
-
 	return checkoutURL, nil
 }
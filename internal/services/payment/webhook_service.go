@@ -2,53 +2,51 @@ package payment
 
 import (
 	"context"
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/hex"
-	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"time"
 
 	"menuvista/internal/models"
+	"menuvista/internal/services/coupon"
 	"menuvista/internal/services/email"
+	"menuvista/internal/services/invoice"
+	"menuvista/internal/services/subscription"
 	"menuvista/internal/storage/persistence"
 	"menuvista/internal/utils"
 
-	"net/http"
-
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type WebhookService struct {
 	queries      *persistence.Queries
 	emailService *email.Service
+	provider     PaymentProvider
+	invoices     *invoice.Service
 }
 
-func NewWebhookService(queries *persistence.Queries, emailService *email.Service) *WebhookService {
+func NewWebhookService(queries *persistence.Queries, emailService *email.Service, provider PaymentProvider, invoices *invoice.Service) *WebhookService {
 	return &WebhookService{
 		queries:      queries,
 		emailService: emailService,
+		provider:     provider,
+		invoices:     invoices,
 	}
 }
 
-func (s *WebhookService) ProcessWebhook(ctx context.Context, body []byte, signature string) error {
-	// 1. Verify signature
-	if !s.verifySignature(body, signature) {
-		return fmt.Errorf("invalid webhook signature")
-	}
-
-	// 2. Parse payload
-	var payload models.ChapaWebhookPayload
-	if err := json.Unmarshal(body, &payload); err != nil {
-		return fmt.Errorf("failed to parse webhook payload: %w", err)
+// ProcessWebhook authenticates a delivery with the configured provider and applies it
+func (s *WebhookService) ProcessWebhook(ctx context.Context, body []byte, header http.Header) error {
+	// 1. Verify signature and parse payload
+	event, err := s.provider.ParseWebhook(body, header)
+	if err != nil {
+		return err
 	}
 
-	// 3. Idempotency check: Save webhook
+	// 2. Idempotency check: Save webhook
 	webhook, err := s.queries.CreatePaymentWebhook(ctx, persistence.CreatePaymentWebhookParams{
-		ProviderEventID: pgtype.Text{String: payload.Data.Reference, Valid: true},
-		EventType:       payload.Event,
+		ProviderEventID: pgtype.Text{String: event.ProviderRef, Valid: true},
+		EventType:       event.Type,
 		Payload:         body,
 	})
 	if err != nil {
@@ -56,57 +54,64 @@ func (s *WebhookService) ProcessWebhook(ctx context.Context, body []byte, signat
 		return nil // Return nil to acknowledge receipt even if duplicate
 	}
 	if webhook.Processed.Bool {
-		log.Printf("[WebhookService] Webhook already processed: %s", payload.Data.Reference)
+		log.Printf("[WebhookService] Webhook already processed: %s", event.ProviderRef)
 		return nil
 	}
 
-	// 4. Process based on event type
-	switch payload.Event {
-	case "payment.success":
-		err = s.handlePaymentSuccess(ctx, payload.Data)
-	case "payment.failed":
-		err = s.handlePaymentFailed(ctx, payload.Data)
-	case "payment.pending":
-		err = s.handlePaymentPending(ctx, payload.Data)
+	// 3. Process based on event type
+	switch event.Type {
+	case EventPaymentSuccess:
+		err = s.handlePaymentSuccess(ctx, event)
+	case EventPaymentFailed:
+		err = s.handlePaymentFailed(ctx, event)
+	case EventPaymentPending:
+		err = s.handlePaymentPending(ctx, event)
 	default:
-		log.Printf("[WebhookService] Unhandled event type: %s", payload.Event)
+		log.Printf("[WebhookService] Unhandled event type: %s", event.Type)
 	}
 
 	if err == nil {
 		// Mark as processed
-		_ = s.queries.MarkWebhookAsProcessed(ctx, pgtype.Text{String: payload.Data.Reference, Valid: true})
+		_ = s.queries.MarkWebhookAsProcessed(ctx, pgtype.Text{String: event.ProviderRef, Valid: true})
 	}
 
 	return err
 }
 
-func (s *WebhookService) verifySignature(body []byte, signature string) bool {
-	secret := os.Getenv("CHAPA_WEBHOOK_SECRET")
-	if secret == "" {
-		log.Printf("[WebhookService] CHAPA_WEBHOOK_SECRET not set")
-		return false
-	}
-
-	h := hmac.New(sha256.New, []byte(secret))
-	h.Write(body)
-	expectedSignature := hex.EncodeToString(h.Sum(nil))
-
-	return hmac.Equal([]byte(expectedSignature), []byte(signature))
-}
+// handlePaymentSuccess only activates what the provider confirms was paid, so a forged or
+// replayed notification cannot grant a subscription on its own
+func (s *WebhookService) handlePaymentSuccess(ctx context.Context, event *WebhookEvent) error {
+	log.Printf("[WebhookService] Processing payment success for tx: %s", event.TxRef)
 
-func (s *WebhookService) handlePaymentSuccess(ctx context.Context, data models.ChapaWebhookData) error {
-	log.Printf("[WebhookService] Processing payment success for tx: %s", data.TxRef)
-	return s.CompletePayment(ctx, data.TxRef, data.Reference)
+	providerRef, paid, err := s.provider.VerifyPayment(ctx, event.TxRef)
+	if err != nil {
+		return fmt.Errorf("failed to verify payment with %s: %w", s.provider.Name(), err)
+	}
+	if !paid {
+		log.Printf("[WebhookService] %s does not report tx %s as paid, ignoring success notification", s.provider.Name(), event.TxRef)
+		return nil
+	}
+	if providerRef == "" {
+		providerRef = event.ProviderRef
+	}
+	return s.CompletePayment(ctx, event.TxRef, providerRef)
 }
 
 func (s *WebhookService) CompletePayment(ctx context.Context, txRef string, providerRef string) error {
 	log.Printf("[WebhookService] Completing payment for tx: %s, providerRef: %s", txRef, providerRef)
 
-	// 0. Idempotency check
+	// 0. Idempotency check. Refunded transactions were completed before, so a late
+	// notification must not activate the subscription again.
 	tx, err := s.queries.GetPaymentTransactionByTxRef(ctx, txRef)
-	if err == nil && tx.Status == "completed" {
-		log.Printf("[WebhookService] Payment already completed for tx: %s", txRef)
-		return nil
+	if err == nil {
+		switch models.PaymentStatus(tx.Status) {
+		case models.PaymentStatusCompleted, models.PaymentStatusRefunded, models.PaymentStatusPartiallyRefunded:
+			log.Printf("[WebhookService] Payment already completed for tx: %s", txRef)
+			return nil
+		case models.PaymentStatusCancelled:
+			// The provider still took the money, so the payment is honoured
+			log.Printf("[WebhookService] Warning: Cancelled checkout %s was paid by owner %v, check for a duplicate payment", txRef, tx.OwnerID)
+		}
 	}
 
 	// 1. Update transaction status
@@ -121,22 +126,36 @@ func (s *WebhookService) CompletePayment(ctx context.Context, txRef string, prov
 
 	// 2. Update invoice status
 	invoice, err := s.queries.UpdateInvoiceStatus(ctx, persistence.UpdateInvoiceStatusParams{
-		InvoiceNumber: txRef,
-		Status:        persistence.InvoiceStatusPaid,
+		TxRef:  txRef,
+		Status: persistence.InvoiceStatusPaid,
 	})
 	if err != nil {
 		log.Printf("[WebhookService] Warning: Failed to update invoice status: %v", err)
 		return fmt.Errorf("failed to update invoice status: %w", err)
 	}
 
-	// 3. Activate Subscription
+	// 3. Activate Subscription. Renewals paid in advance and prorated changes keep the
+	// invoiced period; otherwise a full period of the billing interval starts now.
+	interval := persistence.BillingIntervalMonthly
+	if sub, err := s.queries.GetSubscriptionByID(ctx, invoice.SubscriptionID); err == nil {
+		interval = sub.BillingInterval
+	} else {
+		log.Printf("[WebhookService] Warning: Failed to load subscription %v, assuming monthly: %v", invoice.SubscriptionID, err)
+	}
 	now := time.Now()
-	expiresAt := now.AddDate(0, 1, 0)
+	startsAt, expiresAt := now, PeriodEnd(now, interval)
+	if invoice.BillingPeriodEnd.Valid && invoice.BillingPeriodEnd.Time.After(now) {
+		if invoice.BillingPeriodStart.Time.After(now) {
+			startsAt, expiresAt = invoice.BillingPeriodStart.Time, invoice.BillingPeriodEnd.Time
+		} else if invoice.Prorated {
+			expiresAt = invoice.BillingPeriodEnd.Time
+		}
+	}
 
 	_, err = s.queries.UpdateSubscription(ctx, persistence.UpdateSubscriptionParams{
 		ID:                 invoice.SubscriptionID,
 		Status:             persistence.NullSubscriptionStatus{SubscriptionStatus: persistence.SubscriptionStatusActive, Valid: true},
-		CurrentPeriodStart: pgtype.Timestamp{Time: now, Valid: true},
+		CurrentPeriodStart: pgtype.Timestamp{Time: startsAt, Valid: true},
 		CurrentPeriodEnd:   pgtype.Timestamp{Time: expiresAt, Valid: true},
 	})
 	if err != nil {
@@ -152,6 +171,28 @@ func (s *WebhookService) CompletePayment(ctx context.Context, txRef string, prov
 		log.Printf("[WebhookService] Warning: Failed to update old subscriptions status: %v", err)
 	}
 
+	// Paying again takes back a cancellation at the end of the period
+	if resumed, err := s.queries.ResumeSubscriptionByOwner(ctx, invoice.OwnerID); err == nil {
+		log.Printf("[WebhookService] Payment resumed subscription %v cancelled at period end", resumed.ID)
+		if err := s.queries.MarkSubscriptionCancellationResumed(ctx, resumed.ID); err != nil {
+			log.Printf("[WebhookService] Warning: Failed to mark cancellation resumed: %v", err)
+		}
+	}
+
+	if err := coupon.Redeem(ctx, s.queries, invoice); err != nil {
+		log.Printf("[WebhookService] Warning: Failed to record coupon redemption: %v", err)
+	}
+
+	// Dunning is over once anything is paid
+	if err := s.queries.CompletePaymentRetryJobsByOwner(ctx, invoice.OwnerID); err != nil {
+		log.Printf("[WebhookService] Warning: Failed to close payment retry jobs: %v", err)
+	}
+
+	// Content hidden while on a smaller plan comes back up to the new plan's limits
+	if err := subscription.EnforcePlanLimits(ctx, s.queries, invoice.OwnerID); err != nil {
+		log.Printf("[WebhookService] Warning: Failed to restore content for the new plan: %v", err)
+	}
+
 	// 5. Ensure user is active
 	_, err = s.queries.UpdateUser(ctx, persistence.UpdateUserParams{
 		ID:       invoice.OwnerID,
@@ -167,16 +208,23 @@ func (s *WebhookService) CompletePayment(ctx context.Context, txRef string, prov
 		return fmt.Errorf("failed to get user: %w", err)
 	}
 
-	// 6. Send confirmation email
+	// 6. Send confirmation email with the invoice attached
 	go func() {
+		ctx := context.Background()
+		document, filename, err := s.invoices.RenderPDF(ctx, invoice.ID)
+		if err != nil {
+			log.Printf("[WebhookService] Warning: Failed to render invoice %v, sending email without it: %v", invoice.ID, err)
+		}
 		amount, _ := utils.NumericToFloat(invoice.Amount)
 		if err := s.emailService.SendPaymentSuccessEmail(
-			context.Background(),
+			ctx,
 			user.Email,
 			user.FullName,
-			invoice.InvoiceNumber,
+			invoice.InvoiceNumber.String,
 			amount,
 			invoice.Currency,
+			document,
+			filename,
 		); err != nil {
 			log.Printf("[WebhookService] Failed to send payment success email: %v", err)
 		}
@@ -186,50 +234,19 @@ func (s *WebhookService) CompletePayment(ctx context.Context, txRef string, prov
 	return nil
 }
 
+// VerifyPayment asks the provider whether txRef was paid, returning its reference for it
 func (s *WebhookService) VerifyPayment(ctx context.Context, txRef string) (string, bool, error) {
-	verifyURL := "https://api.chapa.co/v1/transaction/verify/" + txRef
-
-	req, _ := http.NewRequest("GET", verifyURL, nil)
-	req.Header.Set("Authorization", "Bearer "+os.Getenv("CHAPA_SECRET_KEY"))
-
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Do(req)
-	if err != nil {
-		return "", false, err
-	}
-	defer resp.Body.Close()
-
-	var verifyResp map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&verifyResp); err != nil {
-		return "", false, err
-	}
-
-	if status, ok := verifyResp["status"].(string); !ok || status != "success" {
-		return "", false, nil
-	}
-
-	data, ok := verifyResp["data"].(map[string]interface{})
-	if !ok {
-		return "", false, nil
-	}
-
-	providerRef, _ := data["reference"].(string)
-
-	if data["status"] == "success" {
-		return providerRef, true, nil
-	}
-
-	return providerRef, false, nil
+	return s.provider.VerifyPayment(ctx, txRef)
 }
 
-func (s *WebhookService) handlePaymentFailed(ctx context.Context, data models.ChapaWebhookData) error {
-	log.Printf("[WebhookService] Processing payment failure for tx: %s", data.TxRef)
+func (s *WebhookService) handlePaymentFailed(ctx context.Context, event *WebhookEvent) error {
+	log.Printf("[WebhookService] Processing payment failure for tx: %s", event.TxRef)
 
 	// Update transaction status
 	_, err := s.queries.UpdatePaymentTransactionStatus(ctx, persistence.UpdatePaymentTransactionStatusParams{
-		TxRef:                  data.TxRef,
+		TxRef:                  event.TxRef,
 		Status:                 "failed",
-		ProviderTransactionRef: pgtype.Text{String: data.Reference, Valid: true},
+		ProviderTransactionRef: pgtype.Text{String: event.ProviderRef, Valid: true},
 	})
 	if err != nil {
 		return fmt.Errorf("failed to update transaction status: %w", err)
@@ -237,29 +254,36 @@ func (s *WebhookService) handlePaymentFailed(ctx context.Context, data models.Ch
 
 	// Update invoice status
 	invoice, err := s.queries.UpdateInvoiceStatus(ctx, persistence.UpdateInvoiceStatusParams{
-		InvoiceNumber: data.TxRef,
-		Status:        persistence.InvoiceStatusFailed,
+		TxRef:  event.TxRef,
+		Status: persistence.InvoiceStatusFailed,
 	})
 	if err != nil {
 		log.Printf("[WebhookService] Warning: Failed to update invoice status: %v", err)
 	}
 
-	// Mark subscription as past_due
-	_, err = s.queries.UpdateSubscription(ctx, persistence.UpdateSubscriptionParams{
-		ID:     invoice.SubscriptionID,
-		Status: persistence.NullSubscriptionStatus{SubscriptionStatus: persistence.SubscriptionStatusPastDue, Valid: true},
-	})
-	if err != nil {
-		log.Printf("[WebhookService] Warning: Failed to mark subscription as past_due: %v", err)
+	// The coupon redemption held for this checkout goes back to other owners
+	if err := coupon.Release(ctx, s.queries, event.TxRef); err != nil {
+		log.Printf("[WebhookService] Warning: %v", err)
 	}
 
-	// Schedule retry job
-	_, err = s.queries.CreatePaymentRetryJob(ctx, persistence.CreatePaymentRetryJobParams{
-		SubscriptionID: invoice.SubscriptionID,
-		ScheduledFor:   pgtype.Timestamp{Time: time.Now().AddDate(0, 0, 1), Valid: true}, // Retry in 1 day
-	})
-	if err != nil {
-		log.Printf("[WebhookService] Warning: Failed to schedule retry job: %v", err)
+	// A failed upgrade or early renewal leaves the running subscription alone; dunning
+	// starts if it lapses unpaid. Otherwise the subscription goes past_due straight away.
+	if _, err := s.queries.GetActiveSubscriptionByOwner(ctx, invoice.OwnerID); err == nil {
+		log.Printf("[WebhookService] Owner %v still has an active subscription, not starting dunning", invoice.OwnerID)
+	} else {
+		// Mark subscription as past_due
+		_, err = s.queries.UpdateSubscription(ctx, persistence.UpdateSubscriptionParams{
+			ID:     invoice.SubscriptionID,
+			Status: persistence.NullSubscriptionStatus{SubscriptionStatus: persistence.SubscriptionStatusPastDue, Valid: true},
+		})
+		if err != nil {
+			log.Printf("[WebhookService] Warning: Failed to mark subscription as past_due: %v", err)
+		}
+
+		// Schedule retry job; the dunning worker sends the next reminder in 1 day
+		if err := scheduleRetry(ctx, s.queries, invoice.SubscriptionID, time.Now().Add(dunningFirstBackoff)); err != nil {
+			log.Printf("[WebhookService] Warning: Failed to schedule retry job: %v", err)
+		}
 	}
 
 	// Send failure email
@@ -285,13 +309,13 @@ func (s *WebhookService) handlePaymentFailed(ctx context.Context, data models.Ch
 	return nil
 }
 
-func (s *WebhookService) handlePaymentPending(ctx context.Context, data models.ChapaWebhookData) error {
-	log.Printf("[WebhookService] Processing payment pending for tx: %s", data.TxRef)
+func (s *WebhookService) handlePaymentPending(ctx context.Context, event *WebhookEvent) error {
+	log.Printf("[WebhookService] Processing payment pending for tx: %s", event.TxRef)
 
 	// Send pending email
 	go func() {
 		// Get transaction to find user
-		tx, err := s.queries.GetPaymentTransactionByTxRef(context.Background(), data.TxRef)
+		tx, err := s.queries.GetPaymentTransactionByTxRef(context.Background(), event.TxRef)
 		if err != nil {
 			log.Printf("[WebhookService] Failed to get transaction for email: %v", err)
 			return
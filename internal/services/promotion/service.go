@@ -0,0 +1,420 @@
+package promotion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"slices"
+	"time"
+
+	"menuvista/internal/models"
+	"menuvista/internal/storage/persistence"
+	"menuvista/internal/utils"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrPromotionNotFound = errors.New("promotion not found")
+	ErrRestaurantAccess  = errors.New("unauthorized: you do not have access to this restaurant")
+)
+
+// scopeRank orders promotions by specificity; the more specific one wins a price tie.
+var scopeRank = map[persistence.PromotionScope]int{
+	persistence.PromotionScopeRestaurant: 0,
+	persistence.PromotionScopeCategory:   1,
+	persistence.PromotionScopeItem:       2,
+}
+
+type Service struct {
+	queries *persistence.Queries
+}
+
+func NewService(queries *persistence.Queries) *Service {
+	return &Service{
+		queries: queries,
+	}
+}
+
+// Owner management
+
+func (s *Service) CreatePromotion(ctx context.Context, userID, restaurantID uuid.UUID, input models.CreatePromotionRequest) (*models.Promotion, error) {
+	if err := s.verifyAccess(ctx, userID, restaurantID); err != nil {
+		return nil, err
+	}
+
+	fields, err := s.buildFields(ctx, restaurantID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[PromotionService] Creating promotion %q for restaurant %v", input.Name, restaurantID)
+
+	row, err := s.queries.CreatePromotion(ctx, persistence.CreatePromotionParams{
+		RestaurantID:  restaurantID,
+		Name:          fields.Name,
+		Label:         fields.Label,
+		Scope:         fields.Scope,
+		CategoryID:    fields.CategoryID,
+		MenuItemID:    fields.MenuItemID,
+		DiscountType:  fields.DiscountType,
+		DiscountValue: fields.DiscountValue,
+		StartsOn:      fields.StartsOn,
+		EndsOn:        fields.EndsOn,
+		DaysOfWeek:    fields.DaysOfWeek,
+		StartTime:     fields.StartTime,
+		EndTime:       fields.EndTime,
+		IsActive:      fields.IsActive,
+		CreatedBy:     userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create promotion: %w", err)
+	}
+	return mapToDomainPromotion(row), nil
+}
+
+func (s *Service) UpdatePromotion(ctx context.Context, userID, restaurantID, promotionID uuid.UUID, input models.CreatePromotionRequest) (*models.Promotion, error) {
+	if err := s.verifyAccess(ctx, userID, restaurantID); err != nil {
+		return nil, err
+	}
+
+	fields, err := s.buildFields(ctx, restaurantID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := s.queries.UpdatePromotion(ctx, persistence.UpdatePromotionParams{
+		ID:            promotionID,
+		RestaurantID:  restaurantID,
+		Name:          fields.Name,
+		Label:         fields.Label,
+		Scope:         fields.Scope,
+		CategoryID:    fields.CategoryID,
+		MenuItemID:    fields.MenuItemID,
+		DiscountType:  fields.DiscountType,
+		DiscountValue: fields.DiscountValue,
+		StartsOn:      fields.StartsOn,
+		EndsOn:        fields.EndsOn,
+		DaysOfWeek:    fields.DaysOfWeek,
+		StartTime:     fields.StartTime,
+		EndTime:       fields.EndTime,
+		IsActive:      fields.IsActive,
+	})
+	if err != nil {
+		return nil, ErrPromotionNotFound
+	}
+	return mapToDomainPromotion(row), nil
+}
+
+func (s *Service) GetPromotion(ctx context.Context, userID, restaurantID, promotionID uuid.UUID) (*models.Promotion, error) {
+	if err := s.verifyAccess(ctx, userID, restaurantID); err != nil {
+		return nil, err
+	}
+
+	row, err := s.queries.GetPromotionByID(ctx, persistence.GetPromotionByIDParams{
+		ID:           promotionID,
+		RestaurantID: restaurantID,
+	})
+	if err != nil {
+		return nil, ErrPromotionNotFound
+	}
+	return mapToDomainPromotion(row), nil
+}
+
+func (s *Service) ListPromotions(ctx context.Context, userID, restaurantID uuid.UUID) ([]*models.Promotion, error) {
+	if err := s.verifyAccess(ctx, userID, restaurantID); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.ListPromotionsByRestaurant(ctx, restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list promotions: %w", err)
+	}
+
+	promotions := make([]*models.Promotion, len(rows))
+	for i, row := range rows {
+		promotions[i] = mapToDomainPromotion(row)
+	}
+	return promotions, nil
+}
+
+func (s *Service) DeletePromotion(ctx context.Context, userID, restaurantID, promotionID uuid.UUID) error {
+	if err := s.verifyAccess(ctx, userID, restaurantID); err != nil {
+		return err
+	}
+
+	deleted, err := s.queries.DeletePromotion(ctx, persistence.DeletePromotionParams{
+		ID:           promotionID,
+		RestaurantID: restaurantID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete promotion: %w", err)
+	}
+	if deleted == 0 {
+		return ErrPromotionNotFound
+	}
+	return nil
+}
+
+// Pricing
+
+// ApplyPromotions sets the effective price and promotion label on each item from the
+// promotions running right now. When several apply, the lowest resulting price wins.
+func (s *Service) ApplyPromotions(ctx context.Context, restaurantID uuid.UUID, items []*models.MenuItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	// Schedules are set in the restaurant's local time
+	timezone := utils.DefaultTimezone
+	if restaurant, err := s.queries.GetRestaurantByID(ctx, restaurantID); err == nil {
+		timezone = restaurant.Timezone
+	} else {
+		log.Printf("[PromotionService] Failed to load timezone of restaurant %v, using %s: %v", restaurantID, timezone, err)
+	}
+	now := utils.LocalWallClock(time.Now(), timezone)
+
+	rows, err := s.queries.ListCurrentPromotions(ctx, persistence.ListCurrentPromotionsParams{
+		RestaurantID: restaurantID,
+		Today:        pgtype.Date{Time: now, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load promotions: %w", err)
+	}
+
+	var running []persistence.Promotion
+	for _, p := range rows {
+		if runsAt(p, now) {
+			running = append(running, p)
+		}
+	}
+	if len(running) == 0 {
+		return nil
+	}
+
+	for _, item := range items {
+		var best *persistence.Promotion
+		bestPrice := item.Price
+		for i := range running {
+			p := &running[i]
+			if !appliesTo(p, item) {
+				continue
+			}
+			price := discountedPrice(item.Price, p)
+			if price < bestPrice || (best != nil && price == bestPrice && scopeRank[p.Scope] > scopeRank[best.Scope]) {
+				best, bestPrice = p, price
+			}
+		}
+		if best != nil {
+			promotionID := best.ID
+			item.EffectivePrice = bestPrice
+			item.PromotionID = &promotionID
+			item.PromotionLabel = best.Label
+		}
+	}
+	return nil
+}
+
+// runsAt reports whether the promotion's weekday and time-of-day schedule covers t,
+// a restaurant wall-clock time.
+// Date bounds are already filtered in SQL.
+func runsAt(p persistence.Promotion, t time.Time) bool {
+	if len(p.DaysOfWeek) > 0 && !slices.Contains(p.DaysOfWeek, int32(t.Weekday())) {
+		return false
+	}
+	if !p.StartTime.Valid || !p.EndTime.Valid {
+		return true
+	}
+
+	now := int64(t.Hour()*3600+t.Minute()*60+t.Second()) * 1e6
+	start, end := p.StartTime.Microseconds, p.EndTime.Microseconds
+	if start < end {
+		return now >= start && now < end
+	}
+	// Window wraps past midnight, e.g. 22:00-02:00.
+	return now >= start || now < end
+}
+
+func appliesTo(p *persistence.Promotion, item *models.MenuItem) bool {
+	switch p.Scope {
+	case persistence.PromotionScopeRestaurant:
+		return true
+	case persistence.PromotionScopeCategory:
+		return p.CategoryID == item.CategoryID
+	case persistence.PromotionScopeItem:
+		return p.MenuItemID == item.ID
+	}
+	return false
+}
+
+func discountedPrice(price float64, p *persistence.Promotion) float64 {
+	value, _ := p.DiscountValue.Float64Value()
+	var discounted float64
+	switch p.DiscountType {
+	case persistence.DiscountTypePercentage:
+		discounted = price * (1 - value.Float64/100)
+	default:
+		discounted = price - value.Float64
+	}
+	return math.Max(0, math.Round(discounted*100)/100)
+}
+
+// Helpers
+
+// buildFields validates the request and converts it to column values shared by create and update.
+func (s *Service) buildFields(ctx context.Context, restaurantID uuid.UUID, input models.CreatePromotionRequest) (*persistence.UpdatePromotionParams, error) {
+	fields := &persistence.UpdatePromotionParams{
+		Name:          input.Name,
+		Label:         input.Label,
+		Scope:         persistence.PromotionScope(input.Scope),
+		DiscountType:  persistence.DiscountType(input.DiscountType),
+		DiscountValue: utils.ToNumeric(input.DiscountValue),
+		DaysOfWeek:    input.DaysOfWeek,
+		IsActive:      input.IsActive == nil || *input.IsActive,
+	}
+	if fields.DaysOfWeek == nil {
+		fields.DaysOfWeek = []int32{}
+	}
+
+	if input.DiscountType == models.DiscountTypePercentage && input.DiscountValue > 100 {
+		return nil, errors.New("percentage discount cannot exceed 100")
+	}
+
+	switch input.Scope {
+	case models.PromotionScopeCategory:
+		if input.CategoryID == nil {
+			return nil, errors.New("category_id is required for category promotions")
+		}
+		category, err := s.queries.GetCategoryByID(ctx, *input.CategoryID)
+		if err != nil || category.RestaurantID != restaurantID {
+			return nil, errors.New("category not found")
+		}
+		fields.CategoryID = category.ID
+	case models.PromotionScopeItem:
+		if input.MenuItemID == nil {
+			return nil, errors.New("menu_item_id is required for item promotions")
+		}
+		item, err := s.queries.GetMenuItemByID(ctx, *input.MenuItemID)
+		if err != nil || item.RestaurantID != restaurantID {
+			return nil, errors.New("menu item not found")
+		}
+		fields.MenuItemID = item.ID
+	}
+
+	var err error
+	if fields.StartsOn, err = parseDate(input.StartsOn); err != nil {
+		return nil, err
+	}
+	if fields.EndsOn, err = parseDate(input.EndsOn); err != nil {
+		return nil, err
+	}
+	if fields.StartsOn.Valid && fields.EndsOn.Valid && fields.EndsOn.Time.Before(fields.StartsOn.Time) {
+		return nil, errors.New("ends_on must not be before starts_on")
+	}
+
+	if (input.StartTime == "") != (input.EndTime == "") {
+		return nil, errors.New("start_time and end_time must be set together")
+	}
+	if input.StartTime != "" {
+		if fields.StartTime, err = parseClock(input.StartTime); err != nil {
+			return nil, err
+		}
+		if fields.EndTime, err = parseClock(input.EndTime); err != nil {
+			return nil, err
+		}
+	}
+
+	return fields, nil
+}
+
+func (s *Service) verifyAccess(ctx context.Context, userID, restaurantID uuid.UUID) error {
+	user, err := s.queries.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch user: %w", err)
+	}
+
+	switch models.UserRole(user.Role) {
+	case models.RoleOwner:
+		restaurant, err := s.queries.GetRestaurantByID(ctx, restaurantID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch restaurant: %w", err)
+		}
+		if restaurant.OwnerID != user.ID {
+			return ErrRestaurantAccess
+		}
+	case models.RoleStaff:
+		if user.RestaurantID != restaurantID {
+			return ErrRestaurantAccess
+		}
+	default:
+		return ErrRestaurantAccess
+	}
+	return nil
+}
+
+func parseDate(value string) (pgtype.Date, error) {
+	if value == "" {
+		return pgtype.Date{}, nil
+	}
+	t, err := time.Parse(models.PromotionDateLayout, value)
+	if err != nil {
+		return pgtype.Date{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", value)
+	}
+	return pgtype.Date{Time: t, Valid: true}, nil
+}
+
+func parseClock(value string) (pgtype.Time, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return pgtype.Time{}, fmt.Errorf("invalid time %q, expected HH:MM", value)
+	}
+	return pgtype.Time{Microseconds: int64(t.Hour()*3600+t.Minute()*60) * 1e6, Valid: true}, nil
+}
+
+func formatClock(t pgtype.Time) string {
+	if !t.Valid {
+		return ""
+	}
+	minutes := t.Microseconds / 1e6 / 60
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+func formatDate(d pgtype.Date) string {
+	if !d.Valid {
+		return ""
+	}
+	return d.Time.Format(models.PromotionDateLayout)
+}
+
+func mapToDomainPromotion(row persistence.Promotion) *models.Promotion {
+	value, _ := row.DiscountValue.Float64Value()
+	p := &models.Promotion{
+		ID:            row.ID,
+		RestaurantID:  row.RestaurantID,
+		Name:          row.Name,
+		Label:         row.Label,
+		Scope:         models.PromotionScope(row.Scope),
+		DiscountType:  models.DiscountType(row.DiscountType),
+		DiscountValue: value.Float64,
+		StartsOn:      formatDate(row.StartsOn),
+		EndsOn:        formatDate(row.EndsOn),
+		DaysOfWeek:    row.DaysOfWeek,
+		StartTime:     formatClock(row.StartTime),
+		EndTime:       formatClock(row.EndTime),
+		IsActive:      row.IsActive,
+		CreatedAt:     row.CreatedAt.Time,
+		UpdatedAt:     row.UpdatedAt.Time,
+	}
+	if row.CategoryID != uuid.Nil {
+		categoryID := row.CategoryID
+		p.CategoryID = &categoryID
+	}
+	if row.MenuItemID != uuid.Nil {
+		menuItemID := row.MenuItemID
+		p.MenuItemID = &menuItemID
+	}
+	return p
+}
@@ -0,0 +1,641 @@
+package reservation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"menuvista/internal/models"
+	"menuvista/internal/storage/persistence"
+	"menuvista/internal/utils"
+	"menuvista/platform/cache"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// Public booking limits: per client IP per hour, and per guest email per day.
+	maxBookingsPerIP    = 5
+	ipRateWindow        = 60 * 60
+	maxBookingsPerEmail = 3
+	emailRateWindow     = 24 * 60 * 60
+
+	redisKeyBookingIPRate    = "reservation_ip_rate:"
+	redisKeyBookingEmailRate = "reservation_email_rate:"
+)
+
+var (
+	ErrReservationsDisabled = errors.New("this restaurant is not accepting reservations")
+	ErrRestaurantNotFound   = errors.New("restaurant not found")
+	ErrSlotUnavailable      = errors.New("no table is available for the selected time")
+	ErrReservationNotFound  = errors.New("reservation not found")
+	ErrInvalidToken         = errors.New("invalid or already used reservation link")
+	ErrRestaurantAccess     = errors.New("unauthorized: you do not have access to this restaurant")
+	ErrRateLimited          = errors.New("too many reservations, please try again later")
+)
+
+// blockingStatuses are the reservation states that still hold a table.
+var blockingStatuses = map[persistence.ReservationStatus]bool{
+	persistence.ReservationStatusPending:   true,
+	persistence.ReservationStatusConfirmed: true,
+	persistence.ReservationStatusSeated:    true,
+}
+
+type EmailService interface {
+	SendReservationConfirmationEmail(ctx context.Context, email, guestName, restaurantName, reservedAt string, partySize int32, confirmURL, cancelURL string) error
+}
+
+type Service struct {
+	queries      *persistence.Queries
+	redis        *cache.RedisClient
+	emailService EmailService
+}
+
+func NewService(queries *persistence.Queries, redis *cache.RedisClient, emailService EmailService) *Service {
+	return &Service{
+		queries:      queries,
+		redis:        redis,
+		emailService: emailService,
+	}
+}
+
+// Settings
+
+func (s *Service) GetSettings(ctx context.Context, userID, restaurantID uuid.UUID) (*models.ReservationSettings, error) {
+	if err := s.verifyAccess(ctx, userID, restaurantID); err != nil {
+		return nil, err
+	}
+	return s.loadSettings(ctx, restaurantID)
+}
+
+func (s *Service) UpdateSettings(ctx context.Context, userID, restaurantID uuid.UUID, input models.UpdateReservationSettingsRequest) (*models.ReservationSettings, error) {
+	if err := s.verifyAccess(ctx, userID, restaurantID); err != nil {
+		return nil, err
+	}
+
+	log.Printf("[ReservationService] Updating reservation settings for restaurant %v", restaurantID)
+
+	hours := make([]persistence.CreateOpeningHoursParams, len(input.OpeningHours))
+	for i, oh := range input.OpeningHours {
+		opensAt, err := parseClock(oh.OpensAt)
+		if err != nil {
+			return nil, err
+		}
+		closesAt, err := parseClock(oh.ClosesAt)
+		if err != nil {
+			return nil, err
+		}
+		hours[i] = persistence.CreateOpeningHoursParams{
+			RestaurantID: restaurantID,
+			DayOfWeek:    oh.DayOfWeek,
+			OpensAt:      opensAt,
+			ClosesAt:     closesAt,
+		}
+	}
+
+	if _, err := s.queries.UpsertReservationSettings(ctx, persistence.UpsertReservationSettingsParams{
+		RestaurantID:          restaurantID,
+		IsEnabled:             input.IsEnabled,
+		SlotIntervalMinutes:   input.SlotIntervalMinutes,
+		DiningDurationMinutes: input.DiningDurationMinutes,
+		MaxPartySize:          input.MaxPartySize,
+		BookingWindowDays:     input.BookingWindowDays,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to save reservation settings: %w", err)
+	}
+
+	if err := s.queries.DeleteOpeningHoursByRestaurant(ctx, restaurantID); err != nil {
+		return nil, fmt.Errorf("failed to reset opening hours: %w", err)
+	}
+	for _, h := range hours {
+		if _, err := s.queries.CreateOpeningHours(ctx, h); err != nil {
+			return nil, fmt.Errorf("failed to save opening hours: %w", err)
+		}
+	}
+
+	return s.loadSettings(ctx, restaurantID)
+}
+
+// Guest facing
+
+// ListAvailableSlots returns every bookable start time on the given day for a party of the given size.
+func (s *Service) ListAvailableSlots(ctx context.Context, slug, date string, partySize int32) ([]models.ReservationSlot, error) {
+	restaurant, settings, err := s.getBookableRestaurant(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	day, err := time.ParseInLocation("2006-01-02", date, time.UTC)
+	if err != nil {
+		return nil, errors.New("date must be in YYYY-MM-DD format")
+	}
+	if partySize < 1 || partySize > settings.MaxPartySize {
+		return nil, fmt.Errorf("party size must be between 1 and %d", settings.MaxPartySize)
+	}
+
+	return s.buildSlots(ctx, restaurant.ID, utils.LocalWallClock(time.Now(), restaurant.Timezone), settings, day, partySize)
+}
+
+// CreateReservation books a table for a guest and emails them confirm and cancel links.
+// Bookings are limited per client IP and per guest email, since each one sends an email.
+func (s *Service) CreateReservation(ctx context.Context, slug string, input models.CreateReservationRequest, ipAddress string) (*models.Reservation, error) {
+	restaurant, settings, err := s.getBookableRestaurant(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	reservedAt, err := time.ParseInLocation(models.ReservationTimeLayout, input.ReservedAt, time.UTC)
+	if err != nil {
+		return nil, fmt.Errorf("reserved_at must be in %s format", models.ReservationTimeLayout)
+	}
+	if input.PartySize > settings.MaxPartySize {
+		return nil, fmt.Errorf("party size must be between 1 and %d", settings.MaxPartySize)
+	}
+
+	now := utils.LocalWallClock(time.Now(), restaurant.Timezone)
+	if !reservedAt.After(now) {
+		return nil, errors.New("reservation time must be in the future")
+	}
+	if reservedAt.After(now.AddDate(0, 0, int(settings.BookingWindowDays))) {
+		return nil, fmt.Errorf("reservations can only be made up to %d days in advance", settings.BookingWindowDays)
+	}
+
+	duration := time.Duration(settings.DiningDurationMinutes) * time.Minute
+	if !s.withinOpeningHours(ctx, restaurant.ID, reservedAt, duration) {
+		return nil, errors.New("the restaurant is not open at the selected time")
+	}
+
+	tables, err := s.fittingTables(ctx, restaurant.ID, input.PartySize)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkBookingRate(ctx, ipAddress, input.GuestEmail); err != nil {
+		return nil, err
+	}
+
+	log.Printf("[ReservationService] Booking %d guests at %s for %s", input.PartySize, restaurant.Slug, reservedAt.Format(models.ReservationTimeLayout))
+
+	// Try the smallest fitting tables first; the insert itself refuses overlapping bookings,
+	// so a concurrent booking of the same table simply falls through to the next one.
+	for _, table := range tables {
+		row, err := s.queries.CreateReservation(ctx, persistence.CreateReservationParams{
+			RestaurantID:      restaurant.ID,
+			TableID:           table.ID,
+			GuestName:         input.GuestName,
+			GuestPhone:        input.GuestPhone,
+			GuestEmail:        strings.ToLower(input.GuestEmail),
+			PartySize:         input.PartySize,
+			ReservedAt:        pgtype.Timestamp{Time: reservedAt, Valid: true},
+			EndsAt:            pgtype.Timestamp{Time: reservedAt.Add(duration), Valid: true},
+			Notes:             pgtype.Text{String: input.Notes, Valid: input.Notes != ""},
+			ConfirmationToken: newToken(),
+			CancellationToken: newToken(),
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to create reservation: %w", err)
+		}
+
+		reservation := mapToDomainReservation(row)
+		reservation.TableLabel = table.Label
+
+		go func() {
+			baseURL := os.Getenv("APP_BASE_URL") + "/api/v1/reservations"
+			if err := s.emailService.SendReservationConfirmationEmail(
+				context.Background(),
+				row.GuestEmail,
+				row.GuestName,
+				restaurant.Name,
+				reservedAt.Format("Monday, 02 Jan 2006 at 15:04"),
+				row.PartySize,
+				baseURL+"/confirm?token="+row.ConfirmationToken,
+				baseURL+"/cancel?token="+row.CancellationToken,
+			); err != nil {
+				log.Printf("[ReservationService] Failed to send reservation email: %v", err)
+			}
+		}()
+
+		return reservation, nil
+	}
+
+	return nil, ErrSlotUnavailable
+}
+
+// checkBookingRate counts a booking attempt against the client IP and the guest email
+func (s *Service) checkBookingRate(ctx context.Context, ipAddress, guestEmail string) error {
+	if ipAddress != "" && s.overLimit(ctx, redisKeyBookingIPRate+ipAddress, maxBookingsPerIP, ipRateWindow) {
+		return ErrRateLimited
+	}
+	if s.overLimit(ctx, redisKeyBookingEmailRate+strings.ToLower(strings.TrimSpace(guestEmail)), maxBookingsPerEmail, emailRateWindow) {
+		return ErrRateLimited
+	}
+	return nil
+}
+
+// overLimit counts one more hit on key. Like the other public limits it fails open when
+// Redis is unavailable.
+func (s *Service) overLimit(ctx context.Context, key string, max int64, window int) bool {
+	count, err := s.redis.Incr(ctx, key, window)
+	if err != nil {
+		log.Printf("[ReservationService] Rate limit check failed: %v", err)
+		return false
+	}
+	return count > max
+}
+
+func (s *Service) ConfirmReservation(ctx context.Context, token string) (*models.Reservation, error) {
+	row, err := s.queries.ConfirmReservation(ctx, token)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	log.Printf("[ReservationService] Reservation %v confirmed by guest", row.ID)
+	return mapToDomainReservation(row), nil
+}
+
+func (s *Service) CancelReservation(ctx context.Context, token string) (*models.Reservation, error) {
+	row, err := s.queries.CancelReservationByToken(ctx, token)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	log.Printf("[ReservationService] Reservation %v cancelled by guest", row.ID)
+	return mapToDomainReservation(row), nil
+}
+
+// Staff facing
+
+func (s *Service) ListReservationsForDay(ctx context.Context, userID, restaurantID uuid.UUID, date string) ([]*models.Reservation, error) {
+	if err := s.verifyAccess(ctx, userID, restaurantID); err != nil {
+		return nil, err
+	}
+
+	day := s.localTime(ctx, restaurantID, time.Now()).Truncate(24 * time.Hour)
+	if date != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", date, time.UTC)
+		if err != nil {
+			return nil, errors.New("date must be in YYYY-MM-DD format")
+		}
+		day = parsed
+	}
+
+	rows, err := s.queries.ListReservationsForDay(ctx, persistence.ListReservationsForDayParams{
+		RestaurantID: restaurantID,
+		DayStart:     pgtype.Timestamp{Time: day, Valid: true},
+		DayEnd:       pgtype.Timestamp{Time: day.AddDate(0, 0, 1), Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reservations: %w", err)
+	}
+
+	reservations := make([]*models.Reservation, len(rows))
+	for i, row := range rows {
+		reservations[i] = mapToDomainReservation(persistence.Reservation{
+			ID:                row.ID,
+			RestaurantID:      row.RestaurantID,
+			TableID:           row.TableID,
+			GuestName:         row.GuestName,
+			GuestPhone:        row.GuestPhone,
+			GuestEmail:        row.GuestEmail,
+			PartySize:         row.PartySize,
+			ReservedAt:        row.ReservedAt,
+			EndsAt:            row.EndsAt,
+			Notes:             row.Notes,
+			Status:            row.Status,
+			ConfirmationToken: row.ConfirmationToken,
+			CancellationToken: row.CancellationToken,
+			ConfirmedAt:       row.ConfirmedAt,
+			SeatedAt:          row.SeatedAt,
+			CancelledAt:       row.CancelledAt,
+			CreatedAt:         row.CreatedAt,
+			UpdatedAt:         row.UpdatedAt,
+		})
+		reservations[i].TableLabel = row.TableLabel.String
+	}
+	return reservations, nil
+}
+
+func (s *Service) SeatGuests(ctx context.Context, userID, restaurantID, reservationID uuid.UUID) (*models.Reservation, error) {
+	return s.transition(ctx, userID, restaurantID, reservationID, persistence.ReservationStatusSeated)
+}
+
+func (s *Service) MarkNoShow(ctx context.Context, userID, restaurantID, reservationID uuid.UUID) (*models.Reservation, error) {
+	return s.transition(ctx, userID, restaurantID, reservationID, persistence.ReservationStatusNoShow)
+}
+
+func (s *Service) transition(ctx context.Context, userID, restaurantID, reservationID uuid.UUID, status persistence.ReservationStatus) (*models.Reservation, error) {
+	if err := s.verifyAccess(ctx, userID, restaurantID); err != nil {
+		return nil, err
+	}
+
+	current, err := s.queries.GetReservationByID(ctx, persistence.GetReservationByIDParams{
+		ID:           reservationID,
+		RestaurantID: restaurantID,
+	})
+	if err != nil {
+		return nil, ErrReservationNotFound
+	}
+
+	if current.Status != persistence.ReservationStatusPending && current.Status != persistence.ReservationStatusConfirmed {
+		return nil, fmt.Errorf("cannot mark a %s reservation as %s", current.Status, status)
+	}
+	if status == persistence.ReservationStatusNoShow && s.localTime(ctx, restaurantID, time.Now()).Before(current.ReservedAt.Time) {
+		return nil, errors.New("cannot mark a reservation as no-show before its start time")
+	}
+
+	log.Printf("[ReservationService] Reservation %v: %s -> %s by %v", reservationID, current.Status, status, userID)
+
+	row, err := s.queries.UpdateReservationStatus(ctx, persistence.UpdateReservationStatusParams{
+		ID:           reservationID,
+		RestaurantID: restaurantID,
+		Status:       status,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update reservation: %w", err)
+	}
+	return mapToDomainReservation(row), nil
+}
+
+// Helpers
+
+func (s *Service) getBookableRestaurant(ctx context.Context, slug string) (persistence.Restaurant, persistence.ReservationSetting, error) {
+	restaurant, err := s.queries.GetRestaurantBySlug(ctx, slug)
+	if err != nil || !restaurant.IsPublished {
+		return restaurant, persistence.ReservationSetting{}, ErrRestaurantNotFound
+	}
+
+	settings, err := s.queries.GetReservationSettings(ctx, restaurant.ID)
+	if err != nil || !settings.IsEnabled {
+		return restaurant, settings, ErrReservationsDisabled
+	}
+	return restaurant, settings, nil
+}
+
+// buildSlots lists the slots on day that start after now, the restaurant's wall-clock time.
+func (s *Service) buildSlots(ctx context.Context, restaurantID uuid.UUID, now time.Time, settings persistence.ReservationSetting, day time.Time, partySize int32) ([]models.ReservationSlot, error) {
+	if day.After(now.AddDate(0, 0, int(settings.BookingWindowDays))) {
+		return []models.ReservationSlot{}, nil
+	}
+
+	periods, err := s.openingPeriods(ctx, restaurantID, day)
+	if err != nil {
+		return nil, err
+	}
+
+	tables, err := s.fittingTables(ctx, restaurantID, partySize)
+	if err != nil {
+		return nil, err
+	}
+
+	// Periods may run past midnight, so look one day ahead for overlapping bookings.
+	booked, err := s.queries.ListBlockingReservations(ctx, persistence.ListBlockingReservationsParams{
+		RestaurantID: restaurantID,
+		WindowStart:  pgtype.Timestamp{Time: day, Valid: true},
+		WindowEnd:    pgtype.Timestamp{Time: day.AddDate(0, 0, 2), Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load reservations: %w", err)
+	}
+
+	interval := time.Duration(settings.SlotIntervalMinutes) * time.Minute
+	duration := time.Duration(settings.DiningDurationMinutes) * time.Minute
+
+	slots := []models.ReservationSlot{}
+	for _, p := range periods {
+		for start := p[0]; !start.Add(duration).After(p[1]); start = start.Add(interval) {
+			if !start.After(now) {
+				continue
+			}
+			end := start.Add(duration)
+			slots = append(slots, models.ReservationSlot{
+				StartsAt:  start,
+				EndsAt:    end,
+				Available: hasFreeTable(tables, booked, start, end),
+			})
+		}
+	}
+	return slots, nil
+}
+
+// openingPeriods returns the [open, close) intervals for the given day as restaurant wall-clock times.
+func (s *Service) openingPeriods(ctx context.Context, restaurantID uuid.UUID, day time.Time) ([][2]time.Time, error) {
+	hours, err := s.queries.ListOpeningHoursByRestaurant(ctx, restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load opening hours: %w", err)
+	}
+
+	var periods [][2]time.Time
+	for _, h := range hours {
+		if h.DayOfWeek != int32(day.Weekday()) {
+			continue
+		}
+		opens := day.Add(time.Duration(h.OpensAt.Microseconds) * time.Microsecond)
+		closes := day.Add(time.Duration(h.ClosesAt.Microseconds) * time.Microsecond)
+		if !closes.After(opens) {
+			closes = closes.AddDate(0, 0, 1)
+		}
+		periods = append(periods, [2]time.Time{opens, closes})
+	}
+	return periods, nil
+}
+
+// NextOpening returns the first opening time strictly after the given instant, as
+// restaurant wall-clock time, looking one week ahead. ok is false when the restaurant has
+// no opening hours configured.
+func (s *Service) NextOpening(ctx context.Context, restaurantID uuid.UUID, after time.Time) (time.Time, bool, error) {
+	after = s.localTime(ctx, restaurantID, after)
+	day := after.Truncate(24 * time.Hour)
+	for i := 0; i <= 7; i++ {
+		periods, err := s.openingPeriods(ctx, restaurantID, day.AddDate(0, 0, i))
+		if err != nil {
+			return time.Time{}, false, err
+		}
+		var next time.Time
+		for _, p := range periods {
+			if p[0].After(after) && (next.IsZero() || p[0].Before(next)) {
+				next = p[0]
+			}
+		}
+		if !next.IsZero() {
+			return next, true, nil
+		}
+	}
+	return time.Time{}, false, nil
+}
+
+func (s *Service) withinOpeningHours(ctx context.Context, restaurantID uuid.UUID, start time.Time, duration time.Duration) bool {
+	day := start.Truncate(24 * time.Hour)
+	// A booking shortly after midnight may belong to the previous day's late period.
+	for _, d := range []time.Time{day, day.AddDate(0, 0, -1)} {
+		periods, err := s.openingPeriods(ctx, restaurantID, d)
+		if err != nil {
+			return false
+		}
+		for _, p := range periods {
+			if !start.Before(p[0]) && !start.Add(duration).After(p[1]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// fittingTables returns active tables that can seat the party, smallest first.
+func (s *Service) fittingTables(ctx context.Context, restaurantID uuid.UUID, partySize int32) ([]persistence.RestaurantTable, error) {
+	rows, err := s.queries.ListRestaurantTables(ctx, restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tables: %w", err)
+	}
+
+	var tables []persistence.RestaurantTable
+	for _, t := range rows {
+		if t.IsActive && t.Seats >= partySize {
+			tables = append(tables, t)
+		}
+	}
+	sort.SliceStable(tables, func(i, j int) bool { return tables[i].Seats < tables[j].Seats })
+	return tables, nil
+}
+
+func hasFreeTable(tables []persistence.RestaurantTable, booked []persistence.Reservation, start, end time.Time) bool {
+	for _, t := range tables {
+		free := true
+		for _, r := range booked {
+			if r.TableID == t.ID && blockingStatuses[r.Status] && r.ReservedAt.Time.Before(end) && r.EndsAt.Time.After(start) {
+				free = false
+				break
+			}
+		}
+		if free {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Service) loadSettings(ctx context.Context, restaurantID uuid.UUID) (*models.ReservationSettings, error) {
+	result := &models.ReservationSettings{
+		RestaurantID:          restaurantID,
+		SlotIntervalMinutes:   30,
+		DiningDurationMinutes: 90,
+		MaxPartySize:          8,
+		BookingWindowDays:     30,
+		OpeningHours:          []models.OpeningHours{},
+	}
+
+	if settings, err := s.queries.GetReservationSettings(ctx, restaurantID); err == nil {
+		result.IsEnabled = settings.IsEnabled
+		result.SlotIntervalMinutes = settings.SlotIntervalMinutes
+		result.DiningDurationMinutes = settings.DiningDurationMinutes
+		result.MaxPartySize = settings.MaxPartySize
+		result.BookingWindowDays = settings.BookingWindowDays
+	}
+
+	hours, err := s.queries.ListOpeningHoursByRestaurant(ctx, restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load opening hours: %w", err)
+	}
+	for _, h := range hours {
+		result.OpeningHours = append(result.OpeningHours, models.OpeningHours{
+			DayOfWeek: h.DayOfWeek,
+			OpensAt:   formatClock(h.OpensAt),
+			ClosesAt:  formatClock(h.ClosesAt),
+		})
+	}
+	return result, nil
+}
+
+func (s *Service) verifyAccess(ctx context.Context, userID, restaurantID uuid.UUID) error {
+	user, err := s.queries.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch user: %w", err)
+	}
+
+	switch models.UserRole(user.Role) {
+	case models.RoleAdmin:
+		return nil
+	case models.RoleOwner:
+		restaurant, err := s.queries.GetRestaurantByID(ctx, restaurantID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch restaurant: %w", err)
+		}
+		if restaurant.OwnerID != user.ID {
+			return ErrRestaurantAccess
+		}
+	case models.RoleStaff:
+		if user.RestaurantID != restaurantID {
+			return ErrRestaurantAccess
+		}
+	default:
+		return ErrRestaurantAccess
+	}
+	return nil
+}
+
+// localTime converts t to wall-clock time in the restaurant's timezone, so it compares
+// equal to the TIMESTAMP columns, which store restaurant-local wall-clock time.
+func (s *Service) localTime(ctx context.Context, restaurantID uuid.UUID, t time.Time) time.Time {
+	timezone := utils.DefaultTimezone
+	restaurant, err := s.queries.GetRestaurantByID(ctx, restaurantID)
+	if err != nil {
+		log.Printf("[ReservationService] Failed to load timezone of restaurant %v, using %s: %v", restaurantID, timezone, err)
+	} else {
+		timezone = restaurant.Timezone
+	}
+	return utils.LocalWallClock(t, timezone)
+}
+
+func parseClock(value string) (pgtype.Time, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return pgtype.Time{}, fmt.Errorf("invalid time %q, expected HH:MM", value)
+	}
+	return pgtype.Time{Microseconds: int64(t.Hour()*3600+t.Minute()*60) * 1e6, Valid: true}, nil
+}
+
+func formatClock(t pgtype.Time) string {
+	minutes := t.Microseconds / 1e6 / 60
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+func newToken() string {
+	return strings.ReplaceAll(uuid.New().String(), "-", "")
+}
+
+func mapToDomainReservation(row persistence.Reservation) *models.Reservation {
+	r := &models.Reservation{
+		ID:           row.ID,
+		RestaurantID: row.RestaurantID,
+		GuestName:    row.GuestName,
+		GuestPhone:   row.GuestPhone,
+		GuestEmail:   row.GuestEmail,
+		PartySize:    row.PartySize,
+		ReservedAt:   row.ReservedAt.Time,
+		EndsAt:       row.EndsAt.Time,
+		Notes:        row.Notes.String,
+		Status:       models.ReservationStatus(row.Status),
+		CreatedAt:    row.CreatedAt.Time,
+	}
+	if row.TableID != uuid.Nil {
+		tableID := row.TableID
+		r.TableID = &tableID
+	}
+	if row.ConfirmedAt.Valid {
+		r.ConfirmedAt = &row.ConfirmedAt.Time
+	}
+	if row.SeatedAt.Valid {
+		r.SeatedAt = &row.SeatedAt.Time
+	}
+	if row.CancelledAt.Valid {
+		r.CancelledAt = &row.CancelledAt.Time
+	}
+	return r
+}
@@ -2,11 +2,13 @@ package restaurant
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"mime/multipart"
 
 	"menuvista/internal/models"
+	"menuvista/internal/services/subscription"
 	"menuvista/internal/storage/persistence"
 	"menuvista/internal/utils"
 	"menuvista/platform/storage"
@@ -15,22 +17,36 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+// ErrRestaurantPlanLocked is returned when publishing a restaurant that was hidden because
+// it is above the owner's plan limits
+var ErrRestaurantPlanLocked = errors.New("restaurant is above your plan's restaurant limit")
+
+// ErrInvalidTimezone is returned when the timezone is not a known IANA name
+var ErrInvalidTimezone = errors.New("timezone must be an IANA name such as Africa/Addis_Ababa")
+
 type EmailService interface {
 	SendRestaurantApprovalEmail(ctx context.Context, restaurant *persistence.Restaurant, owner *persistence.User) error
 	SendRestaurantRejectionEmail(ctx context.Context, restaurant *persistence.Restaurant, owner *persistence.User, reason string) error
 }
 
+// StaffRoleSeeder creates the built-in staff roles every restaurant starts with
+type StaffRoleSeeder interface {
+	SeedRoles(ctx context.Context, restaurantID uuid.UUID) error
+}
+
 type Service struct {
 	queries      *persistence.Queries
 	r2           *storage.R2Client
 	emailService EmailService
+	roles        StaffRoleSeeder
 }
 
-func NewService(queries *persistence.Queries, r2 *storage.R2Client, emailService EmailService) *Service {
+func NewService(queries *persistence.Queries, r2 *storage.R2Client, emailService EmailService, roles StaffRoleSeeder) *Service {
 	return &Service{
 		queries:      queries,
 		r2:           r2,
 		emailService: emailService,
+		roles:        roles,
 	}
 }
 
@@ -39,14 +55,9 @@ func (s *Service) CreateRestaurant(ctx context.Context, ownerID uuid.UUID, input
 
 	// Tier validation
 	ownerIDStr := ownerID.String()
-	sub, err := s.queries.GetActiveSubscriptionByOwner(ctx, ownerID)
+	features, err := subscription.EffectiveFeatures(ctx, s.queries, ownerID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch subscription: %w", err)
-	}
-
-	var features models.FeatureLimits
-	if err := utils.UnmarshalJSON(sub.Features, &features); err != nil {
-		log.Printf("[RestaurantService] Warning: Failed to unmarshal features: %v", err)
+		return nil, err
 	}
 
 	existingRestaurants, err := s.queries.ListRestaurantsByOwner(ctx, utils.ToUUID(&ownerIDStr))
@@ -92,6 +103,10 @@ func (s *Service) CreateRestaurant(ctx context.Context, ownerID uuid.UUID, input
 		return nil, fmt.Errorf("failed to create restaurant: %w", err)
 	}
 
+	if err := s.roles.SeedRoles(ctx, restaurantRow.ID); err != nil {
+		log.Printf("[RestaurantService] Warning: Failed to seed staff roles for %v: %v", restaurantRow.ID, err)
+	}
+
 	return s.mapToDomainRestaurant(restaurantRow), nil
 }
 
@@ -160,6 +175,21 @@ func (s *Service) UpdateRestaurant(ctx context.Context, id uuid.UUID, ownerID uu
 
 	idStr := id.String()
 	ownerIDStr := ownerID.String()
+
+	// Restaurants hidden by the plan limits come back when the owner upgrades, not by hand
+	if input.IsPublished != nil && *input.IsPublished && !isAdmin {
+		existing, err := s.queries.GetRestaurantByID(ctx, id)
+		if err == nil && existing.PlanLocked {
+			return nil, ErrRestaurantPlanLocked
+		}
+	}
+
+	if input.Timezone != nil {
+		if _, err := utils.LoadTimezone(*input.Timezone); err != nil {
+			return nil, ErrInvalidTimezone
+		}
+	}
+
 	params := persistence.UpdateRestaurantParams{
 		ID:            utils.ToUUID(&idStr),
 		OwnerID:       utils.ToUUID(&ownerIDStr),
@@ -173,6 +203,7 @@ func (s *Service) UpdateRestaurant(ctx context.Context, id uuid.UUID, ownerID uu
 		Address:       pgtype.Text{String: utils.DerefString(input.Address), Valid: input.Address != nil},
 		City:          pgtype.Text{String: utils.DerefString(input.City), Valid: input.City != nil},
 		Country:       pgtype.Text{String: utils.DerefString(input.Country), Valid: input.Country != nil},
+		Timezone:      pgtype.Text{String: utils.DerefString(input.Timezone), Valid: input.Timezone != nil},
 		ThemeSettings: input.ThemeSettings,
 		IsPublished:   pgtype.Bool{Bool: utils.DerefBool(input.IsPublished), Valid: input.IsPublished != nil},
 	}
@@ -237,6 +268,7 @@ func (s *Service) ListRestaurantsWithFilters(ctx context.Context, filters models
 		Country:     utils.ToText(filters.Country),
 		IsPublished: utils.ToBool(filters.IsPublished),
 		Search:      search,
+		SortBy:      utils.ToText(filters.SortBy),
 		Limit:       int32(pagination.PageSize),
 		Offset:      int32(pagination.Offset),
 	})
@@ -272,6 +304,7 @@ func (s *Service) mapToDomainRestaurant(row persistence.Restaurant) *models.Rest
 	ownerID := row.OwnerID
 
 	rankScore, _ := row.RankScore.Float64Value()
+	ratingAvg, _ := row.RatingAvg.Float64Value()
 
 	return &models.Restaurant{
 		ID:            id,
@@ -286,15 +319,19 @@ func (s *Service) mapToDomainRestaurant(row persistence.Restaurant) *models.Rest
 		Address:       row.Address.String,
 		City:          row.City.String,
 		Country:       row.Country.String,
+		Timezone:      row.Timezone,
 		LogoURL:       row.LogoUrl.String,
 		CoverImageURL: row.CoverImageUrl.String,
 		ThemeSettings: row.ThemeSettings,
 		IsPublished:   row.IsPublished,
+		PlanLocked:    row.PlanLocked,
 		// Status:        string(row.Status),
-		ViewCount: row.ViewCount.Int32,
-		RankScore: rankScore.Float64,
-		CreatedAt: row.CreatedAt.Time,
-		UpdatedAt: row.UpdatedAt.Time,
+		ViewCount:   row.ViewCount.Int32,
+		RankScore:   rankScore.Float64,
+		RatingAvg:   ratingAvg.Float64,
+		RatingCount: row.RatingCount,
+		CreatedAt:   row.CreatedAt.Time,
+		UpdatedAt:   row.UpdatedAt.Time,
 	}
 }
 func (s *Service) uploadFile(ctx context.Context, key string, file *multipart.FileHeader) (string, error) {
@@ -0,0 +1,442 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+
+	"menuvista/internal/models"
+	"menuvista/internal/storage/persistence"
+	"menuvista/platform/cache"
+	"menuvista/platform/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// A single IP may post at most maxReviewsPerDay reviews per restaurant per day.
+	maxReviewsPerDay = 3
+	reviewRateWindow = 24 * 60 * 60
+
+	redisKeyReviewRateLimit = "review_rate:"
+	redisKeyReviewReport    = "review_report:"
+
+	// Review photos are posted anonymously and served from the public bucket
+	maxPhotoSize = 5 << 20
+)
+
+// allowedPhotoTypes are the image types accepted for review photos, as sniffed from the
+// file contents rather than the client's Content-Type
+var allowedPhotoTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/webp": true,
+	"image/gif":  true,
+}
+
+var (
+	ErrRestaurantNotFound = errors.New("restaurant not found")
+	ErrMenuItemNotFound   = errors.New("menu item not found")
+	ErrReviewNotFound     = errors.New("review not found")
+	ErrRateLimited        = errors.New("too many reviews from this device, please try again tomorrow")
+	ErrRestaurantAccess   = errors.New("unauthorized: you do not have access to this restaurant")
+	ErrInvalidPhoto       = errors.New("photo must be a JPEG, PNG, WebP or GIF image of at most 5 MB")
+)
+
+type Service struct {
+	queries *persistence.Queries
+	redis   *cache.RedisClient
+	r2      *storage.R2Client
+}
+
+func NewService(queries *persistence.Queries, redis *cache.RedisClient, r2 *storage.R2Client) *Service {
+	return &Service{
+		queries: queries,
+		redis:   redis,
+		r2:      r2,
+	}
+}
+
+// Public
+
+// CreateReview posts a review for a restaurant, or for one of its menu items when itemID is set.
+func (s *Service) CreateReview(ctx context.Context, slug string, itemID *uuid.UUID, input models.CreateReviewRequest, ipAddress string) (*models.Review, error) {
+	restaurant, err := s.getPublishedRestaurant(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	menuItemID := uuid.Nil
+	if itemID != nil {
+		item, err := s.queries.GetMenuItemByID(ctx, *itemID)
+		if err != nil || item.RestaurantID != restaurant.ID {
+			return nil, ErrMenuItemNotFound
+		}
+		menuItemID = item.ID
+	}
+
+	var photoType string
+	if input.Photo != nil {
+		photoType, err = detectPhotoType(input.Photo)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if ipAddress != "" {
+		count, err := s.redis.Incr(ctx, redisKeyReviewRateLimit+restaurant.ID.String()+":"+ipAddress, reviewRateWindow)
+		if err != nil {
+			log.Printf("[ReviewService] Rate limit check failed: %v", err)
+		} else if count > maxReviewsPerDay {
+			return nil, ErrRateLimited
+		}
+	}
+
+	log.Printf("[ReviewService] New %d-star review for restaurant %v (item: %v)", input.Rating, restaurant.ID, menuItemID)
+
+	var photoURL string
+	if input.Photo != nil {
+		url, err := s.uploadFile(ctx, fmt.Sprintf("restaurants/%s/reviews/%s", restaurant.ID.String(), uuid.New().String()), input.Photo, photoType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to upload photo: %w", err)
+		}
+		photoURL = url
+	}
+
+	row, err := s.queries.CreateReview(ctx, persistence.CreateReviewParams{
+		RestaurantID: restaurant.ID,
+		MenuItemID:   menuItemID,
+		ReviewerName: input.ReviewerName,
+		Rating:       input.Rating,
+		Comment:      pgtype.Text{String: input.Comment, Valid: input.Comment != ""},
+		PhotoUrl:     pgtype.Text{String: photoURL, Valid: photoURL != ""},
+		IpAddress:    pgtype.Text{String: ipAddress, Valid: ipAddress != ""},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create review: %w", err)
+	}
+
+	s.refreshAggregates(ctx, row)
+
+	return mapToDomainReview(row), nil
+}
+
+func (s *Service) ListRestaurantReviews(ctx context.Context, slug string, pagination models.PaginationParams) ([]*models.Review, *models.Meta, error) {
+	restaurant, err := s.getPublishedRestaurant(ctx, slug)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, err := s.queries.ListRestaurantReviews(ctx, persistence.ListRestaurantReviewsParams{
+		RestaurantID: restaurant.ID,
+		Limit:        int32(pagination.PageSize),
+		Offset:       int32(pagination.Offset),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list reviews: %w", err)
+	}
+
+	totalRecords, err := s.queries.CountRestaurantReviews(ctx, restaurant.ID)
+	if err != nil {
+		log.Printf("[ReviewService] Warning: Failed to count reviews: %v", err)
+	}
+
+	reviews := make([]*models.Review, len(rows))
+	for i, row := range rows {
+		reviews[i] = mapToPublicReview(row)
+	}
+
+	meta := models.CalculateMeta(pagination.Page, pagination.PageSize, int(totalRecords))
+	return reviews, meta, nil
+}
+
+func (s *Service) ListMenuItemReviews(ctx context.Context, slug string, itemID uuid.UUID, pagination models.PaginationParams) ([]*models.Review, *models.Meta, error) {
+	restaurant, err := s.getPublishedRestaurant(ctx, slug)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	item, err := s.queries.GetMenuItemByID(ctx, itemID)
+	if err != nil || item.RestaurantID != restaurant.ID {
+		return nil, nil, ErrMenuItemNotFound
+	}
+
+	rows, err := s.queries.ListMenuItemReviews(ctx, persistence.ListMenuItemReviewsParams{
+		MenuItemID: item.ID,
+		Limit:      int32(pagination.PageSize),
+		Offset:     int32(pagination.Offset),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list reviews: %w", err)
+	}
+
+	totalRecords, err := s.queries.CountMenuItemReviews(ctx, item.ID)
+	if err != nil {
+		log.Printf("[ReviewService] Warning: Failed to count reviews: %v", err)
+	}
+
+	reviews := make([]*models.Review, len(rows))
+	for i, row := range rows {
+		reviews[i] = mapToPublicReview(row)
+	}
+
+	meta := models.CalculateMeta(pagination.Page, pagination.PageSize, int(totalRecords))
+	return reviews, meta, nil
+}
+
+// ReportReview flags a review for admin moderation. Repeated reports from the same IP are ignored.
+func (s *Service) ReportReview(ctx context.Context, reviewID uuid.UUID, ipAddress string) error {
+	review, err := s.queries.GetReviewByID(ctx, reviewID)
+	if err != nil {
+		return ErrReviewNotFound
+	}
+
+	if ipAddress != "" {
+		count, err := s.redis.Incr(ctx, redisKeyReviewReport+review.ID.String()+":"+ipAddress, reviewRateWindow)
+		if err == nil && count > 1 {
+			return nil
+		}
+	}
+
+	log.Printf("[ReviewService] Review %v reported", review.ID)
+	if err := s.queries.ReportReview(ctx, review.ID); err != nil {
+		return fmt.Errorf("failed to report review: %w", err)
+	}
+	return nil
+}
+
+// Owner
+
+func (s *Service) ListReviewsForOwner(ctx context.Context, userID, restaurantID uuid.UUID, pagination models.PaginationParams) ([]*models.Review, *models.Meta, error) {
+	if err := s.verifyAccess(ctx, userID, restaurantID); err != nil {
+		return nil, nil, err
+	}
+
+	rows, err := s.queries.ListReviewsByRestaurant(ctx, persistence.ListReviewsByRestaurantParams{
+		RestaurantID: restaurantID,
+		Limit:        int32(pagination.PageSize),
+		Offset:       int32(pagination.Offset),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list reviews: %w", err)
+	}
+
+	totalRecords, err := s.queries.CountReviewsByRestaurant(ctx, restaurantID)
+	if err != nil {
+		log.Printf("[ReviewService] Warning: Failed to count reviews: %v", err)
+	}
+
+	reviews := make([]*models.Review, len(rows))
+	for i, row := range rows {
+		reviews[i] = mapToDomainReview(persistence.Review{
+			ID:           row.ID,
+			RestaurantID: row.RestaurantID,
+			MenuItemID:   row.MenuItemID,
+			ReviewerName: row.ReviewerName,
+			Rating:       row.Rating,
+			Comment:      row.Comment,
+			PhotoUrl:     row.PhotoUrl,
+			OwnerReply:   row.OwnerReply,
+			RepliedBy:    row.RepliedBy,
+			RepliedAt:    row.RepliedAt,
+			IsHidden:     row.IsHidden,
+			HiddenReason: row.HiddenReason,
+			ReportCount:  row.ReportCount,
+			IpAddress:    row.IpAddress,
+			CreatedAt:    row.CreatedAt,
+			UpdatedAt:    row.UpdatedAt,
+		})
+		reviews[i].MenuItemName = row.MenuItemName.String
+	}
+
+	meta := models.CalculateMeta(pagination.Page, pagination.PageSize, int(totalRecords))
+	return reviews, meta, nil
+}
+
+func (s *Service) ReplyToReview(ctx context.Context, userID, restaurantID, reviewID uuid.UUID, input models.ReplyToReviewRequest) (*models.Review, error) {
+	if err := s.verifyAccess(ctx, userID, restaurantID); err != nil {
+		return nil, err
+	}
+
+	review, err := s.queries.GetReviewByID(ctx, reviewID)
+	if err != nil || review.RestaurantID != restaurantID {
+		return nil, ErrReviewNotFound
+	}
+
+	log.Printf("[ReviewService] Owner %v replying to review %v", userID, reviewID)
+
+	row, err := s.queries.ReplyToReview(ctx, persistence.ReplyToReviewParams{
+		ID:         review.ID,
+		OwnerReply: pgtype.Text{String: input.Reply, Valid: true},
+		RepliedBy:  userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save reply: %w", err)
+	}
+	return mapToDomainReview(row), nil
+}
+
+// Admin
+
+func (s *Service) ListReportedReviews(ctx context.Context, pagination models.PaginationParams) ([]*models.Review, *models.Meta, error) {
+	rows, err := s.queries.ListReportedReviews(ctx, persistence.ListReportedReviewsParams{
+		Limit:  int32(pagination.PageSize),
+		Offset: int32(pagination.Offset),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list reported reviews: %w", err)
+	}
+
+	totalRecords, err := s.queries.CountReportedReviews(ctx)
+	if err != nil {
+		log.Printf("[ReviewService] Warning: Failed to count reported reviews: %v", err)
+	}
+
+	reviews := make([]*models.Review, len(rows))
+	for i, row := range rows {
+		reviews[i] = mapToDomainReview(row)
+	}
+
+	meta := models.CalculateMeta(pagination.Page, pagination.PageSize, int(totalRecords))
+	return reviews, meta, nil
+}
+
+func (s *Service) ModerateReview(ctx context.Context, reviewID uuid.UUID, input models.ModerateReviewRequest) (*models.Review, error) {
+	log.Printf("[ReviewService] Setting review %v hidden=%v", reviewID, input.IsHidden)
+
+	row, err := s.queries.SetReviewHidden(ctx, persistence.SetReviewHiddenParams{
+		ID:           reviewID,
+		IsHidden:     input.IsHidden,
+		HiddenReason: pgtype.Text{String: input.Reason, Valid: input.IsHidden && input.Reason != ""},
+	})
+	if err != nil {
+		return nil, ErrReviewNotFound
+	}
+
+	s.refreshAggregates(ctx, row)
+
+	return mapToDomainReview(row), nil
+}
+
+// Helpers
+
+// refreshAggregates recomputes the cached rating average and count on the reviewed restaurant or item.
+func (s *Service) refreshAggregates(ctx context.Context, review persistence.Review) {
+	var err error
+	if review.MenuItemID != uuid.Nil {
+		err = s.queries.RefreshMenuItemRating(ctx, review.MenuItemID)
+	} else {
+		err = s.queries.RefreshRestaurantRating(ctx, review.RestaurantID)
+	}
+	if err != nil {
+		log.Printf("[ReviewService] Failed to refresh rating aggregates for review %v: %v", review.ID, err)
+	}
+}
+
+func (s *Service) getPublishedRestaurant(ctx context.Context, slug string) (persistence.Restaurant, error) {
+	restaurant, err := s.queries.GetRestaurantBySlug(ctx, slug)
+	if err != nil || !restaurant.IsPublished {
+		return restaurant, ErrRestaurantNotFound
+	}
+	return restaurant, nil
+}
+
+func (s *Service) verifyAccess(ctx context.Context, userID, restaurantID uuid.UUID) error {
+	user, err := s.queries.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch user: %w", err)
+	}
+
+	switch models.UserRole(user.Role) {
+	case models.RoleOwner:
+		restaurant, err := s.queries.GetRestaurantByID(ctx, restaurantID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch restaurant: %w", err)
+		}
+		if restaurant.OwnerID != user.ID {
+			return ErrRestaurantAccess
+		}
+	case models.RoleStaff:
+		if user.RestaurantID != restaurantID {
+			return ErrRestaurantAccess
+		}
+	default:
+		return ErrRestaurantAccess
+	}
+	return nil
+}
+
+func (s *Service) uploadFile(ctx context.Context, key string, file *multipart.FileHeader, contentType string) (string, error) {
+	if s.r2 == nil {
+		return "", fmt.Errorf("R2 client not initialized")
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %w", err)
+	}
+	defer f.Close()
+
+	return s.r2.UploadFile(ctx, key, f, contentType)
+}
+
+// detectPhotoType checks the photo's size and sniffs its type from the first bytes
+func detectPhotoType(file *multipart.FileHeader) (string, error) {
+	if file.Size > maxPhotoSize {
+		return "", ErrInvalidPhoto
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %w", err)
+	}
+	defer f.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(f, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", ErrInvalidPhoto
+	}
+	contentType := http.DetectContentType(head[:n])
+	if !allowedPhotoTypes[contentType] {
+		return "", ErrInvalidPhoto
+	}
+	return contentType, nil
+}
+
+func mapToDomainReview(row persistence.Review) *models.Review {
+	review := &models.Review{
+		ID:           row.ID,
+		RestaurantID: row.RestaurantID,
+		ReviewerName: row.ReviewerName,
+		Rating:       row.Rating,
+		Comment:      row.Comment.String,
+		PhotoURL:     row.PhotoUrl.String,
+		OwnerReply:   row.OwnerReply.String,
+		IsHidden:     row.IsHidden,
+		HiddenReason: row.HiddenReason.String,
+		ReportCount:  row.ReportCount,
+		CreatedAt:    row.CreatedAt.Time,
+	}
+	if row.MenuItemID != uuid.Nil {
+		menuItemID := row.MenuItemID
+		review.MenuItemID = &menuItemID
+	}
+	if row.RepliedAt.Valid {
+		review.RepliedAt = &row.RepliedAt.Time
+	}
+	return review
+}
+
+// mapToPublicReview strips moderation details that diners should not see.
+func mapToPublicReview(row persistence.Review) *models.Review {
+	review := mapToDomainReview(row)
+	review.HiddenReason = ""
+	review.ReportCount = 0
+	return review
+}
@@ -0,0 +1,145 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"menuvista/platform/cache"
+
+	"github.com/google/uuid"
+)
+
+const (
+	leaderKey = "scheduler:leader"
+	// leaderTTL is how long a replica stays leader without renewing; when the leader
+	// dies another replica takes over within this time
+	leaderTTL = 60 * time.Second
+	// tick is how often the leader is renewed and due jobs are checked
+	tick = 15 * time.Second
+)
+
+// JobFunc is one run of a periodic job. Jobs log their own failures.
+type JobFunc func(ctx context.Context)
+
+type job struct {
+	name     string
+	interval time.Duration
+	run      JobFunc
+	lastRun  time.Time
+}
+
+// Scheduler runs periodic background jobs in process. Every replica starts one, but
+// only the replica holding the Redis leader lock runs the jobs, so each run happens once
+// across the deployment. A run that outlives the lock can overlap with the next leader's,
+// so jobs still guard their side effects (ledgers, claimed rows).
+type Scheduler struct {
+	redis      *cache.RedisClient
+	instanceID string
+
+	mu       sync.Mutex
+	jobs     []*job
+	isLeader bool
+}
+
+func New(redis *cache.RedisClient) *Scheduler {
+	hostname, _ := os.Hostname()
+	return &Scheduler{
+		redis:      redis,
+		instanceID: fmt.Sprintf("%s-%s", hostname, uuid.New().String()),
+	}
+}
+
+// Register adds a job run every interval. Register jobs before calling Start.
+func (s *Scheduler) Register(name string, interval time.Duration, run JobFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs = append(s.jobs, &job{name: name, interval: interval, run: run})
+}
+
+// Start campaigns for leadership and runs due jobs in the background until ctx is done
+func (s *Scheduler) Start(ctx context.Context) {
+	log.Printf("[Scheduler] Starting as %s with %d jobs", s.instanceID, len(s.jobs))
+	go func() {
+		ticker := time.NewTicker(tick)
+		defer ticker.Stop()
+
+		for {
+			s.runDue(ctx)
+
+			select {
+			case <-ctx.Done():
+				s.resign()
+				log.Printf("[Scheduler] Stopped")
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (s *Scheduler) runDue(ctx context.Context) {
+	leader, err := s.redis.AcquireLock(ctx, leaderKey, s.instanceID, leaderTTL)
+	if err != nil {
+		log.Printf("[Scheduler] Leader election failed: %v", err)
+		leader = false
+	}
+
+	s.mu.Lock()
+	if leader != s.isLeader {
+		if leader {
+			log.Printf("[Scheduler] %s is now the leader", s.instanceID)
+		} else {
+			log.Printf("[Scheduler] %s is no longer the leader", s.instanceID)
+		}
+	}
+	s.isLeader = leader
+	jobs := s.jobs
+	s.mu.Unlock()
+
+	if !leader {
+		return
+	}
+
+	// Jobs run one after another; a slow job delays the rest instead of overlapping itself
+	for _, j := range jobs {
+		if ctx.Err() != nil {
+			return
+		}
+		if time.Since(j.lastRun) < j.interval {
+			continue
+		}
+		j.lastRun = time.Now()
+		s.runJob(ctx, j)
+	}
+}
+
+func (s *Scheduler) runJob(ctx context.Context, j *job) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("[Scheduler] Job %s panicked: %v", j.name, r)
+		}
+	}()
+
+	started := time.Now()
+	j.run(ctx)
+	log.Printf("[Scheduler] Job %s finished in %v", j.name, time.Since(started).Round(time.Millisecond))
+}
+
+// resign releases leadership on shutdown so another replica can take over immediately
+func (s *Scheduler) resign() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.isLeader {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.redis.ReleaseLock(ctx, leaderKey, s.instanceID); err != nil {
+		log.Printf("[Scheduler] Failed to release leadership: %v", err)
+	}
+	s.isLeader = false
+}
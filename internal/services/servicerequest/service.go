@@ -0,0 +1,323 @@
+package servicerequest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"menuvista/internal/models"
+	"menuvista/internal/services/activity"
+	"menuvista/internal/storage/persistence"
+	"menuvista/platform/cache"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// A table may raise at most maxRequestsPerWindow requests every rateLimitWindow seconds.
+	maxRequestsPerWindow = 3
+	rateLimitWindow      = 60
+
+	defaultTableSeats = 4
+
+	redisKeyTableRateLimit = "service_request_rate:"
+)
+
+var (
+	ErrTableNotFound    = errors.New("table not found")
+	ErrRateLimited      = errors.New("too many requests from this table, please wait a moment")
+	ErrRequestNotFound  = errors.New("service request not found or already acknowledged")
+	ErrRestaurantAccess = errors.New("unauthorized: you do not have access to this restaurant")
+)
+
+type Service struct {
+	queries         *persistence.Queries
+	redis           *cache.RedisClient
+	activityService *activity.Service
+}
+
+func NewService(queries *persistence.Queries, redis *cache.RedisClient, activityService *activity.Service) *Service {
+	return &Service{
+		queries:         queries,
+		redis:           redis,
+		activityService: activityService,
+	}
+}
+
+// Tables
+
+func (s *Service) CreateTable(ctx context.Context, userID, restaurantID uuid.UUID, input models.CreateRestaurantTableRequest) (*models.RestaurantTable, error) {
+	if err := s.verifyAccess(ctx, userID, restaurantID); err != nil {
+		return nil, err
+	}
+
+	log.Printf("[ServiceRequestService] Creating table %s for restaurant %v", input.Label, restaurantID)
+
+	seats := input.Seats
+	if seats == 0 {
+		seats = defaultTableSeats
+	}
+
+	table, err := s.queries.CreateRestaurantTable(ctx, persistence.CreateRestaurantTableParams{
+		RestaurantID: restaurantID,
+		Label:        input.Label,
+		Token:        strings.ReplaceAll(uuid.New().String(), "-", ""),
+		Seats:        seats,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create table: %w", err)
+	}
+
+	return mapToDomainTable(table), nil
+}
+
+func (s *Service) ListTables(ctx context.Context, userID, restaurantID uuid.UUID) ([]*models.RestaurantTable, error) {
+	if err := s.verifyAccess(ctx, userID, restaurantID); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.ListRestaurantTables(ctx, restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+
+	tables := make([]*models.RestaurantTable, len(rows))
+	for i, row := range rows {
+		tables[i] = mapToDomainTable(row)
+	}
+	return tables, nil
+}
+
+func (s *Service) DeleteTable(ctx context.Context, userID, restaurantID, tableID uuid.UUID) error {
+	if err := s.verifyAccess(ctx, userID, restaurantID); err != nil {
+		return err
+	}
+
+	if err := s.queries.DeleteRestaurantTable(ctx, persistence.DeleteRestaurantTableParams{
+		ID:           tableID,
+		RestaurantID: restaurantID,
+	}); err != nil {
+		return fmt.Errorf("failed to delete table: %w", err)
+	}
+	return nil
+}
+
+// Guest facing
+
+func (s *Service) GetTableInfo(ctx context.Context, token string) (*models.TableInfo, error) {
+	table, err := s.queries.GetRestaurantTableByToken(ctx, token)
+	if err != nil {
+		return nil, ErrTableNotFound
+	}
+
+	restaurant, err := s.queries.GetRestaurantByID(ctx, table.RestaurantID)
+	if err != nil || !restaurant.IsPublished {
+		return nil, ErrTableNotFound
+	}
+
+	return &models.TableInfo{
+		TableLabel:     table.Label,
+		RestaurantID:   restaurant.ID,
+		RestaurantName: restaurant.Name,
+		RestaurantSlug: restaurant.Slug,
+	}, nil
+}
+
+func (s *Service) RaiseRequest(ctx context.Context, token string, input models.CreateServiceRequestRequest) (*models.ServiceRequest, error) {
+	table, err := s.queries.GetRestaurantTableByToken(ctx, token)
+	if err != nil {
+		return nil, ErrTableNotFound
+	}
+
+	// Tables of unpublished restaurants are hidden, as in GetTableInfo
+	restaurant, err := s.queries.GetRestaurantByID(ctx, table.RestaurantID)
+	if err != nil || !restaurant.IsPublished {
+		return nil, ErrTableNotFound
+	}
+
+	// Validate before counting, so a rejected request does not use up the table's quota
+	if input.RequestType == models.ServiceRequestCustom && strings.TrimSpace(input.Note) == "" {
+		return nil, errors.New("a note is required for custom requests")
+	}
+
+	count, err := s.redis.Incr(ctx, redisKeyTableRateLimit+table.ID.String(), rateLimitWindow)
+	if err != nil {
+		// Fail open: a Redis hiccup should not stop guests from reaching staff.
+		log.Printf("[ServiceRequestService] Rate limit check failed for table %v: %v", table.ID, err)
+	} else if count > maxRequestsPerWindow {
+		return nil, ErrRateLimited
+	}
+
+	log.Printf("[ServiceRequestService] Table %s (%v) raised %s", table.Label, table.ID, input.RequestType)
+
+	req, err := s.queries.CreateServiceRequest(ctx, persistence.CreateServiceRequestParams{
+		RestaurantID: table.RestaurantID,
+		TableID:      table.ID,
+		RequestType:  persistence.ServiceRequestType(input.RequestType),
+		Note:         pgtype.Text{String: input.Note, Valid: input.Note != ""},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create service request: %w", err)
+	}
+
+	result := mapToDomainServiceRequest(req)
+	result.TableLabel = table.Label
+	return result, nil
+}
+
+// Staff facing
+
+func (s *Service) ListOpenRequests(ctx context.Context, userID, restaurantID uuid.UUID) ([]*models.ServiceRequest, error) {
+	if err := s.verifyAccess(ctx, userID, restaurantID); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.ListOpenServiceRequests(ctx, restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service requests: %w", err)
+	}
+
+	requests := make([]*models.ServiceRequest, len(rows))
+	for i, row := range rows {
+		requests[i] = mapToDomainServiceRequest(persistence.ServiceRequest{
+			ID:             row.ID,
+			RestaurantID:   row.RestaurantID,
+			TableID:        row.TableID,
+			RequestType:    row.RequestType,
+			Note:           row.Note,
+			Status:         row.Status,
+			AcknowledgedBy: row.AcknowledgedBy,
+			AcknowledgedAt: row.AcknowledgedAt,
+			CreatedAt:      row.CreatedAt,
+		})
+		requests[i].TableLabel = row.TableLabel
+	}
+	return requests, nil
+}
+
+func (s *Service) AcknowledgeRequest(ctx context.Context, userID, restaurantID, requestID uuid.UUID) (*models.ServiceRequest, error) {
+	if err := s.verifyAccess(ctx, userID, restaurantID); err != nil {
+		return nil, err
+	}
+
+	req, err := s.queries.AcknowledgeServiceRequest(ctx, persistence.AcknowledgeServiceRequestParams{
+		ID:             requestID,
+		RestaurantID:   restaurantID,
+		AcknowledgedBy: userID,
+	})
+	if err != nil {
+		return nil, ErrRequestNotFound
+	}
+
+	responseSeconds := req.AcknowledgedAt.Time.Sub(req.CreatedAt.Time).Seconds()
+	log.Printf("[ServiceRequestService] Request %v acknowledged by %v after %.0fs", req.ID, userID, responseSeconds)
+
+	if s.activityService != nil {
+		if err := s.activityService.LogActivity(ctx, models.CreateActivityLogRequest{
+			RestaurantID:   restaurantID,
+			UserID:         userID,
+			ActionType:     "service_request_acknowledged",
+			ActionCategory: "service",
+			Description:    fmt.Sprintf("Acknowledged %s request", req.RequestType),
+			TargetType:     "service_request",
+			TargetID:       req.ID,
+			AfterValue: map[string]interface{}{
+				"request_type":     req.RequestType,
+				"table_id":         req.TableID,
+				"response_seconds": responseSeconds,
+			},
+			Success: true,
+		}); err != nil {
+			log.Printf("[ServiceRequestService] Failed to log acknowledgement: %v", err)
+		}
+	}
+
+	return mapToDomainServiceRequest(req), nil
+}
+
+// GetStats returns the average time between a guest raising a request and staff
+// acknowledging it over the last `days` days.
+func (s *Service) GetStats(ctx context.Context, userID, restaurantID uuid.UUID, days int) (*models.ServiceRequestStats, error) {
+	if err := s.verifyAccess(ctx, userID, restaurantID); err != nil {
+		return nil, err
+	}
+
+	since := time.Now().AddDate(0, 0, -days)
+	row, err := s.queries.GetServiceRequestAckStats(ctx, persistence.GetServiceRequestAckStatsParams{
+		RestaurantID: restaurantID,
+		CreatedAt:    pgtype.Timestamp{Time: since, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch service request stats: %w", err)
+	}
+
+	return &models.ServiceRequestStats{
+		PeriodDays:            days,
+		AcknowledgedCount:     row.AcknowledgedCount,
+		AverageAckLatencySecs: row.AvgAckSeconds,
+	}, nil
+}
+
+func (s *Service) verifyAccess(ctx context.Context, userID, restaurantID uuid.UUID) error {
+	user, err := s.queries.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch user: %w", err)
+	}
+
+	switch models.UserRole(user.Role) {
+	case models.RoleAdmin:
+		return nil
+	case models.RoleOwner:
+		restaurant, err := s.queries.GetRestaurantByID(ctx, restaurantID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch restaurant: %w", err)
+		}
+		if restaurant.OwnerID != user.ID {
+			return ErrRestaurantAccess
+		}
+	case models.RoleStaff:
+		if user.RestaurantID != restaurantID {
+			return ErrRestaurantAccess
+		}
+	default:
+		return ErrRestaurantAccess
+	}
+	return nil
+}
+
+func mapToDomainTable(row persistence.RestaurantTable) *models.RestaurantTable {
+	return &models.RestaurantTable{
+		ID:           row.ID,
+		RestaurantID: row.RestaurantID,
+		Label:        row.Label,
+		Token:        row.Token,
+		Seats:        row.Seats,
+		IsActive:     row.IsActive,
+		CreatedAt:    row.CreatedAt.Time,
+	}
+}
+
+func mapToDomainServiceRequest(row persistence.ServiceRequest) *models.ServiceRequest {
+	req := &models.ServiceRequest{
+		ID:           row.ID,
+		RestaurantID: row.RestaurantID,
+		TableID:      row.TableID,
+		RequestType:  models.ServiceRequestType(row.RequestType),
+		Note:         row.Note.String,
+		Status:       string(row.Status),
+		CreatedAt:    row.CreatedAt.Time,
+	}
+	if row.AcknowledgedBy != uuid.Nil {
+		acknowledgedBy := row.AcknowledgedBy
+		req.AcknowledgedBy = &acknowledgedBy
+	}
+	if row.AcknowledgedAt.Valid {
+		acknowledgedAt := row.AcknowledgedAt.Time
+		req.AcknowledgedAt = &acknowledgedAt
+	}
+	return req
+}
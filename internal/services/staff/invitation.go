@@ -0,0 +1,358 @@
+package staff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"menuvista/internal/models"
+	"menuvista/internal/services/subscription"
+	"menuvista/internal/storage/persistence"
+	"menuvista/internal/utils"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrRestaurantAccess       = errors.New("unauthorized: you do not own this restaurant")
+	ErrStaffLimitReached      = errors.New("staff account limit reached for your tier")
+	ErrEmailTaken             = errors.New("an account with this email already exists")
+	ErrInvitationPending      = errors.New("an invitation is already pending for this email, resend it instead")
+	ErrInvitationNotFound     = errors.New("invitation not found")
+	ErrInvitationExpired      = errors.New("invitation has expired, ask the owner to resend it")
+	ErrInvitationNotAvailable = errors.New("invitation has already been accepted or revoked")
+)
+
+const (
+	invitationTTL       = 72 * time.Hour
+	invitationExpiresIn = "3 days"
+)
+
+// InviteStaff records an invitation and emails the invitee a link to set their password.
+// Pending invitations count toward the tier's staff limit alongside existing staff.
+func (s *Service) InviteStaff(ctx context.Context, input models.CreateStaffRequest) (*models.StaffInvitation, error) {
+	log.Printf("[StaffService] Inviting staff: %s to restaurant: %v", input.Email, input.RestaurantID)
+
+	restaurant, err := s.getOwnedRestaurant(ctx, input.OwnerID, input.RestaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.queries.GetUserByEmail(ctx, input.Email); err == nil {
+		return nil, ErrEmailTaken
+	}
+	if _, err := s.queries.GetPendingStaffInvitationByEmail(ctx, persistence.GetPendingStaffInvitationByEmailParams{
+		RestaurantID: input.RestaurantID,
+		Email:        input.Email,
+	}); err == nil {
+		return nil, ErrInvitationPending
+	}
+
+	if err := s.checkStaffCapacity(ctx, input.OwnerID, input.RestaurantID); err != nil {
+		return nil, err
+	}
+
+	role, err := s.resolveInvitationRole(ctx, input.RestaurantID, input.RoleID)
+	if err != nil {
+		return nil, err
+	}
+
+	token := uuid.New().String()
+	row, err := s.queries.CreateStaffInvitation(ctx, persistence.CreateStaffInvitationParams{
+		RestaurantID: input.RestaurantID,
+		OwnerID:      input.OwnerID,
+		Email:        input.Email,
+		FullName:     input.FullName,
+		Phone:        pgtype.Text{String: input.Phone, Valid: input.Phone != ""},
+		TokenHash:    utils.HashToken(token),
+		ExpiresAt:    pgtype.Timestamp{Time: time.Now().Add(invitationTTL), Valid: true},
+		StaffRoleID:  role.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create invitation: %w", err)
+	}
+
+	s.sendInvitationAsync(row, restaurant.Name, token)
+	return mapInvitation(row), nil
+}
+
+func (s *Service) ListInvitations(ctx context.Context, ownerID, restaurantID uuid.UUID, pagination models.PaginationParams) ([]*models.StaffInvitation, *models.Meta, error) {
+	if _, err := s.getOwnedRestaurant(ctx, ownerID, restaurantID); err != nil {
+		return nil, nil, err
+	}
+
+	rows, err := s.queries.ListStaffInvitationsByRestaurant(ctx, persistence.ListStaffInvitationsByRestaurantParams{
+		RestaurantID: restaurantID,
+		Limit:        int32(pagination.PageSize),
+		Offset:       int32(pagination.Offset),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list invitations: %w", err)
+	}
+
+	total, err := s.queries.CountStaffInvitationsByRestaurant(ctx, restaurantID)
+	if err != nil {
+		log.Printf("[StaffService] Warning: Failed to count invitations: %v", err)
+	}
+
+	invitations := make([]*models.StaffInvitation, len(rows))
+	for i, row := range rows {
+		invitations[i] = mapInvitation(row)
+	}
+
+	return invitations, models.CalculateMeta(pagination.Page, pagination.PageSize, int(total)), nil
+}
+
+// ResendInvitation issues a fresh link and expiry; the previous link stops working.
+func (s *Service) ResendInvitation(ctx context.Context, ownerID, restaurantID, invitationID uuid.UUID) (*models.StaffInvitation, error) {
+	restaurant, err := s.getOwnedRestaurant(ctx, ownerID, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	invitation, err := s.getInvitation(ctx, restaurantID, invitationID)
+	if err != nil {
+		return nil, err
+	}
+	if invitation.Status != persistence.InvitationStatusPending {
+		return nil, ErrInvitationNotAvailable
+	}
+
+	// An expired invite stopped counting toward the limit, so it has to fit again
+	if !invitation.ExpiresAt.Time.After(time.Now()) {
+		if err := s.checkStaffCapacity(ctx, ownerID, restaurantID); err != nil {
+			return nil, err
+		}
+	}
+
+	token := uuid.New().String()
+	row, err := s.queries.RenewStaffInvitation(ctx, persistence.RenewStaffInvitationParams{
+		ID:           invitationID,
+		RestaurantID: restaurantID,
+		TokenHash:    utils.HashToken(token),
+		ExpiresAt:    pgtype.Timestamp{Time: time.Now().Add(invitationTTL), Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrInvitationNotAvailable
+		}
+		return nil, fmt.Errorf("failed to renew invitation: %w", err)
+	}
+
+	s.sendInvitationAsync(row, restaurant.Name, token)
+	return mapInvitation(row), nil
+}
+
+func (s *Service) RevokeInvitation(ctx context.Context, ownerID, restaurantID, invitationID uuid.UUID) error {
+	if _, err := s.getOwnedRestaurant(ctx, ownerID, restaurantID); err != nil {
+		return err
+	}
+	if _, err := s.getInvitation(ctx, restaurantID, invitationID); err != nil {
+		return err
+	}
+
+	rows, err := s.queries.RevokeStaffInvitation(ctx, persistence.RevokeStaffInvitationParams{
+		ID:           invitationID,
+		RestaurantID: restaurantID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revoke invitation: %w", err)
+	}
+	if rows == 0 {
+		return ErrInvitationNotAvailable
+	}
+
+	log.Printf("[StaffService] Revoked invitation %v", invitationID)
+	return nil
+}
+
+// GetInvitationDetails lets the accept page show who the invitation is for.
+func (s *Service) GetInvitationDetails(ctx context.Context, token string) (*models.InvitationDetails, error) {
+	invitation, err := s.getUsableInvitation(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	restaurant, err := s.queries.GetRestaurantByID(ctx, invitation.RestaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch restaurant: %w", err)
+	}
+
+	return &models.InvitationDetails{
+		Email:          invitation.Email,
+		FullName:       invitation.FullName,
+		RestaurantName: restaurant.Name,
+		ExpiresAt:      invitation.ExpiresAt.Time,
+	}, nil
+}
+
+// AcceptInvitation creates the staff account with the invitee's chosen password.
+// The unique email on users makes a second accept of the same invitation fail.
+func (s *Service) AcceptInvitation(ctx context.Context, input models.AcceptInvitationRequest) (*models.User, error) {
+	invitation, err := s.getUsableInvitation(ctx, input.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.queries.GetUserByEmail(ctx, invitation.Email); err == nil {
+		return nil, ErrEmailTaken
+	}
+
+	hashedPassword, err := utils.HashPassword(input.Password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	userRow, err := s.queries.CreateUser(ctx, persistence.CreateUserParams{
+		Email:        invitation.Email,
+		PasswordHash: hashedPassword,
+		FullName:     invitation.FullName,
+		Role:         persistence.UserRoleStaff,
+		OwnerID:      invitation.OwnerID,
+		RestaurantID: invitation.RestaurantID,
+		Phone:        invitation.Phone,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create staff user: %w", err)
+	}
+
+	if invitation.StaffRoleID != uuid.Nil {
+		if _, err := s.queries.AssignStaffRole(ctx, persistence.AssignStaffRoleParams{
+			ID:           userRow.ID,
+			RestaurantID: invitation.RestaurantID,
+			StaffRoleID:  invitation.StaffRoleID,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to assign staff role: %w", err)
+		}
+	}
+
+	// Following the emailed link proves the address, so the account is active right away
+	userRow, err = s.queries.UpdateUser(ctx, persistence.UpdateUserParams{
+		ID:            userRow.ID,
+		IsActive:      pgtype.Bool{Bool: true, Valid: true},
+		EmailVerified: pgtype.Bool{Bool: true, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to activate staff user: %w", err)
+	}
+
+	rows, err := s.queries.AcceptStaffInvitation(ctx, persistence.AcceptStaffInvitationParams{
+		ID:             invitation.ID,
+		AcceptedUserID: userRow.ID,
+	})
+	if err != nil || rows == 0 {
+		log.Printf("[StaffService] Warning: Failed to mark invitation %v accepted: %v", invitation.ID, err)
+	}
+
+	log.Printf("[StaffService] Invitation %v accepted by user %v", invitation.ID, userRow.ID)
+	return s.mapToDomainUser(userRow), nil
+}
+
+// checkStaffCapacity enforces MaxStaffAccounts against staff plus unexpired pending invitations.
+func (s *Service) checkStaffCapacity(ctx context.Context, ownerID, restaurantID uuid.UUID) error {
+	features, err := subscription.EffectiveFeatures(ctx, s.queries, ownerID)
+	if err != nil {
+		return err
+	}
+
+	staffCount, err := s.queries.CountStaffByRestaurant(ctx, restaurantID)
+	if err != nil {
+		return fmt.Errorf("failed to count staff: %w", err)
+	}
+	pendingCount, err := s.queries.CountPendingStaffInvitations(ctx, restaurantID)
+	if err != nil {
+		return fmt.Errorf("failed to count invitations: %w", err)
+	}
+
+	if !utils.TierValueCompare(features.MaxStaffAccounts, int(staffCount+pendingCount)) {
+		return fmt.Errorf("%w (%d)", ErrStaffLimitReached, features.MaxStaffAccounts)
+	}
+	return nil
+}
+
+func (s *Service) getOwnedRestaurant(ctx context.Context, ownerID, restaurantID uuid.UUID) (persistence.Restaurant, error) {
+	restaurant, err := s.queries.GetRestaurantByID(ctx, restaurantID)
+	if err != nil {
+		return persistence.Restaurant{}, fmt.Errorf("failed to fetch restaurant: %w", err)
+	}
+	if restaurant.OwnerID != ownerID {
+		return persistence.Restaurant{}, ErrRestaurantAccess
+	}
+	return restaurant, nil
+}
+
+func (s *Service) getInvitation(ctx context.Context, restaurantID, invitationID uuid.UUID) (persistence.StaffInvitation, error) {
+	invitation, err := s.queries.GetStaffInvitationByID(ctx, persistence.GetStaffInvitationByIDParams{
+		ID:           invitationID,
+		RestaurantID: restaurantID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return invitation, ErrInvitationNotFound
+		}
+		return invitation, fmt.Errorf("failed to fetch invitation: %w", err)
+	}
+	return invitation, nil
+}
+
+func (s *Service) getUsableInvitation(ctx context.Context, token string) (persistence.StaffInvitation, error) {
+	invitation, err := s.queries.GetStaffInvitationByTokenHash(ctx, utils.HashToken(token))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return invitation, ErrInvitationNotFound
+		}
+		return invitation, fmt.Errorf("failed to fetch invitation: %w", err)
+	}
+	if invitation.Status != persistence.InvitationStatusPending {
+		return invitation, ErrInvitationNotAvailable
+	}
+	if !invitation.ExpiresAt.Time.After(time.Now()) {
+		return invitation, ErrInvitationExpired
+	}
+	return invitation, nil
+}
+
+func (s *Service) sendInvitationAsync(invitation persistence.StaffInvitation, restaurantName, token string) {
+	acceptURL := os.Getenv("APP_BASE_URL") + "/accept-invitation?token=" + token
+	go func() {
+		err := s.emailService.SendStaffInvitationEmail(context.Background(), invitation.Email, invitation.FullName, restaurantName, acceptURL, invitationExpiresIn)
+		if err != nil {
+			log.Printf("[StaffService] Failed to send invitation email: %v", err)
+		}
+	}()
+}
+
+func mapInvitation(row persistence.StaffInvitation) *models.StaffInvitation {
+	status := models.InvitationStatus(row.Status)
+	if status == models.InvitationStatusPending && !row.ExpiresAt.Time.After(time.Now()) {
+		status = models.InvitationStatusExpired
+	}
+
+	var acceptedAt *time.Time
+	if row.AcceptedAt.Valid {
+		acceptedAt = &row.AcceptedAt.Time
+	}
+
+	var staffRoleID *uuid.UUID
+	if row.StaffRoleID != uuid.Nil {
+		staffRoleID = &row.StaffRoleID
+	}
+
+	return &models.StaffInvitation{
+		ID:           row.ID,
+		RestaurantID: row.RestaurantID,
+		Email:        row.Email,
+		FullName:     row.FullName,
+		Phone:        row.Phone.String,
+		StaffRoleID:  staffRoleID,
+		Status:       status,
+		ExpiresAt:    row.ExpiresAt.Time,
+		AcceptedAt:   acceptedAt,
+		CreatedAt:    row.CreatedAt.Time,
+	}
+}
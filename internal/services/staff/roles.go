@@ -0,0 +1,296 @@
+package staff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"menuvista/internal/models"
+	"menuvista/internal/storage/persistence"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrRoleNotFound      = errors.New("role not found")
+	ErrRoleBuiltIn       = errors.New("built-in roles cannot be changed or deleted")
+	ErrRoleInUse         = errors.New("role is assigned to staff, reassign them first")
+	ErrRoleNameTaken     = errors.New("a role with this name already exists")
+	ErrInvalidPermission = errors.New("unknown permission")
+	ErrStaffNotFound     = errors.New("staff member not found")
+	ErrNotStaffMember    = errors.New("not an active staff member")
+)
+
+// SeedRoles creates the built-in roles for a new restaurant.
+func (s *Service) SeedRoles(ctx context.Context, restaurantID uuid.UUID) error {
+	for _, preset := range models.StaffRolePresets {
+		_, err := s.queries.CreateStaffRole(ctx, persistence.CreateStaffRoleParams{
+			RestaurantID: restaurantID,
+			Name:         preset.Name,
+			Description:  pgtype.Text{String: preset.Description, Valid: true},
+			Permissions:  preset.Permissions,
+			Preset:       pgtype.Text{String: preset.Key, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create %s role: %w", preset.Key, err)
+		}
+	}
+	return nil
+}
+
+func (s *Service) ListRoles(ctx context.Context, ownerID, restaurantID uuid.UUID) ([]*models.StaffRole, error) {
+	if _, err := s.getOwnedRestaurant(ctx, ownerID, restaurantID); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.ListStaffRolesByRestaurant(ctx, restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+
+	roles := make([]*models.StaffRole, len(rows))
+	for i, row := range rows {
+		roles[i] = mapStaffRole(persistence.StaffRole{
+			ID:           row.ID,
+			RestaurantID: row.RestaurantID,
+			Name:         row.Name,
+			Description:  row.Description,
+			Permissions:  row.Permissions,
+			Preset:       row.Preset,
+			CreatedAt:    row.CreatedAt,
+			UpdatedAt:    row.UpdatedAt,
+		})
+		roles[i].StaffCount = row.StaffCount
+	}
+	return roles, nil
+}
+
+func (s *Service) CreateRole(ctx context.Context, ownerID, restaurantID uuid.UUID, input models.CreateStaffRoleRequest) (*models.StaffRole, error) {
+	if _, err := s.getOwnedRestaurant(ctx, ownerID, restaurantID); err != nil {
+		return nil, err
+	}
+
+	permissions, err := normalizePermissions(input.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := s.queries.CreateStaffRole(ctx, persistence.CreateStaffRoleParams{
+		RestaurantID: restaurantID,
+		Name:         strings.TrimSpace(input.Name),
+		Description:  pgtype.Text{String: input.Description, Valid: input.Description != ""},
+		Permissions:  permissions,
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrRoleNameTaken
+		}
+		return nil, fmt.Errorf("failed to create role: %w", err)
+	}
+
+	log.Printf("[StaffService] Role %q created for restaurant %v", row.Name, restaurantID)
+	return mapStaffRole(row), nil
+}
+
+func (s *Service) UpdateRole(ctx context.Context, ownerID, restaurantID, roleID uuid.UUID, input models.UpdateStaffRoleRequest) (*models.StaffRole, error) {
+	if _, err := s.getOwnedRestaurant(ctx, ownerID, restaurantID); err != nil {
+		return nil, err
+	}
+
+	role, err := s.getRole(ctx, restaurantID, roleID)
+	if err != nil {
+		return nil, err
+	}
+	if role.Preset.Valid {
+		return nil, ErrRoleBuiltIn
+	}
+
+	params := persistence.UpdateStaffRoleParams{
+		ID:           roleID,
+		RestaurantID: restaurantID,
+		Name:         role.Name,
+		Description:  role.Description,
+		Permissions:  role.Permissions,
+	}
+	if input.Name != nil {
+		params.Name = strings.TrimSpace(*input.Name)
+	}
+	if input.Description != nil {
+		params.Description = pgtype.Text{String: *input.Description, Valid: *input.Description != ""}
+	}
+	if input.Permissions != nil {
+		if params.Permissions, err = normalizePermissions(input.Permissions); err != nil {
+			return nil, err
+		}
+	}
+
+	row, err := s.queries.UpdateStaffRole(ctx, params)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrRoleNameTaken
+		}
+		return nil, fmt.Errorf("failed to update role: %w", err)
+	}
+	return mapStaffRole(row), nil
+}
+
+// DeleteRole removes a custom role. Roles still assigned to staff are kept so nobody
+// silently loses access.
+func (s *Service) DeleteRole(ctx context.Context, ownerID, restaurantID, roleID uuid.UUID) error {
+	if _, err := s.getOwnedRestaurant(ctx, ownerID, restaurantID); err != nil {
+		return err
+	}
+
+	role, err := s.getRole(ctx, restaurantID, roleID)
+	if err != nil {
+		return err
+	}
+	if role.Preset.Valid {
+		return ErrRoleBuiltIn
+	}
+
+	assigned, err := s.queries.CountStaffByRole(ctx, roleID)
+	if err != nil {
+		return fmt.Errorf("failed to count staff: %w", err)
+	}
+	if assigned > 0 {
+		return ErrRoleInUse
+	}
+
+	if _, err := s.queries.DeleteStaffRole(ctx, persistence.DeleteStaffRoleParams{
+		ID:           roleID,
+		RestaurantID: restaurantID,
+	}); err != nil {
+		return fmt.Errorf("failed to delete role: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) AssignRole(ctx context.Context, ownerID, restaurantID, staffID, roleID uuid.UUID) error {
+	if _, err := s.getOwnedRestaurant(ctx, ownerID, restaurantID); err != nil {
+		return err
+	}
+	if _, err := s.getRole(ctx, restaurantID, roleID); err != nil {
+		return err
+	}
+
+	rows, err := s.queries.AssignStaffRole(ctx, persistence.AssignStaffRoleParams{
+		ID:           staffID,
+		RestaurantID: restaurantID,
+		StaffRoleID:  roleID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to assign role: %w", err)
+	}
+	if rows == 0 {
+		return ErrStaffNotFound
+	}
+
+	log.Printf("[StaffService] Staff %v assigned role %v", staffID, roleID)
+	return nil
+}
+
+// StaffPermissions returns the restaurant a staff member works at and the permissions of
+// their role. It backs the per-route permission checks, so inactive staff get an error.
+func (s *Service) StaffPermissions(ctx context.Context, userID uuid.UUID) (uuid.UUID, []string, error) {
+	row, err := s.queries.GetStaffPermissions(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, nil, ErrNotStaffMember
+		}
+		return uuid.Nil, nil, fmt.Errorf("failed to fetch staff permissions: %w", err)
+	}
+	return row.RestaurantID, row.Permissions, nil
+}
+
+// OwnsRestaurant reports whether the owner owns the restaurant. Unknown restaurants are
+// not owned by anyone.
+func (s *Service) OwnsRestaurant(ctx context.Context, ownerID, restaurantID uuid.UUID) (bool, error) {
+	restaurant, err := s.queries.GetRestaurantByID(ctx, restaurantID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to fetch restaurant: %w", err)
+	}
+	return restaurant.OwnerID == ownerID, nil
+}
+
+// resolveInvitationRole validates the requested role or falls back to floor staff.
+func (s *Service) resolveInvitationRole(ctx context.Context, restaurantID uuid.UUID, roleID *uuid.UUID) (persistence.StaffRole, error) {
+	if roleID != nil {
+		return s.getRole(ctx, restaurantID, *roleID)
+	}
+
+	role, err := s.queries.GetStaffRoleByPreset(ctx, persistence.GetStaffRoleByPresetParams{
+		RestaurantID: restaurantID,
+		Preset:       pgtype.Text{String: models.StaffRolePresetFloor, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return role, ErrRoleNotFound
+		}
+		return role, fmt.Errorf("failed to fetch default role: %w", err)
+	}
+	return role, nil
+}
+
+func (s *Service) getRole(ctx context.Context, restaurantID, roleID uuid.UUID) (persistence.StaffRole, error) {
+	role, err := s.queries.GetStaffRole(ctx, persistence.GetStaffRoleParams{
+		ID:           roleID,
+		RestaurantID: restaurantID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return role, ErrRoleNotFound
+		}
+		return role, fmt.Errorf("failed to fetch role: %w", err)
+	}
+	return role, nil
+}
+
+// normalizePermissions rejects unknown keys and drops duplicates.
+func normalizePermissions(permissions []string) ([]string, error) {
+	result := make([]string, 0, len(permissions))
+	for _, p := range permissions {
+		if !models.IsValidPermission(p) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidPermission, p)
+		}
+		if !slices.Contains(result, p) {
+			result = append(result, p)
+		}
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("%w: at least one permission is required", ErrInvalidPermission)
+	}
+	return result, nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+func mapStaffRole(row persistence.StaffRole) *models.StaffRole {
+	permissions := row.Permissions
+	if permissions == nil {
+		permissions = []string{}
+	}
+	return &models.StaffRole{
+		ID:           row.ID,
+		RestaurantID: row.RestaurantID,
+		Name:         row.Name,
+		Description:  row.Description.String,
+		Permissions:  permissions,
+		Preset:       row.Preset.String,
+		IsBuiltIn:    row.Preset.Valid,
+		CreatedAt:    row.CreatedAt.Time,
+		UpdatedAt:    row.UpdatedAt.Time,
+	}
+}
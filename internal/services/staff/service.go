@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"time"
 
 	"menuvista/internal/models"
 	"menuvista/internal/services/email"
@@ -24,96 +23,26 @@ type Service struct {
 	queries      *persistence.Queries
 	r2           *storage.R2Client
 	emailService *email.Service
+	sessions     SessionRevoker
 	// smsService   *sms.Service
 }
 
-func NewStaffService(queries *persistence.Queries, r2 *storage.R2Client, emailService *email.Service) *Service {
+// SessionRevoker logs a user out everywhere, so deactivating or removing staff takes
+// effect immediately
+type SessionRevoker interface {
+	RevokeAllSessions(ctx context.Context, userID uuid.UUID) error
+}
+
+func NewStaffService(queries *persistence.Queries, r2 *storage.R2Client, emailService *email.Service, sessions SessionRevoker) *Service {
 	return &Service{
 		queries:      queries,
 		r2:           r2,
 		emailService: emailService,
+		sessions:     sessions,
 		// smsService:   smsService,
 	}
 }
 
-func (s *Service) CreateStaff(ctx context.Context, ownerID uuid.UUID, restaurantID uuid.UUID, input models.CreateUserRequest) (*models.User, error) {
-	log.Printf("[StaffService] Creating staff: %s for restaurant: %v", input.Email, restaurantID)
-
-	// Tier validation
-	sub, err := s.queries.GetActiveSubscriptionByOwner(ctx, ownerID) // Changed from GetSubscriptionByOwner to GetActiveSubscriptionByOwner and ownerID to *ownerID (corrected to ownerID for syntactic correctness)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch subscription: %w", err)
-	}
-
-	// Check status and expiration
-	now := time.Now()
-	isSubActive := false
-	if string(sub.Status) == string(models.SubscriptionStatusActive) {
-		if sub.CurrentPeriodEnd.Time.After(now) {
-			isSubActive = true
-		}
-	} else if string(sub.Status) == string(models.SubscriptionStatusTrialing) {
-		if sub.TrialEnd.Valid && sub.TrialEnd.Time.After(now) {
-			isSubActive = true
-		}
-	}
-
-	if !isSubActive {
-		return nil, fmt.Errorf("subscription is inactive or expired")
-	}
-
-	var features models.FeatureLimits
-	if err := utils.UnmarshalJSON(sub.Features, &features); err != nil {
-		log.Printf("[StaffService] Warning: Failed to unmarshal features: %v", err)
-	}
-
-	existingStaff, err := s.queries.ListStaffByRestaurant(ctx, persistence.ListStaffByRestaurantParams{RestaurantID: restaurantID})
-	if err == nil && len(existingStaff) >= features.MaxStaffAccounts {
-		return nil, fmt.Errorf("staff account limit reached for your tier (%d)", features.MaxStaffAccounts)
-	}
-	password, err := utils.GeneratePassword(12)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate password: %w", err)
-	}
-
-	hashedPassword, err := utils.HashPassword(password)
-	if err != nil {
-		return nil, fmt.Errorf("failed to hash password: %w", err)
-	}
-
-	userRow, err := s.queries.CreateUser(ctx, persistence.CreateUserParams{
-		Email:        input.Email,
-		PasswordHash: hashedPassword,
-		FullName:     input.FullName,
-		Role:         persistence.UserRoleStaff,
-		OwnerID:      ownerID,
-		RestaurantID: restaurantID,
-		Phone:        pgtype.Text{String: input.Phone, Valid: input.Phone != ""},
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create staff user: %w", err)
-	}
-
-	domainUser := s.mapToDomainUser(userRow)
-
-	// Send notifications async
-	go func() {
-		// Email
-		if err := s.emailService.SendStaffWelcomeEmail(context.Background(), domainUser, password); err != nil {
-			log.Printf("[StaffService] Failed to send welcome email: %v", err)
-		}
-
-		// SMS (if phone exists)
-		// if input.Phone != "" {
-		// 	if err := s.smsService.SendCredentials(context.Background(), input.Phone, input.FullName, input.Email, password); err != nil {
-		// 		log.Printf("[StaffService] Failed to send SMS: %v", err)
-		// 	}
-		// }
-	}()
-
-	return domainUser, nil
-}
-
 func (s *Service) ListStaff(ctx context.Context, restaurantID uuid.UUID, pagination models.PaginationParams) ([]*models.User, *models.Meta, error) {
 	fmt.Printf("[StaffService] ListStaff: %v", restaurantID)
 	rows, err := s.queries.ListStaffByRestaurant(ctx, persistence.ListStaffByRestaurantParams{
@@ -143,20 +72,53 @@ func (s *Service) ListStaff(ctx context.Context, restaurantID uuid.UUID, paginat
 }
 
 func (s *Service) UpdateStaffStatus(ctx context.Context, staffID uuid.UUID, restaurantID uuid.UUID, isActive bool) error {
-	return s.queries.UpdateStaffStatus(ctx, persistence.UpdateStaffStatusParams{
+	member := s.isRestaurantStaff(ctx, staffID, restaurantID)
+	if err := s.queries.UpdateStaffStatus(ctx, persistence.UpdateStaffStatusParams{
 		ID:           staffID,
 		RestaurantID: restaurantID,
 		IsActive:     isActive,
-	})
+	}); err != nil {
+		return err
+	}
+
+	if !isActive && member {
+		s.revokeSessions(ctx, staffID)
+	}
+	return nil
 }
 
 func (s *Service) DeleteStaff(ctx context.Context, staffID uuid.UUID, restaurantID uuid.UUID) error {
 	fmt.Printf("[StaffService] Deleting staff: %v", staffID)
 	fmt.Printf("[StaffService] Deleting staff: %v", restaurantID)
-	return s.queries.DeleteStaff(ctx, persistence.DeleteStaffParams{
+	member := s.isRestaurantStaff(ctx, staffID, restaurantID)
+	if err := s.queries.DeleteStaff(ctx, persistence.DeleteStaffParams{
 		ID:           staffID,
 		RestaurantID: restaurantID,
-	})
+	}); err != nil {
+		return err
+	}
+
+	if member {
+		s.revokeSessions(ctx, staffID)
+	}
+	return nil
+}
+
+// isRestaurantStaff reports whether the user is staff of the restaurant. Only they are
+// logged out, since the updates above match nothing for anyone else.
+func (s *Service) isRestaurantStaff(ctx context.Context, staffID, restaurantID uuid.UUID) bool {
+	user, err := s.queries.GetUserByID(ctx, staffID)
+	return err == nil && user.Role == persistence.UserRoleStaff && user.RestaurantID == restaurantID
+}
+
+// revokeSessions logs a staff member out on every device
+func (s *Service) revokeSessions(ctx context.Context, staffID uuid.UUID) {
+	if s.sessions == nil {
+		return
+	}
+	if err := s.sessions.RevokeAllSessions(ctx, staffID); err != nil {
+		log.Printf("[StaffService] Failed to revoke sessions for staff %v: %v", staffID, err)
+	}
 }
 
 func (s *Service) mapToDomainUser(row persistence.User) *models.User {
@@ -164,6 +126,11 @@ func (s *Service) mapToDomainUser(row persistence.User) *models.User {
 	ownerID := row.OwnerID
 	restaurantID := row.RestaurantID
 
+	var staffRoleID *uuid.UUID
+	if row.StaffRoleID != uuid.Nil {
+		staffRoleID = &row.StaffRoleID
+	}
+
 	return &models.User{
 		ID:            id,
 		Email:         row.Email,
@@ -177,6 +144,9 @@ func (s *Service) mapToDomainUser(row persistence.User) *models.User {
 		IsActive:      row.IsActive,
 		CreatedAt:     row.CreatedAt.Time,
 		UpdatedAt:     row.UpdatedAt.Time,
+
+		MustChangePassword: row.MustChangePassword,
+		StaffRoleID:        staffRoleID,
 	}
 }
 func (s *Service) UpdateStaff(ctx context.Context, staffID uuid.UUID, restaurantID uuid.UUID, input models.UpdateUserRequest) (*models.User, error) {
@@ -0,0 +1,130 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+
+	"menuvista/internal/models"
+	"menuvista/internal/storage/persistence"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrNothingToCancel   = errors.New("there is no paid or trial subscription to cancel")
+	ErrAlreadyCancelling = errors.New("subscription is already set to cancel at the end of the period")
+	ErrPastDueCancel     = errors.New("subscription is past due, cancel it immediately instead")
+	ErrNothingToResume   = errors.New("there is no cancelled subscription to resume")
+)
+
+// CancelSubscription cancels the owner's current subscription. By default the plan stays
+// until the end of the paid period (or trial) and then ends without renewing; immediate
+// cancellation ends it now, without a refund, and moves the owner to the free plan. The
+// reason is recorded for churn analysis and the owner gets a confirmation email.
+func (s *Service) CancelSubscription(ctx context.Context, ownerID uuid.UUID, immediate bool, input models.CancelSubscriptionRequest) (*models.SubscriptionDetailsResponse, error) {
+	sub, err := s.queries.GetEffectiveSubscriptionByOwner(ctx, ownerID)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && sub.PlanSlug == FreePlanSlug) {
+		return nil, ErrNothingToCancel
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch subscription: %w", err)
+	}
+
+	if immediate {
+		if err := s.TerminateSubscription(ctx, ownerID, sub.ID); err != nil {
+			return nil, err
+		}
+	} else {
+		switch {
+		case sub.Status == persistence.SubscriptionStatusPastDue:
+			return nil, ErrPastDueCancel
+		case sub.CancelAtPeriodEnd:
+			return nil, ErrAlreadyCancelling
+		}
+		if _, err := s.queries.CancelSubscriptionAtPeriodEnd(ctx, sub.ID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, ErrAlreadyCancelling
+			}
+			return nil, fmt.Errorf("failed to cancel subscription: %w", err)
+		}
+	}
+
+	if _, err := s.queries.CreateSubscriptionCancellation(ctx, persistence.CreateSubscriptionCancellationParams{
+		SubscriptionID: sub.ID,
+		OwnerID:        ownerID,
+		PlanID:         sub.PlanID,
+		Reason:         input.Reason,
+		Feedback:       pgtype.Text{String: input.Feedback, Valid: input.Feedback != ""},
+		Immediate:      immediate,
+	}); err != nil {
+		log.Printf("[SubscriptionService] Warning: Failed to record cancellation reason: %v", err)
+	}
+	log.Printf("[SubscriptionService] Owner %v cancelled subscription %v (immediate: %v, reason: %s)", ownerID, sub.ID, immediate, input.Reason)
+
+	s.sendCancellationConfirmation(ownerID, sub.PlanName, sub.CurrentPeriodEnd, immediate)
+
+	return s.GetSubscriptionDetails(ctx, ownerID)
+}
+
+// TerminateSubscription ends one of the owner's subscriptions now and moves the owner to
+// the free plan. It is used for immediate cancellation and by admin refunds.
+func (s *Service) TerminateSubscription(ctx context.Context, ownerID, subscriptionID uuid.UUID) error {
+	if _, err := s.queries.CancelSubscriptionNow(ctx, subscriptionID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrNothingToCancel
+		}
+		return fmt.Errorf("failed to cancel subscription: %w", err)
+	}
+	// A cancelled subscription is no longer chased for payment
+	if err := s.queries.CompletePaymentRetryJobsByOwner(ctx, ownerID); err != nil {
+		log.Printf("[SubscriptionService] Warning: Failed to close payment retry jobs: %v", err)
+	}
+	// RunLifecycle would do this on its next run; doing it now keeps the owner on a plan
+	if err := s.startFreePlan(ctx, ownerID); err != nil {
+		log.Printf("[SubscriptionService] Warning: Failed to move owner %v to the free plan: %v", ownerID, err)
+	}
+	return nil
+}
+
+// ResumeSubscription takes back a cancellation at period end while the period is running
+func (s *Service) ResumeSubscription(ctx context.Context, ownerID uuid.UUID) (*models.SubscriptionDetailsResponse, error) {
+	sub, err := s.queries.ResumeSubscriptionByOwner(ctx, ownerID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNothingToResume
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to resume subscription: %w", err)
+	}
+
+	if err := s.queries.MarkSubscriptionCancellationResumed(ctx, sub.ID); err != nil {
+		log.Printf("[SubscriptionService] Warning: Failed to mark cancellation resumed: %v", err)
+	}
+	log.Printf("[SubscriptionService] Owner %v resumed subscription %v", ownerID, sub.ID)
+
+	return s.GetSubscriptionDetails(ctx, ownerID)
+}
+
+func (s *Service) sendCancellationConfirmation(ownerID uuid.UUID, planName string, periodEnd pgtype.Timestamp, immediate bool) {
+	actionURL := os.Getenv("APP_BASE_URL") + "/billing"
+	if immediate {
+		actionURL = upgradeURL("")
+	}
+
+	go func() {
+		ctx := context.Background()
+		user, err := s.queries.GetUserByID(ctx, ownerID)
+		if err != nil {
+			log.Printf("[SubscriptionService] Failed to fetch owner %v for cancellation email: %v", ownerID, err)
+			return
+		}
+		endDate := periodEnd.Time.Format("January 2, 2006")
+		if err := s.emailService.SendCancellationConfirmedEmail(ctx, user.Email, user.FullName, planName, endDate, actionURL, immediate); err != nil {
+			log.Printf("[SubscriptionService] Failed to send cancellation confirmation email: %v", err)
+		}
+	}()
+}
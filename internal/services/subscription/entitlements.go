@@ -0,0 +1,117 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"menuvista/internal/models"
+	"menuvista/internal/storage/persistence"
+	"menuvista/internal/utils"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// FreePlanSlug is the plan owners fall back to when their trial or paid subscription ends.
+// It is not listed for purchase.
+const FreePlanSlug = "free"
+
+// EffectiveFeatures returns the limits an owner is entitled to right now: those of a
+// current active or trialing subscription, of a past-due one while its renewal is chased,
+// and otherwise those of the free plan. Owners are never left without limits, so an
+// expired subscription restricts what they can create instead of failing every request.
+func EffectiveFeatures(ctx context.Context, queries *persistence.Queries, ownerID uuid.UUID) (models.FeatureLimits, error) {
+	var raw []byte
+	sub, err := queries.GetEffectiveSubscriptionByOwner(ctx, ownerID)
+	switch {
+	case err == nil:
+		raw = sub.Features
+	case errors.Is(err, pgx.ErrNoRows):
+		plan, err := queries.GetSubscriptionPlanBySlug(ctx, FreePlanSlug)
+		if err != nil {
+			return models.FeatureLimits{}, fmt.Errorf("failed to fetch free plan: %w", err)
+		}
+		raw = plan.Features
+	default:
+		return models.FeatureLimits{}, fmt.Errorf("failed to fetch subscription: %w", err)
+	}
+
+	var features models.FeatureLimits
+	if err := utils.UnmarshalJSON(raw, &features); err != nil {
+		log.Printf("[SubscriptionService] Warning: Failed to unmarshal features for owner %v: %v", ownerID, err)
+	}
+	return features, nil
+}
+
+// EnforcePlanLimits brings an owner's published content in line with their current plan.
+// Everything hidden by an earlier enforcement is restored first, then whatever is above
+// the limits is hidden again and marked plan_locked: the newest restaurants are
+// unpublished, and the last categories and menu items of each restaurant (by display
+// order) are deactivated. Limits that are unlimited (-1) or not set by the plan are skipped.
+// It runs in one transaction, so over-limit content is never published in between and a
+// failure leaves the earlier enforcement in place.
+func EnforcePlanLimits(ctx context.Context, queries *persistence.Queries, ownerID uuid.UUID) error {
+	tx, err := queries.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	queries = queries.WithTx(tx)
+
+	features, err := EffectiveFeatures(ctx, queries, ownerID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := queries.UnlockRestaurants(ctx, ownerID); err != nil {
+		return fmt.Errorf("failed to restore restaurants: %w", err)
+	}
+	if _, err := queries.UnlockCategories(ctx, ownerID); err != nil {
+		return fmt.Errorf("failed to restore categories: %w", err)
+	}
+	if _, err := queries.UnlockMenuItems(ctx, ownerID); err != nil {
+		return fmt.Errorf("failed to restore menu items: %w", err)
+	}
+
+	var locked int64
+	if features.MaxRestaurants > 0 {
+		n, err := queries.LockRestaurantsOverLimit(ctx, persistence.LockRestaurantsOverLimitParams{
+			OwnerID: ownerID,
+			Keep:    int32(features.MaxRestaurants),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to hide restaurants: %w", err)
+		}
+		locked += n
+	}
+	if features.MaxCategories > 0 {
+		n, err := queries.LockCategoriesOverLimit(ctx, persistence.LockCategoriesOverLimitParams{
+			OwnerID: ownerID,
+			Keep:    int32(features.MaxCategories),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to hide categories: %w", err)
+		}
+		locked += n
+	}
+	if features.MaxMenuItems > 0 {
+		n, err := queries.LockMenuItemsOverLimit(ctx, persistence.LockMenuItemsOverLimitParams{
+			OwnerID: ownerID,
+			Keep:    int32(features.MaxMenuItems),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to hide menu items: %w", err)
+		}
+		locked += n
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit plan limits: %w", err)
+	}
+	if locked > 0 {
+		log.Printf("[SubscriptionService] Hid %d records above the plan limits of owner %v", locked, ownerID)
+	}
+	return nil
+}
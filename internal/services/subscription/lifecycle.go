@@ -0,0 +1,95 @@
+package subscription
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"menuvista/internal/storage/persistence"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// LifecycleInterval is how often RunLifecycle should be scheduled
+const LifecycleInterval = 15 * time.Minute
+
+// freePlanPeriod is the period given to free subscriptions; they do not renew or expire
+const freePlanPeriod = 100
+
+// trialExpiredLookback limits expiry emails to trials that ended recently, so a fresh
+// deployment does not email every trial that ever ended
+const trialExpiredLookback = 7 * 24 * time.Hour
+
+// RunLifecycle moves subscriptions through their end states. Subscriptions the owner
+// cancelled at period end and trials past their end are cancelled, and owners left without
+// an active, trialing or past-due subscription (ended trials, cancellations, and paid plans
+// cancelled after dunning) are put on the free plan with their content above its limits
+// hidden. Paid periods that end are moved to past_due by the dunning worker, which keeps
+// the paid limits until it gives up.
+func (s *Service) RunLifecycle(ctx context.Context) {
+	ended, err := s.queries.CancelSubscriptionsAtPeriodEnd(ctx)
+	if err != nil {
+		log.Printf("[SubscriptionService] Failed to end subscriptions cancelled at period end: %v", err)
+	}
+	for _, sub := range ended {
+		log.Printf("[SubscriptionService] Subscription %v of owner %v reached the end of its period after cancellation", sub.ID, sub.OwnerID)
+	}
+
+	expired, err := s.queries.ExpireTrialSubscriptions(ctx)
+	if err != nil {
+		log.Printf("[SubscriptionService] Failed to expire trials: %v", err)
+	}
+	for _, sub := range expired {
+		log.Printf("[SubscriptionService] Trial %v of owner %v ended, now cancelled", sub.ID, sub.OwnerID)
+		if time.Since(sub.CurrentPeriodEnd.Time) < trialExpiredLookback {
+			s.notifyTrialExpired(ctx, sub)
+		}
+	}
+
+	owners, err := s.queries.ListOwnersWithoutSubscription(ctx)
+	if err != nil {
+		log.Printf("[SubscriptionService] Failed to list owners without a subscription: %v", err)
+		return
+	}
+	for _, ownerID := range owners {
+		if err := s.startFreePlan(ctx, ownerID); err != nil {
+			log.Printf("[SubscriptionService] Failed to move owner %v to the free plan: %v", ownerID, err)
+		}
+	}
+}
+
+func (s *Service) startFreePlan(ctx context.Context, ownerID uuid.UUID) error {
+	plan, err := s.queries.GetSubscriptionPlanBySlug(ctx, FreePlanSlug)
+	if err != nil {
+		return fmt.Errorf("failed to fetch free plan: %w", err)
+	}
+
+	now := time.Now()
+	sub, err := s.queries.CreateSubscription(ctx, persistence.CreateSubscriptionParams{
+		OwnerID:            ownerID,
+		PlanID:             plan.ID,
+		Status:             persistence.SubscriptionStatusActive,
+		CurrentPeriodStart: pgtype.Timestamp{Time: now, Valid: true},
+		CurrentPeriodEnd:   pgtype.Timestamp{Time: now.AddDate(freePlanPeriod, 0, 0), Valid: true},
+		BillingInterval:    persistence.BillingIntervalMonthly,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create free subscription: %w", err)
+	}
+	log.Printf("[SubscriptionService] Owner %v moved to the free plan with subscription %v", ownerID, sub.ID)
+
+	return EnforcePlanLimits(ctx, s.queries, ownerID)
+}
+
+func (s *Service) notifyTrialExpired(ctx context.Context, sub persistence.Subscription) {
+	user, err := s.queries.GetUserByID(ctx, sub.OwnerID)
+	if err != nil {
+		log.Printf("[SubscriptionService] Failed to fetch owner %v for trial expiry email: %v", sub.OwnerID, err)
+		return
+	}
+	s.notifyOnce(ctx, sub.ID, "trial_expired", sub.CurrentPeriodEnd, func() error {
+		return s.emailService.SendTrialExpiredEmail(ctx, user.Email, user.FullName, upgradeURL(""))
+	})
+}
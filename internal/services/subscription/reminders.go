@@ -0,0 +1,122 @@
+package subscription
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"time"
+
+	"menuvista/internal/storage/persistence"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// reminderDays are the warnings sent before a trial or paid period ends, most urgent last
+var reminderDays = []int{7, 3, 1}
+
+// ReminderInterval is how often SendExpiryReminders should be scheduled
+const ReminderInterval = time.Hour
+
+// SendExpiryReminders sends the 7, 3 and 1 day warnings for trials and paid periods about
+// to end. Each one is recorded in the subscription_notifications ledger first, so it goes
+// out once per subscription period however often this runs. Only the most urgent warning
+// due is sent: an owner reached with 2 days left gets the 3 day warning and never the 7
+// day one. The email for an ended trial is sent by RunLifecycle when it cancels the trial.
+func (s *Service) SendExpiryReminders(ctx context.Context) {
+	now := time.Now()
+	horizon := now.Add(time.Duration(reminderDays[0]) * 24 * time.Hour)
+
+	sent := 0
+	for _, status := range []persistence.SubscriptionStatus{persistence.SubscriptionStatusTrialing, persistence.SubscriptionStatusActive} {
+		rows, err := s.queries.ListSubscriptionsEndingBetween(ctx, persistence.ListSubscriptionsEndingBetweenParams{
+			Status:     status,
+			EndsAfter:  pgtype.Timestamp{Time: now, Valid: true},
+			EndsBefore: pgtype.Timestamp{Time: horizon, Valid: true},
+		})
+		if err != nil {
+			log.Printf("[SubscriptionService] Failed to list %s subscriptions ending soon: %v", status, err)
+			continue
+		}
+
+		for _, row := range rows {
+			if status == persistence.SubscriptionStatusActive && row.PriceMonthly == 0 {
+				continue // Free plans do not renew
+			}
+			daysLeft := int(math.Ceil(row.CurrentPeriodEnd.Time.Sub(now).Hours() / 24))
+			threshold := warningThreshold(daysLeft)
+			kind := fmt.Sprintf("renewal_warning_%dd", threshold)
+			if status == persistence.SubscriptionStatusTrialing {
+				kind = fmt.Sprintf("trial_warning_%dd", threshold)
+			}
+
+			if s.notifyOnce(ctx, row.ID, kind, row.CurrentPeriodEnd, func() error {
+				return s.sendWarning(ctx, status, row, daysLeft)
+			}) {
+				sent++
+			}
+		}
+	}
+
+	if sent > 0 {
+		log.Printf("[SubscriptionService] Sent %d subscription reminders", sent)
+	}
+}
+
+// warningThreshold picks the most urgent reminder that applies with daysLeft remaining
+func warningThreshold(daysLeft int) int {
+	threshold := reminderDays[0]
+	for _, days := range reminderDays {
+		if daysLeft <= days {
+			threshold = days
+		}
+	}
+	return threshold
+}
+
+// notifyOnce records the notification in the ledger and sends it only if this call was
+// the one to record it. A failed send is removed from the ledger so the next run retries.
+func (s *Service) notifyOnce(ctx context.Context, subscriptionID uuid.UUID, kind string, periodEnd pgtype.Timestamp, send func() error) bool {
+	params := persistence.RecordSubscriptionNotificationParams{
+		SubscriptionID: subscriptionID,
+		Kind:           kind,
+		PeriodEnd:      periodEnd,
+	}
+	recorded, err := s.queries.RecordSubscriptionNotification(ctx, params)
+	if err != nil {
+		log.Printf("[SubscriptionService] Failed to record %s for subscription %v: %v", kind, subscriptionID, err)
+		return false
+	}
+	if recorded == 0 {
+		return false // Already sent for this period
+	}
+
+	if err := send(); err != nil {
+		log.Printf("[SubscriptionService] Failed to send %s for subscription %v: %v", kind, subscriptionID, err)
+		if err := s.queries.DeleteSubscriptionNotification(ctx, persistence.DeleteSubscriptionNotificationParams(params)); err != nil {
+			log.Printf("[SubscriptionService] Warning: Failed to clear %s from the ledger: %v", kind, err)
+		}
+		return false
+	}
+	return true
+}
+
+func (s *Service) sendWarning(ctx context.Context, status persistence.SubscriptionStatus, row persistence.ListSubscriptionsEndingBetweenRow, daysLeft int) error {
+	endDate := row.CurrentPeriodEnd.Time.Format("January 2, 2006")
+	if status == persistence.SubscriptionStatusTrialing {
+		return s.emailService.SendTrialWarningEmail(ctx, row.Email, row.FullName, endDate, daysLeft,
+			upgradeURL("bronze-monthly"), upgradeURL("silver-monthly"), upgradeURL("gold-monthly"))
+	}
+	return s.emailService.SendSubscriptionExpiringEmail(ctx, row.Email, row.FullName, row.PlanName, endDate, daysLeft, upgradeURL(""))
+}
+
+// upgradeURL links to the billing page, preselecting a plan when given
+func upgradeURL(planSlug string) string {
+	url := os.Getenv("APP_BASE_URL") + "/billing/upgrade"
+	if planSlug != "" {
+		url += "?plan=" + planSlug
+	}
+	return url
+}
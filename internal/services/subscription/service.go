@@ -2,23 +2,30 @@ package subscription
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"time"
 
 	"menuvista/internal/models"
+	"menuvista/internal/services/email"
 	"menuvista/internal/storage/persistence"
 	"menuvista/internal/utils"
 
 	"github.com/google/uuid"
 )
 
+var ErrNoScheduledChange = errors.New("no plan change is scheduled")
+
 type Service struct {
-	queries *persistence.Queries
+	queries      *persistence.Queries
+	emailService *email.Service
 }
 
-func NewService(queries *persistence.Queries) *Service {
+func NewService(queries *persistence.Queries, emailService *email.Service) *Service {
 	return &Service{
-		queries: queries,
+		queries:      queries,
+		emailService: emailService,
 	}
 }
 
@@ -45,7 +52,7 @@ func (s *Service) GetPlanBySlug(ctx context.Context, slug string) (*models.Subsc
 
 func (s *Service) GetSubscriptionByOwner(ctx context.Context, ownerID uuid.UUID) (*models.Subscription, error) {
 	ownerIDStr := ownerID.String()
-	row, err := s.queries.GetActiveSubscriptionByOwner(ctx, utils.ToUUID(&ownerIDStr))
+	row, err := s.queries.GetEffectiveSubscriptionByOwner(ctx, utils.ToUUID(&ownerIDStr))
 	if err != nil {
 		return nil, fmt.Errorf("subscription not found: %w", err)
 	}
@@ -53,10 +60,12 @@ func (s *Service) GetSubscriptionByOwner(ctx context.Context, ownerID uuid.UUID)
 }
 
 func (s *Service) GetSubscriptionDetails(ctx context.Context, ownerID uuid.UUID) (*models.SubscriptionDetailsResponse, error) {
-	sub, err := s.GetSubscriptionByOwner(ctx, ownerID)
+	ownerIDStr := ownerID.String()
+	row, err := s.queries.GetEffectiveSubscriptionByOwner(ctx, utils.ToUUID(&ownerIDStr))
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("subscription not found: %w", err)
 	}
+	sub := s.mapToDomainSubscription(row)
 
 	plan, err := s.GetPlanBySlug(ctx, sub.PlanSlug)
 	if err != nil {
@@ -76,20 +85,53 @@ func (s *Service) GetSubscriptionDetails(ctx context.Context, ownerID uuid.UUID)
 		daysRemaining = 0
 	}
 
+	price := plan.PriceMonthly
+	if sub.BillingInterval == models.BillingIntervalAnnual {
+		price = plan.PriceAnnual
+	}
+
+	var scheduled *models.ScheduledPlanChange
+	if row.ScheduledPlanID != uuid.Nil {
+		if next, err := s.queries.GetSubscriptionPlanByID(ctx, row.ScheduledPlanID); err == nil {
+			scheduled = &models.ScheduledPlanChange{
+				PlanName:        next.Name,
+				PlanSlug:        next.Slug,
+				BillingInterval: models.BillingInterval(row.ScheduledBillingInterval.BillingInterval),
+				EffectiveAt:     sub.CurrentPeriodEnd,
+			}
+		}
+	}
+
 	return &models.SubscriptionDetailsResponse{
-		PlanName:      plan.Name,
-		PlanSlug:      plan.Slug,
-		Price:         plan.PriceMonthly, // Assuming monthly for now
-		Currency:      plan.Currency,
-		Status:        sub.Status,
-		StartDate:     sub.CurrentPeriodStart,
-		EndDate:       sub.CurrentPeriodEnd,
-		TrialEnd:      sub.TrialEnd,
-		DaysRemaining: daysRemaining,
-		Features:      plan.Features,
+		PlanName:          plan.Name,
+		PlanSlug:          plan.Slug,
+		BillingInterval:   sub.BillingInterval,
+		Price:             price,
+		Currency:          plan.Currency,
+		Status:            sub.Status,
+		StartDate:         sub.CurrentPeriodStart,
+		EndDate:           sub.CurrentPeriodEnd,
+		TrialEnd:          sub.TrialEnd,
+		DaysRemaining:     daysRemaining,
+		Features:          plan.Features,
+		ScheduledChange:   scheduled,
+		CancelAtPeriodEnd: sub.CancelAtPeriodEnd,
 	}, nil
 }
 
+// CancelScheduledChange keeps the current plan after a downgrade was scheduled
+func (s *Service) CancelScheduledChange(ctx context.Context, ownerID uuid.UUID) error {
+	rows, err := s.queries.ClearScheduledSubscriptionChange(ctx, ownerID)
+	if err != nil {
+		return fmt.Errorf("failed to cancel scheduled change: %w", err)
+	}
+	if rows == 0 {
+		return ErrNoScheduledChange
+	}
+	log.Printf("[SubscriptionService] Scheduled plan change cancelled for owner %v", ownerID)
+	return nil
+}
+
 func (s *Service) mapToDomainPlan(row persistence.SubscriptionPlan) *models.SubscriptionPlan {
 
 	var features models.FeatureLimits
@@ -111,7 +153,7 @@ func (s *Service) mapToDomainPlan(row persistence.SubscriptionPlan) *models.Subs
 	}
 }
 
-func (s *Service) mapToDomainSubscription(row persistence.GetActiveSubscriptionByOwnerRow) *models.Subscription {
+func (s *Service) mapToDomainSubscription(row persistence.GetEffectiveSubscriptionByOwnerRow) *models.Subscription {
 	id := row.ID
 	ownerID := row.OwnerID
 	planID := row.PlanID
@@ -133,10 +175,12 @@ func (s *Service) mapToDomainSubscription(row persistence.GetActiveSubscriptionB
 		OwnerID:            ownerID,
 		PlanID:             planID,
 		Status:             models.SubscriptionStatus(row.Status),
+		BillingInterval:    models.BillingInterval(row.BillingInterval),
 		CurrentPeriodStart: row.CurrentPeriodStart.Time,
 		CurrentPeriodEnd:   row.CurrentPeriodEnd.Time,
 		TrialEnd:           trialEnd,
 		CancelledAt:        cancelledAt,
+		CancelAtPeriodEnd:  row.CancelAtPeriodEnd,
 		CreatedAt:          row.CreatedAt.Time,
 		UpdatedAt:          row.UpdatedAt.Time,
 		PlanName:           row.PlanName,
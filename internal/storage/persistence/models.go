@@ -12,13 +12,185 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type AuthEvent string
+
+const (
+	AuthEventLoginSuccess    AuthEvent = "login_success"
+	AuthEventLoginFailure    AuthEvent = "login_failure"
+	AuthEventAccountLocked   AuthEvent = "account_locked"
+	AuthEventAccountUnlocked AuthEvent = "account_unlocked"
+)
+
+func (e *AuthEvent) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = AuthEvent(s)
+	case string:
+		*e = AuthEvent(s)
+	default:
+		return fmt.Errorf("unsupported scan type for AuthEvent: %T", src)
+	}
+	return nil
+}
+
+type NullAuthEvent struct {
+	AuthEvent AuthEvent `json:"auth_event"`
+	Valid     bool      `json:"valid"` // Valid is true if AuthEvent is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullAuthEvent) Scan(value interface{}) error {
+	if value == nil {
+		ns.AuthEvent, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.AuthEvent.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullAuthEvent) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.AuthEvent), nil
+}
+
+type BillingInterval string
+
+const (
+	BillingIntervalMonthly BillingInterval = "monthly"
+	BillingIntervalAnnual  BillingInterval = "annual"
+)
+
+func (e *BillingInterval) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = BillingInterval(s)
+	case string:
+		*e = BillingInterval(s)
+	default:
+		return fmt.Errorf("unsupported scan type for BillingInterval: %T", src)
+	}
+	return nil
+}
+
+type NullBillingInterval struct {
+	BillingInterval BillingInterval `json:"billing_interval"`
+	Valid           bool            `json:"valid"` // Valid is true if BillingInterval is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullBillingInterval) Scan(value interface{}) error {
+	if value == nil {
+		ns.BillingInterval, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.BillingInterval.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullBillingInterval) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.BillingInterval), nil
+}
+
+type DiscountType string
+
+const (
+	DiscountTypePercentage DiscountType = "percentage"
+	DiscountTypeFixed      DiscountType = "fixed"
+)
+
+func (e *DiscountType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = DiscountType(s)
+	case string:
+		*e = DiscountType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for DiscountType: %T", src)
+	}
+	return nil
+}
+
+type NullDiscountType struct {
+	DiscountType DiscountType `json:"discount_type"`
+	Valid        bool         `json:"valid"` // Valid is true if DiscountType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullDiscountType) Scan(value interface{}) error {
+	if value == nil {
+		ns.DiscountType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.DiscountType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullDiscountType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.DiscountType), nil
+}
+
+type InvitationStatus string
+
+const (
+	InvitationStatusPending  InvitationStatus = "pending"
+	InvitationStatusAccepted InvitationStatus = "accepted"
+	InvitationStatusRevoked  InvitationStatus = "revoked"
+)
+
+func (e *InvitationStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = InvitationStatus(s)
+	case string:
+		*e = InvitationStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for InvitationStatus: %T", src)
+	}
+	return nil
+}
+
+type NullInvitationStatus struct {
+	InvitationStatus InvitationStatus `json:"invitation_status"`
+	Valid            bool             `json:"valid"` // Valid is true if InvitationStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullInvitationStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.InvitationStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.InvitationStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullInvitationStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.InvitationStatus), nil
+}
+
 type InvoiceStatus string
 
 const (
-	InvoiceStatusPending  InvoiceStatus = "pending"
-	InvoiceStatusPaid     InvoiceStatus = "paid"
-	InvoiceStatusFailed   InvoiceStatus = "failed"
-	InvoiceStatusRefunded InvoiceStatus = "refunded"
+	InvoiceStatusPending   InvoiceStatus = "pending"
+	InvoiceStatusPaid      InvoiceStatus = "paid"
+	InvoiceStatusFailed    InvoiceStatus = "failed"
+	InvoiceStatusRefunded  InvoiceStatus = "refunded"
+	InvoiceStatusCancelled InvoiceStatus = "cancelled"
 )
 
 func (e *InvoiceStatus) Scan(src interface{}) error {
@@ -56,6 +228,179 @@ func (ns NullInvoiceStatus) Value() (driver.Value, error) {
 	return string(ns.InvoiceStatus), nil
 }
 
+type PromotionScope string
+
+const (
+	PromotionScopeRestaurant PromotionScope = "restaurant"
+	PromotionScopeCategory   PromotionScope = "category"
+	PromotionScopeItem       PromotionScope = "item"
+)
+
+func (e *PromotionScope) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = PromotionScope(s)
+	case string:
+		*e = PromotionScope(s)
+	default:
+		return fmt.Errorf("unsupported scan type for PromotionScope: %T", src)
+	}
+	return nil
+}
+
+type NullPromotionScope struct {
+	PromotionScope PromotionScope `json:"promotion_scope"`
+	Valid          bool           `json:"valid"` // Valid is true if PromotionScope is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullPromotionScope) Scan(value interface{}) error {
+	if value == nil {
+		ns.PromotionScope, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.PromotionScope.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullPromotionScope) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.PromotionScope), nil
+}
+
+type ReservationStatus string
+
+const (
+	ReservationStatusPending   ReservationStatus = "pending"
+	ReservationStatusConfirmed ReservationStatus = "confirmed"
+	ReservationStatusSeated    ReservationStatus = "seated"
+	ReservationStatusNoShow    ReservationStatus = "no_show"
+	ReservationStatusCancelled ReservationStatus = "cancelled"
+)
+
+func (e *ReservationStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ReservationStatus(s)
+	case string:
+		*e = ReservationStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ReservationStatus: %T", src)
+	}
+	return nil
+}
+
+type NullReservationStatus struct {
+	ReservationStatus ReservationStatus `json:"reservation_status"`
+	Valid             bool              `json:"valid"` // Valid is true if ReservationStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullReservationStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.ReservationStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ReservationStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullReservationStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ReservationStatus), nil
+}
+
+type ServiceRequestStatus string
+
+const (
+	ServiceRequestStatusOpen         ServiceRequestStatus = "open"
+	ServiceRequestStatusAcknowledged ServiceRequestStatus = "acknowledged"
+)
+
+func (e *ServiceRequestStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ServiceRequestStatus(s)
+	case string:
+		*e = ServiceRequestStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ServiceRequestStatus: %T", src)
+	}
+	return nil
+}
+
+type NullServiceRequestStatus struct {
+	ServiceRequestStatus ServiceRequestStatus `json:"service_request_status"`
+	Valid                bool                 `json:"valid"` // Valid is true if ServiceRequestStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullServiceRequestStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.ServiceRequestStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ServiceRequestStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullServiceRequestStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ServiceRequestStatus), nil
+}
+
+type ServiceRequestType string
+
+const (
+	ServiceRequestTypeCallWaiter  ServiceRequestType = "call_waiter"
+	ServiceRequestTypeRequestBill ServiceRequestType = "request_bill"
+	ServiceRequestTypeCustom      ServiceRequestType = "custom"
+)
+
+func (e *ServiceRequestType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ServiceRequestType(s)
+	case string:
+		*e = ServiceRequestType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ServiceRequestType: %T", src)
+	}
+	return nil
+}
+
+type NullServiceRequestType struct {
+	ServiceRequestType ServiceRequestType `json:"service_request_type"`
+	Valid              bool               `json:"valid"` // Valid is true if ServiceRequestType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullServiceRequestType) Scan(value interface{}) error {
+	if value == nil {
+		ns.ServiceRequestType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ServiceRequestType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullServiceRequestType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ServiceRequestType), nil
+}
+
 type SubscriptionStatus string
 
 const (
@@ -194,6 +539,33 @@ type AnalyticsEvent struct {
 	CreatedAt    pgtype.Timestamp `db:"created_at" json:"created_at"`
 }
 
+type ApiKey struct {
+	ID           uuid.UUID        `db:"id" json:"id"`
+	RestaurantID uuid.UUID        `db:"restaurant_id" json:"restaurant_id"`
+	CreatedBy    uuid.UUID        `db:"created_by" json:"created_by"`
+	Name         string           `db:"name" json:"name"`
+	KeyPrefix    string           `db:"key_prefix" json:"key_prefix"`
+	KeyHash      string           `db:"key_hash" json:"key_hash"`
+	Permissions  []string         `db:"permissions" json:"permissions"`
+	ExpiresAt    pgtype.Timestamp `db:"expires_at" json:"expires_at"`
+	LastUsedAt   pgtype.Timestamp `db:"last_used_at" json:"last_used_at"`
+	LastUsedIp   pgtype.Text      `db:"last_used_ip" json:"last_used_ip"`
+	RevokedAt    pgtype.Timestamp `db:"revoked_at" json:"revoked_at"`
+	CreatedAt    pgtype.Timestamp `db:"created_at" json:"created_at"`
+}
+
+type AuthAuditLog struct {
+	ID        uuid.UUID        `db:"id" json:"id"`
+	UserID    uuid.UUID        `db:"user_id" json:"user_id"`
+	Email     string           `db:"email" json:"email"`
+	Event     AuthEvent        `db:"event" json:"event"`
+	Reason    pgtype.Text      `db:"reason" json:"reason"`
+	IpAddress pgtype.Text      `db:"ip_address" json:"ip_address"`
+	UserAgent pgtype.Text      `db:"user_agent" json:"user_agent"`
+	ActorID   uuid.UUID        `db:"actor_id" json:"actor_id"`
+	CreatedAt pgtype.Timestamp `db:"created_at" json:"created_at"`
+}
+
 type Category struct {
 	ID           uuid.UUID        `db:"id" json:"id"`
 	RestaurantID uuid.UUID        `db:"restaurant_id" json:"restaurant_id"`
@@ -205,13 +577,64 @@ type Category struct {
 	CreatedBy    uuid.UUID        `db:"created_by" json:"created_by"`
 	CreatedAt    pgtype.Timestamp `db:"created_at" json:"created_at"`
 	UpdatedAt    pgtype.Timestamp `db:"updated_at" json:"updated_at"`
+	PlanLocked   bool             `db:"plan_locked" json:"plan_locked"`
+}
+
+type Coupon struct {
+	ID              uuid.UUID        `db:"id" json:"id"`
+	Code            string           `db:"code" json:"code"`
+	Description     pgtype.Text      `db:"description" json:"description"`
+	DiscountType    DiscountType     `db:"discount_type" json:"discount_type"`
+	DiscountValue   pgtype.Numeric   `db:"discount_value" json:"discount_value"`
+	PlanIds         []uuid.UUID      `db:"plan_ids" json:"plan_ids"`
+	MaxRedemptions  pgtype.Int4      `db:"max_redemptions" json:"max_redemptions"`
+	RedemptionCount int32            `db:"redemption_count" json:"redemption_count"`
+	DurationCycles  pgtype.Int4      `db:"duration_cycles" json:"duration_cycles"`
+	ExpiresAt       pgtype.Timestamp `db:"expires_at" json:"expires_at"`
+	IsActive        bool             `db:"is_active" json:"is_active"`
+	CreatedBy       uuid.UUID        `db:"created_by" json:"created_by"`
+	CreatedAt       pgtype.Timestamp `db:"created_at" json:"created_at"`
+	UpdatedAt       pgtype.Timestamp `db:"updated_at" json:"updated_at"`
+}
+
+type CouponRedemption struct {
+	ID             uuid.UUID        `db:"id" json:"id"`
+	CouponID       uuid.UUID        `db:"coupon_id" json:"coupon_id"`
+	OwnerID        uuid.UUID        `db:"owner_id" json:"owner_id"`
+	InvoiceID      uuid.UUID        `db:"invoice_id" json:"invoice_id"`
+	DiscountAmount pgtype.Numeric   `db:"discount_amount" json:"discount_amount"`
+	CreatedAt      pgtype.Timestamp `db:"created_at" json:"created_at"`
+}
+
+type CouponReservation struct {
+	ID        uuid.UUID        `db:"id" json:"id"`
+	CouponID  uuid.UUID        `db:"coupon_id" json:"coupon_id"`
+	OwnerID   uuid.UUID        `db:"owner_id" json:"owner_id"`
+	TxRef     string           `db:"tx_ref" json:"tx_ref"`
+	ExpiresAt pgtype.Timestamp `db:"expires_at" json:"expires_at"`
+	CreatedAt pgtype.Timestamp `db:"created_at" json:"created_at"`
+}
+
+type CreditNote struct {
+	ID                     uuid.UUID        `db:"id" json:"id"`
+	CreditNoteNumber       string           `db:"credit_note_number" json:"credit_note_number"`
+	InvoiceID              uuid.UUID        `db:"invoice_id" json:"invoice_id"`
+	OwnerID                uuid.UUID        `db:"owner_id" json:"owner_id"`
+	Amount                 pgtype.Numeric   `db:"amount" json:"amount"`
+	Currency               string           `db:"currency" json:"currency"`
+	VatRate                pgtype.Numeric   `db:"vat_rate" json:"vat_rate"`
+	Reason                 string           `db:"reason" json:"reason"`
+	ProviderRefundRef      pgtype.Text      `db:"provider_refund_ref" json:"provider_refund_ref"`
+	SubscriptionTerminated bool             `db:"subscription_terminated" json:"subscription_terminated"`
+	CreatedBy              uuid.UUID        `db:"created_by" json:"created_by"`
+	CreatedAt              pgtype.Timestamp `db:"created_at" json:"created_at"`
 }
 
 type Invoice struct {
 	ID                       uuid.UUID        `db:"id" json:"id"`
 	SubscriptionID           uuid.UUID        `db:"subscription_id" json:"subscription_id"`
 	OwnerID                  uuid.UUID        `db:"owner_id" json:"owner_id"`
-	InvoiceNumber            string           `db:"invoice_number" json:"invoice_number"`
+	InvoiceNumber            pgtype.Text      `db:"invoice_number" json:"invoice_number"`
 	Amount                   pgtype.Numeric   `db:"amount" json:"amount"`
 	Currency                 string           `db:"currency" json:"currency"`
 	Status                   InvoiceStatus    `db:"status" json:"status"`
@@ -221,27 +644,43 @@ type Invoice struct {
 	PaidAt                   pgtype.Timestamp `db:"paid_at" json:"paid_at"`
 	CreatedAt                pgtype.Timestamp `db:"created_at" json:"created_at"`
 	UpdatedAt                pgtype.Timestamp `db:"updated_at" json:"updated_at"`
+	Prorated                 bool             `db:"prorated" json:"prorated"`
+	CreditAmount             pgtype.Numeric   `db:"credit_amount" json:"credit_amount"`
+	CouponID                 uuid.UUID        `db:"coupon_id" json:"coupon_id"`
+	DiscountAmount           pgtype.Numeric   `db:"discount_amount" json:"discount_amount"`
+	TxRef                    string           `db:"tx_ref" json:"tx_ref"`
+	VatRate                  pgtype.Numeric   `db:"vat_rate" json:"vat_rate"`
+	RefundedAmount           pgtype.Numeric   `db:"refunded_amount" json:"refunded_amount"`
 }
 
 type MenuItem struct {
-	ID           uuid.UUID        `db:"id" json:"id"`
-	RestaurantID uuid.UUID        `db:"restaurant_id" json:"restaurant_id"`
-	CategoryID   uuid.UUID        `db:"category_id" json:"category_id"`
-	Name         string           `db:"name" json:"name"`
-	Description  pgtype.Text      `db:"description" json:"description"`
-	Price        pgtype.Numeric   `db:"price" json:"price"`
-	Currency     string           `db:"currency" json:"currency"`
-	Images       []byte           `db:"images" json:"images"`
-	Allergens    []byte           `db:"allergens" json:"allergens"`
-	DietaryTags  []byte           `db:"dietary_tags" json:"dietary_tags"`
-	SpiceLevel   pgtype.Int4      `db:"spice_level" json:"spice_level"`
-	Calories     pgtype.Int4      `db:"calories" json:"calories"`
-	IsAvailable  bool             `db:"is_available" json:"is_available"`
-	DisplayOrder int32            `db:"display_order" json:"display_order"`
-	ViewCount    pgtype.Int4      `db:"view_count" json:"view_count"`
-	CreatedBy    uuid.UUID        `db:"created_by" json:"created_by"`
-	CreatedAt    pgtype.Timestamp `db:"created_at" json:"created_at"`
-	UpdatedAt    pgtype.Timestamp `db:"updated_at" json:"updated_at"`
+	ID                     uuid.UUID        `db:"id" json:"id"`
+	RestaurantID           uuid.UUID        `db:"restaurant_id" json:"restaurant_id"`
+	CategoryID             uuid.UUID        `db:"category_id" json:"category_id"`
+	Name                   string           `db:"name" json:"name"`
+	Description            pgtype.Text      `db:"description" json:"description"`
+	Price                  pgtype.Numeric   `db:"price" json:"price"`
+	Currency               string           `db:"currency" json:"currency"`
+	Images                 []byte           `db:"images" json:"images"`
+	Allergens              []byte           `db:"allergens" json:"allergens"`
+	DietaryTags            []byte           `db:"dietary_tags" json:"dietary_tags"`
+	SpiceLevel             pgtype.Int4      `db:"spice_level" json:"spice_level"`
+	Calories               pgtype.Int4      `db:"calories" json:"calories"`
+	IsAvailable            bool             `db:"is_available" json:"is_available"`
+	DisplayOrder           int32            `db:"display_order" json:"display_order"`
+	ViewCount              pgtype.Int4      `db:"view_count" json:"view_count"`
+	CreatedBy              uuid.UUID        `db:"created_by" json:"created_by"`
+	CreatedAt              pgtype.Timestamp `db:"created_at" json:"created_at"`
+	UpdatedAt              pgtype.Timestamp `db:"updated_at" json:"updated_at"`
+	RatingAvg              pgtype.Numeric   `db:"rating_avg" json:"rating_avg"`
+	RatingCount            int32            `db:"rating_count" json:"rating_count"`
+	TrackStock             bool             `db:"track_stock" json:"track_stock"`
+	StockQuantity          int32            `db:"stock_quantity" json:"stock_quantity"`
+	LowStockThreshold      int32            `db:"low_stock_threshold" json:"low_stock_threshold"`
+	LowStockNotifiedAt     pgtype.Timestamp `db:"low_stock_notified_at" json:"low_stock_notified_at"`
+	UnavailableUntil       pgtype.Timestamp `db:"unavailable_until" json:"unavailable_until"`
+	PlanLocked             bool             `db:"plan_locked" json:"plan_locked"`
+	WasAvailableBeforeLock bool             `db:"was_available_before_lock" json:"was_available_before_lock"`
 }
 
 type PaymentRetryJob struct {
@@ -266,6 +705,7 @@ type PaymentTransaction struct {
 	ProviderTransactionRef pgtype.Text      `db:"provider_transaction_ref" json:"provider_transaction_ref"`
 	CreatedAt              pgtype.Timestamp `db:"created_at" json:"created_at"`
 	UpdatedAt              pgtype.Timestamp `db:"updated_at" json:"updated_at"`
+	RefundedAmount         pgtype.Numeric   `db:"refunded_amount" json:"refunded_amount"`
 }
 
 type PaymentWebhook struct {
@@ -277,6 +717,66 @@ type PaymentWebhook struct {
 	CreatedAt       pgtype.Timestamp `db:"created_at" json:"created_at"`
 }
 
+type PlatformSetting struct {
+	ID                    int32            `db:"id" json:"id"`
+	RequireAdminTwoFactor bool             `db:"require_admin_two_factor" json:"require_admin_two_factor"`
+	UpdatedBy             uuid.UUID        `db:"updated_by" json:"updated_by"`
+	UpdatedAt             pgtype.Timestamp `db:"updated_at" json:"updated_at"`
+}
+
+type Promotion struct {
+	ID            uuid.UUID        `db:"id" json:"id"`
+	RestaurantID  uuid.UUID        `db:"restaurant_id" json:"restaurant_id"`
+	Name          string           `db:"name" json:"name"`
+	Label         string           `db:"label" json:"label"`
+	Scope         PromotionScope   `db:"scope" json:"scope"`
+	CategoryID    uuid.UUID        `db:"category_id" json:"category_id"`
+	MenuItemID    uuid.UUID        `db:"menu_item_id" json:"menu_item_id"`
+	DiscountType  DiscountType     `db:"discount_type" json:"discount_type"`
+	DiscountValue pgtype.Numeric   `db:"discount_value" json:"discount_value"`
+	StartsOn      pgtype.Date      `db:"starts_on" json:"starts_on"`
+	EndsOn        pgtype.Date      `db:"ends_on" json:"ends_on"`
+	DaysOfWeek    []int32          `db:"days_of_week" json:"days_of_week"`
+	StartTime     pgtype.Time      `db:"start_time" json:"start_time"`
+	EndTime       pgtype.Time      `db:"end_time" json:"end_time"`
+	IsActive      bool             `db:"is_active" json:"is_active"`
+	CreatedBy     uuid.UUID        `db:"created_by" json:"created_by"`
+	CreatedAt     pgtype.Timestamp `db:"created_at" json:"created_at"`
+	UpdatedAt     pgtype.Timestamp `db:"updated_at" json:"updated_at"`
+}
+
+type Reservation struct {
+	ID                uuid.UUID         `db:"id" json:"id"`
+	RestaurantID      uuid.UUID         `db:"restaurant_id" json:"restaurant_id"`
+	TableID           uuid.UUID         `db:"table_id" json:"table_id"`
+	GuestName         string            `db:"guest_name" json:"guest_name"`
+	GuestPhone        string            `db:"guest_phone" json:"guest_phone"`
+	GuestEmail        string            `db:"guest_email" json:"guest_email"`
+	PartySize         int32             `db:"party_size" json:"party_size"`
+	ReservedAt        pgtype.Timestamp  `db:"reserved_at" json:"reserved_at"`
+	EndsAt            pgtype.Timestamp  `db:"ends_at" json:"ends_at"`
+	Notes             pgtype.Text       `db:"notes" json:"notes"`
+	Status            ReservationStatus `db:"status" json:"status"`
+	ConfirmationToken string            `db:"confirmation_token" json:"confirmation_token"`
+	CancellationToken string            `db:"cancellation_token" json:"cancellation_token"`
+	ConfirmedAt       pgtype.Timestamp  `db:"confirmed_at" json:"confirmed_at"`
+	SeatedAt          pgtype.Timestamp  `db:"seated_at" json:"seated_at"`
+	CancelledAt       pgtype.Timestamp  `db:"cancelled_at" json:"cancelled_at"`
+	CreatedAt         pgtype.Timestamp  `db:"created_at" json:"created_at"`
+	UpdatedAt         pgtype.Timestamp  `db:"updated_at" json:"updated_at"`
+}
+
+type ReservationSetting struct {
+	RestaurantID          uuid.UUID        `db:"restaurant_id" json:"restaurant_id"`
+	IsEnabled             bool             `db:"is_enabled" json:"is_enabled"`
+	SlotIntervalMinutes   int32            `db:"slot_interval_minutes" json:"slot_interval_minutes"`
+	DiningDurationMinutes int32            `db:"dining_duration_minutes" json:"dining_duration_minutes"`
+	MaxPartySize          int32            `db:"max_party_size" json:"max_party_size"`
+	BookingWindowDays     int32            `db:"booking_window_days" json:"booking_window_days"`
+	CreatedAt             pgtype.Timestamp `db:"created_at" json:"created_at"`
+	UpdatedAt             pgtype.Timestamp `db:"updated_at" json:"updated_at"`
+}
+
 type Restaurant struct {
 	ID            uuid.UUID        `db:"id" json:"id"`
 	OwnerID       uuid.UUID        `db:"owner_id" json:"owner_id"`
@@ -298,20 +798,127 @@ type Restaurant struct {
 	RankScore     pgtype.Numeric   `db:"rank_score" json:"rank_score"`
 	CreatedAt     pgtype.Timestamp `db:"created_at" json:"created_at"`
 	UpdatedAt     pgtype.Timestamp `db:"updated_at" json:"updated_at"`
+	RatingAvg     pgtype.Numeric   `db:"rating_avg" json:"rating_avg"`
+	RatingCount   int32            `db:"rating_count" json:"rating_count"`
+	PlanLocked    bool             `db:"plan_locked" json:"plan_locked"`
+	Timezone      string           `db:"timezone" json:"timezone"`
+}
+
+type RestaurantOpeningHour struct {
+	ID           uuid.UUID        `db:"id" json:"id"`
+	RestaurantID uuid.UUID        `db:"restaurant_id" json:"restaurant_id"`
+	DayOfWeek    int32            `db:"day_of_week" json:"day_of_week"`
+	OpensAt      pgtype.Time      `db:"opens_at" json:"opens_at"`
+	ClosesAt     pgtype.Time      `db:"closes_at" json:"closes_at"`
+	CreatedAt    pgtype.Timestamp `db:"created_at" json:"created_at"`
+}
+
+type RestaurantTable struct {
+	ID           uuid.UUID        `db:"id" json:"id"`
+	RestaurantID uuid.UUID        `db:"restaurant_id" json:"restaurant_id"`
+	Label        string           `db:"label" json:"label"`
+	Token        string           `db:"token" json:"token"`
+	IsActive     bool             `db:"is_active" json:"is_active"`
+	CreatedAt    pgtype.Timestamp `db:"created_at" json:"created_at"`
+	UpdatedAt    pgtype.Timestamp `db:"updated_at" json:"updated_at"`
+	Seats        int32            `db:"seats" json:"seats"`
+}
+
+type Review struct {
+	ID           uuid.UUID        `db:"id" json:"id"`
+	RestaurantID uuid.UUID        `db:"restaurant_id" json:"restaurant_id"`
+	MenuItemID   uuid.UUID        `db:"menu_item_id" json:"menu_item_id"`
+	ReviewerName string           `db:"reviewer_name" json:"reviewer_name"`
+	Rating       int32            `db:"rating" json:"rating"`
+	Comment      pgtype.Text      `db:"comment" json:"comment"`
+	PhotoUrl     pgtype.Text      `db:"photo_url" json:"photo_url"`
+	OwnerReply   pgtype.Text      `db:"owner_reply" json:"owner_reply"`
+	RepliedBy    uuid.UUID        `db:"replied_by" json:"replied_by"`
+	RepliedAt    pgtype.Timestamp `db:"replied_at" json:"replied_at"`
+	IsHidden     bool             `db:"is_hidden" json:"is_hidden"`
+	HiddenReason pgtype.Text      `db:"hidden_reason" json:"hidden_reason"`
+	ReportCount  int32            `db:"report_count" json:"report_count"`
+	IpAddress    pgtype.Text      `db:"ip_address" json:"ip_address"`
+	CreatedAt    pgtype.Timestamp `db:"created_at" json:"created_at"`
+	UpdatedAt    pgtype.Timestamp `db:"updated_at" json:"updated_at"`
+}
+
+type ServiceRequest struct {
+	ID             uuid.UUID            `db:"id" json:"id"`
+	RestaurantID   uuid.UUID            `db:"restaurant_id" json:"restaurant_id"`
+	TableID        uuid.UUID            `db:"table_id" json:"table_id"`
+	RequestType    ServiceRequestType   `db:"request_type" json:"request_type"`
+	Note           pgtype.Text          `db:"note" json:"note"`
+	Status         ServiceRequestStatus `db:"status" json:"status"`
+	AcknowledgedBy uuid.UUID            `db:"acknowledged_by" json:"acknowledged_by"`
+	AcknowledgedAt pgtype.Timestamp     `db:"acknowledged_at" json:"acknowledged_at"`
+	CreatedAt      pgtype.Timestamp     `db:"created_at" json:"created_at"`
+}
+
+type StaffInvitation struct {
+	ID             uuid.UUID        `db:"id" json:"id"`
+	RestaurantID   uuid.UUID        `db:"restaurant_id" json:"restaurant_id"`
+	OwnerID        uuid.UUID        `db:"owner_id" json:"owner_id"`
+	Email          string           `db:"email" json:"email"`
+	FullName       string           `db:"full_name" json:"full_name"`
+	Phone          pgtype.Text      `db:"phone" json:"phone"`
+	TokenHash      string           `db:"token_hash" json:"token_hash"`
+	Status         InvitationStatus `db:"status" json:"status"`
+	ExpiresAt      pgtype.Timestamp `db:"expires_at" json:"expires_at"`
+	AcceptedUserID uuid.UUID        `db:"accepted_user_id" json:"accepted_user_id"`
+	AcceptedAt     pgtype.Timestamp `db:"accepted_at" json:"accepted_at"`
+	CreatedAt      pgtype.Timestamp `db:"created_at" json:"created_at"`
+	UpdatedAt      pgtype.Timestamp `db:"updated_at" json:"updated_at"`
+	StaffRoleID    uuid.UUID        `db:"staff_role_id" json:"staff_role_id"`
+}
+
+type StaffRole struct {
+	ID           uuid.UUID        `db:"id" json:"id"`
+	RestaurantID uuid.UUID        `db:"restaurant_id" json:"restaurant_id"`
+	Name         string           `db:"name" json:"name"`
+	Description  pgtype.Text      `db:"description" json:"description"`
+	Permissions  []string         `db:"permissions" json:"permissions"`
+	Preset       pgtype.Text      `db:"preset" json:"preset"`
+	CreatedAt    pgtype.Timestamp `db:"created_at" json:"created_at"`
+	UpdatedAt    pgtype.Timestamp `db:"updated_at" json:"updated_at"`
 }
 
 type Subscription struct {
-	ID                            uuid.UUID          `db:"id" json:"id"`
-	OwnerID                       uuid.UUID          `db:"owner_id" json:"owner_id"`
-	PlanID                        uuid.UUID          `db:"plan_id" json:"plan_id"`
-	Status                        SubscriptionStatus `db:"status" json:"status"`
-	CurrentPeriodStart            pgtype.Timestamp   `db:"current_period_start" json:"current_period_start"`
-	CurrentPeriodEnd              pgtype.Timestamp   `db:"current_period_end" json:"current_period_end"`
-	TrialEnd                      pgtype.Timestamp   `db:"trial_end" json:"trial_end"`
-	CancelledAt                   pgtype.Timestamp   `db:"cancelled_at" json:"cancelled_at"`
-	PaymentProviderSubscriptionID pgtype.Text        `db:"payment_provider_subscription_id" json:"payment_provider_subscription_id"`
-	CreatedAt                     pgtype.Timestamp   `db:"created_at" json:"created_at"`
-	UpdatedAt                     pgtype.Timestamp   `db:"updated_at" json:"updated_at"`
+	ID                            uuid.UUID           `db:"id" json:"id"`
+	OwnerID                       uuid.UUID           `db:"owner_id" json:"owner_id"`
+	PlanID                        uuid.UUID           `db:"plan_id" json:"plan_id"`
+	Status                        SubscriptionStatus  `db:"status" json:"status"`
+	CurrentPeriodStart            pgtype.Timestamp    `db:"current_period_start" json:"current_period_start"`
+	CurrentPeriodEnd              pgtype.Timestamp    `db:"current_period_end" json:"current_period_end"`
+	TrialEnd                      pgtype.Timestamp    `db:"trial_end" json:"trial_end"`
+	CancelledAt                   pgtype.Timestamp    `db:"cancelled_at" json:"cancelled_at"`
+	PaymentProviderSubscriptionID pgtype.Text         `db:"payment_provider_subscription_id" json:"payment_provider_subscription_id"`
+	CreatedAt                     pgtype.Timestamp    `db:"created_at" json:"created_at"`
+	UpdatedAt                     pgtype.Timestamp    `db:"updated_at" json:"updated_at"`
+	BillingInterval               BillingInterval     `db:"billing_interval" json:"billing_interval"`
+	ScheduledPlanID               uuid.UUID           `db:"scheduled_plan_id" json:"scheduled_plan_id"`
+	ScheduledBillingInterval      NullBillingInterval `db:"scheduled_billing_interval" json:"scheduled_billing_interval"`
+	CancelAtPeriodEnd             bool                `db:"cancel_at_period_end" json:"cancel_at_period_end"`
+}
+
+type SubscriptionCancellation struct {
+	ID             uuid.UUID        `db:"id" json:"id"`
+	SubscriptionID uuid.UUID        `db:"subscription_id" json:"subscription_id"`
+	OwnerID        uuid.UUID        `db:"owner_id" json:"owner_id"`
+	PlanID         uuid.UUID        `db:"plan_id" json:"plan_id"`
+	Reason         string           `db:"reason" json:"reason"`
+	Feedback       pgtype.Text      `db:"feedback" json:"feedback"`
+	Immediate      bool             `db:"immediate" json:"immediate"`
+	ResumedAt      pgtype.Timestamp `db:"resumed_at" json:"resumed_at"`
+	CreatedAt      pgtype.Timestamp `db:"created_at" json:"created_at"`
+}
+
+type SubscriptionNotification struct {
+	ID             uuid.UUID        `db:"id" json:"id"`
+	SubscriptionID uuid.UUID        `db:"subscription_id" json:"subscription_id"`
+	Kind           string           `db:"kind" json:"kind"`
+	PeriodEnd      pgtype.Timestamp `db:"period_end" json:"period_end"`
+	SentAt         pgtype.Timestamp `db:"sent_at" json:"sent_at"`
 }
 
 type SubscriptionPlan struct {
@@ -348,4 +955,45 @@ type User struct {
 	VerificationToken          pgtype.Text      `db:"verification_token" json:"verification_token"`
 	VerificationTokenExpiresAt pgtype.Timestamp `db:"verification_token_expires_at" json:"verification_token_expires_at"`
 	TrialEndsAt                pgtype.Timestamp `db:"trial_ends_at" json:"trial_ends_at"`
+	MustChangePassword         bool             `db:"must_change_password" json:"must_change_password"`
+	PasswordChangedAt          pgtype.Timestamp `db:"password_changed_at" json:"password_changed_at"`
+	StaffRoleID                uuid.UUID        `db:"staff_role_id" json:"staff_role_id"`
+}
+
+type UserIdentity struct {
+	ID          uuid.UUID        `db:"id" json:"id"`
+	UserID      uuid.UUID        `db:"user_id" json:"user_id"`
+	Provider    string           `db:"provider" json:"provider"`
+	Subject     string           `db:"subject" json:"subject"`
+	Email       pgtype.Text      `db:"email" json:"email"`
+	CreatedAt   pgtype.Timestamp `db:"created_at" json:"created_at"`
+	LastLoginAt pgtype.Timestamp `db:"last_login_at" json:"last_login_at"`
+}
+
+type UserRecoveryCode struct {
+	ID        uuid.UUID        `db:"id" json:"id"`
+	UserID    uuid.UUID        `db:"user_id" json:"user_id"`
+	CodeHash  string           `db:"code_hash" json:"code_hash"`
+	UsedAt    pgtype.Timestamp `db:"used_at" json:"used_at"`
+	CreatedAt pgtype.Timestamp `db:"created_at" json:"created_at"`
+}
+
+type UserSession struct {
+	ID         uuid.UUID        `db:"id" json:"id"`
+	UserID     uuid.UUID        `db:"user_id" json:"user_id"`
+	IpAddress  pgtype.Text      `db:"ip_address" json:"ip_address"`
+	UserAgent  pgtype.Text      `db:"user_agent" json:"user_agent"`
+	DeviceType pgtype.Text      `db:"device_type" json:"device_type"`
+	Browser    pgtype.Text      `db:"browser" json:"browser"`
+	Os         pgtype.Text      `db:"os" json:"os"`
+	CreatedAt  pgtype.Timestamp `db:"created_at" json:"created_at"`
+	LastSeenAt pgtype.Timestamp `db:"last_seen_at" json:"last_seen_at"`
+	ExpiresAt  pgtype.Timestamp `db:"expires_at" json:"expires_at"`
+	RevokedAt  pgtype.Timestamp `db:"revoked_at" json:"revoked_at"`
+}
+
+type UserTwoFactor struct {
+	UserID    uuid.UUID        `db:"user_id" json:"user_id"`
+	Secret    string           `db:"secret" json:"secret"`
+	EnabledAt pgtype.Timestamp `db:"enabled_at" json:"enabled_at"`
 }
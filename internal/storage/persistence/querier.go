@@ -12,75 +12,223 @@ import (
 )
 
 type Querier interface {
+	AcceptStaffInvitation(ctx context.Context, arg AcceptStaffInvitationParams) (int64, error)
+	AcknowledgeServiceRequest(ctx context.Context, arg AcknowledgeServiceRequestParams) (ServiceRequest, error)
+	AddPaymentTransactionRefund(ctx context.Context, arg AddPaymentTransactionRefundParams) (PaymentTransaction, error)
+	AdjustMenuItemStock(ctx context.Context, arg AdjustMenuItemStockParams) (MenuItem, error)
+	ApplyDueScheduledSubscriptionChanges(ctx context.Context) (int64, error)
+	ApplyScheduledSubscriptionChange(ctx context.Context, ownerID uuid.UUID) (int64, error)
+	AssignStaffRole(ctx context.Context, arg AssignStaffRoleParams) (int64, error)
+	CancelPastDueSubscriptionsByOwner(ctx context.Context, ownerID uuid.UUID) (int64, error)
+	CancelPendingRenewalCheckouts(ctx context.Context, ownerID uuid.UUID) ([]string, error)
+	CancelReservationByToken(ctx context.Context, cancellationToken string) (Reservation, error)
+	CancelSubscriptionAtPeriodEnd(ctx context.Context, id uuid.UUID) (Subscription, error)
+	CancelSubscriptionNow(ctx context.Context, id uuid.UUID) (Subscription, error)
+	CancelSubscriptionsAtPeriodEnd(ctx context.Context) ([]Subscription, error)
+	ClaimPaymentRetryJob(ctx context.Context, arg ClaimPaymentRetryJobParams) (PaymentRetryJob, error)
+	ClearScheduledSubscriptionChange(ctx context.Context, ownerID uuid.UUID) (int64, error)
+	CompletePaymentRetryJobsByOwner(ctx context.Context, ownerID uuid.UUID) error
+	ConfirmReservation(ctx context.Context, confirmationToken string) (Reservation, error)
+	ConsumeCouponReservation(ctx context.Context, arg ConsumeCouponReservationParams) (int64, error)
 	CountActivityLogsWithFilters(ctx context.Context, arg CountActivityLogsWithFiltersParams) (int64, error)
 	CountAnalyticsEventsWithFilters(ctx context.Context, arg CountAnalyticsEventsWithFiltersParams) (int64, error)
 	CountCategoriesByRestaurant(ctx context.Context, restaurantID uuid.UUID) (int64, error)
+	CountCouponCyclesByOwner(ctx context.Context, arg CountCouponCyclesByOwnerParams) (int64, error)
+	CountMenuItemReviews(ctx context.Context, menuItemID uuid.UUID) (int64, error)
 	CountMenuItemsByCategory(ctx context.Context, categoryID uuid.UUID) (int64, error)
 	CountInvoicesWithFilters(ctx context.Context, arg CountInvoicesWithFiltersParams) (int64, error)
 	CountMenuItemsByRestaurant(ctx context.Context, restaurantID uuid.UUID) (int64, error)
+	CountPendingStaffInvitations(ctx context.Context, restaurantID uuid.UUID) (int64, error)
+	CountReportedReviews(ctx context.Context) (int64, error)
+	CountRestaurantReviews(ctx context.Context, restaurantID uuid.UUID) (int64, error)
 	CountRestaurantsWithFilters(ctx context.Context, arg CountRestaurantsWithFiltersParams) (int64, error)
+	CountReviewsByRestaurant(ctx context.Context, restaurantID uuid.UUID) (int64, error)
 	CountStaffByRestaurant(ctx context.Context, restaurantID uuid.UUID) (int64, error)
+	CountStaffByRole(ctx context.Context, staffRoleID uuid.UUID) (int64, error)
+	CountStaffInvitationsByRestaurant(ctx context.Context, restaurantID uuid.UUID) (int64, error)
+	CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (ApiKey, error)
 	CreateActivityLog(ctx context.Context, arg CreateActivityLogParams) (ActivityLog, error)
 	CreateAnalyticsEvent(ctx context.Context, arg CreateAnalyticsEventParams) (AnalyticsEvent, error)
+	CreateAuthAuditLog(ctx context.Context, arg CreateAuthAuditLogParams) error
 	CreateCategory(ctx context.Context, arg CreateCategoryParams) (Category, error)
+	CreateCoupon(ctx context.Context, arg CreateCouponParams) (Coupon, error)
+	CreateCouponRedemption(ctx context.Context, arg CreateCouponRedemptionParams) (int64, error)
+	CreateCouponReservation(ctx context.Context, arg CreateCouponReservationParams) (int64, error)
+	CreateCreditNote(ctx context.Context, arg CreateCreditNoteParams) (CreditNote, error)
 	CreateInvoice(ctx context.Context, arg CreateInvoiceParams) (Invoice, error)
 	CreateMenuItem(ctx context.Context, arg CreateMenuItemParams) (MenuItem, error)
+	CreateOpeningHours(ctx context.Context, arg CreateOpeningHoursParams) (RestaurantOpeningHour, error)
 	CreatePaymentRetryJob(ctx context.Context, arg CreatePaymentRetryJobParams) (PaymentRetryJob, error)
 	CreatePaymentTransaction(ctx context.Context, arg CreatePaymentTransactionParams) (PaymentTransaction, error)
 	CreatePaymentWebhook(ctx context.Context, arg CreatePaymentWebhookParams) (PaymentWebhook, error)
+	CreatePromotion(ctx context.Context, arg CreatePromotionParams) (Promotion, error)
+	CreateRecoveryCode(ctx context.Context, arg CreateRecoveryCodeParams) error
+	CreateReservation(ctx context.Context, arg CreateReservationParams) (Reservation, error)
 	CreateRestaurant(ctx context.Context, arg CreateRestaurantParams) (Restaurant, error)
+	CreateRestaurantTable(ctx context.Context, arg CreateRestaurantTableParams) (RestaurantTable, error)
+	CreateReview(ctx context.Context, arg CreateReviewParams) (Review, error)
+	CreateServiceRequest(ctx context.Context, arg CreateServiceRequestParams) (ServiceRequest, error)
+	CreateStaffInvitation(ctx context.Context, arg CreateStaffInvitationParams) (StaffInvitation, error)
+	CreateStaffRole(ctx context.Context, arg CreateStaffRoleParams) (StaffRole, error)
 	CreateSubscription(ctx context.Context, arg CreateSubscriptionParams) (Subscription, error)
+	CreateSubscriptionCancellation(ctx context.Context, arg CreateSubscriptionCancellationParams) (SubscriptionCancellation, error)
 	CreateSubscriptionPlan(ctx context.Context, arg CreateSubscriptionPlanParams) (SubscriptionPlan, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	CreateUserIdentity(ctx context.Context, arg CreateUserIdentityParams) (UserIdentity, error)
+	DeactivateCoupon(ctx context.Context, id uuid.UUID) (int64, error)
 	DeleteCategory(ctx context.Context, id uuid.UUID) error
 	DeleteMenuItem(ctx context.Context, id uuid.UUID) error
+	DeleteOpeningHoursByRestaurant(ctx context.Context, restaurantID uuid.UUID) error
+	DeletePromotion(ctx context.Context, arg DeletePromotionParams) (int64, error)
+	DeleteRecoveryCodes(ctx context.Context, userID uuid.UUID) error
 	DeleteRestaurant(ctx context.Context, arg DeleteRestaurantParams) error
+	DeleteRestaurantTable(ctx context.Context, arg DeleteRestaurantTableParams) error
 	DeleteStaff(ctx context.Context, arg DeleteStaffParams) error
+	DeleteStaffRole(ctx context.Context, arg DeleteStaffRoleParams) (int64, error)
+	DeleteSubscriptionNotification(ctx context.Context, arg DeleteSubscriptionNotificationParams) error
 	DeleteUser(ctx context.Context, id uuid.UUID) error
+	DeleteUserTwoFactor(ctx context.Context, userID uuid.UUID) error
+	EightySixMenuItem(ctx context.Context, arg EightySixMenuItemParams) (MenuItem, error)
+	ExpireTrialSubscriptions(ctx context.Context) ([]Subscription, error)
+	ExtendCouponReservation(ctx context.Context, arg ExtendCouponReservationParams) (int64, error)
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (ApiKey, error)
 	GetActiveSubscriptionByOwner(ctx context.Context, ownerID uuid.UUID) (GetActiveSubscriptionByOwnerRow, error)
 	GetAdminDashboardStats(ctx context.Context) (GetAdminDashboardStatsRow, error)
 	GetAllAdminEmails(ctx context.Context) ([]string, error)
 	GetAnalyticsAggregates(ctx context.Context, arg GetAnalyticsAggregatesParams) ([]AnalyticsAggregate, error)
 	GetCategoryByID(ctx context.Context, id uuid.UUID) (Category, error)
+	GetCouponByCode(ctx context.Context, code string) (Coupon, error)
+	GetCouponByID(ctx context.Context, id uuid.UUID) (Coupon, error)
+	GetEffectiveSubscriptionByOwner(ctx context.Context, ownerID uuid.UUID) (GetEffectiveSubscriptionByOwnerRow, error)
+	GetInvoiceByID(ctx context.Context, id uuid.UUID) (Invoice, error)
+	GetInvoiceDocument(ctx context.Context, id uuid.UUID) (GetInvoiceDocumentRow, error)
+	GetLatestCouponRedemptionByOwner(ctx context.Context, ownerID uuid.UUID) (CouponRedemption, error)
 	GetLatestSubscriptionByOwner(ctx context.Context, ownerID uuid.UUID) (GetLatestSubscriptionByOwnerRow, error)
 	GetMenuItemByID(ctx context.Context, id uuid.UUID) (MenuItem, error)
 	GetPaymentTransactionByTxRef(ctx context.Context, txRef string) (PaymentTransaction, error)
+	GetPendingStaffInvitationByEmail(ctx context.Context, arg GetPendingStaffInvitationByEmailParams) (StaffInvitation, error)
+	GetPlatformSettings(ctx context.Context) (PlatformSetting, error)
+	GetPromotionByID(ctx context.Context, arg GetPromotionByIDParams) (Promotion, error)
 	GetRecentAdminLogs(ctx context.Context, limit int32) ([]GetRecentAdminLogsRow, error)
+	GetReservationByCancellationToken(ctx context.Context, cancellationToken string) (Reservation, error)
+	GetReservationByID(ctx context.Context, arg GetReservationByIDParams) (Reservation, error)
+	GetReservationSettings(ctx context.Context, restaurantID uuid.UUID) (ReservationSetting, error)
 	GetRestaurantByID(ctx context.Context, id uuid.UUID) (Restaurant, error)
 	GetRestaurantBySlug(ctx context.Context, slug string) (Restaurant, error)
 	GetRestaurantDetailsForAdmin(ctx context.Context, id uuid.UUID) (GetRestaurantDetailsForAdminRow, error)
+	GetRestaurantTableByToken(ctx context.Context, token string) (RestaurantTable, error)
+	GetReviewByID(ctx context.Context, id uuid.UUID) (Review, error)
+	GetServiceRequestAckStats(ctx context.Context, arg GetServiceRequestAckStatsParams) (GetServiceRequestAckStatsRow, error)
+	GetStaffInvitationByID(ctx context.Context, arg GetStaffInvitationByIDParams) (StaffInvitation, error)
+	GetStaffInvitationByTokenHash(ctx context.Context, tokenHash string) (StaffInvitation, error)
+	GetStaffPermissions(ctx context.Context, id uuid.UUID) (GetStaffPermissionsRow, error)
+	GetStaffRole(ctx context.Context, arg GetStaffRoleParams) (StaffRole, error)
+	GetStaffRoleByPreset(ctx context.Context, arg GetStaffRoleByPresetParams) (StaffRole, error)
+	GetSubscriptionByID(ctx context.Context, id uuid.UUID) (Subscription, error)
+	GetSubscriptionPlanByID(ctx context.Context, id uuid.UUID) (SubscriptionPlan, error)
 	GetSubscriptionPlanBySlug(ctx context.Context, slug string) (SubscriptionPlan, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserByID(ctx context.Context, id uuid.UUID) (User, error)
+	GetUserIdentity(ctx context.Context, arg GetUserIdentityParams) (UserIdentity, error)
+	GetUserSession(ctx context.Context, arg GetUserSessionParams) (UserSession, error)
+	GetUserTwoFactor(ctx context.Context, userID uuid.UUID) (UserTwoFactor, error)
+	HasCouponReservation(ctx context.Context, arg HasCouponReservationParams) (bool, error)
+	IncrementCouponRedemptions(ctx context.Context, id uuid.UUID) (int64, error)
 	IncrementMenuItemViewCount(ctx context.Context, id uuid.UUID) error
 	IncrementRestaurantViewCount(ctx context.Context, id uuid.UUID) error
+	KeepLockedMenuItemUnavailable(ctx context.Context, id uuid.UUID) error
+	ListAPIKeysByRestaurant(ctx context.Context, restaurantID uuid.UUID) ([]ApiKey, error)
+	ListActiveUserSessions(ctx context.Context, userID uuid.UUID) ([]UserSession, error)
 	ListActivityLogsByRestaurant(ctx context.Context, arg ListActivityLogsByRestaurantParams) ([]ListActivityLogsByRestaurantRow, error)
 	ListActivityLogsWithFilters(ctx context.Context, arg ListActivityLogsWithFiltersParams) ([]ListActivityLogsWithFiltersRow, error)
 	ListAnalyticsEventsWithFilters(ctx context.Context, arg ListAnalyticsEventsWithFiltersParams) ([]AnalyticsEvent, error)
+	ListBlockingReservations(ctx context.Context, arg ListBlockingReservationsParams) ([]Reservation, error)
 	ListCategoriesByRestaurant(ctx context.Context, restaurantID uuid.UUID) ([]Category, error)
+	ListCouponRedemptions(ctx context.Context, couponID uuid.UUID) ([]ListCouponRedemptionsRow, error)
+	ListCoupons(ctx context.Context) ([]Coupon, error)
+	ListCreditNotesByInvoice(ctx context.Context, invoiceID uuid.UUID) ([]CreditNote, error)
+	ListCurrentPromotions(ctx context.Context, arg ListCurrentPromotionsParams) ([]Promotion, error)
+	ListDuePaymentRetryJobs(ctx context.Context, limit int32) ([]PaymentRetryJob, error)
 	ListInvoicesByOwner(ctx context.Context, ownerID uuid.UUID) ([]Invoice, error)
 	ListInvoicesWithFilters(ctx context.Context, arg ListInvoicesWithFiltersParams) ([]Invoice, error)
+	ListLowStockMenuItems(ctx context.Context, restaurantID uuid.UUID) ([]MenuItem, error)
+	ListMenuItemReviews(ctx context.Context, arg ListMenuItemReviewsParams) ([]Review, error)
 	ListMenuItemsByCategory(ctx context.Context, categoryID uuid.UUID) ([]MenuItem, error)
 	ListMenuItemsByRestaurant(ctx context.Context, restaurantID uuid.UUID) ([]MenuItem, error)
+	ListOpenServiceRequests(ctx context.Context, restaurantID uuid.UUID) ([]ListOpenServiceRequestsRow, error)
+	ListOpeningHoursByRestaurant(ctx context.Context, restaurantID uuid.UUID) ([]RestaurantOpeningHour, error)
+	ListOwnersWithoutSubscription(ctx context.Context) ([]uuid.UUID, error)
+	ListPromotionsByRestaurant(ctx context.Context, restaurantID uuid.UUID) ([]Promotion, error)
+	ListReportedReviews(ctx context.Context, arg ListReportedReviewsParams) ([]Review, error)
+	ListReservationsForDay(ctx context.Context, arg ListReservationsForDayParams) ([]ListReservationsForDayRow, error)
+	ListRestaurantReviews(ctx context.Context, arg ListRestaurantReviewsParams) ([]Review, error)
+	ListRestaurantTables(ctx context.Context, restaurantID uuid.UUID) ([]RestaurantTable, error)
 	ListRestaurantsByOwner(ctx context.Context, ownerID uuid.UUID) ([]Restaurant, error)
 	ListRestaurantsWithFilters(ctx context.Context, arg ListRestaurantsWithFiltersParams) ([]Restaurant, error)
+	ListReviewsByRestaurant(ctx context.Context, arg ListReviewsByRestaurantParams) ([]ListReviewsByRestaurantRow, error)
 	ListStaffByOwner(ctx context.Context, ownerID uuid.UUID) ([]User, error)
 	ListStaffByRestaurant(ctx context.Context, arg ListStaffByRestaurantParams) ([]User, error)
+	ListStaffInvitationsByRestaurant(ctx context.Context, arg ListStaffInvitationsByRestaurantParams) ([]StaffInvitation, error)
+	ListStaffRolesByRestaurant(ctx context.Context, restaurantID uuid.UUID) ([]ListStaffRolesByRestaurantRow, error)
 	ListSubscriptionPlans(ctx context.Context) ([]SubscriptionPlan, error)
+	ListSubscriptionsEndingBetween(ctx context.Context, arg ListSubscriptionsEndingBetweenParams) ([]ListSubscriptionsEndingBetweenRow, error)
+	ListUnusedRecoveryCodes(ctx context.Context, userID uuid.UUID) ([]UserRecoveryCode, error)
 	ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error)
 	ListUsersWithFilters(ctx context.Context, arg ListUsersWithFiltersParams) ([]User, error)
+	LockCategoriesOverLimit(ctx context.Context, arg LockCategoriesOverLimitParams) (int64, error)
+	LockMenuItemsOverLimit(ctx context.Context, arg LockMenuItemsOverLimitParams) (int64, error)
+	LockRestaurantsOverLimit(ctx context.Context, arg LockRestaurantsOverLimitParams) (int64, error)
+	MarkExpiredSubscriptionsPastDue(ctx context.Context) ([]Subscription, error)
+	MarkLowStockNotified(ctx context.Context, id uuid.UUID) (int64, error)
+	MarkRecoveryCodeUsed(ctx context.Context, id uuid.UUID) (int64, error)
+	MarkSubscriptionCancellationResumed(ctx context.Context, subscriptionID uuid.UUID) error
 	MarkWebhookAsProcessed(ctx context.Context, providerEventID pgtype.Text) error
+	RecordSubscriptionNotification(ctx context.Context, arg RecordSubscriptionNotificationParams) (int64, error)
+	RefreshMenuItemRating(ctx context.Context, id uuid.UUID) error
+	RefreshRestaurantRating(ctx context.Context, id uuid.UUID) error
+	ReleaseCouponReservation(ctx context.Context, txRef string) (int64, error)
+	ReleaseExpiredCouponReservations(ctx context.Context) (int64, error)
+	ReleaseInvoiceRefund(ctx context.Context, arg ReleaseInvoiceRefundParams) error
+	RenewStaffInvitation(ctx context.Context, arg RenewStaffInvitationParams) (StaffInvitation, error)
+	ReplyToReview(ctx context.Context, arg ReplyToReviewParams) (Review, error)
+	ReportReview(ctx context.Context, id uuid.UUID) error
+	RequireUserPasswordChange(ctx context.Context, id uuid.UUID) error
+	ReserveInvoiceRefund(ctx context.Context, arg ReserveInvoiceRefundParams) (Invoice, error)
+	RestoreExpiredEightySixedItems(ctx context.Context, arg RestoreExpiredEightySixedItemsParams) (int64, error)
+	RestoreMenuItemAvailability(ctx context.Context, id uuid.UUID) (MenuItem, error)
+	ResumeSubscriptionByOwner(ctx context.Context, ownerID uuid.UUID) (Subscription, error)
+	RevokeAPIKey(ctx context.Context, arg RevokeAPIKeyParams) (int64, error)
+	RevokeStaffInvitation(ctx context.Context, arg RevokeStaffInvitationParams) (int64, error)
+	RevokeUserSession(ctx context.Context, id uuid.UUID) error
+	ScheduleSubscriptionChange(ctx context.Context, arg ScheduleSubscriptionChangeParams) error
+	SetPaymentRetryJobStatus(ctx context.Context, arg SetPaymentRetryJobStatusParams) error
+	SetReviewHidden(ctx context.Context, arg SetReviewHiddenParams) (Review, error)
+	TouchAPIKey(ctx context.Context, arg TouchAPIKeyParams) error
+	TouchUserIdentity(ctx context.Context, id uuid.UUID) error
+	UnlockCategories(ctx context.Context, ownerID uuid.UUID) (int64, error)
+	UnlockMenuItems(ctx context.Context, ownerID uuid.UUID) (int64, error)
+	UnlockRestaurants(ctx context.Context, ownerID uuid.UUID) (int64, error)
 	UpdateCategory(ctx context.Context, arg UpdateCategoryParams) (Category, error)
+	UpdateCoupon(ctx context.Context, arg UpdateCouponParams) (Coupon, error)
 	UpdateInvoiceStatus(ctx context.Context, arg UpdateInvoiceStatusParams) (Invoice, error)
 	UpdateMenuItem(ctx context.Context, arg UpdateMenuItemParams) (MenuItem, error)
+	UpdateMenuItemStock(ctx context.Context, arg UpdateMenuItemStockParams) (MenuItem, error)
 	UpdateOldSubscriptionsStatus(ctx context.Context, arg UpdateOldSubscriptionsStatusParams) error
 	UpdatePaymentRetryJob(ctx context.Context, arg UpdatePaymentRetryJobParams) (PaymentRetryJob, error)
 	UpdatePaymentTransactionStatus(ctx context.Context, arg UpdatePaymentTransactionStatusParams) (PaymentTransaction, error)
+	UpdatePlatformSettings(ctx context.Context, arg UpdatePlatformSettingsParams) (PlatformSetting, error)
+	UpdatePromotion(ctx context.Context, arg UpdatePromotionParams) (Promotion, error)
+	UpdateReservationStatus(ctx context.Context, arg UpdateReservationStatusParams) (Reservation, error)
 	UpdateRestaurant(ctx context.Context, arg UpdateRestaurantParams) (Restaurant, error)
+	UpdateStaffRole(ctx context.Context, arg UpdateStaffRoleParams) (StaffRole, error)
 	UpdateStaffStatus(ctx context.Context, arg UpdateStaffStatusParams) error
 	UpdateSubscription(ctx context.Context, arg UpdateSubscriptionParams) (Subscription, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error
 	UpsertAnalyticsAggregate(ctx context.Context, arg UpsertAnalyticsAggregateParams) (AnalyticsAggregate, error)
+	UpsertReservationSettings(ctx context.Context, arg UpsertReservationSettingsParams) (ReservationSetting, error)
+	UpsertUserSession(ctx context.Context, arg UpsertUserSessionParams) error
+	UpsertUserTwoFactor(ctx context.Context, arg UpsertUserTwoFactorParams) (UserTwoFactor, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -13,1001 +13,1016 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const countActivityLogsWithFilters = `-- name: CountActivityLogsWithFilters :one
-SELECT COUNT(*)
-FROM activity_logs al
-WHERE 
-    ($1::uuid IS NULL OR al.restaurant_id = $1) AND
-    ($2::uuid IS NULL OR al.user_id = $2) AND
-    ($3::text IS NULL OR al.action_type = $3) AND
-    ($4::text IS NULL OR al.action_category = $4) AND
-    ($5::text IS NULL OR al.target_type = $5) AND
-    ($6::uuid IS NULL OR al.target_id = $6) AND
-    ($7::boolean IS NULL OR al.success = $7) AND
-    ($8::text IS NULL OR al.description ILIKE '%' || $8 || '%' OR al.target_name ILIKE '%' || $8 || '%')
+const acceptStaffInvitation = `-- name: AcceptStaffInvitation :execrows
+UPDATE staff_invitations
+SET status = 'accepted', accepted_user_id = $2, accepted_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND status = 'pending'
 `
 
-type CountActivityLogsWithFiltersParams struct {
-	RestaurantID   uuid.UUID   `db:"restaurant_id" json:"restaurant_id"`
-	UserID         uuid.UUID   `db:"user_id" json:"user_id"`
-	ActionType     pgtype.Text `db:"action_type" json:"action_type"`
-	ActionCategory pgtype.Text `db:"action_category" json:"action_category"`
-	TargetType     pgtype.Text `db:"target_type" json:"target_type"`
-	TargetID       uuid.UUID   `db:"target_id" json:"target_id"`
-	Success        pgtype.Bool `db:"success" json:"success"`
-	Search         pgtype.Text `db:"search" json:"search"`
+type AcceptStaffInvitationParams struct {
+	ID             uuid.UUID `db:"id" json:"id"`
+	AcceptedUserID uuid.UUID `db:"accepted_user_id" json:"accepted_user_id"`
 }
 
-func (q *Queries) CountActivityLogsWithFilters(ctx context.Context, arg CountActivityLogsWithFiltersParams) (int64, error) {
-	row := q.db.QueryRow(ctx, countActivityLogsWithFilters,
-		arg.RestaurantID,
-		arg.UserID,
-		arg.ActionType,
-		arg.ActionCategory,
-		arg.TargetType,
-		arg.TargetID,
-		arg.Success,
-		arg.Search,
-	)
-	var count int64
-	err := row.Scan(&count)
-	return count, err
+func (q *Queries) AcceptStaffInvitation(ctx context.Context, arg AcceptStaffInvitationParams) (int64, error) {
+	result, err := q.db.Exec(ctx, acceptStaffInvitation, arg.ID, arg.AcceptedUserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-const countAnalyticsEventsWithFilters = `-- name: CountAnalyticsEventsWithFilters :one
-SELECT COUNT(*) FROM analytics_events
-WHERE 
-    ($1::uuid IS NULL OR restaurant_id = $1) AND
-    ($2::text IS NULL OR event_type = $2) AND
-    ($3::text IS NULL OR visitor_id = $3) AND
-    ($4::uuid IS NULL OR session_id = $4) AND
-    ($5::uuid IS NULL OR target_id = $5) AND
-    ($6::text IS NULL OR country = $6) AND
-    ($7::text IS NULL OR city = $7)
+const acknowledgeServiceRequest = `-- name: AcknowledgeServiceRequest :one
+UPDATE service_requests
+SET
+    status = 'acknowledged',
+    acknowledged_by = $3,
+    acknowledged_at = NOW()
+WHERE id = $1 AND restaurant_id = $2 AND status = 'open'
+RETURNING id, restaurant_id, table_id, request_type, note, status, acknowledged_by, acknowledged_at, created_at
 `
 
-type CountAnalyticsEventsWithFiltersParams struct {
-	RestaurantID uuid.UUID   `db:"restaurant_id" json:"restaurant_id"`
-	EventType    pgtype.Text `db:"event_type" json:"event_type"`
-	VisitorID    pgtype.Text `db:"visitor_id" json:"visitor_id"`
-	SessionID    uuid.UUID   `db:"session_id" json:"session_id"`
-	TargetID     uuid.UUID   `db:"target_id" json:"target_id"`
-	Country      pgtype.Text `db:"country" json:"country"`
-	City         pgtype.Text `db:"city" json:"city"`
+type AcknowledgeServiceRequestParams struct {
+	ID             uuid.UUID `db:"id" json:"id"`
+	RestaurantID   uuid.UUID `db:"restaurant_id" json:"restaurant_id"`
+	AcknowledgedBy uuid.UUID `db:"acknowledged_by" json:"acknowledged_by"`
 }
 
-func (q *Queries) CountAnalyticsEventsWithFilters(ctx context.Context, arg CountAnalyticsEventsWithFiltersParams) (int64, error) {
-	row := q.db.QueryRow(ctx, countAnalyticsEventsWithFilters,
-		arg.RestaurantID,
-		arg.EventType,
-		arg.VisitorID,
-		arg.SessionID,
-		arg.TargetID,
-		arg.Country,
-		arg.City,
+func (q *Queries) AcknowledgeServiceRequest(ctx context.Context, arg AcknowledgeServiceRequestParams) (ServiceRequest, error) {
+	row := q.db.QueryRow(ctx, acknowledgeServiceRequest, arg.ID, arg.RestaurantID, arg.AcknowledgedBy)
+	var i ServiceRequest
+	err := row.Scan(
+		&i.ID,
+		&i.RestaurantID,
+		&i.TableID,
+		&i.RequestType,
+		&i.Note,
+		&i.Status,
+		&i.AcknowledgedBy,
+		&i.AcknowledgedAt,
+		&i.CreatedAt,
 	)
-	var count int64
-	err := row.Scan(&count)
-	return count, err
+	return i, err
 }
 
-const countCategoriesByRestaurant = `-- name: CountCategoriesByRestaurant :one
-SELECT COUNT(*) FROM categories
-WHERE restaurant_id = $1
+const addPaymentTransactionRefund = `-- name: AddPaymentTransactionRefund :one
+UPDATE payment_transactions
+SET
+    refunded_amount = refunded_amount + $1,
+    status = CASE WHEN refunded_amount + $1 >= amount THEN 'refunded' ELSE 'partially_refunded' END,
+    updated_at = NOW()
+WHERE tx_ref = $2
+RETURNING id, owner_id, amount, currency, status, tx_ref, reference, provider_transaction_ref, created_at, updated_at, refunded_amount
 `
 
-func (q *Queries) CountCategoriesByRestaurant(ctx context.Context, restaurantID uuid.UUID) (int64, error) {
-	row := q.db.QueryRow(ctx, countCategoriesByRestaurant, restaurantID)
-	var count int64
-	err := row.Scan(&count)
-	return count, err
+type AddPaymentTransactionRefundParams struct {
+	Amount pgtype.Numeric `db:"amount" json:"amount"`
+	TxRef  string         `db:"tx_ref" json:"tx_ref"`
 }
 
-const countInvoicesWithFilters = `-- name: CountInvoicesWithFilters :one
-SELECT COUNT(*) FROM invoices
-WHERE 
-    ($1::uuid IS NULL OR owner_id = $1) AND
-    ($2::uuid IS NULL OR subscription_id = $2) AND
-    ($3::invoice_status IS NULL OR status = $3)
+func (q *Queries) AddPaymentTransactionRefund(ctx context.Context, arg AddPaymentTransactionRefundParams) (PaymentTransaction, error) {
+	row := q.db.QueryRow(ctx, addPaymentTransactionRefund, arg.Amount, arg.TxRef)
+	var i PaymentTransaction
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.Amount,
+		&i.Currency,
+		&i.Status,
+		&i.TxRef,
+		&i.Reference,
+		&i.ProviderTransactionRef,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RefundedAmount,
+	)
+	return i, err
+}
+
+const adjustMenuItemStock = `-- name: AdjustMenuItemStock :one
+UPDATE menu_items
+SET
+    stock_quantity = GREATEST(stock_quantity + $1, 0),
+    is_available = CASE
+        WHEN stock_quantity + $1 <= 0 THEN FALSE
+        WHEN stock_quantity = 0 AND unavailable_until IS NULL AND NOT plan_locked THEN TRUE
+        ELSE is_available
+    END,
+    was_available_before_lock = CASE
+        WHEN plan_locked AND stock_quantity = 0 AND stock_quantity + $1 > 0 AND unavailable_until IS NULL THEN TRUE
+        ELSE was_available_before_lock
+    END,
+    low_stock_notified_at = CASE WHEN stock_quantity + $1 > low_stock_threshold THEN NULL ELSE low_stock_notified_at END,
+    updated_at = NOW()
+WHERE id = $2 AND track_stock = TRUE AND deleted_at IS NULL
+RETURNING id, restaurant_id, category_id, name, description, price, currency, images, allergens, dietary_tags, spice_level, calories, is_available, display_order, view_count, created_by, created_at, updated_at, rating_avg, rating_count, track_stock, stock_quantity, low_stock_threshold, low_stock_notified_at, unavailable_until, plan_locked, was_available_before_lock
 `
 
-type CountInvoicesWithFiltersParams struct {
-	OwnerID        uuid.UUID         `db:"owner_id" json:"owner_id"`
-	SubscriptionID uuid.UUID         `db:"subscription_id" json:"subscription_id"`
-	Status         NullInvoiceStatus `db:"status" json:"status"`
+type AdjustMenuItemStockParams struct {
+	Delta int32     `db:"delta" json:"delta"`
+	ID    uuid.UUID `db:"id" json:"id"`
 }
 
-func (q *Queries) CountInvoicesWithFilters(ctx context.Context, arg CountInvoicesWithFiltersParams) (int64, error) {
-	row := q.db.QueryRow(ctx, countInvoicesWithFilters, arg.OwnerID, arg.SubscriptionID, arg.Status)
-	var count int64
-	err := row.Scan(&count)
-	return count, err
+func (q *Queries) AdjustMenuItemStock(ctx context.Context, arg AdjustMenuItemStockParams) (MenuItem, error) {
+	row := q.db.QueryRow(ctx, adjustMenuItemStock, arg.Delta, arg.ID)
+	var i MenuItem
+	err := row.Scan(
+		&i.ID,
+		&i.RestaurantID,
+		&i.CategoryID,
+		&i.Name,
+		&i.Description,
+		&i.Price,
+		&i.Currency,
+		&i.Images,
+		&i.Allergens,
+		&i.DietaryTags,
+		&i.SpiceLevel,
+		&i.Calories,
+		&i.IsAvailable,
+		&i.DisplayOrder,
+		&i.ViewCount,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RatingAvg,
+		&i.RatingCount,
+		&i.TrackStock,
+		&i.StockQuantity,
+		&i.LowStockThreshold,
+		&i.LowStockNotifiedAt,
+		&i.UnavailableUntil,
+		&i.PlanLocked,
+		&i.WasAvailableBeforeLock,
+	)
+	return i, err
 }
 
-const countMenuItemsByCategory = `-- name: CountMenuItemsByCategory :one
-SELECT COUNT(*) FROM menu_items
-WHERE category_id = $1 
+const applyDueScheduledSubscriptionChanges = `-- name: ApplyDueScheduledSubscriptionChanges :execrows
+UPDATE subscriptions
+SET
+    plan_id = scheduled_plan_id,
+    billing_interval = COALESCE(scheduled_billing_interval, billing_interval),
+    scheduled_plan_id = NULL,
+    scheduled_billing_interval = NULL,
+    updated_at = NOW()
+WHERE status = 'active' AND scheduled_plan_id IS NOT NULL AND current_period_end <= NOW()
 `
 
-func (q *Queries) CountMenuItemsByCategory(ctx context.Context, categoryID uuid.UUID) (int64, error) {
-	row := q.db.QueryRow(ctx, countMenuItemsByCategory, categoryID)
-	var count int64
-	err := row.Scan(&count)
-	return count, err
+func (q *Queries) ApplyDueScheduledSubscriptionChanges(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, applyDueScheduledSubscriptionChanges)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-const countMenuItemsByRestaurant = `-- name: CountMenuItemsByRestaurant :one
-SELECT COUNT(*) FROM menu_items
-WHERE restaurant_id = $1 
+const applyScheduledSubscriptionChange = `-- name: ApplyScheduledSubscriptionChange :execrows
+UPDATE subscriptions
+SET
+    plan_id = scheduled_plan_id,
+    billing_interval = COALESCE(scheduled_billing_interval, billing_interval),
+    scheduled_plan_id = NULL,
+    scheduled_billing_interval = NULL,
+    updated_at = NOW()
+WHERE owner_id = $1 AND status = 'active' AND scheduled_plan_id IS NOT NULL AND current_period_end <= NOW()
 `
 
-func (q *Queries) CountMenuItemsByRestaurant(ctx context.Context, restaurantID uuid.UUID) (int64, error) {
-	row := q.db.QueryRow(ctx, countMenuItemsByRestaurant, restaurantID)
-	var count int64
-	err := row.Scan(&count)
-	return count, err
+func (q *Queries) ApplyScheduledSubscriptionChange(ctx context.Context, ownerID uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, applyScheduledSubscriptionChange, ownerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-
-const countRestaurantsWithFilters = `-- name: CountRestaurantsWithFilters :one
-SELECT COUNT(*) FROM restaurants
-WHERE 
-    ($1::uuid IS NULL OR owner_id = $1) AND
-    ($2::text IS NULL OR cuisine_type = $2) AND
-    ($3::text IS NULL OR city = $3) AND
-    ($4::text IS NULL OR country = $4) AND
-    ($5::boolean IS NULL OR is_published = $5) AND
-    ($6::text IS NULL OR name ILIKE '%' || $6 || '%')
+const assignStaffRole = `-- name: AssignStaffRole :execrows
+UPDATE users
+SET staff_role_id = $3, updated_at = NOW()
+WHERE id = $1 AND restaurant_id = $2 AND role = 'staff' AND deleted_at IS NULL
 `
 
-type CountRestaurantsWithFiltersParams struct {
-	OwnerID     uuid.UUID   `db:"owner_id" json:"owner_id"`
-	CuisineType pgtype.Text `db:"cuisine_type" json:"cuisine_type"`
-	City        pgtype.Text `db:"city" json:"city"`
-	Country     pgtype.Text `db:"country" json:"country"`
-	IsPublished pgtype.Bool `db:"is_published" json:"is_published"`
-	Search      pgtype.Text `db:"search" json:"search"`
+type AssignStaffRoleParams struct {
+	ID           uuid.UUID `db:"id" json:"id"`
+	RestaurantID uuid.UUID `db:"restaurant_id" json:"restaurant_id"`
+	StaffRoleID  uuid.UUID `db:"staff_role_id" json:"staff_role_id"`
 }
 
-func (q *Queries) CountRestaurantsWithFilters(ctx context.Context, arg CountRestaurantsWithFiltersParams) (int64, error) {
-	row := q.db.QueryRow(ctx, countRestaurantsWithFilters,
-		arg.OwnerID,
-		arg.CuisineType,
-		arg.City,
-		arg.Country,
-		arg.IsPublished,
-		arg.Search,
-	)
-	var count int64
-	err := row.Scan(&count)
-	return count, err
+func (q *Queries) AssignStaffRole(ctx context.Context, arg AssignStaffRoleParams) (int64, error) {
+	result, err := q.db.Exec(ctx, assignStaffRole, arg.ID, arg.RestaurantID, arg.StaffRoleID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-const countStaffByRestaurant = `-- name: CountStaffByRestaurant :one
-SELECT COUNT(*) FROM users
-WHERE restaurant_id = $1 AND role = 'staff' 
+const cancelPastDueSubscriptionsByOwner = `-- name: CancelPastDueSubscriptionsByOwner :execrows
+UPDATE subscriptions
+SET status = 'cancelled', cancelled_at = NOW(), updated_at = NOW()
+WHERE owner_id = $1 AND status = 'past_due'
 `
 
-func (q *Queries) CountStaffByRestaurant(ctx context.Context, restaurantID uuid.UUID) (int64, error) {
-	row := q.db.QueryRow(ctx, countStaffByRestaurant, restaurantID)
-	var count int64
-	err := row.Scan(&count)
-	return count, err
+func (q *Queries) CancelPastDueSubscriptionsByOwner(ctx context.Context, ownerID uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, cancelPastDueSubscriptionsByOwner, ownerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-const createActivityLog = `-- name: CreateActivityLog :one
-INSERT INTO activity_logs (
-    restaurant_id, user_id, action_type, action_category, description, target_type, target_id, target_name, before_value, after_value, ip_address, user_agent, device_type, browser, os, success
-) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16
-) RETURNING id, restaurant_id, user_id, action_type, action_category, description, target_type, target_id, target_name, before_value, after_value, ip_address, user_agent, device_type, browser, os, success, created_at
+const cancelPendingRenewalCheckouts = `-- name: CancelPendingRenewalCheckouts :many
+WITH cancelled AS (
+    UPDATE payment_transactions
+    SET status = 'cancelled', updated_at = NOW()
+    WHERE owner_id = $1 AND status = 'pending' AND reference LIKE 'renewal:%'
+    RETURNING tx_ref
+)
+UPDATE invoices
+SET status = 'cancelled', updated_at = NOW()
+WHERE tx_ref IN (SELECT tx_ref FROM cancelled) AND status = 'pending'
+RETURNING tx_ref
 `
 
-type CreateActivityLogParams struct {
-	RestaurantID   uuid.UUID   `db:"restaurant_id" json:"restaurant_id"`
-	UserID         uuid.UUID   `db:"user_id" json:"user_id"`
-	ActionType     string      `db:"action_type" json:"action_type"`
-	ActionCategory string      `db:"action_category" json:"action_category"`
-	Description    pgtype.Text `db:"description" json:"description"`
-	TargetType     pgtype.Text `db:"target_type" json:"target_type"`
-	TargetID       uuid.UUID   `db:"target_id" json:"target_id"`
-	TargetName     pgtype.Text `db:"target_name" json:"target_name"`
-	BeforeValue    []byte      `db:"before_value" json:"before_value"`
-	AfterValue     []byte      `db:"after_value" json:"after_value"`
-	IpAddress      pgtype.Text `db:"ip_address" json:"ip_address"`
-	UserAgent      pgtype.Text `db:"user_agent" json:"user_agent"`
-	DeviceType     pgtype.Text `db:"device_type" json:"device_type"`
-	Browser        pgtype.Text `db:"browser" json:"browser"`
-	Os             pgtype.Text `db:"os" json:"os"`
-	Success        pgtype.Bool `db:"success" json:"success"`
+func (q *Queries) CancelPendingRenewalCheckouts(ctx context.Context, ownerID uuid.UUID) ([]string, error) {
+	rows, err := q.db.Query(ctx, cancelPendingRenewalCheckouts, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var tx_ref string
+		if err := rows.Scan(&tx_ref); err != nil {
+			return nil, err
+		}
+		items = append(items, tx_ref)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-func (q *Queries) CreateActivityLog(ctx context.Context, arg CreateActivityLogParams) (ActivityLog, error) {
-	row := q.db.QueryRow(ctx, createActivityLog,
-		arg.RestaurantID,
-		arg.UserID,
-		arg.ActionType,
-		arg.ActionCategory,
-		arg.Description,
-		arg.TargetType,
-		arg.TargetID,
-		arg.TargetName,
-		arg.BeforeValue,
-		arg.AfterValue,
-		arg.IpAddress,
-		arg.UserAgent,
-		arg.DeviceType,
-		arg.Browser,
-		arg.Os,
-		arg.Success,
-	)
-	var i ActivityLog
+const cancelReservationByToken = `-- name: CancelReservationByToken :one
+UPDATE reservations
+SET
+    status = 'cancelled',
+    cancelled_at = NOW(),
+    updated_at = NOW()
+WHERE cancellation_token = $1 AND status IN ('pending', 'confirmed')
+RETURNING id, restaurant_id, table_id, guest_name, guest_phone, guest_email, party_size, reserved_at, ends_at, notes, status, confirmation_token, cancellation_token, confirmed_at, seated_at, cancelled_at, created_at, updated_at
+`
+
+func (q *Queries) CancelReservationByToken(ctx context.Context, cancellationToken string) (Reservation, error) {
+	row := q.db.QueryRow(ctx, cancelReservationByToken, cancellationToken)
+	var i Reservation
 	err := row.Scan(
 		&i.ID,
 		&i.RestaurantID,
-		&i.UserID,
-		&i.ActionType,
-		&i.ActionCategory,
-		&i.Description,
-		&i.TargetType,
-		&i.TargetID,
-		&i.TargetName,
-		&i.BeforeValue,
-		&i.AfterValue,
-		&i.IpAddress,
-		&i.UserAgent,
-		&i.DeviceType,
-		&i.Browser,
-		&i.Os,
-		&i.Success,
+		&i.TableID,
+		&i.GuestName,
+		&i.GuestPhone,
+		&i.GuestEmail,
+		&i.PartySize,
+		&i.ReservedAt,
+		&i.EndsAt,
+		&i.Notes,
+		&i.Status,
+		&i.ConfirmationToken,
+		&i.CancellationToken,
+		&i.ConfirmedAt,
+		&i.SeatedAt,
+		&i.CancelledAt,
 		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
 
-const createAnalyticsEvent = `-- name: CreateAnalyticsEvent :one
-INSERT INTO analytics_events (
-    restaurant_id, event_type, visitor_id, session_id, target_id, ip_address, device_type, browser, os, country, city
-) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
-) RETURNING id, restaurant_id, event_type, visitor_id, session_id, target_id, ip_address, device_type, browser, os, country, city, created_at
+const cancelSubscriptionAtPeriodEnd = `-- name: CancelSubscriptionAtPeriodEnd :one
+UPDATE subscriptions
+SET cancel_at_period_end = TRUE, scheduled_plan_id = NULL, scheduled_billing_interval = NULL, updated_at = NOW()
+WHERE id = $1 AND status IN ('active', 'trialing') AND cancel_at_period_end = FALSE
+RETURNING id, owner_id, plan_id, status, current_period_start, current_period_end, trial_end, cancelled_at, payment_provider_subscription_id, created_at, updated_at, billing_interval, scheduled_plan_id, scheduled_billing_interval, cancel_at_period_end
 `
 
-type CreateAnalyticsEventParams struct {
-	RestaurantID uuid.UUID   `db:"restaurant_id" json:"restaurant_id"`
-	EventType    string      `db:"event_type" json:"event_type"`
-	VisitorID    string      `db:"visitor_id" json:"visitor_id"`
-	SessionID    uuid.UUID   `db:"session_id" json:"session_id"`
-	TargetID     uuid.UUID   `db:"target_id" json:"target_id"`
-	IpAddress    pgtype.Text `db:"ip_address" json:"ip_address"`
-	DeviceType   pgtype.Text `db:"device_type" json:"device_type"`
-	Browser      pgtype.Text `db:"browser" json:"browser"`
-	Os           pgtype.Text `db:"os" json:"os"`
-	Country      pgtype.Text `db:"country" json:"country"`
-	City         pgtype.Text `db:"city" json:"city"`
-}
-
-func (q *Queries) CreateAnalyticsEvent(ctx context.Context, arg CreateAnalyticsEventParams) (AnalyticsEvent, error) {
-	row := q.db.QueryRow(ctx, createAnalyticsEvent,
-		arg.RestaurantID,
-		arg.EventType,
-		arg.VisitorID,
-		arg.SessionID,
-		arg.TargetID,
-		arg.IpAddress,
-		arg.DeviceType,
-		arg.Browser,
-		arg.Os,
-		arg.Country,
-		arg.City,
-	)
-	var i AnalyticsEvent
+func (q *Queries) CancelSubscriptionAtPeriodEnd(ctx context.Context, id uuid.UUID) (Subscription, error) {
+	row := q.db.QueryRow(ctx, cancelSubscriptionAtPeriodEnd, id)
+	var i Subscription
 	err := row.Scan(
 		&i.ID,
-		&i.RestaurantID,
-		&i.EventType,
-		&i.VisitorID,
-		&i.SessionID,
-		&i.TargetID,
-		&i.IpAddress,
-		&i.DeviceType,
-		&i.Browser,
-		&i.Os,
-		&i.Country,
-		&i.City,
+		&i.OwnerID,
+		&i.PlanID,
+		&i.Status,
+		&i.CurrentPeriodStart,
+		&i.CurrentPeriodEnd,
+		&i.TrialEnd,
+		&i.CancelledAt,
+		&i.PaymentProviderSubscriptionID,
 		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.BillingInterval,
+		&i.ScheduledPlanID,
+		&i.ScheduledBillingInterval,
+		&i.CancelAtPeriodEnd,
 	)
 	return i, err
 }
 
-const createCategory = `-- name: CreateCategory :one
-INSERT INTO categories (
-    restaurant_id, name, description, icon, display_order, is_active, created_by
-) VALUES (
-    $1, $2, $3, $4, $5, $6, $7
-) RETURNING id, restaurant_id, name, description, icon, display_order, is_active, created_by, created_at, updated_at
+const cancelSubscriptionNow = `-- name: CancelSubscriptionNow :one
+UPDATE subscriptions
+SET status = 'cancelled', cancelled_at = NOW(), cancel_at_period_end = FALSE,
+    scheduled_plan_id = NULL, scheduled_billing_interval = NULL, updated_at = NOW()
+WHERE id = $1 AND status IN ('active', 'trialing', 'past_due')
+RETURNING id, owner_id, plan_id, status, current_period_start, current_period_end, trial_end, cancelled_at, payment_provider_subscription_id, created_at, updated_at, billing_interval, scheduled_plan_id, scheduled_billing_interval, cancel_at_period_end
 `
 
-type CreateCategoryParams struct {
-	RestaurantID uuid.UUID   `db:"restaurant_id" json:"restaurant_id"`
-	Name         string      `db:"name" json:"name"`
-	Description  pgtype.Text `db:"description" json:"description"`
-	Icon         pgtype.Text `db:"icon" json:"icon"`
-	DisplayOrder int32       `db:"display_order" json:"display_order"`
-	IsActive     bool        `db:"is_active" json:"is_active"`
-	CreatedBy    uuid.UUID   `db:"created_by" json:"created_by"`
-}
-
-func (q *Queries) CreateCategory(ctx context.Context, arg CreateCategoryParams) (Category, error) {
-	row := q.db.QueryRow(ctx, createCategory,
-		arg.RestaurantID,
-		arg.Name,
-		arg.Description,
-		arg.Icon,
-		arg.DisplayOrder,
-		arg.IsActive,
-		arg.CreatedBy,
-	)
-	var i Category
+func (q *Queries) CancelSubscriptionNow(ctx context.Context, id uuid.UUID) (Subscription, error) {
+	row := q.db.QueryRow(ctx, cancelSubscriptionNow, id)
+	var i Subscription
 	err := row.Scan(
 		&i.ID,
-		&i.RestaurantID,
-		&i.Name,
-		&i.Description,
-		&i.Icon,
-		&i.DisplayOrder,
-		&i.IsActive,
-		&i.CreatedBy,
+		&i.OwnerID,
+		&i.PlanID,
+		&i.Status,
+		&i.CurrentPeriodStart,
+		&i.CurrentPeriodEnd,
+		&i.TrialEnd,
+		&i.CancelledAt,
+		&i.PaymentProviderSubscriptionID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BillingInterval,
+		&i.ScheduledPlanID,
+		&i.ScheduledBillingInterval,
+		&i.CancelAtPeriodEnd,
 	)
 	return i, err
 }
 
-const createInvoice = `-- name: CreateInvoice :one
-INSERT INTO invoices (
-    subscription_id, owner_id, invoice_number, amount, currency, status, billing_period_start, billing_period_end
-) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8
-) RETURNING id, subscription_id, owner_id, invoice_number, amount, currency, status, billing_period_start, billing_period_end, payment_provider_invoice_id, paid_at, created_at, updated_at
+const cancelSubscriptionsAtPeriodEnd = `-- name: CancelSubscriptionsAtPeriodEnd :many
+UPDATE subscriptions
+SET status = 'cancelled', cancelled_at = NOW(), cancel_at_period_end = FALSE, updated_at = NOW()
+WHERE status IN ('active', 'trialing') AND cancel_at_period_end = TRUE AND current_period_end <= NOW()
+RETURNING id, owner_id, plan_id, status, current_period_start, current_period_end, trial_end, cancelled_at, payment_provider_subscription_id, created_at, updated_at, billing_interval, scheduled_plan_id, scheduled_billing_interval, cancel_at_period_end
 `
 
-type CreateInvoiceParams struct {
-	SubscriptionID     uuid.UUID        `db:"subscription_id" json:"subscription_id"`
-	OwnerID            uuid.UUID        `db:"owner_id" json:"owner_id"`
-	InvoiceNumber      string           `db:"invoice_number" json:"invoice_number"`
-	Amount             pgtype.Numeric   `db:"amount" json:"amount"`
-	Currency           string           `db:"currency" json:"currency"`
-	Status             InvoiceStatus    `db:"status" json:"status"`
-	BillingPeriodStart pgtype.Timestamp `db:"billing_period_start" json:"billing_period_start"`
-	BillingPeriodEnd   pgtype.Timestamp `db:"billing_period_end" json:"billing_period_end"`
+func (q *Queries) CancelSubscriptionsAtPeriodEnd(ctx context.Context) ([]Subscription, error) {
+	rows, err := q.db.Query(ctx, cancelSubscriptionsAtPeriodEnd)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Subscription
+	for rows.Next() {
+		var i Subscription
+		if err := rows.Scan(
+			&i.ID,
+			&i.OwnerID,
+			&i.PlanID,
+			&i.Status,
+			&i.CurrentPeriodStart,
+			&i.CurrentPeriodEnd,
+			&i.TrialEnd,
+			&i.CancelledAt,
+			&i.PaymentProviderSubscriptionID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.BillingInterval,
+			&i.ScheduledPlanID,
+			&i.ScheduledBillingInterval,
+			&i.CancelAtPeriodEnd,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-func (q *Queries) CreateInvoice(ctx context.Context, arg CreateInvoiceParams) (Invoice, error) {
-	row := q.db.QueryRow(ctx, createInvoice,
-		arg.SubscriptionID,
-		arg.OwnerID,
-		arg.InvoiceNumber,
-		arg.Amount,
-		arg.Currency,
-		arg.Status,
-		arg.BillingPeriodStart,
-		arg.BillingPeriodEnd,
-	)
-	var i Invoice
+const claimPaymentRetryJob = `-- name: ClaimPaymentRetryJob :one
+UPDATE payment_retry_jobs
+SET status = 'retrying', scheduled_for = $2, updated_at = NOW()
+WHERE id = $1 AND status IN ('pending', 'retrying') AND scheduled_for <= NOW()
+RETURNING id, subscription_id, status, retry_count, max_retries, scheduled_for, created_at, updated_at
+`
+
+type ClaimPaymentRetryJobParams struct {
+	ID           uuid.UUID        `db:"id" json:"id"`
+	ScheduledFor pgtype.Timestamp `db:"scheduled_for" json:"scheduled_for"`
+}
+
+func (q *Queries) ClaimPaymentRetryJob(ctx context.Context, arg ClaimPaymentRetryJobParams) (PaymentRetryJob, error) {
+	row := q.db.QueryRow(ctx, claimPaymentRetryJob, arg.ID, arg.ScheduledFor)
+	var i PaymentRetryJob
 	err := row.Scan(
 		&i.ID,
 		&i.SubscriptionID,
-		&i.OwnerID,
-		&i.InvoiceNumber,
-		&i.Amount,
-		&i.Currency,
 		&i.Status,
-		&i.BillingPeriodStart,
-		&i.BillingPeriodEnd,
-		&i.PaymentProviderInvoiceID,
-		&i.PaidAt,
+		&i.RetryCount,
+		&i.MaxRetries,
+		&i.ScheduledFor,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
 	return i, err
 }
 
-const createMenuItem = `-- name: CreateMenuItem :one
-INSERT INTO menu_items (
-    restaurant_id, category_id, name, description, price, currency, images, allergens, dietary_tags, spice_level, calories, is_available, display_order, created_by
-) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
-) RETURNING id, restaurant_id, category_id, name, description, price, currency, images, allergens, dietary_tags, spice_level, calories, is_available, display_order, view_count, created_by, created_at, updated_at
+const clearScheduledSubscriptionChange = `-- name: ClearScheduledSubscriptionChange :execrows
+UPDATE subscriptions
+SET scheduled_plan_id = NULL, scheduled_billing_interval = NULL, updated_at = NOW()
+WHERE owner_id = $1 AND status = 'active' AND scheduled_plan_id IS NOT NULL
 `
 
-type CreateMenuItemParams struct {
-	RestaurantID uuid.UUID      `db:"restaurant_id" json:"restaurant_id"`
-	CategoryID   uuid.UUID      `db:"category_id" json:"category_id"`
-	Name         string         `db:"name" json:"name"`
-	Description  pgtype.Text    `db:"description" json:"description"`
-	Price        pgtype.Numeric `db:"price" json:"price"`
-	Currency     string         `db:"currency" json:"currency"`
-	Images       []byte         `db:"images" json:"images"`
-	Allergens    []byte         `db:"allergens" json:"allergens"`
-	DietaryTags  []byte         `db:"dietary_tags" json:"dietary_tags"`
-	SpiceLevel   pgtype.Int4    `db:"spice_level" json:"spice_level"`
-	Calories     pgtype.Int4    `db:"calories" json:"calories"`
-	IsAvailable  bool           `db:"is_available" json:"is_available"`
-	DisplayOrder int32          `db:"display_order" json:"display_order"`
-	CreatedBy    uuid.UUID      `db:"created_by" json:"created_by"`
+func (q *Queries) ClearScheduledSubscriptionChange(ctx context.Context, ownerID uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, clearScheduledSubscriptionChange, ownerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-func (q *Queries) CreateMenuItem(ctx context.Context, arg CreateMenuItemParams) (MenuItem, error) {
-	row := q.db.QueryRow(ctx, createMenuItem,
-		arg.RestaurantID,
-		arg.CategoryID,
-		arg.Name,
-		arg.Description,
-		arg.Price,
-		arg.Currency,
-		arg.Images,
-		arg.Allergens,
-		arg.DietaryTags,
-		arg.SpiceLevel,
-		arg.Calories,
-		arg.IsAvailable,
-		arg.DisplayOrder,
-		arg.CreatedBy,
-	)
-	var i MenuItem
+const completePaymentRetryJobsByOwner = `-- name: CompletePaymentRetryJobsByOwner :exec
+UPDATE payment_retry_jobs
+SET status = 'completed', updated_at = NOW()
+WHERE status IN ('pending', 'retrying')
+  AND subscription_id IN (SELECT id FROM subscriptions WHERE owner_id = $1)
+`
+
+func (q *Queries) CompletePaymentRetryJobsByOwner(ctx context.Context, ownerID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, completePaymentRetryJobsByOwner, ownerID)
+	return err
+}
+
+const confirmReservation = `-- name: ConfirmReservation :one
+UPDATE reservations
+SET
+    status = 'confirmed',
+    confirmed_at = NOW(),
+    updated_at = NOW()
+WHERE confirmation_token = $1 AND status = 'pending'
+RETURNING id, restaurant_id, table_id, guest_name, guest_phone, guest_email, party_size, reserved_at, ends_at, notes, status, confirmation_token, cancellation_token, confirmed_at, seated_at, cancelled_at, created_at, updated_at
+`
+
+func (q *Queries) ConfirmReservation(ctx context.Context, confirmationToken string) (Reservation, error) {
+	row := q.db.QueryRow(ctx, confirmReservation, confirmationToken)
+	var i Reservation
 	err := row.Scan(
 		&i.ID,
 		&i.RestaurantID,
-		&i.CategoryID,
-		&i.Name,
-		&i.Description,
-		&i.Price,
-		&i.Currency,
-		&i.Images,
-		&i.Allergens,
-		&i.DietaryTags,
-		&i.SpiceLevel,
-		&i.Calories,
-		&i.IsAvailable,
-		&i.DisplayOrder,
-		&i.ViewCount,
-		&i.CreatedBy,
+		&i.TableID,
+		&i.GuestName,
+		&i.GuestPhone,
+		&i.GuestEmail,
+		&i.PartySize,
+		&i.ReservedAt,
+		&i.EndsAt,
+		&i.Notes,
+		&i.Status,
+		&i.ConfirmationToken,
+		&i.CancellationToken,
+		&i.ConfirmedAt,
+		&i.SeatedAt,
+		&i.CancelledAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
 	return i, err
 }
 
-const createPaymentRetryJob = `-- name: CreatePaymentRetryJob :one
-INSERT INTO payment_retry_jobs (
-    subscription_id, scheduled_for
-) VALUES (
-    $1, $2
-) RETURNING id, subscription_id, status, retry_count, max_retries, scheduled_for, created_at, updated_at
+const consumeCouponReservation = `-- name: ConsumeCouponReservation :execrows
+DELETE FROM coupon_reservations
+WHERE coupon_id = $1 AND owner_id = $2
 `
 
-type CreatePaymentRetryJobParams struct {
-	SubscriptionID uuid.UUID        `db:"subscription_id" json:"subscription_id"`
-	ScheduledFor   pgtype.Timestamp `db:"scheduled_for" json:"scheduled_for"`
+type ConsumeCouponReservationParams struct {
+	CouponID uuid.UUID `db:"coupon_id" json:"coupon_id"`
+	OwnerID  uuid.UUID `db:"owner_id" json:"owner_id"`
 }
 
-func (q *Queries) CreatePaymentRetryJob(ctx context.Context, arg CreatePaymentRetryJobParams) (PaymentRetryJob, error) {
-	row := q.db.QueryRow(ctx, createPaymentRetryJob, arg.SubscriptionID, arg.ScheduledFor)
-	var i PaymentRetryJob
-	err := row.Scan(
-		&i.ID,
-		&i.SubscriptionID,
-		&i.Status,
-		&i.RetryCount,
-		&i.MaxRetries,
-		&i.ScheduledFor,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-	)
-	return i, err
+func (q *Queries) ConsumeCouponReservation(ctx context.Context, arg ConsumeCouponReservationParams) (int64, error) {
+	result, err := q.db.Exec(ctx, consumeCouponReservation, arg.CouponID, arg.OwnerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-const createPaymentTransaction = `-- name: CreatePaymentTransaction :one
-INSERT INTO payment_transactions (
-    owner_id, amount, currency, status, tx_ref, reference
-) VALUES (
-    $1, $2, $3, $4, $5, $6
-) RETURNING id, owner_id, amount, currency, status, tx_ref, reference, provider_transaction_ref, created_at, updated_at
+const countActivityLogsWithFilters = `-- name: CountActivityLogsWithFilters :one
+SELECT COUNT(*)
+FROM activity_logs al
+WHERE 
+    ($1::uuid IS NULL OR al.restaurant_id = $1) AND
+    ($2::uuid IS NULL OR al.user_id = $2) AND
+    ($3::text IS NULL OR al.action_type = $3) AND
+    ($4::text IS NULL OR al.action_category = $4) AND
+    ($5::text IS NULL OR al.target_type = $5) AND
+    ($6::uuid IS NULL OR al.target_id = $6) AND
+    ($7::boolean IS NULL OR al.success = $7) AND
+    ($8::text IS NULL OR al.description ILIKE '%' || $8 || '%' OR al.target_name ILIKE '%' || $8 || '%')
 `
 
-type CreatePaymentTransactionParams struct {
-	OwnerID   uuid.UUID      `db:"owner_id" json:"owner_id"`
-	Amount    pgtype.Numeric `db:"amount" json:"amount"`
-	Currency  string         `db:"currency" json:"currency"`
-	Status    string         `db:"status" json:"status"`
-	TxRef     string         `db:"tx_ref" json:"tx_ref"`
-	Reference pgtype.Text    `db:"reference" json:"reference"`
+type CountActivityLogsWithFiltersParams struct {
+	RestaurantID   uuid.UUID   `db:"restaurant_id" json:"restaurant_id"`
+	UserID         uuid.UUID   `db:"user_id" json:"user_id"`
+	ActionType     pgtype.Text `db:"action_type" json:"action_type"`
+	ActionCategory pgtype.Text `db:"action_category" json:"action_category"`
+	TargetType     pgtype.Text `db:"target_type" json:"target_type"`
+	TargetID       uuid.UUID   `db:"target_id" json:"target_id"`
+	Success        pgtype.Bool `db:"success" json:"success"`
+	Search         pgtype.Text `db:"search" json:"search"`
 }
 
-func (q *Queries) CreatePaymentTransaction(ctx context.Context, arg CreatePaymentTransactionParams) (PaymentTransaction, error) {
-	row := q.db.QueryRow(ctx, createPaymentTransaction,
-		arg.OwnerID,
-		arg.Amount,
-		arg.Currency,
-		arg.Status,
-		arg.TxRef,
-		arg.Reference,
-	)
-	var i PaymentTransaction
-	err := row.Scan(
-		&i.ID,
-		&i.OwnerID,
-		&i.Amount,
-		&i.Currency,
-		&i.Status,
-		&i.TxRef,
-		&i.Reference,
-		&i.ProviderTransactionRef,
-		&i.CreatedAt,
-		&i.UpdatedAt,
+func (q *Queries) CountActivityLogsWithFilters(ctx context.Context, arg CountActivityLogsWithFiltersParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countActivityLogsWithFilters,
+		arg.RestaurantID,
+		arg.UserID,
+		arg.ActionType,
+		arg.ActionCategory,
+		arg.TargetType,
+		arg.TargetID,
+		arg.Success,
+		arg.Search,
 	)
-	return i, err
+	var count int64
+	err := row.Scan(&count)
+	return count, err
 }
 
-const createPaymentWebhook = `-- name: CreatePaymentWebhook :one
-INSERT INTO payment_webhooks (
-    provider_event_id, event_type, payload
-) VALUES (
-    $1, $2, $3
-) ON CONFLICT (provider_event_id) DO NOTHING
-RETURNING id, provider_event_id, event_type, payload, processed, created_at
+const countAnalyticsEventsWithFilters = `-- name: CountAnalyticsEventsWithFilters :one
+SELECT COUNT(*) FROM analytics_events
+WHERE 
+    ($1::uuid IS NULL OR restaurant_id = $1) AND
+    ($2::text IS NULL OR event_type = $2) AND
+    ($3::text IS NULL OR visitor_id = $3) AND
+    ($4::uuid IS NULL OR session_id = $4) AND
+    ($5::uuid IS NULL OR target_id = $5) AND
+    ($6::text IS NULL OR country = $6) AND
+    ($7::text IS NULL OR city = $7)
 `
 
-type CreatePaymentWebhookParams struct {
-	ProviderEventID pgtype.Text `db:"provider_event_id" json:"provider_event_id"`
-	EventType       string      `db:"event_type" json:"event_type"`
-	Payload         []byte      `db:"payload" json:"payload"`
+type CountAnalyticsEventsWithFiltersParams struct {
+	RestaurantID uuid.UUID   `db:"restaurant_id" json:"restaurant_id"`
+	EventType    pgtype.Text `db:"event_type" json:"event_type"`
+	VisitorID    pgtype.Text `db:"visitor_id" json:"visitor_id"`
+	SessionID    uuid.UUID   `db:"session_id" json:"session_id"`
+	TargetID     uuid.UUID   `db:"target_id" json:"target_id"`
+	Country      pgtype.Text `db:"country" json:"country"`
+	City         pgtype.Text `db:"city" json:"city"`
 }
 
-func (q *Queries) CreatePaymentWebhook(ctx context.Context, arg CreatePaymentWebhookParams) (PaymentWebhook, error) {
-	row := q.db.QueryRow(ctx, createPaymentWebhook, arg.ProviderEventID, arg.EventType, arg.Payload)
-	var i PaymentWebhook
-	err := row.Scan(
-		&i.ID,
-		&i.ProviderEventID,
-		&i.EventType,
-		&i.Payload,
-		&i.Processed,
-		&i.CreatedAt,
+func (q *Queries) CountAnalyticsEventsWithFilters(ctx context.Context, arg CountAnalyticsEventsWithFiltersParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countAnalyticsEventsWithFilters,
+		arg.RestaurantID,
+		arg.EventType,
+		arg.VisitorID,
+		arg.SessionID,
+		arg.TargetID,
+		arg.Country,
+		arg.City,
 	)
-	return i, err
+	var count int64
+	err := row.Scan(&count)
+	return count, err
 }
 
-const createRestaurant = `-- name: CreateRestaurant :one
-INSERT INTO restaurants (
-    owner_id, name, slug, description, cuisine_type, phone, email, website, address, city, country, logo_url, cover_image_url, theme_settings
-) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
-) RETURNING id, owner_id, name, slug, description, cuisine_type, phone, email, website, address, city, country, logo_url, cover_image_url, theme_settings, is_published, view_count, rank_score, created_at, updated_at
+const countCategoriesByRestaurant = `-- name: CountCategoriesByRestaurant :one
+SELECT COUNT(*) FROM categories
+WHERE restaurant_id = $1
 `
 
-type CreateRestaurantParams struct {
-	OwnerID       uuid.UUID   `db:"owner_id" json:"owner_id"`
-	Name          string      `db:"name" json:"name"`
-	Slug          string      `db:"slug" json:"slug"`
-	Description   pgtype.Text `db:"description" json:"description"`
-	CuisineType   pgtype.Text `db:"cuisine_type" json:"cuisine_type"`
-	Phone         pgtype.Text `db:"phone" json:"phone"`
-	Email         pgtype.Text `db:"email" json:"email"`
-	Website       pgtype.Text `db:"website" json:"website"`
-	Address       pgtype.Text `db:"address" json:"address"`
-	City          pgtype.Text `db:"city" json:"city"`
-	Country       pgtype.Text `db:"country" json:"country"`
-	LogoUrl       pgtype.Text `db:"logo_url" json:"logo_url"`
-	CoverImageUrl pgtype.Text `db:"cover_image_url" json:"cover_image_url"`
-	ThemeSettings []byte      `db:"theme_settings" json:"theme_settings"`
-}
-
-func (q *Queries) CreateRestaurant(ctx context.Context, arg CreateRestaurantParams) (Restaurant, error) {
-	row := q.db.QueryRow(ctx, createRestaurant,
-		arg.OwnerID,
-		arg.Name,
-		arg.Slug,
-		arg.Description,
-		arg.CuisineType,
-		arg.Phone,
-		arg.Email,
-		arg.Website,
-		arg.Address,
-		arg.City,
-		arg.Country,
-		arg.LogoUrl,
-		arg.CoverImageUrl,
-		arg.ThemeSettings,
-	)
-	var i Restaurant
-	err := row.Scan(
-		&i.ID,
-		&i.OwnerID,
-		&i.Name,
-		&i.Slug,
-		&i.Description,
-		&i.CuisineType,
-		&i.Phone,
-		&i.Email,
-		&i.Website,
-		&i.Address,
-		&i.City,
-		&i.Country,
-		&i.LogoUrl,
-		&i.CoverImageUrl,
-		&i.ThemeSettings,
-		&i.IsPublished,
-		&i.ViewCount,
-		&i.RankScore,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-	)
-	return i, err
+func (q *Queries) CountCategoriesByRestaurant(ctx context.Context, restaurantID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countCategoriesByRestaurant, restaurantID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
 }
 
-const createSubscription = `-- name: CreateSubscription :one
-INSERT INTO subscriptions (
-    owner_id, plan_id, status, current_period_start, current_period_end, trial_end
-) VALUES (
-    $1, $2, $3, $4, $5, $6
-) RETURNING id, owner_id, plan_id, status, current_period_start, current_period_end, trial_end, cancelled_at, payment_provider_subscription_id, created_at, updated_at
+const countCouponCyclesByOwner = `-- name: CountCouponCyclesByOwner :one
+SELECT COUNT(*) FROM coupon_redemptions
+WHERE coupon_id = $1 AND owner_id = $2
 `
 
-type CreateSubscriptionParams struct {
-	OwnerID            uuid.UUID          `db:"owner_id" json:"owner_id"`
-	PlanID             uuid.UUID          `db:"plan_id" json:"plan_id"`
-	Status             SubscriptionStatus `db:"status" json:"status"`
-	CurrentPeriodStart pgtype.Timestamp   `db:"current_period_start" json:"current_period_start"`
-	CurrentPeriodEnd   pgtype.Timestamp   `db:"current_period_end" json:"current_period_end"`
-	TrialEnd           pgtype.Timestamp   `db:"trial_end" json:"trial_end"`
+type CountCouponCyclesByOwnerParams struct {
+	CouponID uuid.UUID `db:"coupon_id" json:"coupon_id"`
+	OwnerID  uuid.UUID `db:"owner_id" json:"owner_id"`
 }
 
-func (q *Queries) CreateSubscription(ctx context.Context, arg CreateSubscriptionParams) (Subscription, error) {
-	row := q.db.QueryRow(ctx, createSubscription,
-		arg.OwnerID,
-		arg.PlanID,
-		arg.Status,
-		arg.CurrentPeriodStart,
-		arg.CurrentPeriodEnd,
-		arg.TrialEnd,
-	)
-	var i Subscription
-	err := row.Scan(
-		&i.ID,
-		&i.OwnerID,
-		&i.PlanID,
-		&i.Status,
-		&i.CurrentPeriodStart,
-		&i.CurrentPeriodEnd,
-		&i.TrialEnd,
-		&i.CancelledAt,
-		&i.PaymentProviderSubscriptionID,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-	)
-	return i, err
+func (q *Queries) CountCouponCyclesByOwner(ctx context.Context, arg CountCouponCyclesByOwnerParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countCouponCyclesByOwner, arg.CouponID, arg.OwnerID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
 }
 
-const createSubscriptionPlan = `-- name: CreateSubscriptionPlan :one
-INSERT INTO subscription_plans (
-    name, slug, description, price_monthly, price_annual, currency, features, display_order
-) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8
-) RETURNING id, name, slug, description, price_monthly, price_annual, currency, features, display_order, is_active, created_at, updated_at
+const countInvoicesWithFilters = `-- name: CountInvoicesWithFilters :one
+SELECT COUNT(*) FROM invoices
+WHERE 
+    owner_id = $1 AND
+    (NULLIF($2::uuid, '00000000-0000-0000-0000-000000000000'::uuid) IS NULL OR subscription_id = $2) AND
+    ($3::invoice_status IS NULL OR status = $3)
 `
 
-type CreateSubscriptionPlanParams struct {
-	Name         string      `db:"name" json:"name"`
-	Slug         string      `db:"slug" json:"slug"`
-	Description  pgtype.Text `db:"description" json:"description"`
-	PriceMonthly int32       `db:"price_monthly" json:"price_monthly"`
-	PriceAnnual  pgtype.Int4 `db:"price_annual" json:"price_annual"`
-	Currency     string      `db:"currency" json:"currency"`
-	Features     []byte      `db:"features" json:"features"`
-	DisplayOrder int32       `db:"display_order" json:"display_order"`
+type CountInvoicesWithFiltersParams struct {
+	OwnerID        uuid.UUID         `db:"owner_id" json:"owner_id"`
+	SubscriptionID uuid.UUID         `db:"subscription_id" json:"subscription_id"`
+	Status         NullInvoiceStatus `db:"status" json:"status"`
 }
 
-func (q *Queries) CreateSubscriptionPlan(ctx context.Context, arg CreateSubscriptionPlanParams) (SubscriptionPlan, error) {
-	row := q.db.QueryRow(ctx, createSubscriptionPlan,
-		arg.Name,
-		arg.Slug,
-		arg.Description,
-		arg.PriceMonthly,
-		arg.PriceAnnual,
-		arg.Currency,
-		arg.Features,
-		arg.DisplayOrder,
-	)
-	var i SubscriptionPlan
-	err := row.Scan(
-		&i.ID,
-		&i.Name,
-		&i.Slug,
-		&i.Description,
-		&i.PriceMonthly,
-		&i.PriceAnnual,
-		&i.Currency,
-		&i.Features,
-		&i.DisplayOrder,
-		&i.IsActive,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-	)
-	return i, err
+func (q *Queries) CountInvoicesWithFilters(ctx context.Context, arg CountInvoicesWithFiltersParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countInvoicesWithFilters, arg.OwnerID, arg.SubscriptionID, arg.Status)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
 }
 
-const createUser = `-- name: CreateUser :one
-INSERT INTO users (
-    id, owner_id, email, password_hash, full_name, role, restaurant_id, phone, avatar_url
-) VALUES (
-    $1, $2, $3, $4, $5, $6, 
-	NULLIF($7, '00000000-0000-0000-0000-000000000000'::uuid), 
-    $8, $9
-) RETURNING id, email, password_hash, full_name, role, owner_id, restaurant_id, phone, avatar_url, email_verified, last_login_at, is_active, created_at, updated_at, email_verified_at, verification_token, verification_token_expires_at, trial_ends_at
+const countMenuItemReviews = `-- name: CountMenuItemReviews :one
+SELECT COUNT(*) FROM reviews
+WHERE menu_item_id = $1 AND is_hidden = FALSE
 `
 
-type CreateUserParams struct {
-	ID           uuid.UUID   `db:"id" json:"id"`
-	OwnerID      uuid.UUID   `db:"owner_id" json:"owner_id"`
-	Email        string      `db:"email" json:"email"`
-	PasswordHash string      `db:"password_hash" json:"password_hash"`
-	FullName     string      `db:"full_name" json:"full_name"`
-	Role         UserRole    `db:"role" json:"role"`
-	RestaurantID uuid.UUID   `db:"restaurant_id" json:"restaurant_id"`
-	Phone        pgtype.Text `db:"phone" json:"phone"`
-	AvatarUrl    pgtype.Text `db:"avatar_url" json:"avatar_url"`
+func (q *Queries) CountMenuItemReviews(ctx context.Context, menuItemID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countMenuItemReviews, menuItemID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
 }
 
-func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
-	fmt.Printf("this is the data %+v\n", arg)
-	row := q.db.QueryRow(ctx, createUser,
-		arg.ID,
-		arg.OwnerID,
-		arg.Email,
-		arg.PasswordHash,
-		arg.FullName,
-		arg.Role,
-		arg.RestaurantID,
-		arg.Phone,
-		arg.AvatarUrl,
-	)
-	var i User
-	err := row.Scan(
-		&i.ID,
-		&i.Email,
-		&i.PasswordHash,
-		&i.FullName,
-		&i.Role,
-		&i.OwnerID,
-		&i.RestaurantID,
-		&i.Phone,
-		&i.AvatarUrl,
-		&i.EmailVerified,
-		&i.LastLoginAt,
-		&i.IsActive,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-		&i.EmailVerifiedAt,
-		&i.VerificationToken,
-		&i.VerificationTokenExpiresAt,
-		&i.TrialEndsAt,
-	)
-	return i, err
+const countMenuItemsByCategory = `-- name: CountMenuItemsByCategory :one
+SELECT COUNT(*) FROM menu_items
+WHERE category_id = $1 
+`
+
+func (q *Queries) CountMenuItemsByCategory(ctx context.Context, categoryID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countMenuItemsByCategory, categoryID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
 }
 
-const deleteCategory = `-- name: DeleteCategory :exec
-DELETE FROM categories WHERE id = $1
+const countMenuItemsByRestaurant = `-- name: CountMenuItemsByRestaurant :one
+SELECT COUNT(*) FROM menu_items
+WHERE restaurant_id = $1 
 `
 
-func (q *Queries) DeleteCategory(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.Exec(ctx, deleteCategory, id)
-	return err
+func (q *Queries) CountMenuItemsByRestaurant(ctx context.Context, restaurantID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countMenuItemsByRestaurant, restaurantID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
 }
 
-const deleteMenuItem = `-- name: DeleteMenuItem :exec
-DELETE FROM menu_items WHERE id = $1
+const countPendingStaffInvitations = `-- name: CountPendingStaffInvitations :one
+SELECT COUNT(*) FROM staff_invitations
+WHERE restaurant_id = $1 AND status = 'pending' AND expires_at > NOW()
 `
 
-func (q *Queries) DeleteMenuItem(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.Exec(ctx, deleteMenuItem, id)
-	return err
+func (q *Queries) CountPendingStaffInvitations(ctx context.Context, restaurantID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countPendingStaffInvitations, restaurantID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
 }
 
-const deleteRestaurant = `-- name: DeleteRestaurant :exec
-DELETE FROM restaurants WHERE id = $1 AND owner_id = $2
+const countReportedReviews = `-- name: CountReportedReviews :one
+SELECT COUNT(*) FROM reviews
+WHERE report_count > 0 OR is_hidden = TRUE
 `
 
-type DeleteRestaurantParams struct {
-	ID      uuid.UUID `db:"id" json:"id"`
-	OwnerID uuid.UUID `db:"owner_id" json:"owner_id"`
+func (q *Queries) CountReportedReviews(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, countReportedReviews)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
 }
 
-func (q *Queries) DeleteRestaurant(ctx context.Context, arg DeleteRestaurantParams) error {
-	res, err := q.db.Exec(ctx, deleteRestaurant, arg.ID, arg.OwnerID)
-	if res.RowsAffected() == 0 {
-		return fmt.Errorf("restaurant not found")
-	}
-	return err
+const countRestaurantReviews = `-- name: CountRestaurantReviews :one
+SELECT COUNT(*) FROM reviews
+WHERE restaurant_id = $1 AND menu_item_id IS NULL AND is_hidden = FALSE
+`
+
+func (q *Queries) CountRestaurantReviews(ctx context.Context, restaurantID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countRestaurantReviews, restaurantID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
 }
 
-const deleteStaff = `-- name: DeleteStaff :exec
-delete from users
-WHERE id = $1 AND restaurant_id = $2 AND role = 'staff'
+const countRestaurantsWithFilters = `-- name: CountRestaurantsWithFilters :one
+SELECT COUNT(*) FROM restaurants
+WHERE 
+    ($1::uuid IS NULL OR owner_id = $1) AND
+    ($2::text IS NULL OR cuisine_type = $2) AND
+    ($3::text IS NULL OR city = $3) AND
+    ($4::text IS NULL OR country = $4) AND
+    ($5::boolean IS NULL OR is_published = $5) AND
+    ($6::text IS NULL OR name ILIKE '%' || $6 || '%')
 `
 
-type DeleteStaffParams struct {
-	ID           uuid.UUID `db:"id" json:"id"`
-	RestaurantID uuid.UUID `db:"restaurant_id" json:"restaurant_id"`
+type CountRestaurantsWithFiltersParams struct {
+	OwnerID     uuid.UUID   `db:"owner_id" json:"owner_id"`
+	CuisineType pgtype.Text `db:"cuisine_type" json:"cuisine_type"`
+	City        pgtype.Text `db:"city" json:"city"`
+	Country     pgtype.Text `db:"country" json:"country"`
+	IsPublished pgtype.Bool `db:"is_published" json:"is_published"`
+	Search      pgtype.Text `db:"search" json:"search"`
 }
 
-func (q *Queries) DeleteStaff(ctx context.Context, arg DeleteStaffParams) error {
-	fmt.Println("Deleting staff with ID:", arg.ID)
-	fmt.Println("Deleting staff with Restaurant ID:", arg.RestaurantID)
-	res, err := q.db.Exec(ctx, deleteStaff, arg.ID, arg.RestaurantID)
-	if res.RowsAffected() == 0 {
-		return fmt.Errorf("staff not found")
-	}
-	return err
+func (q *Queries) CountRestaurantsWithFilters(ctx context.Context, arg CountRestaurantsWithFiltersParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countRestaurantsWithFilters,
+		arg.OwnerID,
+		arg.CuisineType,
+		arg.City,
+		arg.Country,
+		arg.IsPublished,
+		arg.Search,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
 }
 
-const deleteUser = `-- name: DeleteUser :exec
-delete from users WHERE id = $1
+const countReviewsByRestaurant = `-- name: CountReviewsByRestaurant :one
+SELECT COUNT(*) FROM reviews
+WHERE restaurant_id = $1
 `
 
-func (q *Queries) DeleteUser(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.Exec(ctx, deleteUser, id)
-	return err
+func (q *Queries) CountReviewsByRestaurant(ctx context.Context, restaurantID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countReviewsByRestaurant, restaurantID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
 }
 
-const getActiveSubscriptionByOwner = `-- name: GetActiveSubscriptionByOwner :one
-SELECT s.id, s.owner_id, s.plan_id, s.status, s.current_period_start, s.current_period_end, s.trial_end, s.cancelled_at, s.payment_provider_subscription_id, s.created_at, s.updated_at, sp.name as plan_name, sp.slug as plan_slug, sp.features
-FROM subscriptions s
-JOIN subscription_plans sp ON s.plan_id = sp.id
-WHERE s.owner_id = $1 AND s.status = 'active' LIMIT 1
+const countStaffByRestaurant = `-- name: CountStaffByRestaurant :one
+SELECT COUNT(*) FROM users
+WHERE restaurant_id = $1 AND role = 'staff' 
 `
 
-type GetActiveSubscriptionByOwnerRow struct {
-	ID                            uuid.UUID          `db:"id" json:"id"`
-	OwnerID                       uuid.UUID          `db:"owner_id" json:"owner_id"`
-	PlanID                        uuid.UUID          `db:"plan_id" json:"plan_id"`
-	Status                        SubscriptionStatus `db:"status" json:"status"`
-	CurrentPeriodStart            pgtype.Timestamp   `db:"current_period_start" json:"current_period_start"`
-	CurrentPeriodEnd              pgtype.Timestamp   `db:"current_period_end" json:"current_period_end"`
-	TrialEnd                      pgtype.Timestamp   `db:"trial_end" json:"trial_end"`
-	CancelledAt                   pgtype.Timestamp   `db:"cancelled_at" json:"cancelled_at"`
-	PaymentProviderSubscriptionID pgtype.Text        `db:"payment_provider_subscription_id" json:"payment_provider_subscription_id"`
-	CreatedAt                     pgtype.Timestamp   `db:"created_at" json:"created_at"`
-	UpdatedAt                     pgtype.Timestamp   `db:"updated_at" json:"updated_at"`
-	PlanName                      string             `db:"plan_name" json:"plan_name"`
-	PlanSlug                      string             `db:"plan_slug" json:"plan_slug"`
-	Features                      []byte             `db:"features" json:"features"`
+func (q *Queries) CountStaffByRestaurant(ctx context.Context, restaurantID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countStaffByRestaurant, restaurantID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
 }
 
-func (q *Queries) GetActiveSubscriptionByOwner(ctx context.Context, ownerID uuid.UUID) (GetActiveSubscriptionByOwnerRow, error) {
-	row := q.db.QueryRow(ctx, getActiveSubscriptionByOwner, ownerID)
-	var i GetActiveSubscriptionByOwnerRow
-	err := row.Scan(
-		&i.ID,
-		&i.OwnerID,
-		&i.PlanID,
-		&i.Status,
-		&i.CurrentPeriodStart,
-		&i.CurrentPeriodEnd,
-		&i.TrialEnd,
-		&i.CancelledAt,
-		&i.PaymentProviderSubscriptionID,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-		&i.PlanName,
-		&i.PlanSlug,
-		&i.Features,
-	)
-	return i, err
+const countStaffByRole = `-- name: CountStaffByRole :one
+SELECT COUNT(*) FROM users
+WHERE staff_role_id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) CountStaffByRole(ctx context.Context, staffRoleID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countStaffByRole, staffRoleID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
 }
 
-const getAdminDashboardStats = `-- name: GetAdminDashboardStats :one
-SELECT 
-    (SELECT COUNT(*) FROM users ) as total_users,
-    (SELECT COUNT(*) FROM restaurants ) as total_restaurants,
-    (SELECT COUNT(*) FROM subscriptions WHERE status = 'active') as active_subscriptions,
-    (SELECT SUM(amount) FROM invoices WHERE status = 'paid') as total_revenue
+const countStaffInvitationsByRestaurant = `-- name: CountStaffInvitationsByRestaurant :one
+SELECT COUNT(*) FROM staff_invitations
+WHERE restaurant_id = $1
 `
 
-type GetAdminDashboardStatsRow struct {
-	TotalUsers          int64 `db:"total_users" json:"total_users"`
-	TotalRestaurants    int64 `db:"total_restaurants" json:"total_restaurants"`
-	ActiveSubscriptions int64 `db:"active_subscriptions" json:"active_subscriptions"`
-	TotalRevenue        int64 `db:"total_revenue" json:"total_revenue"`
+func (q *Queries) CountStaffInvitationsByRestaurant(ctx context.Context, restaurantID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countStaffInvitationsByRestaurant, restaurantID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
 }
 
-func (q *Queries) GetAdminDashboardStats(ctx context.Context) (GetAdminDashboardStatsRow, error) {
-	row := q.db.QueryRow(ctx, getAdminDashboardStats)
-	var i GetAdminDashboardStatsRow
+const createAPIKey = `-- name: CreateAPIKey :one
+INSERT INTO api_keys (
+    restaurant_id, created_by, name, key_prefix, key_hash, permissions, expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING id, restaurant_id, created_by, name, key_prefix, key_hash, permissions, expires_at, last_used_at, last_used_ip, revoked_at, created_at
+`
+
+type CreateAPIKeyParams struct {
+	RestaurantID uuid.UUID        `db:"restaurant_id" json:"restaurant_id"`
+	CreatedBy    uuid.UUID        `db:"created_by" json:"created_by"`
+	Name         string           `db:"name" json:"name"`
+	KeyPrefix    string           `db:"key_prefix" json:"key_prefix"`
+	KeyHash      string           `db:"key_hash" json:"key_hash"`
+	Permissions  []string         `db:"permissions" json:"permissions"`
+	ExpiresAt    pgtype.Timestamp `db:"expires_at" json:"expires_at"`
+}
+
+func (q *Queries) CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (ApiKey, error) {
+	row := q.db.QueryRow(ctx, createAPIKey,
+		arg.RestaurantID,
+		arg.CreatedBy,
+		arg.Name,
+		arg.KeyPrefix,
+		arg.KeyHash,
+		arg.Permissions,
+		arg.ExpiresAt,
+	)
+	var i ApiKey
 	err := row.Scan(
-		&i.TotalUsers,
-		&i.TotalRestaurants,
-		&i.ActiveSubscriptions,
-		&i.TotalRevenue,
+		&i.ID,
+		&i.RestaurantID,
+		&i.CreatedBy,
+		&i.Name,
+		&i.KeyPrefix,
+		&i.KeyHash,
+		&i.Permissions,
+		&i.ExpiresAt,
+		&i.LastUsedAt,
+		&i.LastUsedIp,
+		&i.RevokedAt,
+		&i.CreatedAt,
 	)
 	return i, err
 }
 
-const getAllAdminEmails = `-- name: GetAllAdminEmails :many
-SELECT email FROM users
-WHERE role = 'admin' 
+const createActivityLog = `-- name: CreateActivityLog :one
+INSERT INTO activity_logs (
+    restaurant_id, user_id, action_type, action_category, description, target_type, target_id, target_name, before_value, after_value, ip_address, user_agent, device_type, browser, os, success
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16
+) RETURNING id, restaurant_id, user_id, action_type, action_category, description, target_type, target_id, target_name, before_value, after_value, ip_address, user_agent, device_type, browser, os, success, created_at
 `
 
-func (q *Queries) GetAllAdminEmails(ctx context.Context) ([]string, error) {
-	rows, err := q.db.Query(ctx, getAllAdminEmails)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []string
-	for rows.Next() {
-		var email string
-		if err := rows.Scan(&email); err != nil {
-			return nil, err
-		}
-		items = append(items, email)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+type CreateActivityLogParams struct {
+	RestaurantID   uuid.UUID   `db:"restaurant_id" json:"restaurant_id"`
+	UserID         uuid.UUID   `db:"user_id" json:"user_id"`
+	ActionType     string      `db:"action_type" json:"action_type"`
+	ActionCategory string      `db:"action_category" json:"action_category"`
+	Description    pgtype.Text `db:"description" json:"description"`
+	TargetType     pgtype.Text `db:"target_type" json:"target_type"`
+	TargetID       uuid.UUID   `db:"target_id" json:"target_id"`
+	TargetName     pgtype.Text `db:"target_name" json:"target_name"`
+	BeforeValue    []byte      `db:"before_value" json:"before_value"`
+	AfterValue     []byte      `db:"after_value" json:"after_value"`
+	IpAddress      pgtype.Text `db:"ip_address" json:"ip_address"`
+	UserAgent      pgtype.Text `db:"user_agent" json:"user_agent"`
+	DeviceType     pgtype.Text `db:"device_type" json:"device_type"`
+	Browser        pgtype.Text `db:"browser" json:"browser"`
+	Os             pgtype.Text `db:"os" json:"os"`
+	Success        pgtype.Bool `db:"success" json:"success"`
 }
 
-const getAnalyticsAggregates = `-- name: GetAnalyticsAggregates :many
-SELECT id, restaurant_id, date, hour, metric_type, target_id, value, created_at, updated_at FROM analytics_aggregates
-WHERE restaurant_id = $1 AND date >= $2 AND date <= $3
-ORDER BY date ASC, hour ASC
+func (q *Queries) CreateActivityLog(ctx context.Context, arg CreateActivityLogParams) (ActivityLog, error) {
+	row := q.db.QueryRow(ctx, createActivityLog,
+		arg.RestaurantID,
+		arg.UserID,
+		arg.ActionType,
+		arg.ActionCategory,
+		arg.Description,
+		arg.TargetType,
+		arg.TargetID,
+		arg.TargetName,
+		arg.BeforeValue,
+		arg.AfterValue,
+		arg.IpAddress,
+		arg.UserAgent,
+		arg.DeviceType,
+		arg.Browser,
+		arg.Os,
+		arg.Success,
+	)
+	var i ActivityLog
+	err := row.Scan(
+		&i.ID,
+		&i.RestaurantID,
+		&i.UserID,
+		&i.ActionType,
+		&i.ActionCategory,
+		&i.Description,
+		&i.TargetType,
+		&i.TargetID,
+		&i.TargetName,
+		&i.BeforeValue,
+		&i.AfterValue,
+		&i.IpAddress,
+		&i.UserAgent,
+		&i.DeviceType,
+		&i.Browser,
+		&i.Os,
+		&i.Success,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createAnalyticsEvent = `-- name: CreateAnalyticsEvent :one
+INSERT INTO analytics_events (
+    restaurant_id, event_type, visitor_id, session_id, target_id, ip_address, device_type, browser, os, country, city
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+) RETURNING id, restaurant_id, event_type, visitor_id, session_id, target_id, ip_address, device_type, browser, os, country, city, created_at
 `
 
-type GetAnalyticsAggregatesParams struct {
+type CreateAnalyticsEventParams struct {
 	RestaurantID uuid.UUID   `db:"restaurant_id" json:"restaurant_id"`
-	Date         pgtype.Date `db:"date" json:"date"`
-	Date_2       pgtype.Date `db:"date_2" json:"date_2"`
+	EventType    string      `db:"event_type" json:"event_type"`
+	VisitorID    string      `db:"visitor_id" json:"visitor_id"`
+	SessionID    uuid.UUID   `db:"session_id" json:"session_id"`
+	TargetID     uuid.UUID   `db:"target_id" json:"target_id"`
+	IpAddress    pgtype.Text `db:"ip_address" json:"ip_address"`
+	DeviceType   pgtype.Text `db:"device_type" json:"device_type"`
+	Browser      pgtype.Text `db:"browser" json:"browser"`
+	Os           pgtype.Text `db:"os" json:"os"`
+	Country      pgtype.Text `db:"country" json:"country"`
+	City         pgtype.Text `db:"city" json:"city"`
 }
 
-func (q *Queries) GetAnalyticsAggregates(ctx context.Context, arg GetAnalyticsAggregatesParams) ([]AnalyticsAggregate, error) {
-	rows, err := q.db.Query(ctx, getAnalyticsAggregates, arg.RestaurantID, arg.Date, arg.Date_2)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []AnalyticsAggregate
-	for rows.Next() {
-		var i AnalyticsAggregate
-		if err := rows.Scan(
-			&i.ID,
-			&i.RestaurantID,
-			&i.Date,
-			&i.Hour,
-			&i.MetricType,
-			&i.TargetID,
-			&i.Value,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+func (q *Queries) CreateAnalyticsEvent(ctx context.Context, arg CreateAnalyticsEventParams) (AnalyticsEvent, error) {
+	row := q.db.QueryRow(ctx, createAnalyticsEvent,
+		arg.RestaurantID,
+		arg.EventType,
+		arg.VisitorID,
+		arg.SessionID,
+		arg.TargetID,
+		arg.IpAddress,
+		arg.DeviceType,
+		arg.Browser,
+		arg.Os,
+		arg.Country,
+		arg.City,
+	)
+	var i AnalyticsEvent
+	err := row.Scan(
+		&i.ID,
+		&i.RestaurantID,
+		&i.EventType,
+		&i.VisitorID,
+		&i.SessionID,
+		&i.TargetID,
+		&i.IpAddress,
+		&i.DeviceType,
+		&i.Browser,
+		&i.Os,
+		&i.Country,
+		&i.City,
+		&i.CreatedAt,
+	)
+	return i, err
 }
 
-const getCategoryByID = `-- name: GetCategoryByID :one
-SELECT id, restaurant_id, name, description, icon, display_order, is_active, created_by, created_at, updated_at FROM categories
-WHERE id = $1 LIMIT 1
+const createAuthAuditLog = `-- name: CreateAuthAuditLog :exec
+INSERT INTO auth_audit_logs (
+    user_id, email, event, reason, ip_address, user_agent, actor_id
+) VALUES (
+    NULLIF($1::uuid, '00000000-0000-0000-0000-000000000000'::uuid), $2, $3, $4, $5, $6, NULLIF($7::uuid, '00000000-0000-0000-0000-000000000000'::uuid)
+)
 `
 
-func (q *Queries) GetCategoryByID(ctx context.Context, id uuid.UUID) (Category, error) {
-	row := q.db.QueryRow(ctx, getCategoryByID, id)
+type CreateAuthAuditLogParams struct {
+	UserID    uuid.UUID   `db:"user_id" json:"user_id"`
+	Email     string      `db:"email" json:"email"`
+	Event     AuthEvent   `db:"event" json:"event"`
+	Reason    pgtype.Text `db:"reason" json:"reason"`
+	IpAddress pgtype.Text `db:"ip_address" json:"ip_address"`
+	UserAgent pgtype.Text `db:"user_agent" json:"user_agent"`
+	ActorID   uuid.UUID   `db:"actor_id" json:"actor_id"`
+}
+
+func (q *Queries) CreateAuthAuditLog(ctx context.Context, arg CreateAuthAuditLogParams) error {
+	_, err := q.db.Exec(ctx, createAuthAuditLog,
+		arg.UserID,
+		arg.Email,
+		arg.Event,
+		arg.Reason,
+		arg.IpAddress,
+		arg.UserAgent,
+		arg.ActorID,
+	)
+	return err
+}
+
+const createCategory = `-- name: CreateCategory :one
+INSERT INTO categories (
+    restaurant_id, name, description, icon, display_order, is_active, created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING id, restaurant_id, name, description, icon, display_order, is_active, created_by, created_at, updated_at, plan_locked
+`
+
+type CreateCategoryParams struct {
+	RestaurantID uuid.UUID   `db:"restaurant_id" json:"restaurant_id"`
+	Name         string      `db:"name" json:"name"`
+	Description  pgtype.Text `db:"description" json:"description"`
+	Icon         pgtype.Text `db:"icon" json:"icon"`
+	DisplayOrder int32       `db:"display_order" json:"display_order"`
+	IsActive     bool        `db:"is_active" json:"is_active"`
+	CreatedBy    uuid.UUID   `db:"created_by" json:"created_by"`
+}
+
+func (q *Queries) CreateCategory(ctx context.Context, arg CreateCategoryParams) (Category, error) {
+	row := q.db.QueryRow(ctx, createCategory,
+		arg.RestaurantID,
+		arg.Name,
+		arg.Description,
+		arg.Icon,
+		arg.DisplayOrder,
+		arg.IsActive,
+		arg.CreatedBy,
+	)
 	var i Category
 	err := row.Scan(
 		&i.ID,
@@ -1020,65 +1035,281 @@ func (q *Queries) GetCategoryByID(ctx context.Context, id uuid.UUID) (Category,
 		&i.CreatedBy,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.PlanLocked,
 	)
 	return i, err
 }
 
-const getLatestSubscriptionByOwner = `-- name: GetLatestSubscriptionByOwner :one
-SELECT s.id, s.owner_id, s.plan_id, s.status, s.current_period_start, s.current_period_end, s.trial_end, s.cancelled_at, s.payment_provider_subscription_id, s.created_at, s.updated_at, sp.name as plan_name, sp.slug as plan_slug, sp.features
-FROM subscriptions s
-JOIN subscription_plans sp ON s.plan_id = sp.id
-WHERE s.owner_id = $1 
-ORDER BY s.created_at DESC
-LIMIT 1
+const createCoupon = `-- name: CreateCoupon :one
+INSERT INTO coupons (
+    code, description, discount_type, discount_value, plan_ids, max_redemptions, duration_cycles, expires_at, is_active, created_by
+) VALUES (
+    UPPER($1), $2, $3, $4, $5, $6, $7, $8, $9, $10
+) RETURNING id, code, description, discount_type, discount_value, plan_ids, max_redemptions, redemption_count, duration_cycles, expires_at, is_active, created_by, created_at, updated_at
 `
 
-type GetLatestSubscriptionByOwnerRow struct {
-	ID                            uuid.UUID          `db:"id" json:"id"`
-	OwnerID                       uuid.UUID          `db:"owner_id" json:"owner_id"`
-	PlanID                        uuid.UUID          `db:"plan_id" json:"plan_id"`
-	Status                        SubscriptionStatus `db:"status" json:"status"`
-	CurrentPeriodStart            pgtype.Timestamp   `db:"current_period_start" json:"current_period_start"`
-	CurrentPeriodEnd              pgtype.Timestamp   `db:"current_period_end" json:"current_period_end"`
-	TrialEnd                      pgtype.Timestamp   `db:"trial_end" json:"trial_end"`
-	CancelledAt                   pgtype.Timestamp   `db:"cancelled_at" json:"cancelled_at"`
-	PaymentProviderSubscriptionID pgtype.Text        `db:"payment_provider_subscription_id" json:"payment_provider_subscription_id"`
-	CreatedAt                     pgtype.Timestamp   `db:"created_at" json:"created_at"`
-	UpdatedAt                     pgtype.Timestamp   `db:"updated_at" json:"updated_at"`
-	PlanName                      string             `db:"plan_name" json:"plan_name"`
-	PlanSlug                      string             `db:"plan_slug" json:"plan_slug"`
-	Features                      []byte             `db:"features" json:"features"`
+type CreateCouponParams struct {
+	Code           string           `db:"code" json:"code"`
+	Description    pgtype.Text      `db:"description" json:"description"`
+	DiscountType   DiscountType     `db:"discount_type" json:"discount_type"`
+	DiscountValue  pgtype.Numeric   `db:"discount_value" json:"discount_value"`
+	PlanIds        []uuid.UUID      `db:"plan_ids" json:"plan_ids"`
+	MaxRedemptions pgtype.Int4      `db:"max_redemptions" json:"max_redemptions"`
+	DurationCycles pgtype.Int4      `db:"duration_cycles" json:"duration_cycles"`
+	ExpiresAt      pgtype.Timestamp `db:"expires_at" json:"expires_at"`
+	IsActive       bool             `db:"is_active" json:"is_active"`
+	CreatedBy      uuid.UUID        `db:"created_by" json:"created_by"`
 }
 
-func (q *Queries) GetLatestSubscriptionByOwner(ctx context.Context, ownerID uuid.UUID) (GetLatestSubscriptionByOwnerRow, error) {
-	row := q.db.QueryRow(ctx, getLatestSubscriptionByOwner, ownerID)
-	var i GetLatestSubscriptionByOwnerRow
-	err := row.Scan(
+func (q *Queries) CreateCoupon(ctx context.Context, arg CreateCouponParams) (Coupon, error) {
+	row := q.db.QueryRow(ctx, createCoupon,
+		arg.Code,
+		arg.Description,
+		arg.DiscountType,
+		arg.DiscountValue,
+		arg.PlanIds,
+		arg.MaxRedemptions,
+		arg.DurationCycles,
+		arg.ExpiresAt,
+		arg.IsActive,
+		arg.CreatedBy,
+	)
+	var i Coupon
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.Description,
+		&i.DiscountType,
+		&i.DiscountValue,
+		&i.PlanIds,
+		&i.MaxRedemptions,
+		&i.RedemptionCount,
+		&i.DurationCycles,
+		&i.ExpiresAt,
+		&i.IsActive,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createCouponRedemption = `-- name: CreateCouponRedemption :execrows
+INSERT INTO coupon_redemptions (
+    coupon_id, owner_id, invoice_id, discount_amount
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (invoice_id) DO NOTHING
+`
+
+type CreateCouponRedemptionParams struct {
+	CouponID       uuid.UUID      `db:"coupon_id" json:"coupon_id"`
+	OwnerID        uuid.UUID      `db:"owner_id" json:"owner_id"`
+	InvoiceID      uuid.UUID      `db:"invoice_id" json:"invoice_id"`
+	DiscountAmount pgtype.Numeric `db:"discount_amount" json:"discount_amount"`
+}
+
+func (q *Queries) CreateCouponRedemption(ctx context.Context, arg CreateCouponRedemptionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, createCouponRedemption,
+		arg.CouponID,
+		arg.OwnerID,
+		arg.InvoiceID,
+		arg.DiscountAmount,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const createCouponReservation = `-- name: CreateCouponReservation :execrows
+INSERT INTO coupon_reservations (coupon_id, owner_id, tx_ref, expires_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (coupon_id, owner_id) DO NOTHING
+`
+
+type CreateCouponReservationParams struct {
+	CouponID  uuid.UUID        `db:"coupon_id" json:"coupon_id"`
+	OwnerID   uuid.UUID        `db:"owner_id" json:"owner_id"`
+	TxRef     string           `db:"tx_ref" json:"tx_ref"`
+	ExpiresAt pgtype.Timestamp `db:"expires_at" json:"expires_at"`
+}
+
+func (q *Queries) CreateCouponReservation(ctx context.Context, arg CreateCouponReservationParams) (int64, error) {
+	result, err := q.db.Exec(ctx, createCouponReservation,
+		arg.CouponID,
+		arg.OwnerID,
+		arg.TxRef,
+		arg.ExpiresAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const createCreditNote = `-- name: CreateCreditNote :one
+INSERT INTO credit_notes (
+    credit_note_number, invoice_id, owner_id, amount, currency, vat_rate, reason, provider_refund_ref, subscription_terminated, created_by
+) VALUES (
+    'CN-' || TO_CHAR(NOW(), 'YYYY') || '-' || LPAD(nextval('credit_note_number_seq')::text, 6, '0'),
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING id, credit_note_number, invoice_id, owner_id, amount, currency, vat_rate, reason, provider_refund_ref, subscription_terminated, created_by, created_at
+`
+
+type CreateCreditNoteParams struct {
+	InvoiceID              uuid.UUID      `db:"invoice_id" json:"invoice_id"`
+	OwnerID                uuid.UUID      `db:"owner_id" json:"owner_id"`
+	Amount                 pgtype.Numeric `db:"amount" json:"amount"`
+	Currency               string         `db:"currency" json:"currency"`
+	VatRate                pgtype.Numeric `db:"vat_rate" json:"vat_rate"`
+	Reason                 string         `db:"reason" json:"reason"`
+	ProviderRefundRef      pgtype.Text    `db:"provider_refund_ref" json:"provider_refund_ref"`
+	SubscriptionTerminated bool           `db:"subscription_terminated" json:"subscription_terminated"`
+	CreatedBy              uuid.UUID      `db:"created_by" json:"created_by"`
+}
+
+func (q *Queries) CreateCreditNote(ctx context.Context, arg CreateCreditNoteParams) (CreditNote, error) {
+	row := q.db.QueryRow(ctx, createCreditNote,
+		arg.InvoiceID,
+		arg.OwnerID,
+		arg.Amount,
+		arg.Currency,
+		arg.VatRate,
+		arg.Reason,
+		arg.ProviderRefundRef,
+		arg.SubscriptionTerminated,
+		arg.CreatedBy,
+	)
+	var i CreditNote
+	err := row.Scan(
 		&i.ID,
+		&i.CreditNoteNumber,
+		&i.InvoiceID,
 		&i.OwnerID,
-		&i.PlanID,
+		&i.Amount,
+		&i.Currency,
+		&i.VatRate,
+		&i.Reason,
+		&i.ProviderRefundRef,
+		&i.SubscriptionTerminated,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createInvoice = `-- name: CreateInvoice :one
+INSERT INTO invoices (
+    subscription_id, owner_id, tx_ref, amount, currency, status, billing_period_start, billing_period_end, prorated, credit_amount,
+    coupon_id, discount_amount, vat_rate
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
+    NULLIF($11::uuid, '00000000-0000-0000-0000-000000000000'::uuid), $12, $13
+) RETURNING id, subscription_id, owner_id, invoice_number, amount, currency, status, billing_period_start, billing_period_end, payment_provider_invoice_id, paid_at, created_at, updated_at, prorated, credit_amount, coupon_id, discount_amount, tx_ref, vat_rate, refunded_amount
+`
+
+type CreateInvoiceParams struct {
+	SubscriptionID     uuid.UUID        `db:"subscription_id" json:"subscription_id"`
+	OwnerID            uuid.UUID        `db:"owner_id" json:"owner_id"`
+	TxRef              string           `db:"tx_ref" json:"tx_ref"`
+	Amount             pgtype.Numeric   `db:"amount" json:"amount"`
+	Currency           string           `db:"currency" json:"currency"`
+	Status             InvoiceStatus    `db:"status" json:"status"`
+	BillingPeriodStart pgtype.Timestamp `db:"billing_period_start" json:"billing_period_start"`
+	BillingPeriodEnd   pgtype.Timestamp `db:"billing_period_end" json:"billing_period_end"`
+	Prorated           bool             `db:"prorated" json:"prorated"`
+	CreditAmount       pgtype.Numeric   `db:"credit_amount" json:"credit_amount"`
+	CouponID           uuid.UUID        `db:"coupon_id" json:"coupon_id"`
+	DiscountAmount     pgtype.Numeric   `db:"discount_amount" json:"discount_amount"`
+	VatRate            pgtype.Numeric   `db:"vat_rate" json:"vat_rate"`
+}
+
+func (q *Queries) CreateInvoice(ctx context.Context, arg CreateInvoiceParams) (Invoice, error) {
+	row := q.db.QueryRow(ctx, createInvoice,
+		arg.SubscriptionID,
+		arg.OwnerID,
+		arg.TxRef,
+		arg.Amount,
+		arg.Currency,
+		arg.Status,
+		arg.BillingPeriodStart,
+		arg.BillingPeriodEnd,
+		arg.Prorated,
+		arg.CreditAmount,
+		arg.CouponID,
+		arg.DiscountAmount,
+		arg.VatRate,
+	)
+	var i Invoice
+	err := row.Scan(
+		&i.ID,
+		&i.SubscriptionID,
+		&i.OwnerID,
+		&i.InvoiceNumber,
+		&i.Amount,
+		&i.Currency,
 		&i.Status,
-		&i.CurrentPeriodStart,
-		&i.CurrentPeriodEnd,
-		&i.TrialEnd,
-		&i.CancelledAt,
-		&i.PaymentProviderSubscriptionID,
+		&i.BillingPeriodStart,
+		&i.BillingPeriodEnd,
+		&i.PaymentProviderInvoiceID,
+		&i.PaidAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
-		&i.PlanName,
-		&i.PlanSlug,
-		&i.Features,
+		&i.Prorated,
+		&i.CreditAmount,
+		&i.CouponID,
+		&i.DiscountAmount,
+		&i.TxRef,
+		&i.VatRate,
+		&i.RefundedAmount,
 	)
 	return i, err
 }
 
-const getMenuItemByID = `-- name: GetMenuItemByID :one
-SELECT id, restaurant_id, category_id, name, description, price, currency, images, allergens, dietary_tags, spice_level, calories, is_available, display_order, view_count, created_by, created_at, updated_at FROM menu_items
-WHERE id = $1  LIMIT 1
+const createMenuItem = `-- name: CreateMenuItem :one
+INSERT INTO menu_items (
+    restaurant_id, category_id, name, description, price, currency, images, allergens, dietary_tags, spice_level, calories, is_available, display_order, created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
+) RETURNING id, restaurant_id, category_id, name, description, price, currency, images, allergens, dietary_tags, spice_level, calories, is_available, display_order, view_count, created_by, created_at, updated_at, rating_avg, rating_count, track_stock, stock_quantity, low_stock_threshold, low_stock_notified_at, unavailable_until, plan_locked, was_available_before_lock
 `
 
-func (q *Queries) GetMenuItemByID(ctx context.Context, id uuid.UUID) (MenuItem, error) {
-	row := q.db.QueryRow(ctx, getMenuItemByID, id)
+type CreateMenuItemParams struct {
+	RestaurantID uuid.UUID      `db:"restaurant_id" json:"restaurant_id"`
+	CategoryID   uuid.UUID      `db:"category_id" json:"category_id"`
+	Name         string         `db:"name" json:"name"`
+	Description  pgtype.Text    `db:"description" json:"description"`
+	Price        pgtype.Numeric `db:"price" json:"price"`
+	Currency     string         `db:"currency" json:"currency"`
+	Images       []byte         `db:"images" json:"images"`
+	Allergens    []byte         `db:"allergens" json:"allergens"`
+	DietaryTags  []byte         `db:"dietary_tags" json:"dietary_tags"`
+	SpiceLevel   pgtype.Int4    `db:"spice_level" json:"spice_level"`
+	Calories     pgtype.Int4    `db:"calories" json:"calories"`
+	IsAvailable  bool           `db:"is_available" json:"is_available"`
+	DisplayOrder int32          `db:"display_order" json:"display_order"`
+	CreatedBy    uuid.UUID      `db:"created_by" json:"created_by"`
+}
+
+func (q *Queries) CreateMenuItem(ctx context.Context, arg CreateMenuItemParams) (MenuItem, error) {
+	row := q.db.QueryRow(ctx, createMenuItem,
+		arg.RestaurantID,
+		arg.CategoryID,
+		arg.Name,
+		arg.Description,
+		arg.Price,
+		arg.Currency,
+		arg.Images,
+		arg.Allergens,
+		arg.DietaryTags,
+		arg.SpiceLevel,
+		arg.Calories,
+		arg.IsAvailable,
+		arg.DisplayOrder,
+		arg.CreatedBy,
+	)
 	var i MenuItem
 	err := row.Scan(
 		&i.ID,
@@ -1099,17 +1330,115 @@ func (q *Queries) GetMenuItemByID(ctx context.Context, id uuid.UUID) (MenuItem,
 		&i.CreatedBy,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.RatingAvg,
+		&i.RatingCount,
+		&i.TrackStock,
+		&i.StockQuantity,
+		&i.LowStockThreshold,
+		&i.LowStockNotifiedAt,
+		&i.UnavailableUntil,
+		&i.PlanLocked,
+		&i.WasAvailableBeforeLock,
 	)
 	return i, err
 }
 
-const getPaymentTransactionByTxRef = `-- name: GetPaymentTransactionByTxRef :one
-SELECT id, owner_id, amount, currency, status, tx_ref, reference, provider_transaction_ref, created_at, updated_at FROM payment_transactions
-WHERE tx_ref = $1 LIMIT 1
+const createOpeningHours = `-- name: CreateOpeningHours :one
+INSERT INTO restaurant_opening_hours (
+    restaurant_id, day_of_week, opens_at, closes_at
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING id, restaurant_id, day_of_week, opens_at, closes_at, created_at
 `
 
-func (q *Queries) GetPaymentTransactionByTxRef(ctx context.Context, txRef string) (PaymentTransaction, error) {
-	row := q.db.QueryRow(ctx, getPaymentTransactionByTxRef, txRef)
+type CreateOpeningHoursParams struct {
+	RestaurantID uuid.UUID   `db:"restaurant_id" json:"restaurant_id"`
+	DayOfWeek    int32       `db:"day_of_week" json:"day_of_week"`
+	OpensAt      pgtype.Time `db:"opens_at" json:"opens_at"`
+	ClosesAt     pgtype.Time `db:"closes_at" json:"closes_at"`
+}
+
+func (q *Queries) CreateOpeningHours(ctx context.Context, arg CreateOpeningHoursParams) (RestaurantOpeningHour, error) {
+	row := q.db.QueryRow(ctx, createOpeningHours,
+		arg.RestaurantID,
+		arg.DayOfWeek,
+		arg.OpensAt,
+		arg.ClosesAt,
+	)
+	var i RestaurantOpeningHour
+	err := row.Scan(
+		&i.ID,
+		&i.RestaurantID,
+		&i.DayOfWeek,
+		&i.OpensAt,
+		&i.ClosesAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createPaymentRetryJob = `-- name: CreatePaymentRetryJob :one
+INSERT INTO payment_retry_jobs (
+    subscription_id, scheduled_for
+)
+SELECT $1::uuid, $2::timestamp
+WHERE NOT EXISTS (
+    SELECT 1 FROM payment_retry_jobs j
+    JOIN subscriptions s ON s.id = j.subscription_id
+    WHERE s.owner_id = (SELECT owner_id FROM subscriptions WHERE id = $1)
+      AND j.status IN ('pending', 'retrying')
+)
+ON CONFLICT (subscription_id) WHERE status IN ('pending', 'retrying') DO NOTHING
+RETURNING id, subscription_id, status, retry_count, max_retries, scheduled_for, created_at, updated_at
+`
+
+type CreatePaymentRetryJobParams struct {
+	SubscriptionID uuid.UUID        `db:"subscription_id" json:"subscription_id"`
+	ScheduledFor   pgtype.Timestamp `db:"scheduled_for" json:"scheduled_for"`
+}
+
+func (q *Queries) CreatePaymentRetryJob(ctx context.Context, arg CreatePaymentRetryJobParams) (PaymentRetryJob, error) {
+	row := q.db.QueryRow(ctx, createPaymentRetryJob, arg.SubscriptionID, arg.ScheduledFor)
+	var i PaymentRetryJob
+	err := row.Scan(
+		&i.ID,
+		&i.SubscriptionID,
+		&i.Status,
+		&i.RetryCount,
+		&i.MaxRetries,
+		&i.ScheduledFor,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createPaymentTransaction = `-- name: CreatePaymentTransaction :one
+INSERT INTO payment_transactions (
+    owner_id, amount, currency, status, tx_ref, reference
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING id, owner_id, amount, currency, status, tx_ref, reference, provider_transaction_ref, created_at, updated_at, refunded_amount
+`
+
+type CreatePaymentTransactionParams struct {
+	OwnerID   uuid.UUID      `db:"owner_id" json:"owner_id"`
+	Amount    pgtype.Numeric `db:"amount" json:"amount"`
+	Currency  string         `db:"currency" json:"currency"`
+	Status    string         `db:"status" json:"status"`
+	TxRef     string         `db:"tx_ref" json:"tx_ref"`
+	Reference pgtype.Text    `db:"reference" json:"reference"`
+}
+
+func (q *Queries) CreatePaymentTransaction(ctx context.Context, arg CreatePaymentTransactionParams) (PaymentTransaction, error) {
+	row := q.db.QueryRow(ctx, createPaymentTransaction,
+		arg.OwnerID,
+		arg.Amount,
+		arg.Currency,
+		arg.Status,
+		arg.TxRef,
+		arg.Reference,
+	)
 	var i PaymentTransaction
 	err := row.Scan(
 		&i.ID,
@@ -1122,183 +1451,236 @@ func (q *Queries) GetPaymentTransactionByTxRef(ctx context.Context, txRef string
 		&i.ProviderTransactionRef,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.RefundedAmount,
 	)
 	return i, err
 }
 
-const getRecentAdminLogs = `-- name: GetRecentAdminLogs :many
-SELECT al.id, al.restaurant_id, al.user_id, al.action_type, al.action_category, al.description, al.target_type, al.target_id, al.target_name, al.before_value, al.after_value, al.ip_address, al.user_agent, al.device_type, al.browser, al.os, al.success, al.created_at, u.full_name as user_name, u.email as user_email
-FROM activity_logs al
-JOIN users u ON al.user_id = u.id
-ORDER BY al.created_at DESC
-LIMIT $1
+const createPaymentWebhook = `-- name: CreatePaymentWebhook :one
+INSERT INTO payment_webhooks (
+    provider_event_id, event_type, payload
+) VALUES (
+    $1, $2, $3
+) ON CONFLICT (provider_event_id) DO NOTHING
+RETURNING id, provider_event_id, event_type, payload, processed, created_at
 `
 
-type GetRecentAdminLogsRow struct {
-	ID             uuid.UUID        `db:"id" json:"id"`
-	RestaurantID   uuid.UUID        `db:"restaurant_id" json:"restaurant_id"`
-	UserID         uuid.UUID        `db:"user_id" json:"user_id"`
-	ActionType     string           `db:"action_type" json:"action_type"`
-	ActionCategory string           `db:"action_category" json:"action_category"`
-	Description    pgtype.Text      `db:"description" json:"description"`
-	TargetType     pgtype.Text      `db:"target_type" json:"target_type"`
-	TargetID       uuid.UUID        `db:"target_id" json:"target_id"`
-	TargetName     pgtype.Text      `db:"target_name" json:"target_name"`
-	BeforeValue    []byte           `db:"before_value" json:"before_value"`
-	AfterValue     []byte           `db:"after_value" json:"after_value"`
-	IpAddress      pgtype.Text      `db:"ip_address" json:"ip_address"`
-	UserAgent      pgtype.Text      `db:"user_agent" json:"user_agent"`
-	DeviceType     pgtype.Text      `db:"device_type" json:"device_type"`
-	Browser        pgtype.Text      `db:"browser" json:"browser"`
-	Os             pgtype.Text      `db:"os" json:"os"`
-	Success        pgtype.Bool      `db:"success" json:"success"`
-	CreatedAt      pgtype.Timestamp `db:"created_at" json:"created_at"`
-	UserName       string           `db:"user_name" json:"user_name"`
-	UserEmail      string           `db:"user_email" json:"user_email"`
-}
-
-func (q *Queries) GetRecentAdminLogs(ctx context.Context, limit int32) ([]GetRecentAdminLogsRow, error) {
-	rows, err := q.db.Query(ctx, getRecentAdminLogs, limit)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []GetRecentAdminLogsRow
-	for rows.Next() {
-		var i GetRecentAdminLogsRow
-		if err := rows.Scan(
-			&i.ID,
-			&i.RestaurantID,
-			&i.UserID,
-			&i.ActionType,
-			&i.ActionCategory,
-			&i.Description,
-			&i.TargetType,
-			&i.TargetID,
-			&i.TargetName,
-			&i.BeforeValue,
-			&i.AfterValue,
-			&i.IpAddress,
-			&i.UserAgent,
-			&i.DeviceType,
-			&i.Browser,
-			&i.Os,
-			&i.Success,
-			&i.CreatedAt,
-			&i.UserName,
-			&i.UserEmail,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+type CreatePaymentWebhookParams struct {
+	ProviderEventID pgtype.Text `db:"provider_event_id" json:"provider_event_id"`
+	EventType       string      `db:"event_type" json:"event_type"`
+	Payload         []byte      `db:"payload" json:"payload"`
 }
 
-const getRestaurantByID = `-- name: GetRestaurantByID :one
-SELECT id, owner_id, name, slug, description, cuisine_type, phone, email, website, address, city, country, logo_url, cover_image_url, theme_settings, is_published, view_count, rank_score, created_at, updated_at FROM restaurants
-WHERE id = $1  LIMIT 1
+func (q *Queries) CreatePaymentWebhook(ctx context.Context, arg CreatePaymentWebhookParams) (PaymentWebhook, error) {
+	row := q.db.QueryRow(ctx, createPaymentWebhook, arg.ProviderEventID, arg.EventType, arg.Payload)
+	var i PaymentWebhook
+	err := row.Scan(
+		&i.ID,
+		&i.ProviderEventID,
+		&i.EventType,
+		&i.Payload,
+		&i.Processed,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createPromotion = `-- name: CreatePromotion :one
+INSERT INTO promotions (
+    restaurant_id, name, label, scope, category_id, menu_item_id, discount_type, discount_value,
+    starts_on, ends_on, days_of_week, start_time, end_time, is_active, created_by
+) VALUES (
+    $1, $2, $3, $4, NULLIF($5::uuid, '00000000-0000-0000-0000-000000000000'::uuid), NULLIF($6::uuid, '00000000-0000-0000-0000-000000000000'::uuid), $7, $8,
+    $9, $10, $11, $12, $13, $14, $15
+) RETURNING id, restaurant_id, name, label, scope, category_id, menu_item_id, discount_type, discount_value, starts_on, ends_on, days_of_week, start_time, end_time, is_active, created_by, created_at, updated_at
 `
 
-func (q *Queries) GetRestaurantByID(ctx context.Context, id uuid.UUID) (Restaurant, error) {
-	row := q.db.QueryRow(ctx, getRestaurantByID, id)
-	var i Restaurant
+type CreatePromotionParams struct {
+	RestaurantID  uuid.UUID      `db:"restaurant_id" json:"restaurant_id"`
+	Name          string         `db:"name" json:"name"`
+	Label         string         `db:"label" json:"label"`
+	Scope         PromotionScope `db:"scope" json:"scope"`
+	CategoryID    uuid.UUID      `db:"category_id" json:"category_id"`
+	MenuItemID    uuid.UUID      `db:"menu_item_id" json:"menu_item_id"`
+	DiscountType  DiscountType   `db:"discount_type" json:"discount_type"`
+	DiscountValue pgtype.Numeric `db:"discount_value" json:"discount_value"`
+	StartsOn      pgtype.Date    `db:"starts_on" json:"starts_on"`
+	EndsOn        pgtype.Date    `db:"ends_on" json:"ends_on"`
+	DaysOfWeek    []int32        `db:"days_of_week" json:"days_of_week"`
+	StartTime     pgtype.Time    `db:"start_time" json:"start_time"`
+	EndTime       pgtype.Time    `db:"end_time" json:"end_time"`
+	IsActive      bool           `db:"is_active" json:"is_active"`
+	CreatedBy     uuid.UUID      `db:"created_by" json:"created_by"`
+}
+
+func (q *Queries) CreatePromotion(ctx context.Context, arg CreatePromotionParams) (Promotion, error) {
+	row := q.db.QueryRow(ctx, createPromotion,
+		arg.RestaurantID,
+		arg.Name,
+		arg.Label,
+		arg.Scope,
+		arg.CategoryID,
+		arg.MenuItemID,
+		arg.DiscountType,
+		arg.DiscountValue,
+		arg.StartsOn,
+		arg.EndsOn,
+		arg.DaysOfWeek,
+		arg.StartTime,
+		arg.EndTime,
+		arg.IsActive,
+		arg.CreatedBy,
+	)
+	var i Promotion
 	err := row.Scan(
 		&i.ID,
-		&i.OwnerID,
+		&i.RestaurantID,
 		&i.Name,
-		&i.Slug,
-		&i.Description,
-		&i.CuisineType,
-		&i.Phone,
-		&i.Email,
-		&i.Website,
-		&i.Address,
-		&i.City,
-		&i.Country,
-		&i.LogoUrl,
-		&i.CoverImageUrl,
-		&i.ThemeSettings,
-		&i.IsPublished,
-		&i.ViewCount,
-		&i.RankScore,
+		&i.Label,
+		&i.Scope,
+		&i.CategoryID,
+		&i.MenuItemID,
+		&i.DiscountType,
+		&i.DiscountValue,
+		&i.StartsOn,
+		&i.EndsOn,
+		&i.DaysOfWeek,
+		&i.StartTime,
+		&i.EndTime,
+		&i.IsActive,
+		&i.CreatedBy,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
 	return i, err
 }
 
-const getRestaurantBySlug = `-- name: GetRestaurantBySlug :one
-SELECT id, owner_id, name, slug, description, cuisine_type, phone, email, website, address, city, country, logo_url, cover_image_url, theme_settings, is_published, view_count, rank_score, created_at, updated_at FROM restaurants
-WHERE slug = $1  LIMIT 1
+const createRecoveryCode = `-- name: CreateRecoveryCode :exec
+INSERT INTO user_recovery_codes (user_id, code_hash)
+VALUES ($1, $2)
 `
 
-func (q *Queries) GetRestaurantBySlug(ctx context.Context, slug string) (Restaurant, error) {
-	row := q.db.QueryRow(ctx, getRestaurantBySlug, slug)
-	var i Restaurant
+type CreateRecoveryCodeParams struct {
+	UserID   uuid.UUID `db:"user_id" json:"user_id"`
+	CodeHash string    `db:"code_hash" json:"code_hash"`
+}
+
+func (q *Queries) CreateRecoveryCode(ctx context.Context, arg CreateRecoveryCodeParams) error {
+	_, err := q.db.Exec(ctx, createRecoveryCode, arg.UserID, arg.CodeHash)
+	return err
+}
+
+const createReservation = `-- name: CreateReservation :one
+INSERT INTO reservations (
+    restaurant_id, table_id, guest_name, guest_phone, guest_email, party_size,
+    reserved_at, ends_at, notes, confirmation_token, cancellation_token
+)
+SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+WHERE NOT EXISTS (
+    SELECT 1 FROM reservations r
+    WHERE r.table_id = $2
+      AND r.status IN ('pending', 'confirmed', 'seated')
+      AND r.reserved_at < $8
+      AND r.ends_at > $7
+)
+RETURNING id, restaurant_id, table_id, guest_name, guest_phone, guest_email, party_size, reserved_at, ends_at, notes, status, confirmation_token, cancellation_token, confirmed_at, seated_at, cancelled_at, created_at, updated_at
+`
+
+type CreateReservationParams struct {
+	RestaurantID      uuid.UUID        `db:"restaurant_id" json:"restaurant_id"`
+	TableID           uuid.UUID        `db:"table_id" json:"table_id"`
+	GuestName         string           `db:"guest_name" json:"guest_name"`
+	GuestPhone        string           `db:"guest_phone" json:"guest_phone"`
+	GuestEmail        string           `db:"guest_email" json:"guest_email"`
+	PartySize         int32            `db:"party_size" json:"party_size"`
+	ReservedAt        pgtype.Timestamp `db:"reserved_at" json:"reserved_at"`
+	EndsAt            pgtype.Timestamp `db:"ends_at" json:"ends_at"`
+	Notes             pgtype.Text      `db:"notes" json:"notes"`
+	ConfirmationToken string           `db:"confirmation_token" json:"confirmation_token"`
+	CancellationToken string           `db:"cancellation_token" json:"cancellation_token"`
+}
+
+func (q *Queries) CreateReservation(ctx context.Context, arg CreateReservationParams) (Reservation, error) {
+	row := q.db.QueryRow(ctx, createReservation,
+		arg.RestaurantID,
+		arg.TableID,
+		arg.GuestName,
+		arg.GuestPhone,
+		arg.GuestEmail,
+		arg.PartySize,
+		arg.ReservedAt,
+		arg.EndsAt,
+		arg.Notes,
+		arg.ConfirmationToken,
+		arg.CancellationToken,
+	)
+	var i Reservation
 	err := row.Scan(
 		&i.ID,
-		&i.OwnerID,
-		&i.Name,
-		&i.Slug,
-		&i.Description,
-		&i.CuisineType,
-		&i.Phone,
-		&i.Email,
-		&i.Website,
-		&i.Address,
-		&i.City,
-		&i.Country,
-		&i.LogoUrl,
-		&i.CoverImageUrl,
-		&i.ThemeSettings,
-		&i.IsPublished,
-		&i.ViewCount,
-		&i.RankScore,
+		&i.RestaurantID,
+		&i.TableID,
+		&i.GuestName,
+		&i.GuestPhone,
+		&i.GuestEmail,
+		&i.PartySize,
+		&i.ReservedAt,
+		&i.EndsAt,
+		&i.Notes,
+		&i.Status,
+		&i.ConfirmationToken,
+		&i.CancellationToken,
+		&i.ConfirmedAt,
+		&i.SeatedAt,
+		&i.CancelledAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
 	return i, err
 }
 
-const getRestaurantDetailsForAdmin = `-- name: GetRestaurantDetailsForAdmin :one
-SELECT r.id, r.owner_id, r.name, r.slug, r.description, r.cuisine_type, r.phone, r.email, r.website, r.address, r.city, r.country, r.logo_url, r.cover_image_url, r.theme_settings, r.is_published, r.view_count, r.rank_score, r.created_at, r.updated_at, u.full_name as owner_name, u.email as owner_email
-FROM restaurants r
-JOIN users u ON r.owner_id = u.id
-WHERE r.id = $1
+const createRestaurant = `-- name: CreateRestaurant :one
+INSERT INTO restaurants (
+    owner_id, name, slug, description, cuisine_type, phone, email, website, address, city, country, logo_url, cover_image_url, theme_settings
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
+) RETURNING id, owner_id, name, slug, description, cuisine_type, phone, email, website, address, city, country, logo_url, cover_image_url, theme_settings, is_published, view_count, rank_score, created_at, updated_at, rating_avg, rating_count, plan_locked, timezone
 `
 
-type GetRestaurantDetailsForAdminRow struct {
-	ID            uuid.UUID        `db:"id" json:"id"`
-	OwnerID       uuid.UUID        `db:"owner_id" json:"owner_id"`
-	Name          string           `db:"name" json:"name"`
-	Slug          string           `db:"slug" json:"slug"`
-	Description   pgtype.Text      `db:"description" json:"description"`
-	CuisineType   pgtype.Text      `db:"cuisine_type" json:"cuisine_type"`
-	Phone         pgtype.Text      `db:"phone" json:"phone"`
-	Email         pgtype.Text      `db:"email" json:"email"`
-	Website       pgtype.Text      `db:"website" json:"website"`
-	Address       pgtype.Text      `db:"address" json:"address"`
-	City          pgtype.Text      `db:"city" json:"city"`
-	Country       pgtype.Text      `db:"country" json:"country"`
-	LogoUrl       pgtype.Text      `db:"logo_url" json:"logo_url"`
-	CoverImageUrl pgtype.Text      `db:"cover_image_url" json:"cover_image_url"`
-	ThemeSettings []byte           `db:"theme_settings" json:"theme_settings"`
-	IsPublished   bool             `db:"is_published" json:"is_published"`
-	ViewCount     pgtype.Int4      `db:"view_count" json:"view_count"`
-	RankScore     pgtype.Numeric   `db:"rank_score" json:"rank_score"`
-	CreatedAt     pgtype.Timestamp `db:"created_at" json:"created_at"`
-	UpdatedAt     pgtype.Timestamp `db:"updated_at" json:"updated_at"`
-	OwnerName     string           `db:"owner_name" json:"owner_name"`
-	OwnerEmail    string           `db:"owner_email" json:"owner_email"`
+type CreateRestaurantParams struct {
+	OwnerID       uuid.UUID   `db:"owner_id" json:"owner_id"`
+	Name          string      `db:"name" json:"name"`
+	Slug          string      `db:"slug" json:"slug"`
+	Description   pgtype.Text `db:"description" json:"description"`
+	CuisineType   pgtype.Text `db:"cuisine_type" json:"cuisine_type"`
+	Phone         pgtype.Text `db:"phone" json:"phone"`
+	Email         pgtype.Text `db:"email" json:"email"`
+	Website       pgtype.Text `db:"website" json:"website"`
+	Address       pgtype.Text `db:"address" json:"address"`
+	City          pgtype.Text `db:"city" json:"city"`
+	Country       pgtype.Text `db:"country" json:"country"`
+	LogoUrl       pgtype.Text `db:"logo_url" json:"logo_url"`
+	CoverImageUrl pgtype.Text `db:"cover_image_url" json:"cover_image_url"`
+	ThemeSettings []byte      `db:"theme_settings" json:"theme_settings"`
 }
 
-func (q *Queries) GetRestaurantDetailsForAdmin(ctx context.Context, id uuid.UUID) (GetRestaurantDetailsForAdminRow, error) {
-	row := q.db.QueryRow(ctx, getRestaurantDetailsForAdmin, id)
-	var i GetRestaurantDetailsForAdminRow
+func (q *Queries) CreateRestaurant(ctx context.Context, arg CreateRestaurantParams) (Restaurant, error) {
+	row := q.db.QueryRow(ctx, createRestaurant,
+		arg.OwnerID,
+		arg.Name,
+		arg.Slug,
+		arg.Description,
+		arg.CuisineType,
+		arg.Phone,
+		arg.Email,
+		arg.Website,
+		arg.Address,
+		arg.City,
+		arg.Country,
+		arg.LogoUrl,
+		arg.CoverImageUrl,
+		arg.ThemeSettings,
+	)
+	var i Restaurant
 	err := row.Scan(
 		&i.ID,
 		&i.OwnerID,
@@ -1320,185 +1702,3751 @@ func (q *Queries) GetRestaurantDetailsForAdmin(ctx context.Context, id uuid.UUID
 		&i.RankScore,
 		&i.CreatedAt,
 		&i.UpdatedAt,
-		&i.OwnerName,
-		&i.OwnerEmail,
+		&i.RatingAvg,
+		&i.RatingCount,
+		&i.PlanLocked,
+		&i.Timezone,
 	)
 	return i, err
 }
 
-const getSubscriptionPlanBySlug = `-- name: GetSubscriptionPlanBySlug :one
-SELECT id, name, slug, description, price_monthly, price_annual, currency, features, display_order, is_active, created_at, updated_at FROM subscription_plans
-WHERE slug = $1 LIMIT 1
+const createRestaurantTable = `-- name: CreateRestaurantTable :one
+INSERT INTO restaurant_tables (
+    restaurant_id, label, token, seats
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING id, restaurant_id, label, token, is_active, created_at, updated_at, seats
 `
 
-func (q *Queries) GetSubscriptionPlanBySlug(ctx context.Context, slug string) (SubscriptionPlan, error) {
-	row := q.db.QueryRow(ctx, getSubscriptionPlanBySlug, slug)
-	var i SubscriptionPlan
+type CreateRestaurantTableParams struct {
+	RestaurantID uuid.UUID `db:"restaurant_id" json:"restaurant_id"`
+	Label        string    `db:"label" json:"label"`
+	Token        string    `db:"token" json:"token"`
+	Seats        int32     `db:"seats" json:"seats"`
+}
+
+func (q *Queries) CreateRestaurantTable(ctx context.Context, arg CreateRestaurantTableParams) (RestaurantTable, error) {
+	row := q.db.QueryRow(ctx, createRestaurantTable,
+		arg.RestaurantID,
+		arg.Label,
+		arg.Token,
+		arg.Seats,
+	)
+	var i RestaurantTable
 	err := row.Scan(
 		&i.ID,
-		&i.Name,
-		&i.Slug,
-		&i.Description,
-		&i.PriceMonthly,
-		&i.PriceAnnual,
-		&i.Currency,
-		&i.Features,
-		&i.DisplayOrder,
+		&i.RestaurantID,
+		&i.Label,
+		&i.Token,
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Seats,
 	)
 	return i, err
 }
 
-const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, email, password_hash, full_name, role, owner_id, restaurant_id, phone, avatar_url, email_verified, last_login_at, is_active, created_at, updated_at, email_verified_at, verification_token, verification_token_expires_at, trial_ends_at FROM users
-WHERE email = $1 LIMIT 1
+const createReview = `-- name: CreateReview :one
+INSERT INTO reviews (
+    restaurant_id, menu_item_id, reviewer_name, rating, comment, photo_url, ip_address
+) VALUES (
+    $1, NULLIF($2::uuid, '00000000-0000-0000-0000-000000000000'::uuid), $3, $4, $5, $6, $7
+) RETURNING id, restaurant_id, menu_item_id, reviewer_name, rating, comment, photo_url, owner_reply, replied_by, replied_at, is_hidden, hidden_reason, report_count, ip_address, created_at, updated_at
 `
 
-func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
-	row := q.db.QueryRow(ctx, getUserByEmail, email)
-	var i User
+type CreateReviewParams struct {
+	RestaurantID uuid.UUID   `db:"restaurant_id" json:"restaurant_id"`
+	MenuItemID   uuid.UUID   `db:"menu_item_id" json:"menu_item_id"`
+	ReviewerName string      `db:"reviewer_name" json:"reviewer_name"`
+	Rating       int32       `db:"rating" json:"rating"`
+	Comment      pgtype.Text `db:"comment" json:"comment"`
+	PhotoUrl     pgtype.Text `db:"photo_url" json:"photo_url"`
+	IpAddress    pgtype.Text `db:"ip_address" json:"ip_address"`
+}
+
+func (q *Queries) CreateReview(ctx context.Context, arg CreateReviewParams) (Review, error) {
+	row := q.db.QueryRow(ctx, createReview,
+		arg.RestaurantID,
+		arg.MenuItemID,
+		arg.ReviewerName,
+		arg.Rating,
+		arg.Comment,
+		arg.PhotoUrl,
+		arg.IpAddress,
+	)
+	var i Review
 	err := row.Scan(
 		&i.ID,
-		&i.Email,
-		&i.PasswordHash,
-		&i.FullName,
-		&i.Role,
-		&i.OwnerID,
 		&i.RestaurantID,
-		&i.Phone,
-		&i.AvatarUrl,
-		&i.EmailVerified,
-		&i.LastLoginAt,
-		&i.IsActive,
+		&i.MenuItemID,
+		&i.ReviewerName,
+		&i.Rating,
+		&i.Comment,
+		&i.PhotoUrl,
+		&i.OwnerReply,
+		&i.RepliedBy,
+		&i.RepliedAt,
+		&i.IsHidden,
+		&i.HiddenReason,
+		&i.ReportCount,
+		&i.IpAddress,
 		&i.CreatedAt,
 		&i.UpdatedAt,
-		&i.EmailVerifiedAt,
-		&i.VerificationToken,
-		&i.VerificationTokenExpiresAt,
-		&i.TrialEndsAt,
 	)
 	return i, err
 }
 
-const getUserByID = `-- name: GetUserByID :one
-SELECT id, email, password_hash, full_name, role, owner_id, restaurant_id, phone, avatar_url, email_verified, last_login_at, is_active, created_at, updated_at, email_verified_at, verification_token, verification_token_expires_at, trial_ends_at FROM users
-WHERE id = $1  LIMIT 1
+const createServiceRequest = `-- name: CreateServiceRequest :one
+INSERT INTO service_requests (
+    restaurant_id, table_id, request_type, note
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING id, restaurant_id, table_id, request_type, note, status, acknowledged_by, acknowledged_at, created_at
 `
 
-func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (User, error) {
-	row := q.db.QueryRow(ctx, getUserByID, id)
-	var i User
+type CreateServiceRequestParams struct {
+	RestaurantID uuid.UUID          `db:"restaurant_id" json:"restaurant_id"`
+	TableID      uuid.UUID          `db:"table_id" json:"table_id"`
+	RequestType  ServiceRequestType `db:"request_type" json:"request_type"`
+	Note         pgtype.Text        `db:"note" json:"note"`
+}
+
+func (q *Queries) CreateServiceRequest(ctx context.Context, arg CreateServiceRequestParams) (ServiceRequest, error) {
+	row := q.db.QueryRow(ctx, createServiceRequest,
+		arg.RestaurantID,
+		arg.TableID,
+		arg.RequestType,
+		arg.Note,
+	)
+	var i ServiceRequest
 	err := row.Scan(
 		&i.ID,
-		&i.Email,
-		&i.PasswordHash,
-		&i.FullName,
-		&i.Role,
-		&i.OwnerID,
 		&i.RestaurantID,
-		&i.Phone,
-		&i.AvatarUrl,
-		&i.EmailVerified,
-		&i.LastLoginAt,
-		&i.IsActive,
+		&i.TableID,
+		&i.RequestType,
+		&i.Note,
+		&i.Status,
+		&i.AcknowledgedBy,
+		&i.AcknowledgedAt,
 		&i.CreatedAt,
-		&i.UpdatedAt,
-		&i.EmailVerifiedAt,
-		&i.VerificationToken,
-		&i.VerificationTokenExpiresAt,
-		&i.TrialEndsAt,
 	)
 	return i, err
 }
 
-const incrementMenuItemViewCount = `-- name: IncrementMenuItemViewCount :exec
-UPDATE menu_items SET view_count = view_count + 1 WHERE id = $1
+const createStaffInvitation = `-- name: CreateStaffInvitation :one
+INSERT INTO staff_invitations (
+    restaurant_id, owner_id, email, full_name, phone, token_hash, expires_at, staff_role_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, NULLIF($8::uuid, '00000000-0000-0000-0000-000000000000'::uuid)
+) RETURNING id, restaurant_id, owner_id, email, full_name, phone, token_hash, status, expires_at, accepted_user_id, accepted_at, created_at, updated_at, staff_role_id
 `
 
-func (q *Queries) IncrementMenuItemViewCount(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.Exec(ctx, incrementMenuItemViewCount, id)
+type CreateStaffInvitationParams struct {
+	RestaurantID uuid.UUID        `db:"restaurant_id" json:"restaurant_id"`
+	OwnerID      uuid.UUID        `db:"owner_id" json:"owner_id"`
+	Email        string           `db:"email" json:"email"`
+	FullName     string           `db:"full_name" json:"full_name"`
+	Phone        pgtype.Text      `db:"phone" json:"phone"`
+	TokenHash    string           `db:"token_hash" json:"token_hash"`
+	ExpiresAt    pgtype.Timestamp `db:"expires_at" json:"expires_at"`
+	StaffRoleID  uuid.UUID        `db:"staff_role_id" json:"staff_role_id"`
+}
+
+func (q *Queries) CreateStaffInvitation(ctx context.Context, arg CreateStaffInvitationParams) (StaffInvitation, error) {
+	row := q.db.QueryRow(ctx, createStaffInvitation,
+		arg.RestaurantID,
+		arg.OwnerID,
+		arg.Email,
+		arg.FullName,
+		arg.Phone,
+		arg.TokenHash,
+		arg.ExpiresAt,
+		arg.StaffRoleID,
+	)
+	var i StaffInvitation
+	err := row.Scan(
+		&i.ID,
+		&i.RestaurantID,
+		&i.OwnerID,
+		&i.Email,
+		&i.FullName,
+		&i.Phone,
+		&i.TokenHash,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.AcceptedUserID,
+		&i.AcceptedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.StaffRoleID,
+	)
+	return i, err
+}
+
+const createStaffRole = `-- name: CreateStaffRole :one
+INSERT INTO staff_roles (
+    restaurant_id, name, description, permissions, preset
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING id, restaurant_id, name, description, permissions, preset, created_at, updated_at
+`
+
+type CreateStaffRoleParams struct {
+	RestaurantID uuid.UUID   `db:"restaurant_id" json:"restaurant_id"`
+	Name         string      `db:"name" json:"name"`
+	Description  pgtype.Text `db:"description" json:"description"`
+	Permissions  []string    `db:"permissions" json:"permissions"`
+	Preset       pgtype.Text `db:"preset" json:"preset"`
+}
+
+func (q *Queries) CreateStaffRole(ctx context.Context, arg CreateStaffRoleParams) (StaffRole, error) {
+	row := q.db.QueryRow(ctx, createStaffRole,
+		arg.RestaurantID,
+		arg.Name,
+		arg.Description,
+		arg.Permissions,
+		arg.Preset,
+	)
+	var i StaffRole
+	err := row.Scan(
+		&i.ID,
+		&i.RestaurantID,
+		&i.Name,
+		&i.Description,
+		&i.Permissions,
+		&i.Preset,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createSubscription = `-- name: CreateSubscription :one
+INSERT INTO subscriptions (
+    owner_id, plan_id, status, current_period_start, current_period_end, trial_end, billing_interval
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING id, owner_id, plan_id, status, current_period_start, current_period_end, trial_end, cancelled_at, payment_provider_subscription_id, created_at, updated_at, billing_interval, scheduled_plan_id, scheduled_billing_interval, cancel_at_period_end
+`
+
+type CreateSubscriptionParams struct {
+	OwnerID            uuid.UUID          `db:"owner_id" json:"owner_id"`
+	PlanID             uuid.UUID          `db:"plan_id" json:"plan_id"`
+	Status             SubscriptionStatus `db:"status" json:"status"`
+	CurrentPeriodStart pgtype.Timestamp   `db:"current_period_start" json:"current_period_start"`
+	CurrentPeriodEnd   pgtype.Timestamp   `db:"current_period_end" json:"current_period_end"`
+	TrialEnd           pgtype.Timestamp   `db:"trial_end" json:"trial_end"`
+	BillingInterval    BillingInterval    `db:"billing_interval" json:"billing_interval"`
+}
+
+func (q *Queries) CreateSubscription(ctx context.Context, arg CreateSubscriptionParams) (Subscription, error) {
+	row := q.db.QueryRow(ctx, createSubscription,
+		arg.OwnerID,
+		arg.PlanID,
+		arg.Status,
+		arg.CurrentPeriodStart,
+		arg.CurrentPeriodEnd,
+		arg.TrialEnd,
+		arg.BillingInterval,
+	)
+	var i Subscription
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.PlanID,
+		&i.Status,
+		&i.CurrentPeriodStart,
+		&i.CurrentPeriodEnd,
+		&i.TrialEnd,
+		&i.CancelledAt,
+		&i.PaymentProviderSubscriptionID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.BillingInterval,
+		&i.ScheduledPlanID,
+		&i.ScheduledBillingInterval,
+		&i.CancelAtPeriodEnd,
+	)
+	return i, err
+}
+
+const createSubscriptionCancellation = `-- name: CreateSubscriptionCancellation :one
+INSERT INTO subscription_cancellations (
+    subscription_id, owner_id, plan_id, reason, feedback, immediate
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING id, subscription_id, owner_id, plan_id, reason, feedback, immediate, resumed_at, created_at
+`
+
+type CreateSubscriptionCancellationParams struct {
+	SubscriptionID uuid.UUID   `db:"subscription_id" json:"subscription_id"`
+	OwnerID        uuid.UUID   `db:"owner_id" json:"owner_id"`
+	PlanID         uuid.UUID   `db:"plan_id" json:"plan_id"`
+	Reason         string      `db:"reason" json:"reason"`
+	Feedback       pgtype.Text `db:"feedback" json:"feedback"`
+	Immediate      bool        `db:"immediate" json:"immediate"`
+}
+
+func (q *Queries) CreateSubscriptionCancellation(ctx context.Context, arg CreateSubscriptionCancellationParams) (SubscriptionCancellation, error) {
+	row := q.db.QueryRow(ctx, createSubscriptionCancellation,
+		arg.SubscriptionID,
+		arg.OwnerID,
+		arg.PlanID,
+		arg.Reason,
+		arg.Feedback,
+		arg.Immediate,
+	)
+	var i SubscriptionCancellation
+	err := row.Scan(
+		&i.ID,
+		&i.SubscriptionID,
+		&i.OwnerID,
+		&i.PlanID,
+		&i.Reason,
+		&i.Feedback,
+		&i.Immediate,
+		&i.ResumedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createSubscriptionPlan = `-- name: CreateSubscriptionPlan :one
+INSERT INTO subscription_plans (
+    name, slug, description, price_monthly, price_annual, currency, features, display_order
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING id, name, slug, description, price_monthly, price_annual, currency, features, display_order, is_active, created_at, updated_at
+`
+
+type CreateSubscriptionPlanParams struct {
+	Name         string      `db:"name" json:"name"`
+	Slug         string      `db:"slug" json:"slug"`
+	Description  pgtype.Text `db:"description" json:"description"`
+	PriceMonthly int32       `db:"price_monthly" json:"price_monthly"`
+	PriceAnnual  pgtype.Int4 `db:"price_annual" json:"price_annual"`
+	Currency     string      `db:"currency" json:"currency"`
+	Features     []byte      `db:"features" json:"features"`
+	DisplayOrder int32       `db:"display_order" json:"display_order"`
+}
+
+func (q *Queries) CreateSubscriptionPlan(ctx context.Context, arg CreateSubscriptionPlanParams) (SubscriptionPlan, error) {
+	row := q.db.QueryRow(ctx, createSubscriptionPlan,
+		arg.Name,
+		arg.Slug,
+		arg.Description,
+		arg.PriceMonthly,
+		arg.PriceAnnual,
+		arg.Currency,
+		arg.Features,
+		arg.DisplayOrder,
+	)
+	var i SubscriptionPlan
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Slug,
+		&i.Description,
+		&i.PriceMonthly,
+		&i.PriceAnnual,
+		&i.Currency,
+		&i.Features,
+		&i.DisplayOrder,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createUser = `-- name: CreateUser :one
+INSERT INTO users (
+    id, owner_id, email, password_hash, full_name, role, restaurant_id, phone, avatar_url
+) VALUES (
+    $1, $2, $3, $4, $5, $6, 
+	NULLIF($7, '00000000-0000-0000-0000-000000000000'::uuid), 
+    $8, $9
+) RETURNING id, email, password_hash, full_name, role, owner_id, restaurant_id, phone, avatar_url, email_verified, last_login_at, is_active, created_at, updated_at, email_verified_at, verification_token, verification_token_expires_at, trial_ends_at, must_change_password, password_changed_at, staff_role_id
+`
+
+type CreateUserParams struct {
+	ID           uuid.UUID   `db:"id" json:"id"`
+	OwnerID      uuid.UUID   `db:"owner_id" json:"owner_id"`
+	Email        string      `db:"email" json:"email"`
+	PasswordHash string      `db:"password_hash" json:"password_hash"`
+	FullName     string      `db:"full_name" json:"full_name"`
+	Role         UserRole    `db:"role" json:"role"`
+	RestaurantID uuid.UUID   `db:"restaurant_id" json:"restaurant_id"`
+	Phone        pgtype.Text `db:"phone" json:"phone"`
+	AvatarUrl    pgtype.Text `db:"avatar_url" json:"avatar_url"`
+}
+
+func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
+	fmt.Printf("this is the data %+v\n", arg)
+	row := q.db.QueryRow(ctx, createUser,
+		arg.ID,
+		arg.OwnerID,
+		arg.Email,
+		arg.PasswordHash,
+		arg.FullName,
+		arg.Role,
+		arg.RestaurantID,
+		arg.Phone,
+		arg.AvatarUrl,
+	)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.PasswordHash,
+		&i.FullName,
+		&i.Role,
+		&i.OwnerID,
+		&i.RestaurantID,
+		&i.Phone,
+		&i.AvatarUrl,
+		&i.EmailVerified,
+		&i.LastLoginAt,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.EmailVerifiedAt,
+		&i.VerificationToken,
+		&i.VerificationTokenExpiresAt,
+		&i.TrialEndsAt,
+		&i.MustChangePassword,
+		&i.PasswordChangedAt,
+		&i.StaffRoleID,
+	)
+	return i, err
+}
+
+const createUserIdentity = `-- name: CreateUserIdentity :one
+INSERT INTO user_identities (
+    user_id, provider, subject, email, last_login_at
+) VALUES (
+    $1, $2, $3, $4, NOW()
+) RETURNING id, user_id, provider, subject, email, created_at, last_login_at
+`
+
+type CreateUserIdentityParams struct {
+	UserID   uuid.UUID   `db:"user_id" json:"user_id"`
+	Provider string      `db:"provider" json:"provider"`
+	Subject  string      `db:"subject" json:"subject"`
+	Email    pgtype.Text `db:"email" json:"email"`
+}
+
+func (q *Queries) CreateUserIdentity(ctx context.Context, arg CreateUserIdentityParams) (UserIdentity, error) {
+	row := q.db.QueryRow(ctx, createUserIdentity,
+		arg.UserID,
+		arg.Provider,
+		arg.Subject,
+		arg.Email,
+	)
+	var i UserIdentity
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Provider,
+		&i.Subject,
+		&i.Email,
+		&i.CreatedAt,
+		&i.LastLoginAt,
+	)
+	return i, err
+}
+
+const deactivateCoupon = `-- name: DeactivateCoupon :execrows
+UPDATE coupons
+SET is_active = FALSE, updated_at = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) DeactivateCoupon(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deactivateCoupon, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteCategory = `-- name: DeleteCategory :exec
+DELETE FROM categories WHERE id = $1
+`
+
+func (q *Queries) DeleteCategory(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteCategory, id)
 	return err
 }
 
-const incrementRestaurantViewCount = `-- name: IncrementRestaurantViewCount :exec
-UPDATE restaurants SET view_count = view_count + 1 WHERE id = $1
+const deleteMenuItem = `-- name: DeleteMenuItem :exec
+DELETE FROM menu_items WHERE id = $1
 `
 
-func (q *Queries) IncrementRestaurantViewCount(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.Exec(ctx, incrementRestaurantViewCount, id)
+func (q *Queries) DeleteMenuItem(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteMenuItem, id)
 	return err
 }
 
-const listActivityLogsByRestaurant = `-- name: ListActivityLogsByRestaurant :many
-SELECT al.id, al.restaurant_id, al.user_id, al.action_type, al.action_category, al.description, al.target_type, al.target_id, al.target_name, al.before_value, al.after_value, al.ip_address, al.user_agent, al.device_type, al.browser, al.os, al.success, al.created_at, u.full_name as user_name, u.email as user_email
-FROM activity_logs al
-JOIN users u ON al.user_id = u.id
-WHERE al.restaurant_id = $1
-ORDER BY al.created_at DESC
+const deleteOpeningHoursByRestaurant = `-- name: DeleteOpeningHoursByRestaurant :exec
+DELETE FROM restaurant_opening_hours
+WHERE restaurant_id = $1
+`
+
+func (q *Queries) DeleteOpeningHoursByRestaurant(ctx context.Context, restaurantID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteOpeningHoursByRestaurant, restaurantID)
+	return err
+}
+
+const deletePromotion = `-- name: DeletePromotion :execrows
+DELETE FROM promotions
+WHERE id = $1 AND restaurant_id = $2
+`
+
+type DeletePromotionParams struct {
+	ID           uuid.UUID `db:"id" json:"id"`
+	RestaurantID uuid.UUID `db:"restaurant_id" json:"restaurant_id"`
+}
+
+func (q *Queries) DeletePromotion(ctx context.Context, arg DeletePromotionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deletePromotion, arg.ID, arg.RestaurantID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteRecoveryCodes = `-- name: DeleteRecoveryCodes :exec
+DELETE FROM user_recovery_codes
+WHERE user_id = $1
+`
+
+func (q *Queries) DeleteRecoveryCodes(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteRecoveryCodes, userID)
+	return err
+}
+
+const deleteRestaurant = `-- name: DeleteRestaurant :exec
+DELETE FROM restaurants WHERE id = $1 AND owner_id = $2
+`
+
+type DeleteRestaurantParams struct {
+	ID      uuid.UUID `db:"id" json:"id"`
+	OwnerID uuid.UUID `db:"owner_id" json:"owner_id"`
+}
+
+func (q *Queries) DeleteRestaurant(ctx context.Context, arg DeleteRestaurantParams) error {
+	res, err := q.db.Exec(ctx, deleteRestaurant, arg.ID, arg.OwnerID)
+	if res.RowsAffected() == 0 {
+		return fmt.Errorf("restaurant not found")
+	}
+	return err
+}
+
+const deleteRestaurantTable = `-- name: DeleteRestaurantTable :exec
+DELETE FROM restaurant_tables
+WHERE id = $1 AND restaurant_id = $2
+`
+
+type DeleteRestaurantTableParams struct {
+	ID           uuid.UUID `db:"id" json:"id"`
+	RestaurantID uuid.UUID `db:"restaurant_id" json:"restaurant_id"`
+}
+
+func (q *Queries) DeleteRestaurantTable(ctx context.Context, arg DeleteRestaurantTableParams) error {
+	_, err := q.db.Exec(ctx, deleteRestaurantTable, arg.ID, arg.RestaurantID)
+	return err
+}
+
+const deleteStaff = `-- name: DeleteStaff :exec
+delete from users
+WHERE id = $1 AND restaurant_id = $2 AND role = 'staff'
+`
+
+type DeleteStaffParams struct {
+	ID           uuid.UUID `db:"id" json:"id"`
+	RestaurantID uuid.UUID `db:"restaurant_id" json:"restaurant_id"`
+}
+
+func (q *Queries) DeleteStaff(ctx context.Context, arg DeleteStaffParams) error {
+	fmt.Println("Deleting staff with ID:", arg.ID)
+	fmt.Println("Deleting staff with Restaurant ID:", arg.RestaurantID)
+	res, err := q.db.Exec(ctx, deleteStaff, arg.ID, arg.RestaurantID)
+	if res.RowsAffected() == 0 {
+		return fmt.Errorf("staff not found")
+	}
+	return err
+}
+
+const deleteStaffRole = `-- name: DeleteStaffRole :execrows
+DELETE FROM staff_roles
+WHERE id = $1 AND restaurant_id = $2 AND preset IS NULL
+`
+
+type DeleteStaffRoleParams struct {
+	ID           uuid.UUID `db:"id" json:"id"`
+	RestaurantID uuid.UUID `db:"restaurant_id" json:"restaurant_id"`
+}
+
+func (q *Queries) DeleteStaffRole(ctx context.Context, arg DeleteStaffRoleParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteStaffRole, arg.ID, arg.RestaurantID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteSubscriptionNotification = `-- name: DeleteSubscriptionNotification :exec
+DELETE FROM subscription_notifications
+WHERE subscription_id = $1 AND kind = $2 AND period_end = $3
+`
+
+type DeleteSubscriptionNotificationParams struct {
+	SubscriptionID uuid.UUID        `db:"subscription_id" json:"subscription_id"`
+	Kind           string           `db:"kind" json:"kind"`
+	PeriodEnd      pgtype.Timestamp `db:"period_end" json:"period_end"`
+}
+
+func (q *Queries) DeleteSubscriptionNotification(ctx context.Context, arg DeleteSubscriptionNotificationParams) error {
+	_, err := q.db.Exec(ctx, deleteSubscriptionNotification, arg.SubscriptionID, arg.Kind, arg.PeriodEnd)
+	return err
+}
+
+const deleteUser = `-- name: DeleteUser :exec
+delete from users WHERE id = $1
+`
+
+func (q *Queries) DeleteUser(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteUser, id)
+	return err
+}
+
+const deleteUserTwoFactor = `-- name: DeleteUserTwoFactor :exec
+DELETE FROM user_two_factor
+WHERE user_id = $1
+`
+
+func (q *Queries) DeleteUserTwoFactor(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteUserTwoFactor, userID)
+	return err
+}
+
+const eightySixMenuItem = `-- name: EightySixMenuItem :one
+UPDATE menu_items
+SET
+    is_available = FALSE,
+    unavailable_until = $2,
+    updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, restaurant_id, category_id, name, description, price, currency, images, allergens, dietary_tags, spice_level, calories, is_available, display_order, view_count, created_by, created_at, updated_at, rating_avg, rating_count, track_stock, stock_quantity, low_stock_threshold, low_stock_notified_at, unavailable_until, plan_locked, was_available_before_lock
+`
+
+type EightySixMenuItemParams struct {
+	ID               uuid.UUID        `db:"id" json:"id"`
+	UnavailableUntil pgtype.Timestamp `db:"unavailable_until" json:"unavailable_until"`
+}
+
+func (q *Queries) EightySixMenuItem(ctx context.Context, arg EightySixMenuItemParams) (MenuItem, error) {
+	row := q.db.QueryRow(ctx, eightySixMenuItem, arg.ID, arg.UnavailableUntil)
+	var i MenuItem
+	err := row.Scan(
+		&i.ID,
+		&i.RestaurantID,
+		&i.CategoryID,
+		&i.Name,
+		&i.Description,
+		&i.Price,
+		&i.Currency,
+		&i.Images,
+		&i.Allergens,
+		&i.DietaryTags,
+		&i.SpiceLevel,
+		&i.Calories,
+		&i.IsAvailable,
+		&i.DisplayOrder,
+		&i.ViewCount,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RatingAvg,
+		&i.RatingCount,
+		&i.TrackStock,
+		&i.StockQuantity,
+		&i.LowStockThreshold,
+		&i.LowStockNotifiedAt,
+		&i.UnavailableUntil,
+		&i.PlanLocked,
+		&i.WasAvailableBeforeLock,
+	)
+	return i, err
+}
+
+const expireTrialSubscriptions = `-- name: ExpireTrialSubscriptions :many
+UPDATE subscriptions
+SET status = 'cancelled', cancelled_at = NOW(), updated_at = NOW()
+WHERE status = 'trialing' AND current_period_end <= NOW()
+RETURNING id, owner_id, plan_id, status, current_period_start, current_period_end, trial_end, cancelled_at, payment_provider_subscription_id, created_at, updated_at, billing_interval, scheduled_plan_id, scheduled_billing_interval, cancel_at_period_end
+`
+
+func (q *Queries) ExpireTrialSubscriptions(ctx context.Context) ([]Subscription, error) {
+	rows, err := q.db.Query(ctx, expireTrialSubscriptions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Subscription
+	for rows.Next() {
+		var i Subscription
+		if err := rows.Scan(
+			&i.ID,
+			&i.OwnerID,
+			&i.PlanID,
+			&i.Status,
+			&i.CurrentPeriodStart,
+			&i.CurrentPeriodEnd,
+			&i.TrialEnd,
+			&i.CancelledAt,
+			&i.PaymentProviderSubscriptionID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.BillingInterval,
+			&i.ScheduledPlanID,
+			&i.ScheduledBillingInterval,
+			&i.CancelAtPeriodEnd,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const extendCouponReservation = `-- name: ExtendCouponReservation :execrows
+UPDATE coupon_reservations
+SET tx_ref = $3, expires_at = $4
+WHERE coupon_id = $1 AND owner_id = $2
+`
+
+type ExtendCouponReservationParams struct {
+	CouponID  uuid.UUID        `db:"coupon_id" json:"coupon_id"`
+	OwnerID   uuid.UUID        `db:"owner_id" json:"owner_id"`
+	TxRef     string           `db:"tx_ref" json:"tx_ref"`
+	ExpiresAt pgtype.Timestamp `db:"expires_at" json:"expires_at"`
+}
+
+func (q *Queries) ExtendCouponReservation(ctx context.Context, arg ExtendCouponReservationParams) (int64, error) {
+	result, err := q.db.Exec(ctx, extendCouponReservation,
+		arg.CouponID,
+		arg.OwnerID,
+		arg.TxRef,
+		arg.ExpiresAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getAPIKeyByHash = `-- name: GetAPIKeyByHash :one
+SELECT id, restaurant_id, created_by, name, key_prefix, key_hash, permissions, expires_at, last_used_at, last_used_ip, revoked_at, created_at FROM api_keys
+WHERE key_hash = $1 LIMIT 1
+`
+
+func (q *Queries) GetAPIKeyByHash(ctx context.Context, keyHash string) (ApiKey, error) {
+	row := q.db.QueryRow(ctx, getAPIKeyByHash, keyHash)
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.RestaurantID,
+		&i.CreatedBy,
+		&i.Name,
+		&i.KeyPrefix,
+		&i.KeyHash,
+		&i.Permissions,
+		&i.ExpiresAt,
+		&i.LastUsedAt,
+		&i.LastUsedIp,
+		&i.RevokedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getActiveSubscriptionByOwner = `-- name: GetActiveSubscriptionByOwner :one
+SELECT s.id, s.owner_id, s.plan_id, s.status, s.current_period_start, s.current_period_end, s.trial_end, s.cancelled_at, s.payment_provider_subscription_id, s.created_at, s.updated_at, s.billing_interval, s.scheduled_plan_id, s.scheduled_billing_interval, s.cancel_at_period_end, sp.name as plan_name, sp.slug as plan_slug, sp.features
+FROM subscriptions s
+JOIN subscription_plans sp ON s.plan_id = sp.id
+WHERE s.owner_id = $1 AND s.status = 'active' LIMIT 1
+`
+
+type GetActiveSubscriptionByOwnerRow struct {
+	ID                            uuid.UUID           `db:"id" json:"id"`
+	OwnerID                       uuid.UUID           `db:"owner_id" json:"owner_id"`
+	PlanID                        uuid.UUID           `db:"plan_id" json:"plan_id"`
+	Status                        SubscriptionStatus  `db:"status" json:"status"`
+	CurrentPeriodStart            pgtype.Timestamp    `db:"current_period_start" json:"current_period_start"`
+	CurrentPeriodEnd              pgtype.Timestamp    `db:"current_period_end" json:"current_period_end"`
+	TrialEnd                      pgtype.Timestamp    `db:"trial_end" json:"trial_end"`
+	CancelledAt                   pgtype.Timestamp    `db:"cancelled_at" json:"cancelled_at"`
+	PaymentProviderSubscriptionID pgtype.Text         `db:"payment_provider_subscription_id" json:"payment_provider_subscription_id"`
+	CreatedAt                     pgtype.Timestamp    `db:"created_at" json:"created_at"`
+	UpdatedAt                     pgtype.Timestamp    `db:"updated_at" json:"updated_at"`
+	BillingInterval               BillingInterval     `db:"billing_interval" json:"billing_interval"`
+	ScheduledPlanID               uuid.UUID           `db:"scheduled_plan_id" json:"scheduled_plan_id"`
+	ScheduledBillingInterval      NullBillingInterval `db:"scheduled_billing_interval" json:"scheduled_billing_interval"`
+	CancelAtPeriodEnd             bool                `db:"cancel_at_period_end" json:"cancel_at_period_end"`
+	PlanName                      string              `db:"plan_name" json:"plan_name"`
+	PlanSlug                      string              `db:"plan_slug" json:"plan_slug"`
+	Features                      []byte              `db:"features" json:"features"`
+}
+
+func (q *Queries) GetActiveSubscriptionByOwner(ctx context.Context, ownerID uuid.UUID) (GetActiveSubscriptionByOwnerRow, error) {
+	row := q.db.QueryRow(ctx, getActiveSubscriptionByOwner, ownerID)
+	var i GetActiveSubscriptionByOwnerRow
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.PlanID,
+		&i.Status,
+		&i.CurrentPeriodStart,
+		&i.CurrentPeriodEnd,
+		&i.TrialEnd,
+		&i.CancelledAt,
+		&i.PaymentProviderSubscriptionID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.BillingInterval,
+		&i.ScheduledPlanID,
+		&i.ScheduledBillingInterval,
+		&i.CancelAtPeriodEnd,
+		&i.PlanName,
+		&i.PlanSlug,
+		&i.Features,
+	)
+	return i, err
+}
+
+const getAdminDashboardStats = `-- name: GetAdminDashboardStats :one
+SELECT 
+    (SELECT COUNT(*) FROM users ) as total_users,
+    (SELECT COUNT(*) FROM restaurants ) as total_restaurants,
+    (SELECT COUNT(*) FROM subscriptions WHERE status = 'active') as active_subscriptions,
+    (SELECT SUM(amount) FROM invoices WHERE status = 'paid') as total_revenue
+`
+
+type GetAdminDashboardStatsRow struct {
+	TotalUsers          int64 `db:"total_users" json:"total_users"`
+	TotalRestaurants    int64 `db:"total_restaurants" json:"total_restaurants"`
+	ActiveSubscriptions int64 `db:"active_subscriptions" json:"active_subscriptions"`
+	TotalRevenue        int64 `db:"total_revenue" json:"total_revenue"`
+}
+
+func (q *Queries) GetAdminDashboardStats(ctx context.Context) (GetAdminDashboardStatsRow, error) {
+	row := q.db.QueryRow(ctx, getAdminDashboardStats)
+	var i GetAdminDashboardStatsRow
+	err := row.Scan(
+		&i.TotalUsers,
+		&i.TotalRestaurants,
+		&i.ActiveSubscriptions,
+		&i.TotalRevenue,
+	)
+	return i, err
+}
+
+const getAllAdminEmails = `-- name: GetAllAdminEmails :many
+SELECT email FROM users
+WHERE role = 'admin' 
+`
+
+func (q *Queries) GetAllAdminEmails(ctx context.Context) ([]string, error) {
+	rows, err := q.db.Query(ctx, getAllAdminEmails)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var email string
+		if err := rows.Scan(&email); err != nil {
+			return nil, err
+		}
+		items = append(items, email)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAnalyticsAggregates = `-- name: GetAnalyticsAggregates :many
+SELECT id, restaurant_id, date, hour, metric_type, target_id, value, created_at, updated_at FROM analytics_aggregates
+WHERE restaurant_id = $1 AND date >= $2 AND date <= $3
+ORDER BY date ASC, hour ASC
+`
+
+type GetAnalyticsAggregatesParams struct {
+	RestaurantID uuid.UUID   `db:"restaurant_id" json:"restaurant_id"`
+	Date         pgtype.Date `db:"date" json:"date"`
+	Date_2       pgtype.Date `db:"date_2" json:"date_2"`
+}
+
+func (q *Queries) GetAnalyticsAggregates(ctx context.Context, arg GetAnalyticsAggregatesParams) ([]AnalyticsAggregate, error) {
+	rows, err := q.db.Query(ctx, getAnalyticsAggregates, arg.RestaurantID, arg.Date, arg.Date_2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AnalyticsAggregate
+	for rows.Next() {
+		var i AnalyticsAggregate
+		if err := rows.Scan(
+			&i.ID,
+			&i.RestaurantID,
+			&i.Date,
+			&i.Hour,
+			&i.MetricType,
+			&i.TargetID,
+			&i.Value,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCategoryByID = `-- name: GetCategoryByID :one
+SELECT id, restaurant_id, name, description, icon, display_order, is_active, created_by, created_at, updated_at, plan_locked FROM categories
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetCategoryByID(ctx context.Context, id uuid.UUID) (Category, error) {
+	row := q.db.QueryRow(ctx, getCategoryByID, id)
+	var i Category
+	err := row.Scan(
+		&i.ID,
+		&i.RestaurantID,
+		&i.Name,
+		&i.Description,
+		&i.Icon,
+		&i.DisplayOrder,
+		&i.IsActive,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.PlanLocked,
+	)
+	return i, err
+}
+
+const getCouponByCode = `-- name: GetCouponByCode :one
+SELECT id, code, description, discount_type, discount_value, plan_ids, max_redemptions, redemption_count, duration_cycles, expires_at, is_active, created_by, created_at, updated_at FROM coupons
+WHERE code = UPPER($1)
+`
+
+func (q *Queries) GetCouponByCode(ctx context.Context, code string) (Coupon, error) {
+	row := q.db.QueryRow(ctx, getCouponByCode, code)
+	var i Coupon
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.Description,
+		&i.DiscountType,
+		&i.DiscountValue,
+		&i.PlanIds,
+		&i.MaxRedemptions,
+		&i.RedemptionCount,
+		&i.DurationCycles,
+		&i.ExpiresAt,
+		&i.IsActive,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getCouponByID = `-- name: GetCouponByID :one
+SELECT id, code, description, discount_type, discount_value, plan_ids, max_redemptions, redemption_count, duration_cycles, expires_at, is_active, created_by, created_at, updated_at FROM coupons
+WHERE id = $1
+`
+
+func (q *Queries) GetCouponByID(ctx context.Context, id uuid.UUID) (Coupon, error) {
+	row := q.db.QueryRow(ctx, getCouponByID, id)
+	var i Coupon
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.Description,
+		&i.DiscountType,
+		&i.DiscountValue,
+		&i.PlanIds,
+		&i.MaxRedemptions,
+		&i.RedemptionCount,
+		&i.DurationCycles,
+		&i.ExpiresAt,
+		&i.IsActive,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getEffectiveSubscriptionByOwner = `-- name: GetEffectiveSubscriptionByOwner :one
+SELECT s.id, s.owner_id, s.plan_id, s.status, s.current_period_start, s.current_period_end, s.trial_end, s.cancelled_at, s.payment_provider_subscription_id, s.created_at, s.updated_at, s.billing_interval, s.scheduled_plan_id, s.scheduled_billing_interval, s.cancel_at_period_end, sp.name as plan_name, sp.slug as plan_slug, sp.features
+FROM subscriptions s
+JOIN subscription_plans sp ON s.plan_id = sp.id
+WHERE s.owner_id = $1 AND (
+    s.status = 'past_due' OR
+    (s.status IN ('active', 'trialing') AND s.current_period_end > NOW())
+)
+ORDER BY CASE s.status WHEN 'active' THEN 0 WHEN 'past_due' THEN 1 ELSE 2 END, s.current_period_end DESC
+LIMIT 1
+`
+
+type GetEffectiveSubscriptionByOwnerRow struct {
+	ID                            uuid.UUID           `db:"id" json:"id"`
+	OwnerID                       uuid.UUID           `db:"owner_id" json:"owner_id"`
+	PlanID                        uuid.UUID           `db:"plan_id" json:"plan_id"`
+	Status                        SubscriptionStatus  `db:"status" json:"status"`
+	CurrentPeriodStart            pgtype.Timestamp    `db:"current_period_start" json:"current_period_start"`
+	CurrentPeriodEnd              pgtype.Timestamp    `db:"current_period_end" json:"current_period_end"`
+	TrialEnd                      pgtype.Timestamp    `db:"trial_end" json:"trial_end"`
+	CancelledAt                   pgtype.Timestamp    `db:"cancelled_at" json:"cancelled_at"`
+	PaymentProviderSubscriptionID pgtype.Text         `db:"payment_provider_subscription_id" json:"payment_provider_subscription_id"`
+	CreatedAt                     pgtype.Timestamp    `db:"created_at" json:"created_at"`
+	UpdatedAt                     pgtype.Timestamp    `db:"updated_at" json:"updated_at"`
+	BillingInterval               BillingInterval     `db:"billing_interval" json:"billing_interval"`
+	ScheduledPlanID               uuid.UUID           `db:"scheduled_plan_id" json:"scheduled_plan_id"`
+	ScheduledBillingInterval      NullBillingInterval `db:"scheduled_billing_interval" json:"scheduled_billing_interval"`
+	CancelAtPeriodEnd             bool                `db:"cancel_at_period_end" json:"cancel_at_period_end"`
+	PlanName                      string              `db:"plan_name" json:"plan_name"`
+	PlanSlug                      string              `db:"plan_slug" json:"plan_slug"`
+	Features                      []byte              `db:"features" json:"features"`
+}
+
+func (q *Queries) GetEffectiveSubscriptionByOwner(ctx context.Context, ownerID uuid.UUID) (GetEffectiveSubscriptionByOwnerRow, error) {
+	row := q.db.QueryRow(ctx, getEffectiveSubscriptionByOwner, ownerID)
+	var i GetEffectiveSubscriptionByOwnerRow
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.PlanID,
+		&i.Status,
+		&i.CurrentPeriodStart,
+		&i.CurrentPeriodEnd,
+		&i.TrialEnd,
+		&i.CancelledAt,
+		&i.PaymentProviderSubscriptionID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.BillingInterval,
+		&i.ScheduledPlanID,
+		&i.ScheduledBillingInterval,
+		&i.CancelAtPeriodEnd,
+		&i.PlanName,
+		&i.PlanSlug,
+		&i.Features,
+	)
+	return i, err
+}
+
+const getInvoiceByID = `-- name: GetInvoiceByID :one
+SELECT id, subscription_id, owner_id, invoice_number, amount, currency, status, billing_period_start, billing_period_end, payment_provider_invoice_id, paid_at, created_at, updated_at, prorated, credit_amount, coupon_id, discount_amount, tx_ref, vat_rate, refunded_amount FROM invoices
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetInvoiceByID(ctx context.Context, id uuid.UUID) (Invoice, error) {
+	row := q.db.QueryRow(ctx, getInvoiceByID, id)
+	var i Invoice
+	err := row.Scan(
+		&i.ID,
+		&i.SubscriptionID,
+		&i.OwnerID,
+		&i.InvoiceNumber,
+		&i.Amount,
+		&i.Currency,
+		&i.Status,
+		&i.BillingPeriodStart,
+		&i.BillingPeriodEnd,
+		&i.PaymentProviderInvoiceID,
+		&i.PaidAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Prorated,
+		&i.CreditAmount,
+		&i.CouponID,
+		&i.DiscountAmount,
+		&i.TxRef,
+		&i.VatRate,
+		&i.RefundedAmount,
+	)
+	return i, err
+}
+
+const getInvoiceDocument = `-- name: GetInvoiceDocument :one
+SELECT i.id, i.subscription_id, i.owner_id, i.invoice_number, i.amount, i.currency, i.status, i.billing_period_start, i.billing_period_end, i.payment_provider_invoice_id, i.paid_at, i.created_at, i.updated_at, i.prorated, i.credit_amount, i.coupon_id, i.discount_amount, i.tx_ref, i.vat_rate, i.refunded_amount, u.full_name AS owner_name, u.email AS owner_email, u.phone AS owner_phone,
+    p.name AS plan_name, s.billing_interval, c.code AS coupon_code
+FROM invoices i
+JOIN users u ON i.owner_id = u.id
+JOIN subscriptions s ON i.subscription_id = s.id
+JOIN subscription_plans p ON s.plan_id = p.id
+LEFT JOIN coupons c ON i.coupon_id = c.id
+WHERE i.id = $1
+`
+
+type GetInvoiceDocumentRow struct {
+	ID                       uuid.UUID        `db:"id" json:"id"`
+	SubscriptionID           uuid.UUID        `db:"subscription_id" json:"subscription_id"`
+	OwnerID                  uuid.UUID        `db:"owner_id" json:"owner_id"`
+	InvoiceNumber            pgtype.Text      `db:"invoice_number" json:"invoice_number"`
+	Amount                   pgtype.Numeric   `db:"amount" json:"amount"`
+	Currency                 string           `db:"currency" json:"currency"`
+	Status                   InvoiceStatus    `db:"status" json:"status"`
+	BillingPeriodStart       pgtype.Timestamp `db:"billing_period_start" json:"billing_period_start"`
+	BillingPeriodEnd         pgtype.Timestamp `db:"billing_period_end" json:"billing_period_end"`
+	PaymentProviderInvoiceID pgtype.Text      `db:"payment_provider_invoice_id" json:"payment_provider_invoice_id"`
+	PaidAt                   pgtype.Timestamp `db:"paid_at" json:"paid_at"`
+	CreatedAt                pgtype.Timestamp `db:"created_at" json:"created_at"`
+	UpdatedAt                pgtype.Timestamp `db:"updated_at" json:"updated_at"`
+	Prorated                 bool             `db:"prorated" json:"prorated"`
+	CreditAmount             pgtype.Numeric   `db:"credit_amount" json:"credit_amount"`
+	CouponID                 uuid.UUID        `db:"coupon_id" json:"coupon_id"`
+	DiscountAmount           pgtype.Numeric   `db:"discount_amount" json:"discount_amount"`
+	TxRef                    string           `db:"tx_ref" json:"tx_ref"`
+	VatRate                  pgtype.Numeric   `db:"vat_rate" json:"vat_rate"`
+	RefundedAmount           pgtype.Numeric   `db:"refunded_amount" json:"refunded_amount"`
+	OwnerName                string           `db:"owner_name" json:"owner_name"`
+	OwnerEmail               string           `db:"owner_email" json:"owner_email"`
+	OwnerPhone               pgtype.Text      `db:"owner_phone" json:"owner_phone"`
+	PlanName                 string           `db:"plan_name" json:"plan_name"`
+	BillingInterval          BillingInterval  `db:"billing_interval" json:"billing_interval"`
+	CouponCode               pgtype.Text      `db:"coupon_code" json:"coupon_code"`
+}
+
+func (q *Queries) GetInvoiceDocument(ctx context.Context, id uuid.UUID) (GetInvoiceDocumentRow, error) {
+	row := q.db.QueryRow(ctx, getInvoiceDocument, id)
+	var i GetInvoiceDocumentRow
+	err := row.Scan(
+		&i.ID,
+		&i.SubscriptionID,
+		&i.OwnerID,
+		&i.InvoiceNumber,
+		&i.Amount,
+		&i.Currency,
+		&i.Status,
+		&i.BillingPeriodStart,
+		&i.BillingPeriodEnd,
+		&i.PaymentProviderInvoiceID,
+		&i.PaidAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Prorated,
+		&i.CreditAmount,
+		&i.CouponID,
+		&i.DiscountAmount,
+		&i.TxRef,
+		&i.VatRate,
+		&i.RefundedAmount,
+		&i.OwnerName,
+		&i.OwnerEmail,
+		&i.OwnerPhone,
+		&i.PlanName,
+		&i.BillingInterval,
+		&i.CouponCode,
+	)
+	return i, err
+}
+
+const getLatestCouponRedemptionByOwner = `-- name: GetLatestCouponRedemptionByOwner :one
+SELECT id, coupon_id, owner_id, invoice_id, discount_amount, created_at FROM coupon_redemptions
+WHERE owner_id = $1
+ORDER BY created_at DESC
+LIMIT 1
+`
+
+func (q *Queries) GetLatestCouponRedemptionByOwner(ctx context.Context, ownerID uuid.UUID) (CouponRedemption, error) {
+	row := q.db.QueryRow(ctx, getLatestCouponRedemptionByOwner, ownerID)
+	var i CouponRedemption
+	err := row.Scan(
+		&i.ID,
+		&i.CouponID,
+		&i.OwnerID,
+		&i.InvoiceID,
+		&i.DiscountAmount,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getLatestSubscriptionByOwner = `-- name: GetLatestSubscriptionByOwner :one
+SELECT s.id, s.owner_id, s.plan_id, s.status, s.current_period_start, s.current_period_end, s.trial_end, s.cancelled_at, s.payment_provider_subscription_id, s.created_at, s.updated_at, s.billing_interval, s.scheduled_plan_id, s.scheduled_billing_interval, s.cancel_at_period_end, sp.name as plan_name, sp.slug as plan_slug, sp.features
+FROM subscriptions s
+JOIN subscription_plans sp ON s.plan_id = sp.id
+WHERE s.owner_id = $1 
+ORDER BY s.created_at DESC
+LIMIT 1
+`
+
+type GetLatestSubscriptionByOwnerRow struct {
+	ID                            uuid.UUID           `db:"id" json:"id"`
+	OwnerID                       uuid.UUID           `db:"owner_id" json:"owner_id"`
+	PlanID                        uuid.UUID           `db:"plan_id" json:"plan_id"`
+	Status                        SubscriptionStatus  `db:"status" json:"status"`
+	CurrentPeriodStart            pgtype.Timestamp    `db:"current_period_start" json:"current_period_start"`
+	CurrentPeriodEnd              pgtype.Timestamp    `db:"current_period_end" json:"current_period_end"`
+	TrialEnd                      pgtype.Timestamp    `db:"trial_end" json:"trial_end"`
+	CancelledAt                   pgtype.Timestamp    `db:"cancelled_at" json:"cancelled_at"`
+	PaymentProviderSubscriptionID pgtype.Text         `db:"payment_provider_subscription_id" json:"payment_provider_subscription_id"`
+	CreatedAt                     pgtype.Timestamp    `db:"created_at" json:"created_at"`
+	UpdatedAt                     pgtype.Timestamp    `db:"updated_at" json:"updated_at"`
+	BillingInterval               BillingInterval     `db:"billing_interval" json:"billing_interval"`
+	ScheduledPlanID               uuid.UUID           `db:"scheduled_plan_id" json:"scheduled_plan_id"`
+	ScheduledBillingInterval      NullBillingInterval `db:"scheduled_billing_interval" json:"scheduled_billing_interval"`
+	CancelAtPeriodEnd             bool                `db:"cancel_at_period_end" json:"cancel_at_period_end"`
+	PlanName                      string              `db:"plan_name" json:"plan_name"`
+	PlanSlug                      string              `db:"plan_slug" json:"plan_slug"`
+	Features                      []byte              `db:"features" json:"features"`
+}
+
+func (q *Queries) GetLatestSubscriptionByOwner(ctx context.Context, ownerID uuid.UUID) (GetLatestSubscriptionByOwnerRow, error) {
+	row := q.db.QueryRow(ctx, getLatestSubscriptionByOwner, ownerID)
+	var i GetLatestSubscriptionByOwnerRow
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.PlanID,
+		&i.Status,
+		&i.CurrentPeriodStart,
+		&i.CurrentPeriodEnd,
+		&i.TrialEnd,
+		&i.CancelledAt,
+		&i.PaymentProviderSubscriptionID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.BillingInterval,
+		&i.ScheduledPlanID,
+		&i.ScheduledBillingInterval,
+		&i.CancelAtPeriodEnd,
+		&i.PlanName,
+		&i.PlanSlug,
+		&i.Features,
+	)
+	return i, err
+}
+
+const getMenuItemByID = `-- name: GetMenuItemByID :one
+SELECT id, restaurant_id, category_id, name, description, price, currency, images, allergens, dietary_tags, spice_level, calories, is_available, display_order, view_count, created_by, created_at, updated_at, rating_avg, rating_count, track_stock, stock_quantity, low_stock_threshold, low_stock_notified_at, unavailable_until, plan_locked, was_available_before_lock FROM menu_items
+WHERE id = $1  LIMIT 1
+`
+
+func (q *Queries) GetMenuItemByID(ctx context.Context, id uuid.UUID) (MenuItem, error) {
+	row := q.db.QueryRow(ctx, getMenuItemByID, id)
+	var i MenuItem
+	err := row.Scan(
+		&i.ID,
+		&i.RestaurantID,
+		&i.CategoryID,
+		&i.Name,
+		&i.Description,
+		&i.Price,
+		&i.Currency,
+		&i.Images,
+		&i.Allergens,
+		&i.DietaryTags,
+		&i.SpiceLevel,
+		&i.Calories,
+		&i.IsAvailable,
+		&i.DisplayOrder,
+		&i.ViewCount,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RatingAvg,
+		&i.RatingCount,
+		&i.TrackStock,
+		&i.StockQuantity,
+		&i.LowStockThreshold,
+		&i.LowStockNotifiedAt,
+		&i.UnavailableUntil,
+		&i.PlanLocked,
+		&i.WasAvailableBeforeLock,
+	)
+	return i, err
+}
+
+const getPaymentTransactionByTxRef = `-- name: GetPaymentTransactionByTxRef :one
+SELECT id, owner_id, amount, currency, status, tx_ref, reference, provider_transaction_ref, created_at, updated_at, refunded_amount FROM payment_transactions
+WHERE tx_ref = $1 LIMIT 1
+`
+
+func (q *Queries) GetPaymentTransactionByTxRef(ctx context.Context, txRef string) (PaymentTransaction, error) {
+	row := q.db.QueryRow(ctx, getPaymentTransactionByTxRef, txRef)
+	var i PaymentTransaction
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.Amount,
+		&i.Currency,
+		&i.Status,
+		&i.TxRef,
+		&i.Reference,
+		&i.ProviderTransactionRef,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RefundedAmount,
+	)
+	return i, err
+}
+
+const getPendingStaffInvitationByEmail = `-- name: GetPendingStaffInvitationByEmail :one
+SELECT id, restaurant_id, owner_id, email, full_name, phone, token_hash, status, expires_at, accepted_user_id, accepted_at, created_at, updated_at, staff_role_id FROM staff_invitations
+WHERE restaurant_id = $1 AND LOWER(email) = LOWER($2) AND status = 'pending'
+LIMIT 1
+`
+
+type GetPendingStaffInvitationByEmailParams struct {
+	RestaurantID uuid.UUID `db:"restaurant_id" json:"restaurant_id"`
+	Email        string    `db:"email" json:"email"`
+}
+
+func (q *Queries) GetPendingStaffInvitationByEmail(ctx context.Context, arg GetPendingStaffInvitationByEmailParams) (StaffInvitation, error) {
+	row := q.db.QueryRow(ctx, getPendingStaffInvitationByEmail, arg.RestaurantID, arg.Email)
+	var i StaffInvitation
+	err := row.Scan(
+		&i.ID,
+		&i.RestaurantID,
+		&i.OwnerID,
+		&i.Email,
+		&i.FullName,
+		&i.Phone,
+		&i.TokenHash,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.AcceptedUserID,
+		&i.AcceptedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.StaffRoleID,
+	)
+	return i, err
+}
+
+const getPlatformSettings = `-- name: GetPlatformSettings :one
+SELECT id, require_admin_two_factor, updated_by, updated_at FROM platform_settings
+WHERE id = 1 LIMIT 1
+`
+
+func (q *Queries) GetPlatformSettings(ctx context.Context) (PlatformSetting, error) {
+	row := q.db.QueryRow(ctx, getPlatformSettings)
+	var i PlatformSetting
+	err := row.Scan(
+		&i.ID,
+		&i.RequireAdminTwoFactor,
+		&i.UpdatedBy,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getPromotionByID = `-- name: GetPromotionByID :one
+SELECT id, restaurant_id, name, label, scope, category_id, menu_item_id, discount_type, discount_value, starts_on, ends_on, days_of_week, start_time, end_time, is_active, created_by, created_at, updated_at FROM promotions
+WHERE id = $1 AND restaurant_id = $2 LIMIT 1
+`
+
+type GetPromotionByIDParams struct {
+	ID           uuid.UUID `db:"id" json:"id"`
+	RestaurantID uuid.UUID `db:"restaurant_id" json:"restaurant_id"`
+}
+
+func (q *Queries) GetPromotionByID(ctx context.Context, arg GetPromotionByIDParams) (Promotion, error) {
+	row := q.db.QueryRow(ctx, getPromotionByID, arg.ID, arg.RestaurantID)
+	var i Promotion
+	err := row.Scan(
+		&i.ID,
+		&i.RestaurantID,
+		&i.Name,
+		&i.Label,
+		&i.Scope,
+		&i.CategoryID,
+		&i.MenuItemID,
+		&i.DiscountType,
+		&i.DiscountValue,
+		&i.StartsOn,
+		&i.EndsOn,
+		&i.DaysOfWeek,
+		&i.StartTime,
+		&i.EndTime,
+		&i.IsActive,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getRecentAdminLogs = `-- name: GetRecentAdminLogs :many
+SELECT al.id, al.restaurant_id, al.user_id, al.action_type, al.action_category, al.description, al.target_type, al.target_id, al.target_name, al.before_value, al.after_value, al.ip_address, al.user_agent, al.device_type, al.browser, al.os, al.success, al.created_at, u.full_name as user_name, u.email as user_email
+FROM activity_logs al
+JOIN users u ON al.user_id = u.id
+ORDER BY al.created_at DESC
+LIMIT $1
+`
+
+type GetRecentAdminLogsRow struct {
+	ID             uuid.UUID        `db:"id" json:"id"`
+	RestaurantID   uuid.UUID        `db:"restaurant_id" json:"restaurant_id"`
+	UserID         uuid.UUID        `db:"user_id" json:"user_id"`
+	ActionType     string           `db:"action_type" json:"action_type"`
+	ActionCategory string           `db:"action_category" json:"action_category"`
+	Description    pgtype.Text      `db:"description" json:"description"`
+	TargetType     pgtype.Text      `db:"target_type" json:"target_type"`
+	TargetID       uuid.UUID        `db:"target_id" json:"target_id"`
+	TargetName     pgtype.Text      `db:"target_name" json:"target_name"`
+	BeforeValue    []byte           `db:"before_value" json:"before_value"`
+	AfterValue     []byte           `db:"after_value" json:"after_value"`
+	IpAddress      pgtype.Text      `db:"ip_address" json:"ip_address"`
+	UserAgent      pgtype.Text      `db:"user_agent" json:"user_agent"`
+	DeviceType     pgtype.Text      `db:"device_type" json:"device_type"`
+	Browser        pgtype.Text      `db:"browser" json:"browser"`
+	Os             pgtype.Text      `db:"os" json:"os"`
+	Success        pgtype.Bool      `db:"success" json:"success"`
+	CreatedAt      pgtype.Timestamp `db:"created_at" json:"created_at"`
+	UserName       string           `db:"user_name" json:"user_name"`
+	UserEmail      string           `db:"user_email" json:"user_email"`
+}
+
+func (q *Queries) GetRecentAdminLogs(ctx context.Context, limit int32) ([]GetRecentAdminLogsRow, error) {
+	rows, err := q.db.Query(ctx, getRecentAdminLogs, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetRecentAdminLogsRow
+	for rows.Next() {
+		var i GetRecentAdminLogsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.RestaurantID,
+			&i.UserID,
+			&i.ActionType,
+			&i.ActionCategory,
+			&i.Description,
+			&i.TargetType,
+			&i.TargetID,
+			&i.TargetName,
+			&i.BeforeValue,
+			&i.AfterValue,
+			&i.IpAddress,
+			&i.UserAgent,
+			&i.DeviceType,
+			&i.Browser,
+			&i.Os,
+			&i.Success,
+			&i.CreatedAt,
+			&i.UserName,
+			&i.UserEmail,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReservationByCancellationToken = `-- name: GetReservationByCancellationToken :one
+SELECT id, restaurant_id, table_id, guest_name, guest_phone, guest_email, party_size, reserved_at, ends_at, notes, status, confirmation_token, cancellation_token, confirmed_at, seated_at, cancelled_at, created_at, updated_at FROM reservations
+WHERE cancellation_token = $1
+`
+
+func (q *Queries) GetReservationByCancellationToken(ctx context.Context, cancellationToken string) (Reservation, error) {
+	row := q.db.QueryRow(ctx, getReservationByCancellationToken, cancellationToken)
+	var i Reservation
+	err := row.Scan(
+		&i.ID,
+		&i.RestaurantID,
+		&i.TableID,
+		&i.GuestName,
+		&i.GuestPhone,
+		&i.GuestEmail,
+		&i.PartySize,
+		&i.ReservedAt,
+		&i.EndsAt,
+		&i.Notes,
+		&i.Status,
+		&i.ConfirmationToken,
+		&i.CancellationToken,
+		&i.ConfirmedAt,
+		&i.SeatedAt,
+		&i.CancelledAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getReservationByID = `-- name: GetReservationByID :one
+SELECT id, restaurant_id, table_id, guest_name, guest_phone, guest_email, party_size, reserved_at, ends_at, notes, status, confirmation_token, cancellation_token, confirmed_at, seated_at, cancelled_at, created_at, updated_at FROM reservations
+WHERE id = $1 AND restaurant_id = $2
+`
+
+type GetReservationByIDParams struct {
+	ID           uuid.UUID `db:"id" json:"id"`
+	RestaurantID uuid.UUID `db:"restaurant_id" json:"restaurant_id"`
+}
+
+func (q *Queries) GetReservationByID(ctx context.Context, arg GetReservationByIDParams) (Reservation, error) {
+	row := q.db.QueryRow(ctx, getReservationByID, arg.ID, arg.RestaurantID)
+	var i Reservation
+	err := row.Scan(
+		&i.ID,
+		&i.RestaurantID,
+		&i.TableID,
+		&i.GuestName,
+		&i.GuestPhone,
+		&i.GuestEmail,
+		&i.PartySize,
+		&i.ReservedAt,
+		&i.EndsAt,
+		&i.Notes,
+		&i.Status,
+		&i.ConfirmationToken,
+		&i.CancellationToken,
+		&i.ConfirmedAt,
+		&i.SeatedAt,
+		&i.CancelledAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getReservationSettings = `-- name: GetReservationSettings :one
+SELECT restaurant_id, is_enabled, slot_interval_minutes, dining_duration_minutes, max_party_size, booking_window_days, created_at, updated_at FROM reservation_settings
+WHERE restaurant_id = $1
+`
+
+func (q *Queries) GetReservationSettings(ctx context.Context, restaurantID uuid.UUID) (ReservationSetting, error) {
+	row := q.db.QueryRow(ctx, getReservationSettings, restaurantID)
+	var i ReservationSetting
+	err := row.Scan(
+		&i.RestaurantID,
+		&i.IsEnabled,
+		&i.SlotIntervalMinutes,
+		&i.DiningDurationMinutes,
+		&i.MaxPartySize,
+		&i.BookingWindowDays,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getRestaurantByID = `-- name: GetRestaurantByID :one
+SELECT id, owner_id, name, slug, description, cuisine_type, phone, email, website, address, city, country, logo_url, cover_image_url, theme_settings, is_published, view_count, rank_score, created_at, updated_at, rating_avg, rating_count, plan_locked, timezone FROM restaurants
+WHERE id = $1  LIMIT 1
+`
+
+func (q *Queries) GetRestaurantByID(ctx context.Context, id uuid.UUID) (Restaurant, error) {
+	row := q.db.QueryRow(ctx, getRestaurantByID, id)
+	var i Restaurant
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.Name,
+		&i.Slug,
+		&i.Description,
+		&i.CuisineType,
+		&i.Phone,
+		&i.Email,
+		&i.Website,
+		&i.Address,
+		&i.City,
+		&i.Country,
+		&i.LogoUrl,
+		&i.CoverImageUrl,
+		&i.ThemeSettings,
+		&i.IsPublished,
+		&i.ViewCount,
+		&i.RankScore,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RatingAvg,
+		&i.RatingCount,
+		&i.PlanLocked,
+		&i.Timezone,
+	)
+	return i, err
+}
+
+const getRestaurantBySlug = `-- name: GetRestaurantBySlug :one
+SELECT id, owner_id, name, slug, description, cuisine_type, phone, email, website, address, city, country, logo_url, cover_image_url, theme_settings, is_published, view_count, rank_score, created_at, updated_at, rating_avg, rating_count, plan_locked, timezone FROM restaurants
+WHERE slug = $1  LIMIT 1
+`
+
+func (q *Queries) GetRestaurantBySlug(ctx context.Context, slug string) (Restaurant, error) {
+	row := q.db.QueryRow(ctx, getRestaurantBySlug, slug)
+	var i Restaurant
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.Name,
+		&i.Slug,
+		&i.Description,
+		&i.CuisineType,
+		&i.Phone,
+		&i.Email,
+		&i.Website,
+		&i.Address,
+		&i.City,
+		&i.Country,
+		&i.LogoUrl,
+		&i.CoverImageUrl,
+		&i.ThemeSettings,
+		&i.IsPublished,
+		&i.ViewCount,
+		&i.RankScore,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RatingAvg,
+		&i.RatingCount,
+		&i.PlanLocked,
+		&i.Timezone,
+	)
+	return i, err
+}
+
+const getRestaurantDetailsForAdmin = `-- name: GetRestaurantDetailsForAdmin :one
+SELECT r.id, r.owner_id, r.name, r.slug, r.description, r.cuisine_type, r.phone, r.email, r.website, r.address, r.city, r.country, r.logo_url, r.cover_image_url, r.theme_settings, r.is_published, r.view_count, r.rank_score, r.created_at, r.updated_at, r.rating_avg, r.rating_count, r.plan_locked, r.timezone, u.full_name as owner_name, u.email as owner_email,
+    sub.status as subscription_status, sub.plan_name as subscription_plan,
+    sub.current_period_end as subscription_period_end, sub.cancel_at_period_end
+FROM restaurants r
+JOIN users u ON r.owner_id = u.id
+LEFT JOIN LATERAL (
+    SELECT s.status, sp.name AS plan_name, s.current_period_end, s.cancel_at_period_end
+    FROM subscriptions s
+    JOIN subscription_plans sp ON s.plan_id = sp.id
+    WHERE s.owner_id = r.owner_id AND s.status IN ('active', 'trialing', 'past_due')
+    ORDER BY CASE s.status WHEN 'active' THEN 0 WHEN 'past_due' THEN 1 ELSE 2 END, s.current_period_end DESC
+    LIMIT 1
+) sub ON TRUE
+WHERE r.id = $1
+`
+
+type GetRestaurantDetailsForAdminRow struct {
+	ID                    uuid.UUID              `db:"id" json:"id"`
+	OwnerID               uuid.UUID              `db:"owner_id" json:"owner_id"`
+	Name                  string                 `db:"name" json:"name"`
+	Slug                  string                 `db:"slug" json:"slug"`
+	Description           pgtype.Text            `db:"description" json:"description"`
+	CuisineType           pgtype.Text            `db:"cuisine_type" json:"cuisine_type"`
+	Phone                 pgtype.Text            `db:"phone" json:"phone"`
+	Email                 pgtype.Text            `db:"email" json:"email"`
+	Website               pgtype.Text            `db:"website" json:"website"`
+	Address               pgtype.Text            `db:"address" json:"address"`
+	City                  pgtype.Text            `db:"city" json:"city"`
+	Country               pgtype.Text            `db:"country" json:"country"`
+	LogoUrl               pgtype.Text            `db:"logo_url" json:"logo_url"`
+	CoverImageUrl         pgtype.Text            `db:"cover_image_url" json:"cover_image_url"`
+	ThemeSettings         []byte                 `db:"theme_settings" json:"theme_settings"`
+	IsPublished           bool                   `db:"is_published" json:"is_published"`
+	ViewCount             pgtype.Int4            `db:"view_count" json:"view_count"`
+	RankScore             pgtype.Numeric         `db:"rank_score" json:"rank_score"`
+	CreatedAt             pgtype.Timestamp       `db:"created_at" json:"created_at"`
+	UpdatedAt             pgtype.Timestamp       `db:"updated_at" json:"updated_at"`
+	RatingAvg             pgtype.Numeric         `db:"rating_avg" json:"rating_avg"`
+	RatingCount           int32                  `db:"rating_count" json:"rating_count"`
+	PlanLocked            bool                   `db:"plan_locked" json:"plan_locked"`
+	Timezone              string                 `db:"timezone" json:"timezone"`
+	OwnerName             string                 `db:"owner_name" json:"owner_name"`
+	OwnerEmail            string                 `db:"owner_email" json:"owner_email"`
+	SubscriptionStatus    NullSubscriptionStatus `db:"subscription_status" json:"subscription_status"`
+	SubscriptionPlan      pgtype.Text            `db:"subscription_plan" json:"subscription_plan"`
+	SubscriptionPeriodEnd pgtype.Timestamp       `db:"subscription_period_end" json:"subscription_period_end"`
+	CancelAtPeriodEnd     pgtype.Bool            `db:"cancel_at_period_end" json:"cancel_at_period_end"`
+}
+
+func (q *Queries) GetRestaurantDetailsForAdmin(ctx context.Context, id uuid.UUID) (GetRestaurantDetailsForAdminRow, error) {
+	row := q.db.QueryRow(ctx, getRestaurantDetailsForAdmin, id)
+	var i GetRestaurantDetailsForAdminRow
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.Name,
+		&i.Slug,
+		&i.Description,
+		&i.CuisineType,
+		&i.Phone,
+		&i.Email,
+		&i.Website,
+		&i.Address,
+		&i.City,
+		&i.Country,
+		&i.LogoUrl,
+		&i.CoverImageUrl,
+		&i.ThemeSettings,
+		&i.IsPublished,
+		&i.ViewCount,
+		&i.RankScore,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RatingAvg,
+		&i.RatingCount,
+		&i.PlanLocked,
+		&i.Timezone,
+		&i.OwnerName,
+		&i.OwnerEmail,
+		&i.SubscriptionStatus,
+		&i.SubscriptionPlan,
+		&i.SubscriptionPeriodEnd,
+		&i.CancelAtPeriodEnd,
+	)
+	return i, err
+}
+
+const getRestaurantTableByToken = `-- name: GetRestaurantTableByToken :one
+SELECT id, restaurant_id, label, token, is_active, created_at, updated_at, seats FROM restaurant_tables
+WHERE token = $1 AND is_active = TRUE
+`
+
+func (q *Queries) GetRestaurantTableByToken(ctx context.Context, token string) (RestaurantTable, error) {
+	row := q.db.QueryRow(ctx, getRestaurantTableByToken, token)
+	var i RestaurantTable
+	err := row.Scan(
+		&i.ID,
+		&i.RestaurantID,
+		&i.Label,
+		&i.Token,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Seats,
+	)
+	return i, err
+}
+
+const getReviewByID = `-- name: GetReviewByID :one
+SELECT id, restaurant_id, menu_item_id, reviewer_name, rating, comment, photo_url, owner_reply, replied_by, replied_at, is_hidden, hidden_reason, report_count, ip_address, created_at, updated_at FROM reviews
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetReviewByID(ctx context.Context, id uuid.UUID) (Review, error) {
+	row := q.db.QueryRow(ctx, getReviewByID, id)
+	var i Review
+	err := row.Scan(
+		&i.ID,
+		&i.RestaurantID,
+		&i.MenuItemID,
+		&i.ReviewerName,
+		&i.Rating,
+		&i.Comment,
+		&i.PhotoUrl,
+		&i.OwnerReply,
+		&i.RepliedBy,
+		&i.RepliedAt,
+		&i.IsHidden,
+		&i.HiddenReason,
+		&i.ReportCount,
+		&i.IpAddress,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getServiceRequestAckStats = `-- name: GetServiceRequestAckStats :one
+SELECT
+    COUNT(*) as acknowledged_count,
+    COALESCE(AVG(EXTRACT(EPOCH FROM (acknowledged_at - created_at))), 0)::float8 as avg_ack_seconds
+FROM service_requests
+WHERE restaurant_id = $1 AND acknowledged_at IS NOT NULL AND created_at >= $2
+`
+
+type GetServiceRequestAckStatsParams struct {
+	RestaurantID uuid.UUID        `db:"restaurant_id" json:"restaurant_id"`
+	CreatedAt    pgtype.Timestamp `db:"created_at" json:"created_at"`
+}
+
+type GetServiceRequestAckStatsRow struct {
+	AcknowledgedCount int64   `db:"acknowledged_count" json:"acknowledged_count"`
+	AvgAckSeconds     float64 `db:"avg_ack_seconds" json:"avg_ack_seconds"`
+}
+
+func (q *Queries) GetServiceRequestAckStats(ctx context.Context, arg GetServiceRequestAckStatsParams) (GetServiceRequestAckStatsRow, error) {
+	row := q.db.QueryRow(ctx, getServiceRequestAckStats, arg.RestaurantID, arg.CreatedAt)
+	var i GetServiceRequestAckStatsRow
+	err := row.Scan(
+		&i.AcknowledgedCount,
+		&i.AvgAckSeconds,
+	)
+	return i, err
+}
+
+const getStaffInvitationByID = `-- name: GetStaffInvitationByID :one
+SELECT id, restaurant_id, owner_id, email, full_name, phone, token_hash, status, expires_at, accepted_user_id, accepted_at, created_at, updated_at, staff_role_id FROM staff_invitations
+WHERE id = $1 AND restaurant_id = $2 LIMIT 1
+`
+
+type GetStaffInvitationByIDParams struct {
+	ID           uuid.UUID `db:"id" json:"id"`
+	RestaurantID uuid.UUID `db:"restaurant_id" json:"restaurant_id"`
+}
+
+func (q *Queries) GetStaffInvitationByID(ctx context.Context, arg GetStaffInvitationByIDParams) (StaffInvitation, error) {
+	row := q.db.QueryRow(ctx, getStaffInvitationByID, arg.ID, arg.RestaurantID)
+	var i StaffInvitation
+	err := row.Scan(
+		&i.ID,
+		&i.RestaurantID,
+		&i.OwnerID,
+		&i.Email,
+		&i.FullName,
+		&i.Phone,
+		&i.TokenHash,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.AcceptedUserID,
+		&i.AcceptedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.StaffRoleID,
+	)
+	return i, err
+}
+
+const getStaffInvitationByTokenHash = `-- name: GetStaffInvitationByTokenHash :one
+SELECT id, restaurant_id, owner_id, email, full_name, phone, token_hash, status, expires_at, accepted_user_id, accepted_at, created_at, updated_at, staff_role_id FROM staff_invitations
+WHERE token_hash = $1 LIMIT 1
+`
+
+func (q *Queries) GetStaffInvitationByTokenHash(ctx context.Context, tokenHash string) (StaffInvitation, error) {
+	row := q.db.QueryRow(ctx, getStaffInvitationByTokenHash, tokenHash)
+	var i StaffInvitation
+	err := row.Scan(
+		&i.ID,
+		&i.RestaurantID,
+		&i.OwnerID,
+		&i.Email,
+		&i.FullName,
+		&i.Phone,
+		&i.TokenHash,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.AcceptedUserID,
+		&i.AcceptedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.StaffRoleID,
+	)
+	return i, err
+}
+
+const getStaffPermissions = `-- name: GetStaffPermissions :one
+SELECT u.restaurant_id, COALESCE(r.permissions, '{}')::TEXT[] AS permissions
+FROM users u
+LEFT JOIN staff_roles r ON r.id = u.staff_role_id
+WHERE u.id = $1 AND u.role = 'staff' AND u.is_active = TRUE AND u.deleted_at IS NULL
+LIMIT 1
+`
+
+type GetStaffPermissionsRow struct {
+	RestaurantID uuid.UUID `db:"restaurant_id" json:"restaurant_id"`
+	Permissions  []string  `db:"permissions" json:"permissions"`
+}
+
+func (q *Queries) GetStaffPermissions(ctx context.Context, id uuid.UUID) (GetStaffPermissionsRow, error) {
+	row := q.db.QueryRow(ctx, getStaffPermissions, id)
+	var i GetStaffPermissionsRow
+	err := row.Scan(
+		&i.RestaurantID,
+		&i.Permissions,
+	)
+	return i, err
+}
+
+const getStaffRole = `-- name: GetStaffRole :one
+SELECT id, restaurant_id, name, description, permissions, preset, created_at, updated_at FROM staff_roles
+WHERE id = $1 AND restaurant_id = $2 LIMIT 1
+`
+
+type GetStaffRoleParams struct {
+	ID           uuid.UUID `db:"id" json:"id"`
+	RestaurantID uuid.UUID `db:"restaurant_id" json:"restaurant_id"`
+}
+
+func (q *Queries) GetStaffRole(ctx context.Context, arg GetStaffRoleParams) (StaffRole, error) {
+	row := q.db.QueryRow(ctx, getStaffRole, arg.ID, arg.RestaurantID)
+	var i StaffRole
+	err := row.Scan(
+		&i.ID,
+		&i.RestaurantID,
+		&i.Name,
+		&i.Description,
+		&i.Permissions,
+		&i.Preset,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getStaffRoleByPreset = `-- name: GetStaffRoleByPreset :one
+SELECT id, restaurant_id, name, description, permissions, preset, created_at, updated_at FROM staff_roles
+WHERE restaurant_id = $1 AND preset = $2 LIMIT 1
+`
+
+type GetStaffRoleByPresetParams struct {
+	RestaurantID uuid.UUID   `db:"restaurant_id" json:"restaurant_id"`
+	Preset       pgtype.Text `db:"preset" json:"preset"`
+}
+
+func (q *Queries) GetStaffRoleByPreset(ctx context.Context, arg GetStaffRoleByPresetParams) (StaffRole, error) {
+	row := q.db.QueryRow(ctx, getStaffRoleByPreset, arg.RestaurantID, arg.Preset)
+	var i StaffRole
+	err := row.Scan(
+		&i.ID,
+		&i.RestaurantID,
+		&i.Name,
+		&i.Description,
+		&i.Permissions,
+		&i.Preset,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getSubscriptionByID = `-- name: GetSubscriptionByID :one
+SELECT id, owner_id, plan_id, status, current_period_start, current_period_end, trial_end, cancelled_at, payment_provider_subscription_id, created_at, updated_at, billing_interval, scheduled_plan_id, scheduled_billing_interval, cancel_at_period_end FROM subscriptions
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetSubscriptionByID(ctx context.Context, id uuid.UUID) (Subscription, error) {
+	row := q.db.QueryRow(ctx, getSubscriptionByID, id)
+	var i Subscription
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.PlanID,
+		&i.Status,
+		&i.CurrentPeriodStart,
+		&i.CurrentPeriodEnd,
+		&i.TrialEnd,
+		&i.CancelledAt,
+		&i.PaymentProviderSubscriptionID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.BillingInterval,
+		&i.ScheduledPlanID,
+		&i.ScheduledBillingInterval,
+		&i.CancelAtPeriodEnd,
+	)
+	return i, err
+}
+
+const getSubscriptionPlanByID = `-- name: GetSubscriptionPlanByID :one
+SELECT id, name, slug, description, price_monthly, price_annual, currency, features, display_order, is_active, created_at, updated_at FROM subscription_plans
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetSubscriptionPlanByID(ctx context.Context, id uuid.UUID) (SubscriptionPlan, error) {
+	row := q.db.QueryRow(ctx, getSubscriptionPlanByID, id)
+	var i SubscriptionPlan
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Slug,
+		&i.Description,
+		&i.PriceMonthly,
+		&i.PriceAnnual,
+		&i.Currency,
+		&i.Features,
+		&i.DisplayOrder,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getSubscriptionPlanBySlug = `-- name: GetSubscriptionPlanBySlug :one
+SELECT id, name, slug, description, price_monthly, price_annual, currency, features, display_order, is_active, created_at, updated_at FROM subscription_plans
+WHERE slug = $1 LIMIT 1
+`
+
+func (q *Queries) GetSubscriptionPlanBySlug(ctx context.Context, slug string) (SubscriptionPlan, error) {
+	row := q.db.QueryRow(ctx, getSubscriptionPlanBySlug, slug)
+	var i SubscriptionPlan
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Slug,
+		&i.Description,
+		&i.PriceMonthly,
+		&i.PriceAnnual,
+		&i.Currency,
+		&i.Features,
+		&i.DisplayOrder,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getUserByEmail = `-- name: GetUserByEmail :one
+SELECT id, email, password_hash, full_name, role, owner_id, restaurant_id, phone, avatar_url, email_verified, last_login_at, is_active, created_at, updated_at, email_verified_at, verification_token, verification_token_expires_at, trial_ends_at, must_change_password, password_changed_at, staff_role_id FROM users
+WHERE LOWER(email) = LOWER($1) AND deleted_at IS NULL LIMIT 1
+`
+
+func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByEmail, email)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.PasswordHash,
+		&i.FullName,
+		&i.Role,
+		&i.OwnerID,
+		&i.RestaurantID,
+		&i.Phone,
+		&i.AvatarUrl,
+		&i.EmailVerified,
+		&i.LastLoginAt,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.EmailVerifiedAt,
+		&i.VerificationToken,
+		&i.VerificationTokenExpiresAt,
+		&i.TrialEndsAt,
+		&i.MustChangePassword,
+		&i.PasswordChangedAt,
+		&i.StaffRoleID,
+	)
+	return i, err
+}
+
+const getUserByID = `-- name: GetUserByID :one
+SELECT id, email, password_hash, full_name, role, owner_id, restaurant_id, phone, avatar_url, email_verified, last_login_at, is_active, created_at, updated_at, email_verified_at, verification_token, verification_token_expires_at, trial_ends_at, must_change_password, password_changed_at, staff_role_id FROM users
+WHERE id = $1  LIMIT 1
+`
+
+func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByID, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.PasswordHash,
+		&i.FullName,
+		&i.Role,
+		&i.OwnerID,
+		&i.RestaurantID,
+		&i.Phone,
+		&i.AvatarUrl,
+		&i.EmailVerified,
+		&i.LastLoginAt,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.EmailVerifiedAt,
+		&i.VerificationToken,
+		&i.VerificationTokenExpiresAt,
+		&i.TrialEndsAt,
+		&i.MustChangePassword,
+		&i.PasswordChangedAt,
+		&i.StaffRoleID,
+	)
+	return i, err
+}
+
+const getUserIdentity = `-- name: GetUserIdentity :one
+SELECT id, user_id, provider, subject, email, created_at, last_login_at FROM user_identities
+WHERE provider = $1 AND subject = $2 LIMIT 1
+`
+
+type GetUserIdentityParams struct {
+	Provider string `db:"provider" json:"provider"`
+	Subject  string `db:"subject" json:"subject"`
+}
+
+func (q *Queries) GetUserIdentity(ctx context.Context, arg GetUserIdentityParams) (UserIdentity, error) {
+	row := q.db.QueryRow(ctx, getUserIdentity, arg.Provider, arg.Subject)
+	var i UserIdentity
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Provider,
+		&i.Subject,
+		&i.Email,
+		&i.CreatedAt,
+		&i.LastLoginAt,
+	)
+	return i, err
+}
+
+const getUserSession = `-- name: GetUserSession :one
+SELECT id, user_id, ip_address, user_agent, device_type, browser, os, created_at, last_seen_at, expires_at, revoked_at FROM user_sessions
+WHERE id = $1 AND user_id = $2 LIMIT 1
+`
+
+type GetUserSessionParams struct {
+	ID     uuid.UUID `db:"id" json:"id"`
+	UserID uuid.UUID `db:"user_id" json:"user_id"`
+}
+
+func (q *Queries) GetUserSession(ctx context.Context, arg GetUserSessionParams) (UserSession, error) {
+	row := q.db.QueryRow(ctx, getUserSession, arg.ID, arg.UserID)
+	var i UserSession
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.IpAddress,
+		&i.UserAgent,
+		&i.DeviceType,
+		&i.Browser,
+		&i.Os,
+		&i.CreatedAt,
+		&i.LastSeenAt,
+		&i.ExpiresAt,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const getUserTwoFactor = `-- name: GetUserTwoFactor :one
+SELECT user_id, secret, enabled_at FROM user_two_factor
+WHERE user_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetUserTwoFactor(ctx context.Context, userID uuid.UUID) (UserTwoFactor, error) {
+	row := q.db.QueryRow(ctx, getUserTwoFactor, userID)
+	var i UserTwoFactor
+	err := row.Scan(
+		&i.UserID,
+		&i.Secret,
+		&i.EnabledAt,
+	)
+	return i, err
+}
+
+const hasCouponReservation = `-- name: HasCouponReservation :one
+SELECT EXISTS (
+    SELECT 1 FROM coupon_reservations WHERE coupon_id = $1 AND owner_id = $2
+)
+`
+
+type HasCouponReservationParams struct {
+	CouponID uuid.UUID `db:"coupon_id" json:"coupon_id"`
+	OwnerID  uuid.UUID `db:"owner_id" json:"owner_id"`
+}
+
+func (q *Queries) HasCouponReservation(ctx context.Context, arg HasCouponReservationParams) (bool, error) {
+	row := q.db.QueryRow(ctx, hasCouponReservation, arg.CouponID, arg.OwnerID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const incrementCouponRedemptions = `-- name: IncrementCouponRedemptions :execrows
+UPDATE coupons
+SET redemption_count = redemption_count + 1, updated_at = NOW()
+WHERE id = $1 AND (max_redemptions IS NULL OR redemption_count < max_redemptions)
+`
+
+func (q *Queries) IncrementCouponRedemptions(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, incrementCouponRedemptions, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const incrementMenuItemViewCount = `-- name: IncrementMenuItemViewCount :exec
+UPDATE menu_items SET view_count = view_count + 1 WHERE id = $1
+`
+
+func (q *Queries) IncrementMenuItemViewCount(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, incrementMenuItemViewCount, id)
+	return err
+}
+
+const incrementRestaurantViewCount = `-- name: IncrementRestaurantViewCount :exec
+UPDATE restaurants SET view_count = view_count + 1 WHERE id = $1
+`
+
+func (q *Queries) IncrementRestaurantViewCount(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, incrementRestaurantViewCount, id)
+	return err
+}
+
+const keepLockedMenuItemUnavailable = `-- name: KeepLockedMenuItemUnavailable :exec
+UPDATE menu_items
+SET was_available_before_lock = FALSE, updated_at = NOW()
+WHERE id = $1 AND plan_locked = TRUE
+`
+
+func (q *Queries) KeepLockedMenuItemUnavailable(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, keepLockedMenuItemUnavailable, id)
+	return err
+}
+
+const listAPIKeysByRestaurant = `-- name: ListAPIKeysByRestaurant :many
+SELECT id, restaurant_id, created_by, name, key_prefix, key_hash, permissions, expires_at, last_used_at, last_used_ip, revoked_at, created_at FROM api_keys
+WHERE restaurant_id = $1
+ORDER BY revoked_at IS NOT NULL, created_at DESC
+`
+
+func (q *Queries) ListAPIKeysByRestaurant(ctx context.Context, restaurantID uuid.UUID) ([]ApiKey, error) {
+	rows, err := q.db.Query(ctx, listAPIKeysByRestaurant, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ApiKey
+	for rows.Next() {
+		var i ApiKey
+		if err := rows.Scan(
+			&i.ID,
+			&i.RestaurantID,
+			&i.CreatedBy,
+			&i.Name,
+			&i.KeyPrefix,
+			&i.KeyHash,
+			&i.Permissions,
+			&i.ExpiresAt,
+			&i.LastUsedAt,
+			&i.LastUsedIp,
+			&i.RevokedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listActiveUserSessions = `-- name: ListActiveUserSessions :many
+SELECT id, user_id, ip_address, user_agent, device_type, browser, os, created_at, last_seen_at, expires_at, revoked_at FROM user_sessions
+WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > NOW()
+ORDER BY last_seen_at DESC
+`
+
+func (q *Queries) ListActiveUserSessions(ctx context.Context, userID uuid.UUID) ([]UserSession, error) {
+	rows, err := q.db.Query(ctx, listActiveUserSessions, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []UserSession
+	for rows.Next() {
+		var i UserSession
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.IpAddress,
+			&i.UserAgent,
+			&i.DeviceType,
+			&i.Browser,
+			&i.Os,
+			&i.CreatedAt,
+			&i.LastSeenAt,
+			&i.ExpiresAt,
+			&i.RevokedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listActivityLogsByRestaurant = `-- name: ListActivityLogsByRestaurant :many
+SELECT al.id, al.restaurant_id, al.user_id, al.action_type, al.action_category, al.description, al.target_type, al.target_id, al.target_name, al.before_value, al.after_value, al.ip_address, al.user_agent, al.device_type, al.browser, al.os, al.success, al.created_at, u.full_name as user_name, u.email as user_email
+FROM activity_logs al
+JOIN users u ON al.user_id = u.id
+WHERE al.restaurant_id = $1
+ORDER BY al.created_at DESC
+LIMIT $2 OFFSET $3
+`
+
+type ListActivityLogsByRestaurantParams struct {
+	RestaurantID uuid.UUID `db:"restaurant_id" json:"restaurant_id"`
+	Limit        int32     `db:"limit" json:"limit"`
+	Offset       int32     `db:"offset" json:"offset"`
+}
+
+type ListActivityLogsByRestaurantRow struct {
+	ID             uuid.UUID        `db:"id" json:"id"`
+	RestaurantID   uuid.UUID        `db:"restaurant_id" json:"restaurant_id"`
+	UserID         uuid.UUID        `db:"user_id" json:"user_id"`
+	ActionType     string           `db:"action_type" json:"action_type"`
+	ActionCategory string           `db:"action_category" json:"action_category"`
+	Description    pgtype.Text      `db:"description" json:"description"`
+	TargetType     pgtype.Text      `db:"target_type" json:"target_type"`
+	TargetID       uuid.UUID        `db:"target_id" json:"target_id"`
+	TargetName     pgtype.Text      `db:"target_name" json:"target_name"`
+	BeforeValue    []byte           `db:"before_value" json:"before_value"`
+	AfterValue     []byte           `db:"after_value" json:"after_value"`
+	IpAddress      pgtype.Text      `db:"ip_address" json:"ip_address"`
+	UserAgent      pgtype.Text      `db:"user_agent" json:"user_agent"`
+	DeviceType     pgtype.Text      `db:"device_type" json:"device_type"`
+	Browser        pgtype.Text      `db:"browser" json:"browser"`
+	Os             pgtype.Text      `db:"os" json:"os"`
+	Success        pgtype.Bool      `db:"success" json:"success"`
+	CreatedAt      pgtype.Timestamp `db:"created_at" json:"created_at"`
+	UserName       string           `db:"user_name" json:"user_name"`
+	UserEmail      string           `db:"user_email" json:"user_email"`
+}
+
+func (q *Queries) ListActivityLogsByRestaurant(ctx context.Context, arg ListActivityLogsByRestaurantParams) ([]ListActivityLogsByRestaurantRow, error) {
+	rows, err := q.db.Query(ctx, listActivityLogsByRestaurant, arg.RestaurantID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListActivityLogsByRestaurantRow
+	for rows.Next() {
+		var i ListActivityLogsByRestaurantRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.RestaurantID,
+			&i.UserID,
+			&i.ActionType,
+			&i.ActionCategory,
+			&i.Description,
+			&i.TargetType,
+			&i.TargetID,
+			&i.TargetName,
+			&i.BeforeValue,
+			&i.AfterValue,
+			&i.IpAddress,
+			&i.UserAgent,
+			&i.DeviceType,
+			&i.Browser,
+			&i.Os,
+			&i.Success,
+			&i.CreatedAt,
+			&i.UserName,
+			&i.UserEmail,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listActivityLogsWithFilters = `-- name: ListActivityLogsWithFilters :many
+SELECT al.id, al.restaurant_id, al.user_id, al.action_type, al.action_category, al.description, al.target_type, al.target_id, al.target_name, al.before_value, al.after_value, al.ip_address, al.user_agent, al.device_type, al.browser, al.os, al.success, al.created_at, u.full_name as user_name, u.email as user_email
+FROM activity_logs al
+JOIN users u ON al.user_id = u.id
+WHERE 
+    ($3::uuid IS NULL OR al.restaurant_id = $3) AND
+    ($4::uuid IS NULL OR al.user_id = $4) AND
+    ($5::text IS NULL OR al.action_type = $5) AND
+    ($6::text IS NULL OR al.action_category = $6) AND
+    ($7::text IS NULL OR al.target_type = $7) AND
+    ($8::uuid IS NULL OR al.target_id = $8) AND
+    ($9::boolean IS NULL OR al.success = $9) AND
+    ($10::text IS NULL OR al.description ILIKE '%' || $10 || '%' OR al.target_name ILIKE '%' || $10 || '%')
+ORDER BY al.created_at DESC
+LIMIT $1 OFFSET $2
+`
+
+type ListActivityLogsWithFiltersParams struct {
+	Limit          int32       `db:"limit" json:"limit"`
+	Offset         int32       `db:"offset" json:"offset"`
+	RestaurantID   uuid.UUID   `db:"restaurant_id" json:"restaurant_id"`
+	UserID         uuid.UUID   `db:"user_id" json:"user_id"`
+	ActionType     pgtype.Text `db:"action_type" json:"action_type"`
+	ActionCategory pgtype.Text `db:"action_category" json:"action_category"`
+	TargetType     pgtype.Text `db:"target_type" json:"target_type"`
+	TargetID       uuid.UUID   `db:"target_id" json:"target_id"`
+	Success        pgtype.Bool `db:"success" json:"success"`
+	Search         pgtype.Text `db:"search" json:"search"`
+}
+
+type ListActivityLogsWithFiltersRow struct {
+	ID             uuid.UUID        `db:"id" json:"id"`
+	RestaurantID   uuid.UUID        `db:"restaurant_id" json:"restaurant_id"`
+	UserID         uuid.UUID        `db:"user_id" json:"user_id"`
+	ActionType     string           `db:"action_type" json:"action_type"`
+	ActionCategory string           `db:"action_category" json:"action_category"`
+	Description    pgtype.Text      `db:"description" json:"description"`
+	TargetType     pgtype.Text      `db:"target_type" json:"target_type"`
+	TargetID       uuid.UUID        `db:"target_id" json:"target_id"`
+	TargetName     pgtype.Text      `db:"target_name" json:"target_name"`
+	BeforeValue    []byte           `db:"before_value" json:"before_value"`
+	AfterValue     []byte           `db:"after_value" json:"after_value"`
+	IpAddress      pgtype.Text      `db:"ip_address" json:"ip_address"`
+	UserAgent      pgtype.Text      `db:"user_agent" json:"user_agent"`
+	DeviceType     pgtype.Text      `db:"device_type" json:"device_type"`
+	Browser        pgtype.Text      `db:"browser" json:"browser"`
+	Os             pgtype.Text      `db:"os" json:"os"`
+	Success        pgtype.Bool      `db:"success" json:"success"`
+	CreatedAt      pgtype.Timestamp `db:"created_at" json:"created_at"`
+	UserName       string           `db:"user_name" json:"user_name"`
+	UserEmail      string           `db:"user_email" json:"user_email"`
+}
+
+func (q *Queries) ListActivityLogsWithFilters(ctx context.Context, arg ListActivityLogsWithFiltersParams) ([]ListActivityLogsWithFiltersRow, error) {
+	rows, err := q.db.Query(ctx, listActivityLogsWithFilters,
+		arg.Limit,
+		arg.Offset,
+		arg.RestaurantID,
+		arg.UserID,
+		arg.ActionType,
+		arg.ActionCategory,
+		arg.TargetType,
+		arg.TargetID,
+		arg.Success,
+		arg.Search,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListActivityLogsWithFiltersRow
+	for rows.Next() {
+		var i ListActivityLogsWithFiltersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.RestaurantID,
+			&i.UserID,
+			&i.ActionType,
+			&i.ActionCategory,
+			&i.Description,
+			&i.TargetType,
+			&i.TargetID,
+			&i.TargetName,
+			&i.BeforeValue,
+			&i.AfterValue,
+			&i.IpAddress,
+			&i.UserAgent,
+			&i.DeviceType,
+			&i.Browser,
+			&i.Os,
+			&i.Success,
+			&i.CreatedAt,
+			&i.UserName,
+			&i.UserEmail,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAnalyticsEventsWithFilters = `-- name: ListAnalyticsEventsWithFilters :many
+SELECT id, restaurant_id, event_type, visitor_id, session_id, target_id, ip_address, device_type, browser, os, country, city, created_at FROM analytics_events
+WHERE 
+    ($3::uuid IS NULL OR restaurant_id = $3) AND
+    ($4::text IS NULL OR event_type = $4) AND
+    ($5::text IS NULL OR visitor_id = $5) AND
+    ($6::uuid IS NULL OR session_id = $6) AND
+    ($7::uuid IS NULL OR target_id = $7) AND
+    ($8::text IS NULL OR country = $8) AND
+    ($9::text IS NULL OR city = $9)
+ORDER BY created_at DESC
+LIMIT $1 OFFSET $2
+`
+
+type ListAnalyticsEventsWithFiltersParams struct {
+	Limit        int32       `db:"limit" json:"limit"`
+	Offset       int32       `db:"offset" json:"offset"`
+	RestaurantID uuid.UUID   `db:"restaurant_id" json:"restaurant_id"`
+	EventType    pgtype.Text `db:"event_type" json:"event_type"`
+	VisitorID    pgtype.Text `db:"visitor_id" json:"visitor_id"`
+	SessionID    uuid.UUID   `db:"session_id" json:"session_id"`
+	TargetID     uuid.UUID   `db:"target_id" json:"target_id"`
+	Country      pgtype.Text `db:"country" json:"country"`
+	City         pgtype.Text `db:"city" json:"city"`
+}
+
+func (q *Queries) ListAnalyticsEventsWithFilters(ctx context.Context, arg ListAnalyticsEventsWithFiltersParams) ([]AnalyticsEvent, error) {
+	rows, err := q.db.Query(ctx, listAnalyticsEventsWithFilters,
+		arg.Limit,
+		arg.Offset,
+		arg.RestaurantID,
+		arg.EventType,
+		arg.VisitorID,
+		arg.SessionID,
+		arg.TargetID,
+		arg.Country,
+		arg.City,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AnalyticsEvent
+	for rows.Next() {
+		var i AnalyticsEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.RestaurantID,
+			&i.EventType,
+			&i.VisitorID,
+			&i.SessionID,
+			&i.TargetID,
+			&i.IpAddress,
+			&i.DeviceType,
+			&i.Browser,
+			&i.Os,
+			&i.Country,
+			&i.City,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBlockingReservations = `-- name: ListBlockingReservations :many
+SELECT id, restaurant_id, table_id, guest_name, guest_phone, guest_email, party_size, reserved_at, ends_at, notes, status, confirmation_token, cancellation_token, confirmed_at, seated_at, cancelled_at, created_at, updated_at FROM reservations
+WHERE restaurant_id = $1
+  AND status IN ('pending', 'confirmed', 'seated')
+  AND ends_at > $2
+  AND reserved_at < $3
+`
+
+type ListBlockingReservationsParams struct {
+	RestaurantID uuid.UUID        `db:"restaurant_id" json:"restaurant_id"`
+	WindowStart  pgtype.Timestamp `db:"window_start" json:"window_start"`
+	WindowEnd    pgtype.Timestamp `db:"window_end" json:"window_end"`
+}
+
+func (q *Queries) ListBlockingReservations(ctx context.Context, arg ListBlockingReservationsParams) ([]Reservation, error) {
+	rows, err := q.db.Query(ctx, listBlockingReservations, arg.RestaurantID, arg.WindowStart, arg.WindowEnd)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Reservation
+	for rows.Next() {
+		var i Reservation
+		if err := rows.Scan(
+			&i.ID,
+			&i.RestaurantID,
+			&i.TableID,
+			&i.GuestName,
+			&i.GuestPhone,
+			&i.GuestEmail,
+			&i.PartySize,
+			&i.ReservedAt,
+			&i.EndsAt,
+			&i.Notes,
+			&i.Status,
+			&i.ConfirmationToken,
+			&i.CancellationToken,
+			&i.ConfirmedAt,
+			&i.SeatedAt,
+			&i.CancelledAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCategoriesByRestaurant = `-- name: ListCategoriesByRestaurant :many
+SELECT id, restaurant_id, name, description, icon, display_order, is_active, created_by, created_at, updated_at, plan_locked FROM categories
+WHERE restaurant_id = $1
+ORDER BY display_order ASC
+`
+
+func (q *Queries) ListCategoriesByRestaurant(ctx context.Context, restaurantID uuid.UUID) ([]Category, error) {
+	rows, err := q.db.Query(ctx, listCategoriesByRestaurant, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Category
+	for rows.Next() {
+		var i Category
+		if err := rows.Scan(
+			&i.ID,
+			&i.RestaurantID,
+			&i.Name,
+			&i.Description,
+			&i.Icon,
+			&i.DisplayOrder,
+			&i.IsActive,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.PlanLocked,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCouponRedemptions = `-- name: ListCouponRedemptions :many
+SELECT cr.id, cr.coupon_id, cr.owner_id, cr.invoice_id, cr.discount_amount, cr.created_at, u.email AS owner_email, u.full_name AS owner_name, i.invoice_number
+FROM coupon_redemptions cr
+JOIN users u ON cr.owner_id = u.id
+JOIN invoices i ON cr.invoice_id = i.id
+WHERE cr.coupon_id = $1
+ORDER BY cr.created_at DESC
+`
+
+type ListCouponRedemptionsRow struct {
+	ID             uuid.UUID        `db:"id" json:"id"`
+	CouponID       uuid.UUID        `db:"coupon_id" json:"coupon_id"`
+	OwnerID        uuid.UUID        `db:"owner_id" json:"owner_id"`
+	InvoiceID      uuid.UUID        `db:"invoice_id" json:"invoice_id"`
+	DiscountAmount pgtype.Numeric   `db:"discount_amount" json:"discount_amount"`
+	CreatedAt      pgtype.Timestamp `db:"created_at" json:"created_at"`
+	OwnerEmail     string           `db:"owner_email" json:"owner_email"`
+	OwnerName      string           `db:"owner_name" json:"owner_name"`
+	InvoiceNumber  pgtype.Text      `db:"invoice_number" json:"invoice_number"`
+}
+
+func (q *Queries) ListCouponRedemptions(ctx context.Context, couponID uuid.UUID) ([]ListCouponRedemptionsRow, error) {
+	rows, err := q.db.Query(ctx, listCouponRedemptions, couponID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCouponRedemptionsRow
+	for rows.Next() {
+		var i ListCouponRedemptionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.CouponID,
+			&i.OwnerID,
+			&i.InvoiceID,
+			&i.DiscountAmount,
+			&i.CreatedAt,
+			&i.OwnerEmail,
+			&i.OwnerName,
+			&i.InvoiceNumber,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCoupons = `-- name: ListCoupons :many
+SELECT id, code, description, discount_type, discount_value, plan_ids, max_redemptions, redemption_count, duration_cycles, expires_at, is_active, created_by, created_at, updated_at FROM coupons
+ORDER BY created_at DESC
+`
+
+func (q *Queries) ListCoupons(ctx context.Context) ([]Coupon, error) {
+	rows, err := q.db.Query(ctx, listCoupons)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Coupon
+	for rows.Next() {
+		var i Coupon
+		if err := rows.Scan(
+			&i.ID,
+			&i.Code,
+			&i.Description,
+			&i.DiscountType,
+			&i.DiscountValue,
+			&i.PlanIds,
+			&i.MaxRedemptions,
+			&i.RedemptionCount,
+			&i.DurationCycles,
+			&i.ExpiresAt,
+			&i.IsActive,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCreditNotesByInvoice = `-- name: ListCreditNotesByInvoice :many
+SELECT id, credit_note_number, invoice_id, owner_id, amount, currency, vat_rate, reason, provider_refund_ref, subscription_terminated, created_by, created_at FROM credit_notes
+WHERE invoice_id = $1
+ORDER BY created_at
+`
+
+func (q *Queries) ListCreditNotesByInvoice(ctx context.Context, invoiceID uuid.UUID) ([]CreditNote, error) {
+	rows, err := q.db.Query(ctx, listCreditNotesByInvoice, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CreditNote
+	for rows.Next() {
+		var i CreditNote
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreditNoteNumber,
+			&i.InvoiceID,
+			&i.OwnerID,
+			&i.Amount,
+			&i.Currency,
+			&i.VatRate,
+			&i.Reason,
+			&i.ProviderRefundRef,
+			&i.SubscriptionTerminated,
+			&i.CreatedBy,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCurrentPromotions = `-- name: ListCurrentPromotions :many
+SELECT id, restaurant_id, name, label, scope, category_id, menu_item_id, discount_type, discount_value, starts_on, ends_on, days_of_week, start_time, end_time, is_active, created_by, created_at, updated_at FROM promotions
+WHERE restaurant_id = $1
+  AND is_active = TRUE
+  AND (starts_on IS NULL OR starts_on <= $2::date)
+  AND (ends_on IS NULL OR ends_on >= $2::date)
+`
+
+type ListCurrentPromotionsParams struct {
+	RestaurantID uuid.UUID   `db:"restaurant_id" json:"restaurant_id"`
+	Today        pgtype.Date `db:"today" json:"today"`
+}
+
+func (q *Queries) ListCurrentPromotions(ctx context.Context, arg ListCurrentPromotionsParams) ([]Promotion, error) {
+	rows, err := q.db.Query(ctx, listCurrentPromotions, arg.RestaurantID, arg.Today)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Promotion
+	for rows.Next() {
+		var i Promotion
+		if err := rows.Scan(
+			&i.ID,
+			&i.RestaurantID,
+			&i.Name,
+			&i.Label,
+			&i.Scope,
+			&i.CategoryID,
+			&i.MenuItemID,
+			&i.DiscountType,
+			&i.DiscountValue,
+			&i.StartsOn,
+			&i.EndsOn,
+			&i.DaysOfWeek,
+			&i.StartTime,
+			&i.EndTime,
+			&i.IsActive,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDuePaymentRetryJobs = `-- name: ListDuePaymentRetryJobs :many
+SELECT id, subscription_id, status, retry_count, max_retries, scheduled_for, created_at, updated_at FROM payment_retry_jobs
+WHERE status IN ('pending', 'retrying') AND scheduled_for <= NOW()
+ORDER BY scheduled_for
+LIMIT $1
+`
+
+func (q *Queries) ListDuePaymentRetryJobs(ctx context.Context, limit int32) ([]PaymentRetryJob, error) {
+	rows, err := q.db.Query(ctx, listDuePaymentRetryJobs, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PaymentRetryJob
+	for rows.Next() {
+		var i PaymentRetryJob
+		if err := rows.Scan(
+			&i.ID,
+			&i.SubscriptionID,
+			&i.Status,
+			&i.RetryCount,
+			&i.MaxRetries,
+			&i.ScheduledFor,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listInvoicesByOwner = `-- name: ListInvoicesByOwner :many
+SELECT id, subscription_id, owner_id, invoice_number, amount, currency, status, billing_period_start, billing_period_end, payment_provider_invoice_id, paid_at, created_at, updated_at, prorated, credit_amount, coupon_id, discount_amount, tx_ref, vat_rate, refunded_amount FROM invoices
+WHERE owner_id = $1
+ORDER BY created_at DESC
+`
+
+func (q *Queries) ListInvoicesByOwner(ctx context.Context, ownerID uuid.UUID) ([]Invoice, error) {
+	rows, err := q.db.Query(ctx, listInvoicesByOwner, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Invoice
+	for rows.Next() {
+		var i Invoice
+		if err := rows.Scan(
+			&i.ID,
+			&i.SubscriptionID,
+			&i.OwnerID,
+			&i.InvoiceNumber,
+			&i.Amount,
+			&i.Currency,
+			&i.Status,
+			&i.BillingPeriodStart,
+			&i.BillingPeriodEnd,
+			&i.PaymentProviderInvoiceID,
+			&i.PaidAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Prorated,
+			&i.CreditAmount,
+			&i.CouponID,
+			&i.DiscountAmount,
+			&i.TxRef,
+			&i.VatRate,
+			&i.RefundedAmount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listInvoicesWithFilters = `-- name: ListInvoicesWithFilters :many
+SELECT id, subscription_id, owner_id, invoice_number, amount, currency, status, billing_period_start, billing_period_end, payment_provider_invoice_id, paid_at, created_at, updated_at, prorated, credit_amount, coupon_id, discount_amount, tx_ref, vat_rate, refunded_amount FROM invoices
+WHERE 
+    owner_id = $1 AND
+    (NULLIF($2::uuid, '00000000-0000-0000-0000-000000000000'::uuid) IS NULL OR subscription_id = $2) AND
+    ($3::invoice_status IS NULL OR status = $3)
+ORDER BY created_at DESC
+LIMIT $4 OFFSET $5
+`
+
+type ListInvoicesWithFiltersParams struct {
+	OwnerID        uuid.UUID         `db:"owner_id" json:"owner_id"`
+	SubscriptionID uuid.UUID         `db:"subscription_id" json:"subscription_id"`
+	Status         NullInvoiceStatus `db:"status" json:"status"`
+	Limit          int32             `db:"limit" json:"limit"`
+	Offset         int32             `db:"offset" json:"offset"`
+}
+
+func (q *Queries) ListInvoicesWithFilters(ctx context.Context, arg ListInvoicesWithFiltersParams) ([]Invoice, error) {
+	rows, err := q.db.Query(ctx, listInvoicesWithFilters,
+		arg.OwnerID,
+		arg.SubscriptionID,
+		arg.Status,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Invoice
+	for rows.Next() {
+		var i Invoice
+		if err := rows.Scan(
+			&i.ID,
+			&i.SubscriptionID,
+			&i.OwnerID,
+			&i.InvoiceNumber,
+			&i.Amount,
+			&i.Currency,
+			&i.Status,
+			&i.BillingPeriodStart,
+			&i.BillingPeriodEnd,
+			&i.PaymentProviderInvoiceID,
+			&i.PaidAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Prorated,
+			&i.CreditAmount,
+			&i.CouponID,
+			&i.DiscountAmount,
+			&i.TxRef,
+			&i.VatRate,
+			&i.RefundedAmount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLowStockMenuItems = `-- name: ListLowStockMenuItems :many
+SELECT id, restaurant_id, category_id, name, description, price, currency, images, allergens, dietary_tags, spice_level, calories, is_available, display_order, view_count, created_by, created_at, updated_at, rating_avg, rating_count, track_stock, stock_quantity, low_stock_threshold, low_stock_notified_at, unavailable_until, plan_locked, was_available_before_lock FROM menu_items
+WHERE restaurant_id = $1 AND track_stock = TRUE AND stock_quantity <= low_stock_threshold AND deleted_at IS NULL
+ORDER BY stock_quantity ASC, name ASC
+`
+
+func (q *Queries) ListLowStockMenuItems(ctx context.Context, restaurantID uuid.UUID) ([]MenuItem, error) {
+	rows, err := q.db.Query(ctx, listLowStockMenuItems, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []MenuItem
+	for rows.Next() {
+		var i MenuItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.RestaurantID,
+			&i.CategoryID,
+			&i.Name,
+			&i.Description,
+			&i.Price,
+			&i.Currency,
+			&i.Images,
+			&i.Allergens,
+			&i.DietaryTags,
+			&i.SpiceLevel,
+			&i.Calories,
+			&i.IsAvailable,
+			&i.DisplayOrder,
+			&i.ViewCount,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.RatingAvg,
+			&i.RatingCount,
+			&i.TrackStock,
+			&i.StockQuantity,
+			&i.LowStockThreshold,
+			&i.LowStockNotifiedAt,
+			&i.UnavailableUntil,
+			&i.PlanLocked,
+			&i.WasAvailableBeforeLock,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMenuItemReviews = `-- name: ListMenuItemReviews :many
+SELECT id, restaurant_id, menu_item_id, reviewer_name, rating, comment, photo_url, owner_reply, replied_by, replied_at, is_hidden, hidden_reason, report_count, ip_address, created_at, updated_at FROM reviews
+WHERE menu_item_id = $1 AND is_hidden = FALSE
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3
+`
+
+type ListMenuItemReviewsParams struct {
+	MenuItemID uuid.UUID `db:"menu_item_id" json:"menu_item_id"`
+	Limit      int32     `db:"limit" json:"limit"`
+	Offset     int32     `db:"offset" json:"offset"`
+}
+
+func (q *Queries) ListMenuItemReviews(ctx context.Context, arg ListMenuItemReviewsParams) ([]Review, error) {
+	rows, err := q.db.Query(ctx, listMenuItemReviews, arg.MenuItemID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Review
+	for rows.Next() {
+		var i Review
+		if err := rows.Scan(
+			&i.ID,
+			&i.RestaurantID,
+			&i.MenuItemID,
+			&i.ReviewerName,
+			&i.Rating,
+			&i.Comment,
+			&i.PhotoUrl,
+			&i.OwnerReply,
+			&i.RepliedBy,
+			&i.RepliedAt,
+			&i.IsHidden,
+			&i.HiddenReason,
+			&i.ReportCount,
+			&i.IpAddress,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMenuItemsByCategory = `-- name: ListMenuItemsByCategory :many
+SELECT id, restaurant_id, category_id, name, description, price, currency, images, allergens, dietary_tags, spice_level, calories, is_available, display_order, view_count, created_by, created_at, updated_at, rating_avg, rating_count, track_stock, stock_quantity, low_stock_threshold, low_stock_notified_at, unavailable_until, plan_locked, was_available_before_lock FROM menu_items
+WHERE category_id = $1 
+ORDER BY display_order ASC
+`
+
+func (q *Queries) ListMenuItemsByCategory(ctx context.Context, categoryID uuid.UUID) ([]MenuItem, error) {
+	rows, err := q.db.Query(ctx, listMenuItemsByCategory, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []MenuItem
+	for rows.Next() {
+		var i MenuItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.RestaurantID,
+			&i.CategoryID,
+			&i.Name,
+			&i.Description,
+			&i.Price,
+			&i.Currency,
+			&i.Images,
+			&i.Allergens,
+			&i.DietaryTags,
+			&i.SpiceLevel,
+			&i.Calories,
+			&i.IsAvailable,
+			&i.DisplayOrder,
+			&i.ViewCount,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.RatingAvg,
+			&i.RatingCount,
+			&i.TrackStock,
+			&i.StockQuantity,
+			&i.LowStockThreshold,
+			&i.LowStockNotifiedAt,
+			&i.UnavailableUntil,
+			&i.PlanLocked,
+			&i.WasAvailableBeforeLock,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMenuItemsByRestaurant = `-- name: ListMenuItemsByRestaurant :many
+SELECT id, restaurant_id, category_id, name, description, price, currency, images, allergens, dietary_tags, spice_level, calories, is_available, display_order, view_count, created_by, created_at, updated_at, rating_avg, rating_count, track_stock, stock_quantity, low_stock_threshold, low_stock_notified_at, unavailable_until, plan_locked, was_available_before_lock FROM menu_items
+WHERE restaurant_id = $1 
+ORDER BY category_id, display_order ASC
+`
+
+func (q *Queries) ListMenuItemsByRestaurant(ctx context.Context, restaurantID uuid.UUID) ([]MenuItem, error) {
+	rows, err := q.db.Query(ctx, listMenuItemsByRestaurant, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []MenuItem
+	for rows.Next() {
+		var i MenuItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.RestaurantID,
+			&i.CategoryID,
+			&i.Name,
+			&i.Description,
+			&i.Price,
+			&i.Currency,
+			&i.Images,
+			&i.Allergens,
+			&i.DietaryTags,
+			&i.SpiceLevel,
+			&i.Calories,
+			&i.IsAvailable,
+			&i.DisplayOrder,
+			&i.ViewCount,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.RatingAvg,
+			&i.RatingCount,
+			&i.TrackStock,
+			&i.StockQuantity,
+			&i.LowStockThreshold,
+			&i.LowStockNotifiedAt,
+			&i.UnavailableUntil,
+			&i.PlanLocked,
+			&i.WasAvailableBeforeLock,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOpenServiceRequests = `-- name: ListOpenServiceRequests :many
+SELECT sr.id, sr.restaurant_id, sr.table_id, sr.request_type, sr.note, sr.status, sr.acknowledged_by, sr.acknowledged_at, sr.created_at, t.label as table_label
+FROM service_requests sr
+JOIN restaurant_tables t ON sr.table_id = t.id
+WHERE sr.restaurant_id = $1 AND sr.status = 'open'
+ORDER BY sr.created_at ASC
+`
+
+type ListOpenServiceRequestsRow struct {
+	ID             uuid.UUID            `db:"id" json:"id"`
+	RestaurantID   uuid.UUID            `db:"restaurant_id" json:"restaurant_id"`
+	TableID        uuid.UUID            `db:"table_id" json:"table_id"`
+	RequestType    ServiceRequestType   `db:"request_type" json:"request_type"`
+	Note           pgtype.Text          `db:"note" json:"note"`
+	Status         ServiceRequestStatus `db:"status" json:"status"`
+	AcknowledgedBy uuid.UUID            `db:"acknowledged_by" json:"acknowledged_by"`
+	AcknowledgedAt pgtype.Timestamp     `db:"acknowledged_at" json:"acknowledged_at"`
+	CreatedAt      pgtype.Timestamp     `db:"created_at" json:"created_at"`
+	TableLabel     string               `db:"table_label" json:"table_label"`
+}
+
+func (q *Queries) ListOpenServiceRequests(ctx context.Context, restaurantID uuid.UUID) ([]ListOpenServiceRequestsRow, error) {
+	rows, err := q.db.Query(ctx, listOpenServiceRequests, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListOpenServiceRequestsRow
+	for rows.Next() {
+		var i ListOpenServiceRequestsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.RestaurantID,
+			&i.TableID,
+			&i.RequestType,
+			&i.Note,
+			&i.Status,
+			&i.AcknowledgedBy,
+			&i.AcknowledgedAt,
+			&i.CreatedAt,
+			&i.TableLabel,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOpeningHoursByRestaurant = `-- name: ListOpeningHoursByRestaurant :many
+SELECT id, restaurant_id, day_of_week, opens_at, closes_at, created_at FROM restaurant_opening_hours
+WHERE restaurant_id = $1
+ORDER BY day_of_week ASC, opens_at ASC
+`
+
+func (q *Queries) ListOpeningHoursByRestaurant(ctx context.Context, restaurantID uuid.UUID) ([]RestaurantOpeningHour, error) {
+	rows, err := q.db.Query(ctx, listOpeningHoursByRestaurant, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RestaurantOpeningHour
+	for rows.Next() {
+		var i RestaurantOpeningHour
+		if err := rows.Scan(
+			&i.ID,
+			&i.RestaurantID,
+			&i.DayOfWeek,
+			&i.OpensAt,
+			&i.ClosesAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOwnersWithoutSubscription = `-- name: ListOwnersWithoutSubscription :many
+SELECT DISTINCT s.owner_id
+FROM subscriptions s
+JOIN users u ON s.owner_id = u.id
+WHERE s.status = 'cancelled' AND u.is_active = TRUE AND NOT EXISTS (
+    SELECT 1 FROM subscriptions o
+    WHERE o.owner_id = s.owner_id AND o.status IN ('active', 'trialing', 'past_due')
+)
+`
+
+func (q *Queries) ListOwnersWithoutSubscription(ctx context.Context) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, listOwnersWithoutSubscription)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var owner_id uuid.UUID
+		if err := rows.Scan(&owner_id); err != nil {
+			return nil, err
+		}
+		items = append(items, owner_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPromotionsByRestaurant = `-- name: ListPromotionsByRestaurant :many
+SELECT id, restaurant_id, name, label, scope, category_id, menu_item_id, discount_type, discount_value, starts_on, ends_on, days_of_week, start_time, end_time, is_active, created_by, created_at, updated_at FROM promotions
+WHERE restaurant_id = $1
+ORDER BY created_at DESC
+`
+
+func (q *Queries) ListPromotionsByRestaurant(ctx context.Context, restaurantID uuid.UUID) ([]Promotion, error) {
+	rows, err := q.db.Query(ctx, listPromotionsByRestaurant, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Promotion
+	for rows.Next() {
+		var i Promotion
+		if err := rows.Scan(
+			&i.ID,
+			&i.RestaurantID,
+			&i.Name,
+			&i.Label,
+			&i.Scope,
+			&i.CategoryID,
+			&i.MenuItemID,
+			&i.DiscountType,
+			&i.DiscountValue,
+			&i.StartsOn,
+			&i.EndsOn,
+			&i.DaysOfWeek,
+			&i.StartTime,
+			&i.EndTime,
+			&i.IsActive,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReportedReviews = `-- name: ListReportedReviews :many
+SELECT id, restaurant_id, menu_item_id, reviewer_name, rating, comment, photo_url, owner_reply, replied_by, replied_at, is_hidden, hidden_reason, report_count, ip_address, created_at, updated_at FROM reviews
+WHERE report_count > 0 OR is_hidden = TRUE
+ORDER BY report_count DESC, created_at DESC
+LIMIT $1 OFFSET $2
+`
+
+type ListReportedReviewsParams struct {
+	Limit  int32 `db:"limit" json:"limit"`
+	Offset int32 `db:"offset" json:"offset"`
+}
+
+func (q *Queries) ListReportedReviews(ctx context.Context, arg ListReportedReviewsParams) ([]Review, error) {
+	rows, err := q.db.Query(ctx, listReportedReviews, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Review
+	for rows.Next() {
+		var i Review
+		if err := rows.Scan(
+			&i.ID,
+			&i.RestaurantID,
+			&i.MenuItemID,
+			&i.ReviewerName,
+			&i.Rating,
+			&i.Comment,
+			&i.PhotoUrl,
+			&i.OwnerReply,
+			&i.RepliedBy,
+			&i.RepliedAt,
+			&i.IsHidden,
+			&i.HiddenReason,
+			&i.ReportCount,
+			&i.IpAddress,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReservationsForDay = `-- name: ListReservationsForDay :many
+SELECT r.id, r.restaurant_id, r.table_id, r.guest_name, r.guest_phone, r.guest_email, r.party_size, r.reserved_at, r.ends_at, r.notes, r.status, r.confirmation_token, r.cancellation_token, r.confirmed_at, r.seated_at, r.cancelled_at, r.created_at, r.updated_at, t.label as table_label
+FROM reservations r
+LEFT JOIN restaurant_tables t ON r.table_id = t.id
+WHERE r.restaurant_id = $1
+  AND r.reserved_at >= $2
+  AND r.reserved_at < $3
+ORDER BY r.reserved_at ASC
+`
+
+type ListReservationsForDayParams struct {
+	RestaurantID uuid.UUID        `db:"restaurant_id" json:"restaurant_id"`
+	DayStart     pgtype.Timestamp `db:"day_start" json:"day_start"`
+	DayEnd       pgtype.Timestamp `db:"day_end" json:"day_end"`
+}
+
+type ListReservationsForDayRow struct {
+	ID                uuid.UUID         `db:"id" json:"id"`
+	RestaurantID      uuid.UUID         `db:"restaurant_id" json:"restaurant_id"`
+	TableID           uuid.UUID         `db:"table_id" json:"table_id"`
+	GuestName         string            `db:"guest_name" json:"guest_name"`
+	GuestPhone        string            `db:"guest_phone" json:"guest_phone"`
+	GuestEmail        string            `db:"guest_email" json:"guest_email"`
+	PartySize         int32             `db:"party_size" json:"party_size"`
+	ReservedAt        pgtype.Timestamp  `db:"reserved_at" json:"reserved_at"`
+	EndsAt            pgtype.Timestamp  `db:"ends_at" json:"ends_at"`
+	Notes             pgtype.Text       `db:"notes" json:"notes"`
+	Status            ReservationStatus `db:"status" json:"status"`
+	ConfirmationToken string            `db:"confirmation_token" json:"confirmation_token"`
+	CancellationToken string            `db:"cancellation_token" json:"cancellation_token"`
+	ConfirmedAt       pgtype.Timestamp  `db:"confirmed_at" json:"confirmed_at"`
+	SeatedAt          pgtype.Timestamp  `db:"seated_at" json:"seated_at"`
+	CancelledAt       pgtype.Timestamp  `db:"cancelled_at" json:"cancelled_at"`
+	CreatedAt         pgtype.Timestamp  `db:"created_at" json:"created_at"`
+	UpdatedAt         pgtype.Timestamp  `db:"updated_at" json:"updated_at"`
+	TableLabel        pgtype.Text       `db:"table_label" json:"table_label"`
+}
+
+func (q *Queries) ListReservationsForDay(ctx context.Context, arg ListReservationsForDayParams) ([]ListReservationsForDayRow, error) {
+	rows, err := q.db.Query(ctx, listReservationsForDay, arg.RestaurantID, arg.DayStart, arg.DayEnd)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListReservationsForDayRow
+	for rows.Next() {
+		var i ListReservationsForDayRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.RestaurantID,
+			&i.TableID,
+			&i.GuestName,
+			&i.GuestPhone,
+			&i.GuestEmail,
+			&i.PartySize,
+			&i.ReservedAt,
+			&i.EndsAt,
+			&i.Notes,
+			&i.Status,
+			&i.ConfirmationToken,
+			&i.CancellationToken,
+			&i.ConfirmedAt,
+			&i.SeatedAt,
+			&i.CancelledAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.TableLabel,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRestaurantReviews = `-- name: ListRestaurantReviews :many
+SELECT id, restaurant_id, menu_item_id, reviewer_name, rating, comment, photo_url, owner_reply, replied_by, replied_at, is_hidden, hidden_reason, report_count, ip_address, created_at, updated_at FROM reviews
+WHERE restaurant_id = $1 AND menu_item_id IS NULL AND is_hidden = FALSE
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3
+`
+
+type ListRestaurantReviewsParams struct {
+	RestaurantID uuid.UUID `db:"restaurant_id" json:"restaurant_id"`
+	Limit        int32     `db:"limit" json:"limit"`
+	Offset       int32     `db:"offset" json:"offset"`
+}
+
+func (q *Queries) ListRestaurantReviews(ctx context.Context, arg ListRestaurantReviewsParams) ([]Review, error) {
+	rows, err := q.db.Query(ctx, listRestaurantReviews, arg.RestaurantID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Review
+	for rows.Next() {
+		var i Review
+		if err := rows.Scan(
+			&i.ID,
+			&i.RestaurantID,
+			&i.MenuItemID,
+			&i.ReviewerName,
+			&i.Rating,
+			&i.Comment,
+			&i.PhotoUrl,
+			&i.OwnerReply,
+			&i.RepliedBy,
+			&i.RepliedAt,
+			&i.IsHidden,
+			&i.HiddenReason,
+			&i.ReportCount,
+			&i.IpAddress,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRestaurantTables = `-- name: ListRestaurantTables :many
+SELECT id, restaurant_id, label, token, is_active, created_at, updated_at, seats FROM restaurant_tables
+WHERE restaurant_id = $1
+ORDER BY label ASC
+`
+
+func (q *Queries) ListRestaurantTables(ctx context.Context, restaurantID uuid.UUID) ([]RestaurantTable, error) {
+	rows, err := q.db.Query(ctx, listRestaurantTables, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RestaurantTable
+	for rows.Next() {
+		var i RestaurantTable
+		if err := rows.Scan(
+			&i.ID,
+			&i.RestaurantID,
+			&i.Label,
+			&i.Token,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Seats,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRestaurantsByOwner = `-- name: ListRestaurantsByOwner :many
+SELECT id, owner_id, name, slug, description, cuisine_type, phone, email, website, address, city, country, logo_url, cover_image_url, theme_settings, is_published, view_count, rank_score, created_at, updated_at, rating_avg, rating_count, plan_locked, timezone FROM restaurants
+WHERE owner_id = $1 
+ORDER BY created_at DESC
+`
+
+func (q *Queries) ListRestaurantsByOwner(ctx context.Context, ownerID uuid.UUID) ([]Restaurant, error) {
+	rows, err := q.db.Query(ctx, listRestaurantsByOwner, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Restaurant
+	for rows.Next() {
+		var i Restaurant
+		if err := rows.Scan(
+			&i.ID,
+			&i.OwnerID,
+			&i.Name,
+			&i.Slug,
+			&i.Description,
+			&i.CuisineType,
+			&i.Phone,
+			&i.Email,
+			&i.Website,
+			&i.Address,
+			&i.City,
+			&i.Country,
+			&i.LogoUrl,
+			&i.CoverImageUrl,
+			&i.ThemeSettings,
+			&i.IsPublished,
+			&i.ViewCount,
+			&i.RankScore,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.RatingAvg,
+			&i.RatingCount,
+			&i.PlanLocked,
+			&i.Timezone,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRestaurantsWithFilters = `-- name: ListRestaurantsWithFilters :many
+SELECT id, owner_id, name, slug, description, cuisine_type, phone, email, website, address, city, country, logo_url, cover_image_url, theme_settings, is_published, view_count, rank_score, created_at, updated_at, rating_avg, rating_count, plan_locked, timezone FROM restaurants
+WHERE 
+    ($3::uuid IS NULL OR owner_id = $3) AND
+    ($4::text IS NULL OR cuisine_type = $4) AND
+    ($5::text IS NULL OR city = $5) AND
+    ($6::text IS NULL OR country = $6) AND
+    ($7::boolean IS NULL OR is_published = $7) AND
+    ($8::text IS NULL OR name ILIKE '%' || $8 || '%')
+ORDER BY
+    -- Bayesian average: a handful of 5-star reviews should not outrank a long track record
+    CASE WHEN $9::text = 'rating' THEN (rating_avg * rating_count + 3.5 * 5) / (rating_count + 5) END DESC,
+    CASE WHEN $9::text = 'reviews' THEN rating_count END DESC,
+    CASE WHEN $9::text = 'popular' THEN view_count END DESC NULLS LAST,
+    created_at DESC
+LIMIT $1 OFFSET $2
+`
+
+type ListRestaurantsWithFiltersParams struct {
+	Limit       int32       `db:"limit" json:"limit"`
+	Offset      int32       `db:"offset" json:"offset"`
+	OwnerID     *uuid.UUID  `db:"owner_id" json:"owner_id"`
+	CuisineType pgtype.Text `db:"cuisine_type" json:"cuisine_type"`
+	City        pgtype.Text `db:"city" json:"city"`
+	Country     pgtype.Text `db:"country" json:"country"`
+	IsPublished pgtype.Bool `db:"is_published" json:"is_published"`
+	Search      pgtype.Text `db:"search" json:"search"`
+	SortBy      pgtype.Text `db:"sort_by" json:"sort_by"`
+}
+
+func (q *Queries) ListRestaurantsWithFilters(ctx context.Context, arg ListRestaurantsWithFiltersParams) ([]Restaurant, error) {
+	var ownerID any
+	if arg.OwnerID != nil && *arg.OwnerID != uuid.Nil {
+		ownerID = *arg.OwnerID
+	} else {
+		ownerID = nil
+	}
+	fmt.Println(ownerID)
+	fmt.Println(arg)
+	rows, err := q.db.Query(ctx, listRestaurantsWithFilters,
+		arg.Limit,
+		arg.Offset,
+		ownerID,
+		arg.CuisineType,
+		arg.City,
+		arg.Country,
+		arg.IsPublished,
+		arg.Search,
+		arg.SortBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Restaurant
+	for rows.Next() {
+		var i Restaurant
+		if err := rows.Scan(
+			&i.ID,
+			&i.OwnerID,
+			&i.Name,
+			&i.Slug,
+			&i.Description,
+			&i.CuisineType,
+			&i.Phone,
+			&i.Email,
+			&i.Website,
+			&i.Address,
+			&i.City,
+			&i.Country,
+			&i.LogoUrl,
+			&i.CoverImageUrl,
+			&i.ThemeSettings,
+			&i.IsPublished,
+			&i.ViewCount,
+			&i.RankScore,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.RatingAvg,
+			&i.RatingCount,
+			&i.PlanLocked,
+			&i.Timezone,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReviewsByRestaurant = `-- name: ListReviewsByRestaurant :many
+SELECT rv.id, rv.restaurant_id, rv.menu_item_id, rv.reviewer_name, rv.rating, rv.comment, rv.photo_url, rv.owner_reply, rv.replied_by, rv.replied_at, rv.is_hidden, rv.hidden_reason, rv.report_count, rv.ip_address, rv.created_at, rv.updated_at, mi.name as menu_item_name
+FROM reviews rv
+LEFT JOIN menu_items mi ON rv.menu_item_id = mi.id
+WHERE rv.restaurant_id = $1
+ORDER BY rv.created_at DESC
 LIMIT $2 OFFSET $3
 `
 
-type ListActivityLogsByRestaurantParams struct {
+type ListReviewsByRestaurantParams struct {
 	RestaurantID uuid.UUID `db:"restaurant_id" json:"restaurant_id"`
 	Limit        int32     `db:"limit" json:"limit"`
 	Offset       int32     `db:"offset" json:"offset"`
 }
 
-type ListActivityLogsByRestaurantRow struct {
-	ID             uuid.UUID        `db:"id" json:"id"`
-	RestaurantID   uuid.UUID        `db:"restaurant_id" json:"restaurant_id"`
-	UserID         uuid.UUID        `db:"user_id" json:"user_id"`
-	ActionType     string           `db:"action_type" json:"action_type"`
-	ActionCategory string           `db:"action_category" json:"action_category"`
-	Description    pgtype.Text      `db:"description" json:"description"`
-	TargetType     pgtype.Text      `db:"target_type" json:"target_type"`
-	TargetID       uuid.UUID        `db:"target_id" json:"target_id"`
-	TargetName     pgtype.Text      `db:"target_name" json:"target_name"`
-	BeforeValue    []byte           `db:"before_value" json:"before_value"`
-	AfterValue     []byte           `db:"after_value" json:"after_value"`
-	IpAddress      pgtype.Text      `db:"ip_address" json:"ip_address"`
-	UserAgent      pgtype.Text      `db:"user_agent" json:"user_agent"`
-	DeviceType     pgtype.Text      `db:"device_type" json:"device_type"`
-	Browser        pgtype.Text      `db:"browser" json:"browser"`
-	Os             pgtype.Text      `db:"os" json:"os"`
-	Success        pgtype.Bool      `db:"success" json:"success"`
-	CreatedAt      pgtype.Timestamp `db:"created_at" json:"created_at"`
-	UserName       string           `db:"user_name" json:"user_name"`
-	UserEmail      string           `db:"user_email" json:"user_email"`
+type ListReviewsByRestaurantRow struct {
+	ID           uuid.UUID        `db:"id" json:"id"`
+	RestaurantID uuid.UUID        `db:"restaurant_id" json:"restaurant_id"`
+	MenuItemID   uuid.UUID        `db:"menu_item_id" json:"menu_item_id"`
+	ReviewerName string           `db:"reviewer_name" json:"reviewer_name"`
+	Rating       int32            `db:"rating" json:"rating"`
+	Comment      pgtype.Text      `db:"comment" json:"comment"`
+	PhotoUrl     pgtype.Text      `db:"photo_url" json:"photo_url"`
+	OwnerReply   pgtype.Text      `db:"owner_reply" json:"owner_reply"`
+	RepliedBy    uuid.UUID        `db:"replied_by" json:"replied_by"`
+	RepliedAt    pgtype.Timestamp `db:"replied_at" json:"replied_at"`
+	IsHidden     bool             `db:"is_hidden" json:"is_hidden"`
+	HiddenReason pgtype.Text      `db:"hidden_reason" json:"hidden_reason"`
+	ReportCount  int32            `db:"report_count" json:"report_count"`
+	IpAddress    pgtype.Text      `db:"ip_address" json:"ip_address"`
+	CreatedAt    pgtype.Timestamp `db:"created_at" json:"created_at"`
+	UpdatedAt    pgtype.Timestamp `db:"updated_at" json:"updated_at"`
+	MenuItemName pgtype.Text      `db:"menu_item_name" json:"menu_item_name"`
 }
 
-func (q *Queries) ListActivityLogsByRestaurant(ctx context.Context, arg ListActivityLogsByRestaurantParams) ([]ListActivityLogsByRestaurantRow, error) {
-	rows, err := q.db.Query(ctx, listActivityLogsByRestaurant, arg.RestaurantID, arg.Limit, arg.Offset)
+func (q *Queries) ListReviewsByRestaurant(ctx context.Context, arg ListReviewsByRestaurantParams) ([]ListReviewsByRestaurantRow, error) {
+	rows, err := q.db.Query(ctx, listReviewsByRestaurant, arg.RestaurantID, arg.Limit, arg.Offset)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ListActivityLogsByRestaurantRow
+	var items []ListReviewsByRestaurantRow
 	for rows.Next() {
-		var i ListActivityLogsByRestaurantRow
+		var i ListReviewsByRestaurantRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.RestaurantID,
-			&i.UserID,
-			&i.ActionType,
-			&i.ActionCategory,
-			&i.Description,
-			&i.TargetType,
-			&i.TargetID,
-			&i.TargetName,
-			&i.BeforeValue,
-			&i.AfterValue,
+			&i.MenuItemID,
+			&i.ReviewerName,
+			&i.Rating,
+			&i.Comment,
+			&i.PhotoUrl,
+			&i.OwnerReply,
+			&i.RepliedBy,
+			&i.RepliedAt,
+			&i.IsHidden,
+			&i.HiddenReason,
+			&i.ReportCount,
 			&i.IpAddress,
-			&i.UserAgent,
-			&i.DeviceType,
-			&i.Browser,
-			&i.Os,
-			&i.Success,
 			&i.CreatedAt,
-			&i.UserName,
-			&i.UserEmail,
+			&i.UpdatedAt,
+			&i.MenuItemName,
 		); err != nil {
 			return nil, err
 		}
@@ -1510,100 +5458,98 @@ func (q *Queries) ListActivityLogsByRestaurant(ctx context.Context, arg ListActi
 	return items, nil
 }
 
-const listActivityLogsWithFilters = `-- name: ListActivityLogsWithFilters :many
-SELECT al.id, al.restaurant_id, al.user_id, al.action_type, al.action_category, al.description, al.target_type, al.target_id, al.target_name, al.before_value, al.after_value, al.ip_address, al.user_agent, al.device_type, al.browser, al.os, al.success, al.created_at, u.full_name as user_name, u.email as user_email
-FROM activity_logs al
-JOIN users u ON al.user_id = u.id
-WHERE 
-    ($3::uuid IS NULL OR al.restaurant_id = $3) AND
-    ($4::uuid IS NULL OR al.user_id = $4) AND
-    ($5::text IS NULL OR al.action_type = $5) AND
-    ($6::text IS NULL OR al.action_category = $6) AND
-    ($7::text IS NULL OR al.target_type = $7) AND
-    ($8::uuid IS NULL OR al.target_id = $8) AND
-    ($9::boolean IS NULL OR al.success = $9) AND
-    ($10::text IS NULL OR al.description ILIKE '%' || $10 || '%' OR al.target_name ILIKE '%' || $10 || '%')
-ORDER BY al.created_at DESC
-LIMIT $1 OFFSET $2
+const listStaffByOwner = `-- name: ListStaffByOwner :many
+SELECT id, email, password_hash, full_name, role, owner_id, restaurant_id, phone, avatar_url, email_verified, last_login_at, is_active, created_at, updated_at, email_verified_at, verification_token, verification_token_expires_at, trial_ends_at, must_change_password, password_changed_at, staff_role_id FROM users
+WHERE owner_id = $1 AND role = 'staff' 
+ORDER BY created_at DESC
 `
 
-type ListActivityLogsWithFiltersParams struct {
-	Limit          int32       `db:"limit" json:"limit"`
-	Offset         int32       `db:"offset" json:"offset"`
-	RestaurantID   uuid.UUID   `db:"restaurant_id" json:"restaurant_id"`
-	UserID         uuid.UUID   `db:"user_id" json:"user_id"`
-	ActionType     pgtype.Text `db:"action_type" json:"action_type"`
-	ActionCategory pgtype.Text `db:"action_category" json:"action_category"`
-	TargetType     pgtype.Text `db:"target_type" json:"target_type"`
-	TargetID       uuid.UUID   `db:"target_id" json:"target_id"`
-	Success        pgtype.Bool `db:"success" json:"success"`
-	Search         pgtype.Text `db:"search" json:"search"`
+func (q *Queries) ListStaffByOwner(ctx context.Context, ownerID uuid.UUID) ([]User, error) {
+	rows, err := q.db.Query(ctx, listStaffByOwner, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Email,
+			&i.PasswordHash,
+			&i.FullName,
+			&i.Role,
+			&i.OwnerID,
+			&i.RestaurantID,
+			&i.Phone,
+			&i.AvatarUrl,
+			&i.EmailVerified,
+			&i.LastLoginAt,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.EmailVerifiedAt,
+			&i.VerificationToken,
+			&i.VerificationTokenExpiresAt,
+			&i.TrialEndsAt,
+			&i.MustChangePassword,
+			&i.PasswordChangedAt,
+			&i.StaffRoleID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-type ListActivityLogsWithFiltersRow struct {
-	ID             uuid.UUID        `db:"id" json:"id"`
-	RestaurantID   uuid.UUID        `db:"restaurant_id" json:"restaurant_id"`
-	UserID         uuid.UUID        `db:"user_id" json:"user_id"`
-	ActionType     string           `db:"action_type" json:"action_type"`
-	ActionCategory string           `db:"action_category" json:"action_category"`
-	Description    pgtype.Text      `db:"description" json:"description"`
-	TargetType     pgtype.Text      `db:"target_type" json:"target_type"`
-	TargetID       uuid.UUID        `db:"target_id" json:"target_id"`
-	TargetName     pgtype.Text      `db:"target_name" json:"target_name"`
-	BeforeValue    []byte           `db:"before_value" json:"before_value"`
-	AfterValue     []byte           `db:"after_value" json:"after_value"`
-	IpAddress      pgtype.Text      `db:"ip_address" json:"ip_address"`
-	UserAgent      pgtype.Text      `db:"user_agent" json:"user_agent"`
-	DeviceType     pgtype.Text      `db:"device_type" json:"device_type"`
-	Browser        pgtype.Text      `db:"browser" json:"browser"`
-	Os             pgtype.Text      `db:"os" json:"os"`
-	Success        pgtype.Bool      `db:"success" json:"success"`
-	CreatedAt      pgtype.Timestamp `db:"created_at" json:"created_at"`
-	UserName       string           `db:"user_name" json:"user_name"`
-	UserEmail      string           `db:"user_email" json:"user_email"`
+const listStaffByRestaurant = `-- name: ListStaffByRestaurant :many
+SELECT id, email, password_hash, full_name, role, owner_id, restaurant_id, phone, avatar_url, email_verified, last_login_at, is_active, created_at, updated_at, email_verified_at, verification_token, verification_token_expires_at, trial_ends_at, must_change_password, password_changed_at, staff_role_id FROM users
+WHERE restaurant_id = $1 AND role = 'staff' 
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3
+`
+
+type ListStaffByRestaurantParams struct {
+	RestaurantID uuid.UUID `db:"restaurant_id" json:"restaurant_id"`
+	Limit        int32     `db:"limit" json:"limit"`
+	Offset       int32     `db:"offset" json:"offset"`
 }
 
-func (q *Queries) ListActivityLogsWithFilters(ctx context.Context, arg ListActivityLogsWithFiltersParams) ([]ListActivityLogsWithFiltersRow, error) {
-	rows, err := q.db.Query(ctx, listActivityLogsWithFilters,
-		arg.Limit,
-		arg.Offset,
-		arg.RestaurantID,
-		arg.UserID,
-		arg.ActionType,
-		arg.ActionCategory,
-		arg.TargetType,
-		arg.TargetID,
-		arg.Success,
-		arg.Search,
-	)
+func (q *Queries) ListStaffByRestaurant(ctx context.Context, arg ListStaffByRestaurantParams) ([]User, error) {
+	rows, err := q.db.Query(ctx, listStaffByRestaurant, arg.RestaurantID, arg.Limit, arg.Offset)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ListActivityLogsWithFiltersRow
+	var items []User
 	for rows.Next() {
-		var i ListActivityLogsWithFiltersRow
+		var i User
 		if err := rows.Scan(
 			&i.ID,
+			&i.Email,
+			&i.PasswordHash,
+			&i.FullName,
+			&i.Role,
+			&i.OwnerID,
 			&i.RestaurantID,
-			&i.UserID,
-			&i.ActionType,
-			&i.ActionCategory,
-			&i.Description,
-			&i.TargetType,
-			&i.TargetID,
-			&i.TargetName,
-			&i.BeforeValue,
-			&i.AfterValue,
-			&i.IpAddress,
-			&i.UserAgent,
-			&i.DeviceType,
-			&i.Browser,
-			&i.Os,
-			&i.Success,
+			&i.Phone,
+			&i.AvatarUrl,
+			&i.EmailVerified,
+			&i.LastLoginAt,
+			&i.IsActive,
 			&i.CreatedAt,
-			&i.UserName,
-			&i.UserEmail,
+			&i.UpdatedAt,
+			&i.EmailVerifiedAt,
+			&i.VerificationToken,
+			&i.VerificationTokenExpiresAt,
+			&i.TrialEndsAt,
+			&i.MustChangePassword,
+			&i.PasswordChangedAt,
+			&i.StaffRoleID,
 		); err != nil {
 			return nil, err
 		}
@@ -1615,65 +5561,93 @@ func (q *Queries) ListActivityLogsWithFilters(ctx context.Context, arg ListActiv
 	return items, nil
 }
 
-const listAnalyticsEventsWithFilters = `-- name: ListAnalyticsEventsWithFilters :many
-SELECT id, restaurant_id, event_type, visitor_id, session_id, target_id, ip_address, device_type, browser, os, country, city, created_at FROM analytics_events
-WHERE 
-    ($3::uuid IS NULL OR restaurant_id = $3) AND
-    ($4::text IS NULL OR event_type = $4) AND
-    ($5::text IS NULL OR visitor_id = $5) AND
-    ($6::uuid IS NULL OR session_id = $6) AND
-    ($7::uuid IS NULL OR target_id = $7) AND
-    ($8::text IS NULL OR country = $8) AND
-    ($9::text IS NULL OR city = $9)
+const listStaffInvitationsByRestaurant = `-- name: ListStaffInvitationsByRestaurant :many
+SELECT id, restaurant_id, owner_id, email, full_name, phone, token_hash, status, expires_at, accepted_user_id, accepted_at, created_at, updated_at, staff_role_id FROM staff_invitations
+WHERE restaurant_id = $1
 ORDER BY created_at DESC
-LIMIT $1 OFFSET $2
+LIMIT $2 OFFSET $3
 `
 
-type ListAnalyticsEventsWithFiltersParams struct {
-	Limit        int32       `db:"limit" json:"limit"`
-	Offset       int32       `db:"offset" json:"offset"`
-	RestaurantID uuid.UUID   `db:"restaurant_id" json:"restaurant_id"`
-	EventType    pgtype.Text `db:"event_type" json:"event_type"`
-	VisitorID    pgtype.Text `db:"visitor_id" json:"visitor_id"`
-	SessionID    uuid.UUID   `db:"session_id" json:"session_id"`
-	TargetID     uuid.UUID   `db:"target_id" json:"target_id"`
-	Country      pgtype.Text `db:"country" json:"country"`
-	City         pgtype.Text `db:"city" json:"city"`
+type ListStaffInvitationsByRestaurantParams struct {
+	RestaurantID uuid.UUID `db:"restaurant_id" json:"restaurant_id"`
+	Limit        int32     `db:"limit" json:"limit"`
+	Offset       int32     `db:"offset" json:"offset"`
+}
+
+func (q *Queries) ListStaffInvitationsByRestaurant(ctx context.Context, arg ListStaffInvitationsByRestaurantParams) ([]StaffInvitation, error) {
+	rows, err := q.db.Query(ctx, listStaffInvitationsByRestaurant, arg.RestaurantID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []StaffInvitation
+	for rows.Next() {
+		var i StaffInvitation
+		if err := rows.Scan(
+			&i.ID,
+			&i.RestaurantID,
+			&i.OwnerID,
+			&i.Email,
+			&i.FullName,
+			&i.Phone,
+			&i.TokenHash,
+			&i.Status,
+			&i.ExpiresAt,
+			&i.AcceptedUserID,
+			&i.AcceptedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.StaffRoleID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStaffRolesByRestaurant = `-- name: ListStaffRolesByRestaurant :many
+SELECT r.id, r.restaurant_id, r.name, r.description, r.permissions, r.preset, r.created_at, r.updated_at,
+    (SELECT COUNT(*) FROM users u WHERE u.staff_role_id = r.id AND u.deleted_at IS NULL) AS staff_count
+FROM staff_roles r
+WHERE r.restaurant_id = $1
+ORDER BY r.preset IS NULL, r.name
+`
+
+type ListStaffRolesByRestaurantRow struct {
+	ID           uuid.UUID        `db:"id" json:"id"`
+	RestaurantID uuid.UUID        `db:"restaurant_id" json:"restaurant_id"`
+	Name         string           `db:"name" json:"name"`
+	Description  pgtype.Text      `db:"description" json:"description"`
+	Permissions  []string         `db:"permissions" json:"permissions"`
+	Preset       pgtype.Text      `db:"preset" json:"preset"`
+	CreatedAt    pgtype.Timestamp `db:"created_at" json:"created_at"`
+	UpdatedAt    pgtype.Timestamp `db:"updated_at" json:"updated_at"`
+	StaffCount   int64            `db:"staff_count" json:"staff_count"`
 }
 
-func (q *Queries) ListAnalyticsEventsWithFilters(ctx context.Context, arg ListAnalyticsEventsWithFiltersParams) ([]AnalyticsEvent, error) {
-	rows, err := q.db.Query(ctx, listAnalyticsEventsWithFilters,
-		arg.Limit,
-		arg.Offset,
-		arg.RestaurantID,
-		arg.EventType,
-		arg.VisitorID,
-		arg.SessionID,
-		arg.TargetID,
-		arg.Country,
-		arg.City,
-	)
+func (q *Queries) ListStaffRolesByRestaurant(ctx context.Context, restaurantID uuid.UUID) ([]ListStaffRolesByRestaurantRow, error) {
+	rows, err := q.db.Query(ctx, listStaffRolesByRestaurant, restaurantID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []AnalyticsEvent
+	var items []ListStaffRolesByRestaurantRow
 	for rows.Next() {
-		var i AnalyticsEvent
+		var i ListStaffRolesByRestaurantRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.RestaurantID,
-			&i.EventType,
-			&i.VisitorID,
-			&i.SessionID,
-			&i.TargetID,
-			&i.IpAddress,
-			&i.DeviceType,
-			&i.Browser,
-			&i.Os,
-			&i.Country,
-			&i.City,
+			&i.Name,
+			&i.Description,
+			&i.Permissions,
+			&i.Preset,
 			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.StaffCount,
 		); err != nil {
 			return nil, err
 		}
@@ -1685,30 +5659,32 @@ func (q *Queries) ListAnalyticsEventsWithFilters(ctx context.Context, arg ListAn
 	return items, nil
 }
 
-const listCategoriesByRestaurant = `-- name: ListCategoriesByRestaurant :many
-SELECT id, restaurant_id, name, description, icon, display_order, is_active, created_by, created_at, updated_at FROM categories
-WHERE restaurant_id = $1
+const listSubscriptionPlans = `-- name: ListSubscriptionPlans :many
+SELECT id, name, slug, description, price_monthly, price_annual, currency, features, display_order, is_active, created_at, updated_at FROM subscription_plans
+WHERE is_active = TRUE
 ORDER BY display_order ASC
 `
 
-func (q *Queries) ListCategoriesByRestaurant(ctx context.Context, restaurantID uuid.UUID) ([]Category, error) {
-	rows, err := q.db.Query(ctx, listCategoriesByRestaurant, restaurantID)
+func (q *Queries) ListSubscriptionPlans(ctx context.Context) ([]SubscriptionPlan, error) {
+	rows, err := q.db.Query(ctx, listSubscriptionPlans)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Category
+	var items []SubscriptionPlan
 	for rows.Next() {
-		var i Category
+		var i SubscriptionPlan
 		if err := rows.Scan(
 			&i.ID,
-			&i.RestaurantID,
 			&i.Name,
+			&i.Slug,
 			&i.Description,
-			&i.Icon,
+			&i.PriceMonthly,
+			&i.PriceAnnual,
+			&i.Currency,
+			&i.Features,
 			&i.DisplayOrder,
 			&i.IsActive,
-			&i.CreatedBy,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -1722,35 +5698,49 @@ func (q *Queries) ListCategoriesByRestaurant(ctx context.Context, restaurantID u
 	return items, nil
 }
 
-const listInvoicesByOwner = `-- name: ListInvoicesByOwner :many
-SELECT id, subscription_id, owner_id, invoice_number, amount, currency, status, billing_period_start, billing_period_end, payment_provider_invoice_id, paid_at, created_at, updated_at FROM invoices
-WHERE owner_id = $1
-ORDER BY created_at DESC
+const listSubscriptionsEndingBetween = `-- name: ListSubscriptionsEndingBetween :many
+SELECT s.id, s.owner_id, s.current_period_end, sp.name AS plan_name, sp.price_monthly, u.email, u.full_name
+FROM subscriptions s
+JOIN subscription_plans sp ON s.plan_id = sp.id
+JOIN users u ON s.owner_id = u.id
+WHERE s.status = $1 AND s.current_period_end > $2 AND s.current_period_end <= $3
+  AND s.cancel_at_period_end = FALSE AND u.is_active = TRUE
+ORDER BY s.current_period_end
 `
 
-func (q *Queries) ListInvoicesByOwner(ctx context.Context, ownerID uuid.UUID) ([]Invoice, error) {
-	rows, err := q.db.Query(ctx, listInvoicesByOwner, ownerID)
+type ListSubscriptionsEndingBetweenParams struct {
+	Status     SubscriptionStatus `db:"status" json:"status"`
+	EndsAfter  pgtype.Timestamp   `db:"ends_after" json:"ends_after"`
+	EndsBefore pgtype.Timestamp   `db:"ends_before" json:"ends_before"`
+}
+
+type ListSubscriptionsEndingBetweenRow struct {
+	ID               uuid.UUID        `db:"id" json:"id"`
+	OwnerID          uuid.UUID        `db:"owner_id" json:"owner_id"`
+	CurrentPeriodEnd pgtype.Timestamp `db:"current_period_end" json:"current_period_end"`
+	PlanName         string           `db:"plan_name" json:"plan_name"`
+	PriceMonthly     int32            `db:"price_monthly" json:"price_monthly"`
+	Email            string           `db:"email" json:"email"`
+	FullName         string           `db:"full_name" json:"full_name"`
+}
+
+func (q *Queries) ListSubscriptionsEndingBetween(ctx context.Context, arg ListSubscriptionsEndingBetweenParams) ([]ListSubscriptionsEndingBetweenRow, error) {
+	rows, err := q.db.Query(ctx, listSubscriptionsEndingBetween, arg.Status, arg.EndsAfter, arg.EndsBefore)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Invoice
+	var items []ListSubscriptionsEndingBetweenRow
 	for rows.Next() {
-		var i Invoice
+		var i ListSubscriptionsEndingBetweenRow
 		if err := rows.Scan(
 			&i.ID,
-			&i.SubscriptionID,
 			&i.OwnerID,
-			&i.InvoiceNumber,
-			&i.Amount,
-			&i.Currency,
-			&i.Status,
-			&i.BillingPeriodStart,
-			&i.BillingPeriodEnd,
-			&i.PaymentProviderInvoiceID,
-			&i.PaidAt,
-			&i.CreatedAt,
-			&i.UpdatedAt,
+			&i.CurrentPeriodEnd,
+			&i.PlanName,
+			&i.PriceMonthly,
+			&i.Email,
+			&i.FullName,
 		); err != nil {
 			return nil, err
 		}
@@ -1762,53 +5752,27 @@ func (q *Queries) ListInvoicesByOwner(ctx context.Context, ownerID uuid.UUID) ([
 	return items, nil
 }
 
-const listInvoicesWithFilters = `-- name: ListInvoicesWithFilters :many
-SELECT id, subscription_id, owner_id, invoice_number, amount, currency, status, billing_period_start, billing_period_end, payment_provider_invoice_id, paid_at, created_at, updated_at FROM invoices
-WHERE 
-    ($3::uuid IS NULL OR owner_id = $3) AND
-    ($4::uuid IS NULL OR subscription_id = $4) AND
-    ($5::invoice_status IS NULL OR status = $5)
-ORDER BY created_at DESC
-LIMIT $1 OFFSET $2
+const listUnusedRecoveryCodes = `-- name: ListUnusedRecoveryCodes :many
+SELECT id, user_id, code_hash, used_at, created_at FROM user_recovery_codes
+WHERE user_id = $1 AND used_at IS NULL
+ORDER BY created_at
 `
 
-type ListInvoicesWithFiltersParams struct {
-	Limit          int32             `db:"limit" json:"limit"`
-	Offset         int32             `db:"offset" json:"offset"`
-	OwnerID        uuid.UUID         `db:"owner_id" json:"owner_id"`
-	SubscriptionID uuid.UUID         `db:"subscription_id" json:"subscription_id"`
-	Status         NullInvoiceStatus `db:"status" json:"status"`
-}
-
-func (q *Queries) ListInvoicesWithFilters(ctx context.Context, arg ListInvoicesWithFiltersParams) ([]Invoice, error) {
-	rows, err := q.db.Query(ctx, listInvoicesWithFilters,
-		arg.Limit,
-		arg.Offset,
-		arg.OwnerID,
-		arg.SubscriptionID,
-		arg.Status,
-	)
+func (q *Queries) ListUnusedRecoveryCodes(ctx context.Context, userID uuid.UUID) ([]UserRecoveryCode, error) {
+	rows, err := q.db.Query(ctx, listUnusedRecoveryCodes, userID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Invoice
+	var items []UserRecoveryCode
 	for rows.Next() {
-		var i Invoice
+		var i UserRecoveryCode
 		if err := rows.Scan(
 			&i.ID,
-			&i.SubscriptionID,
-			&i.OwnerID,
-			&i.InvoiceNumber,
-			&i.Amount,
-			&i.Currency,
-			&i.Status,
-			&i.BillingPeriodStart,
-			&i.BillingPeriodEnd,
-			&i.PaymentProviderInvoiceID,
-			&i.PaidAt,
+			&i.UserID,
+			&i.CodeHash,
+			&i.UsedAt,
 			&i.CreatedAt,
-			&i.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -1820,40 +5784,48 @@ func (q *Queries) ListInvoicesWithFilters(ctx context.Context, arg ListInvoicesW
 	return items, nil
 }
 
-const listMenuItemsByCategory = `-- name: ListMenuItemsByCategory :many
-SELECT id, restaurant_id, category_id, name, description, price, currency, images, allergens, dietary_tags, spice_level, calories, is_available, display_order, view_count, created_by, created_at, updated_at FROM menu_items
-WHERE category_id = $1 
-ORDER BY display_order ASC
+const listUsers = `-- name: ListUsers :many
+SELECT id, email, password_hash, full_name, role, owner_id, restaurant_id, phone, avatar_url, email_verified, last_login_at, is_active, created_at, updated_at, email_verified_at, verification_token, verification_token_expires_at, trial_ends_at, must_change_password, password_changed_at, staff_role_id FROM users
+ORDER BY created_at DESC
+LIMIT $1 OFFSET $2
 `
 
-func (q *Queries) ListMenuItemsByCategory(ctx context.Context, categoryID uuid.UUID) ([]MenuItem, error) {
-	rows, err := q.db.Query(ctx, listMenuItemsByCategory, categoryID)
+type ListUsersParams struct {
+	Limit  int32 `db:"limit" json:"limit"`
+	Offset int32 `db:"offset" json:"offset"`
+}
+
+func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error) {
+	rows, err := q.db.Query(ctx, listUsers, arg.Limit, arg.Offset)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []MenuItem
+	var items []User
 	for rows.Next() {
-		var i MenuItem
+		var i User
 		if err := rows.Scan(
 			&i.ID,
+			&i.Email,
+			&i.PasswordHash,
+			&i.FullName,
+			&i.Role,
+			&i.OwnerID,
 			&i.RestaurantID,
-			&i.CategoryID,
-			&i.Name,
-			&i.Description,
-			&i.Price,
-			&i.Currency,
-			&i.Images,
-			&i.Allergens,
-			&i.DietaryTags,
-			&i.SpiceLevel,
-			&i.Calories,
-			&i.IsAvailable,
-			&i.DisplayOrder,
-			&i.ViewCount,
-			&i.CreatedBy,
+			&i.Phone,
+			&i.AvatarUrl,
+			&i.EmailVerified,
+			&i.LastLoginAt,
+			&i.IsActive,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.EmailVerifiedAt,
+			&i.VerificationToken,
+			&i.VerificationTokenExpiresAt,
+			&i.TrialEndsAt,
+			&i.MustChangePassword,
+			&i.PasswordChangedAt,
+			&i.StaffRoleID,
 		); err != nil {
 			return nil, err
 		}
@@ -1865,40 +5837,64 @@ func (q *Queries) ListMenuItemsByCategory(ctx context.Context, categoryID uuid.U
 	return items, nil
 }
 
-const listMenuItemsByRestaurant = `-- name: ListMenuItemsByRestaurant :many
-SELECT id, restaurant_id, category_id, name, description, price, currency, images, allergens, dietary_tags, spice_level, calories, is_available, display_order, view_count, created_by, created_at, updated_at FROM menu_items
-WHERE restaurant_id = $1 
-ORDER BY category_id, display_order ASC
+const listUsersWithFilters = `-- name: ListUsersWithFilters :many
+SELECT id, email, password_hash, full_name, role, owner_id, restaurant_id, phone, avatar_url, email_verified, last_login_at, is_active, created_at, updated_at, email_verified_at, verification_token, verification_token_expires_at, trial_ends_at, must_change_password, password_changed_at, staff_role_id FROM users
+WHERE 
+    ($3::text IS NULL OR email = $3) AND
+    ($4::user_role IS NULL OR role = $4) AND
+    ($5::boolean IS NULL OR is_active = $5) AND
+    ($6::text IS NULL OR full_name ILIKE '%' || $6 || '%' OR email ILIKE '%' || $6 || '%') 
+ORDER BY created_at DESC
+LIMIT $1 OFFSET $2
 `
 
-func (q *Queries) ListMenuItemsByRestaurant(ctx context.Context, restaurantID uuid.UUID) ([]MenuItem, error) {
-	rows, err := q.db.Query(ctx, listMenuItemsByRestaurant, restaurantID)
+type ListUsersWithFiltersParams struct {
+	Limit    int32        `db:"limit" json:"limit"`
+	Offset   int32        `db:"offset" json:"offset"`
+	Email    pgtype.Text  `db:"email" json:"email"`
+	Role     NullUserRole `db:"role" json:"role"`
+	IsActive pgtype.Bool  `db:"is_active" json:"is_active"`
+	Search   pgtype.Text  `db:"search" json:"search"`
+}
+
+func (q *Queries) ListUsersWithFilters(ctx context.Context, arg ListUsersWithFiltersParams) ([]User, error) {
+	rows, err := q.db.Query(ctx, listUsersWithFilters,
+		arg.Limit,
+		arg.Offset,
+		arg.Email,
+		arg.Role,
+		arg.IsActive,
+		arg.Search,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []MenuItem
+	var items []User
 	for rows.Next() {
-		var i MenuItem
+		var i User
 		if err := rows.Scan(
 			&i.ID,
+			&i.Email,
+			&i.PasswordHash,
+			&i.FullName,
+			&i.Role,
+			&i.OwnerID,
 			&i.RestaurantID,
-			&i.CategoryID,
-			&i.Name,
-			&i.Description,
-			&i.Price,
-			&i.Currency,
-			&i.Images,
-			&i.Allergens,
-			&i.DietaryTags,
-			&i.SpiceLevel,
-			&i.Calories,
-			&i.IsAvailable,
-			&i.DisplayOrder,
-			&i.ViewCount,
-			&i.CreatedBy,
+			&i.Phone,
+			&i.AvatarUrl,
+			&i.EmailVerified,
+			&i.LastLoginAt,
+			&i.IsActive,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.EmailVerifiedAt,
+			&i.VerificationToken,
+			&i.VerificationTokenExpiresAt,
+			&i.TrialEndsAt,
+			&i.MustChangePassword,
+			&i.PasswordChangedAt,
+			&i.StaffRoleID,
 		); err != nil {
 			return nil, err
 		}
@@ -1910,42 +5906,117 @@ func (q *Queries) ListMenuItemsByRestaurant(ctx context.Context, restaurantID uu
 	return items, nil
 }
 
-const listRestaurantsByOwner = `-- name: ListRestaurantsByOwner :many
-SELECT id, owner_id, name, slug, description, cuisine_type, phone, email, website, address, city, country, logo_url, cover_image_url, theme_settings, is_published, view_count, rank_score, created_at, updated_at FROM restaurants
-WHERE owner_id = $1 
-ORDER BY created_at DESC
+const lockCategoriesOverLimit = `-- name: LockCategoriesOverLimit :execrows
+UPDATE categories
+SET is_active = FALSE, plan_locked = TRUE, updated_at = NOW()
+WHERE id IN (
+    SELECT ranked.id FROM (
+        SELECT c.id, ROW_NUMBER() OVER (PARTITION BY c.restaurant_id ORDER BY c.display_order, c.created_at) AS position
+        FROM categories c
+        JOIN restaurants r ON c.restaurant_id = r.id
+        WHERE r.owner_id = $1 AND c.is_active = TRUE
+    ) ranked
+    WHERE ranked.position > $2::int
+)
+`
+
+type LockCategoriesOverLimitParams struct {
+	OwnerID uuid.UUID `db:"owner_id" json:"owner_id"`
+	Keep    int32     `db:"keep" json:"keep"`
+}
+
+func (q *Queries) LockCategoriesOverLimit(ctx context.Context, arg LockCategoriesOverLimitParams) (int64, error) {
+	result, err := q.db.Exec(ctx, lockCategoriesOverLimit, arg.OwnerID, arg.Keep)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const lockMenuItemsOverLimit = `-- name: LockMenuItemsOverLimit :execrows
+UPDATE menu_items
+SET was_available_before_lock = is_available, is_available = FALSE, plan_locked = TRUE, updated_at = NOW()
+WHERE id IN (
+    SELECT ranked.id FROM (
+        SELECT m.id, ROW_NUMBER() OVER (PARTITION BY m.restaurant_id ORDER BY m.display_order, m.created_at) AS position
+        FROM menu_items m
+        JOIN restaurants r ON m.restaurant_id = r.id
+        WHERE r.owner_id = $1 AND m.deleted_at IS NULL
+    ) ranked
+    WHERE ranked.position > $2::int
+) AND NOT plan_locked
+`
+
+type LockMenuItemsOverLimitParams struct {
+	OwnerID uuid.UUID `db:"owner_id" json:"owner_id"`
+	Keep    int32     `db:"keep" json:"keep"`
+}
+
+func (q *Queries) LockMenuItemsOverLimit(ctx context.Context, arg LockMenuItemsOverLimitParams) (int64, error) {
+	result, err := q.db.Exec(ctx, lockMenuItemsOverLimit, arg.OwnerID, arg.Keep)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const lockRestaurantsOverLimit = `-- name: LockRestaurantsOverLimit :execrows
+UPDATE restaurants
+SET is_published = FALSE, plan_locked = TRUE, updated_at = NOW()
+WHERE id IN (
+    SELECT id FROM restaurants
+    WHERE owner_id = $1 AND is_published = TRUE
+    ORDER BY created_at ASC
+    OFFSET $2
+)
+`
+
+type LockRestaurantsOverLimitParams struct {
+	OwnerID uuid.UUID `db:"owner_id" json:"owner_id"`
+	Keep    int32     `db:"keep" json:"keep"`
+}
+
+func (q *Queries) LockRestaurantsOverLimit(ctx context.Context, arg LockRestaurantsOverLimitParams) (int64, error) {
+	result, err := q.db.Exec(ctx, lockRestaurantsOverLimit, arg.OwnerID, arg.Keep)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const markExpiredSubscriptionsPastDue = `-- name: MarkExpiredSubscriptionsPastDue :many
+UPDATE subscriptions
+SET status = 'past_due', updated_at = NOW()
+WHERE status = 'active' AND current_period_end <= NOW() AND cancel_at_period_end = FALSE
+  AND plan_id IN (SELECT id FROM subscription_plans WHERE price_monthly > 0)
+RETURNING id, owner_id, plan_id, status, current_period_start, current_period_end, trial_end, cancelled_at, payment_provider_subscription_id, created_at, updated_at, billing_interval, scheduled_plan_id, scheduled_billing_interval, cancel_at_period_end
 `
 
-func (q *Queries) ListRestaurantsByOwner(ctx context.Context, ownerID uuid.UUID) ([]Restaurant, error) {
-	rows, err := q.db.Query(ctx, listRestaurantsByOwner, ownerID)
+func (q *Queries) MarkExpiredSubscriptionsPastDue(ctx context.Context) ([]Subscription, error) {
+	rows, err := q.db.Query(ctx, markExpiredSubscriptionsPastDue)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Restaurant
+	var items []Subscription
 	for rows.Next() {
-		var i Restaurant
+		var i Subscription
 		if err := rows.Scan(
 			&i.ID,
 			&i.OwnerID,
-			&i.Name,
-			&i.Slug,
-			&i.Description,
-			&i.CuisineType,
-			&i.Phone,
-			&i.Email,
-			&i.Website,
-			&i.Address,
-			&i.City,
-			&i.Country,
-			&i.LogoUrl,
-			&i.CoverImageUrl,
-			&i.ThemeSettings,
-			&i.IsPublished,
-			&i.ViewCount,
-			&i.RankScore,
+			&i.PlanID,
+			&i.Status,
+			&i.CurrentPeriodStart,
+			&i.CurrentPeriodEnd,
+			&i.TrialEnd,
+			&i.CancelledAt,
+			&i.PaymentProviderSubscriptionID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.BillingInterval,
+			&i.ScheduledPlanID,
+			&i.ScheduledBillingInterval,
+			&i.CancelAtPeriodEnd,
 		); err != nil {
 			return nil, err
 		}
@@ -1957,347 +6028,595 @@ func (q *Queries) ListRestaurantsByOwner(ctx context.Context, ownerID uuid.UUID)
 	return items, nil
 }
 
-const listRestaurantsWithFilters = `-- name: ListRestaurantsWithFilters :many
-SELECT id, owner_id, name, slug, description, cuisine_type, phone, email, website, address, city, country, logo_url, cover_image_url, theme_settings, is_published, view_count, rank_score, created_at, updated_at FROM restaurants
-WHERE 
-    ($3::uuid IS NULL OR owner_id = $3) AND
-    ($4::text IS NULL OR cuisine_type = $4) AND
-    ($5::text IS NULL OR city = $5) AND
-    ($6::text IS NULL OR country = $6) AND
-    ($7::boolean IS NULL OR is_published = $7) AND
-    ($8::text IS NULL OR name ILIKE '%' || $8 || '%')
-ORDER BY created_at DESC
-LIMIT $1 OFFSET $2
+const markLowStockNotified = `-- name: MarkLowStockNotified :execrows
+UPDATE menu_items
+SET low_stock_notified_at = NOW()
+WHERE id = $1 AND low_stock_notified_at IS NULL
 `
 
-type ListRestaurantsWithFiltersParams struct {
-	Limit       int32       `db:"limit" json:"limit"`
-	Offset      int32       `db:"offset" json:"offset"`
-	OwnerID     *uuid.UUID  `db:"owner_id" json:"owner_id"`
-	CuisineType pgtype.Text `db:"cuisine_type" json:"cuisine_type"`
-	City        pgtype.Text `db:"city" json:"city"`
-	Country     pgtype.Text `db:"country" json:"country"`
-	IsPublished pgtype.Bool `db:"is_published" json:"is_published"`
-	Search      pgtype.Text `db:"search" json:"search"`
+func (q *Queries) MarkLowStockNotified(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, markLowStockNotified, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-func (q *Queries) ListRestaurantsWithFilters(ctx context.Context, arg ListRestaurantsWithFiltersParams) ([]Restaurant, error) {
-	var ownerID any
-	if arg.OwnerID != nil && *arg.OwnerID != uuid.Nil {
-		ownerID = *arg.OwnerID
-	} else {
-		ownerID = nil
+const markRecoveryCodeUsed = `-- name: MarkRecoveryCodeUsed :execrows
+UPDATE user_recovery_codes
+SET used_at = NOW()
+WHERE id = $1 AND used_at IS NULL
+`
+
+func (q *Queries) MarkRecoveryCodeUsed(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, markRecoveryCodeUsed, id)
+	if err != nil {
+		return 0, err
 	}
-	fmt.Println(ownerID)
-	fmt.Println(arg)
-	rows, err := q.db.Query(ctx, listRestaurantsWithFilters,
-		arg.Limit,
-		arg.Offset,
-		ownerID,
-		arg.CuisineType,
-		arg.City,
-		arg.Country,
-		arg.IsPublished,
-		arg.Search,
+	return result.RowsAffected(), nil
+}
+
+const markSubscriptionCancellationResumed = `-- name: MarkSubscriptionCancellationResumed :exec
+UPDATE subscription_cancellations
+SET resumed_at = NOW()
+WHERE subscription_id = $1 AND immediate = FALSE AND resumed_at IS NULL
+`
+
+func (q *Queries) MarkSubscriptionCancellationResumed(ctx context.Context, subscriptionID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, markSubscriptionCancellationResumed, subscriptionID)
+	return err
+}
+
+const markWebhookAsProcessed = `-- name: MarkWebhookAsProcessed :exec
+UPDATE payment_webhooks SET processed = TRUE WHERE provider_event_id = $1
+`
+
+func (q *Queries) MarkWebhookAsProcessed(ctx context.Context, providerEventID pgtype.Text) error {
+	_, err := q.db.Exec(ctx, markWebhookAsProcessed, providerEventID)
+	return err
+}
+
+const recordSubscriptionNotification = `-- name: RecordSubscriptionNotification :execrows
+INSERT INTO subscription_notifications (
+    subscription_id, kind, period_end
+) VALUES (
+    $1, $2, $3
+) ON CONFLICT (subscription_id, kind, period_end) DO NOTHING
+`
+
+type RecordSubscriptionNotificationParams struct {
+	SubscriptionID uuid.UUID        `db:"subscription_id" json:"subscription_id"`
+	Kind           string           `db:"kind" json:"kind"`
+	PeriodEnd      pgtype.Timestamp `db:"period_end" json:"period_end"`
+}
+
+func (q *Queries) RecordSubscriptionNotification(ctx context.Context, arg RecordSubscriptionNotificationParams) (int64, error) {
+	result, err := q.db.Exec(ctx, recordSubscriptionNotification, arg.SubscriptionID, arg.Kind, arg.PeriodEnd)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const refreshMenuItemRating = `-- name: RefreshMenuItemRating :exec
+UPDATE menu_items
+SET
+    rating_avg = COALESCE((SELECT AVG(rating) FROM reviews WHERE reviews.menu_item_id = menu_items.id AND is_hidden = FALSE), 0),
+    rating_count = (SELECT COUNT(*) FROM reviews WHERE reviews.menu_item_id = menu_items.id AND is_hidden = FALSE)
+WHERE id = $1
+`
+
+func (q *Queries) RefreshMenuItemRating(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, refreshMenuItemRating, id)
+	return err
+}
+
+const refreshRestaurantRating = `-- name: RefreshRestaurantRating :exec
+UPDATE restaurants
+SET
+    rating_avg = COALESCE((SELECT AVG(rating) FROM reviews WHERE reviews.restaurant_id = restaurants.id AND menu_item_id IS NULL AND is_hidden = FALSE), 0),
+    rating_count = (SELECT COUNT(*) FROM reviews WHERE reviews.restaurant_id = restaurants.id AND menu_item_id IS NULL AND is_hidden = FALSE)
+WHERE id = $1
+`
+
+func (q *Queries) RefreshRestaurantRating(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, refreshRestaurantRating, id)
+	return err
+}
+
+const releaseCouponReservation = `-- name: ReleaseCouponReservation :execrows
+WITH released AS (
+    DELETE FROM coupon_reservations WHERE tx_ref = $1 RETURNING coupon_id
+)
+UPDATE coupons
+SET redemption_count = GREATEST(redemption_count - 1, 0), updated_at = NOW()
+WHERE id IN (SELECT coupon_id FROM released)
+`
+
+func (q *Queries) ReleaseCouponReservation(ctx context.Context, txRef string) (int64, error) {
+	result, err := q.db.Exec(ctx, releaseCouponReservation, txRef)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const releaseExpiredCouponReservations = `-- name: ReleaseExpiredCouponReservations :execrows
+WITH released AS (
+    DELETE FROM coupon_reservations WHERE expires_at <= NOW() RETURNING coupon_id
+), totals AS (
+    SELECT coupon_id, COUNT(*) AS released_count FROM released GROUP BY coupon_id
+)
+UPDATE coupons
+SET redemption_count = GREATEST(coupons.redemption_count - totals.released_count, 0), updated_at = NOW()
+FROM totals
+WHERE coupons.id = totals.coupon_id
+`
+
+func (q *Queries) ReleaseExpiredCouponReservations(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, releaseExpiredCouponReservations)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const releaseInvoiceRefund = `-- name: ReleaseInvoiceRefund :exec
+UPDATE invoices
+SET
+    refunded_amount = refunded_amount - $1,
+    status = 'paid',
+    updated_at = NOW()
+WHERE id = $2
+`
+
+type ReleaseInvoiceRefundParams struct {
+	Amount pgtype.Numeric `db:"amount" json:"amount"`
+	ID     uuid.UUID      `db:"id" json:"id"`
+}
+
+func (q *Queries) ReleaseInvoiceRefund(ctx context.Context, arg ReleaseInvoiceRefundParams) error {
+	_, err := q.db.Exec(ctx, releaseInvoiceRefund, arg.Amount, arg.ID)
+	return err
+}
+
+const renewStaffInvitation = `-- name: RenewStaffInvitation :one
+UPDATE staff_invitations
+SET token_hash = $3, expires_at = $4, updated_at = NOW()
+WHERE id = $1 AND restaurant_id = $2 AND status = 'pending'
+RETURNING id, restaurant_id, owner_id, email, full_name, phone, token_hash, status, expires_at, accepted_user_id, accepted_at, created_at, updated_at, staff_role_id
+`
+
+type RenewStaffInvitationParams struct {
+	ID           uuid.UUID        `db:"id" json:"id"`
+	RestaurantID uuid.UUID        `db:"restaurant_id" json:"restaurant_id"`
+	TokenHash    string           `db:"token_hash" json:"token_hash"`
+	ExpiresAt    pgtype.Timestamp `db:"expires_at" json:"expires_at"`
+}
+
+func (q *Queries) RenewStaffInvitation(ctx context.Context, arg RenewStaffInvitationParams) (StaffInvitation, error) {
+	row := q.db.QueryRow(ctx, renewStaffInvitation,
+		arg.ID,
+		arg.RestaurantID,
+		arg.TokenHash,
+		arg.ExpiresAt,
+	)
+	var i StaffInvitation
+	err := row.Scan(
+		&i.ID,
+		&i.RestaurantID,
+		&i.OwnerID,
+		&i.Email,
+		&i.FullName,
+		&i.Phone,
+		&i.TokenHash,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.AcceptedUserID,
+		&i.AcceptedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.StaffRoleID,
+	)
+	return i, err
+}
+
+const replyToReview = `-- name: ReplyToReview :one
+UPDATE reviews
+SET
+    owner_reply = $2,
+    replied_by = $3,
+    replied_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1
+RETURNING id, restaurant_id, menu_item_id, reviewer_name, rating, comment, photo_url, owner_reply, replied_by, replied_at, is_hidden, hidden_reason, report_count, ip_address, created_at, updated_at
+`
+
+type ReplyToReviewParams struct {
+	ID         uuid.UUID   `db:"id" json:"id"`
+	OwnerReply pgtype.Text `db:"owner_reply" json:"owner_reply"`
+	RepliedBy  uuid.UUID   `db:"replied_by" json:"replied_by"`
+}
+
+func (q *Queries) ReplyToReview(ctx context.Context, arg ReplyToReviewParams) (Review, error) {
+	row := q.db.QueryRow(ctx, replyToReview, arg.ID, arg.OwnerReply, arg.RepliedBy)
+	var i Review
+	err := row.Scan(
+		&i.ID,
+		&i.RestaurantID,
+		&i.MenuItemID,
+		&i.ReviewerName,
+		&i.Rating,
+		&i.Comment,
+		&i.PhotoUrl,
+		&i.OwnerReply,
+		&i.RepliedBy,
+		&i.RepliedAt,
+		&i.IsHidden,
+		&i.HiddenReason,
+		&i.ReportCount,
+		&i.IpAddress,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const reportReview = `-- name: ReportReview :exec
+UPDATE reviews SET report_count = report_count + 1 WHERE id = $1
+`
+
+func (q *Queries) ReportReview(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, reportReview, id)
+	return err
+}
+
+const requireUserPasswordChange = `-- name: RequireUserPasswordChange :exec
+UPDATE users
+SET must_change_password = TRUE, updated_at = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) RequireUserPasswordChange(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, requireUserPasswordChange, id)
+	return err
+}
+
+const reserveInvoiceRefund = `-- name: ReserveInvoiceRefund :one
+UPDATE invoices
+SET
+    refunded_amount = refunded_amount + $1,
+    status = CASE WHEN refunded_amount + $1 >= amount THEN 'refunded'::invoice_status ELSE status END,
+    updated_at = NOW()
+WHERE id = $2 AND status = 'paid' AND refunded_amount + $1 <= amount
+RETURNING id, subscription_id, owner_id, invoice_number, amount, currency, status, billing_period_start, billing_period_end, payment_provider_invoice_id, paid_at, created_at, updated_at, prorated, credit_amount, coupon_id, discount_amount, tx_ref, vat_rate, refunded_amount
+`
+
+type ReserveInvoiceRefundParams struct {
+	Amount pgtype.Numeric `db:"amount" json:"amount"`
+	ID     uuid.UUID      `db:"id" json:"id"`
+}
+
+func (q *Queries) ReserveInvoiceRefund(ctx context.Context, arg ReserveInvoiceRefundParams) (Invoice, error) {
+	row := q.db.QueryRow(ctx, reserveInvoiceRefund, arg.Amount, arg.ID)
+	var i Invoice
+	err := row.Scan(
+		&i.ID,
+		&i.SubscriptionID,
+		&i.OwnerID,
+		&i.InvoiceNumber,
+		&i.Amount,
+		&i.Currency,
+		&i.Status,
+		&i.BillingPeriodStart,
+		&i.BillingPeriodEnd,
+		&i.PaymentProviderInvoiceID,
+		&i.PaidAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Prorated,
+		&i.CreditAmount,
+		&i.CouponID,
+		&i.DiscountAmount,
+		&i.TxRef,
+		&i.VatRate,
+		&i.RefundedAmount,
+	)
+	return i, err
+}
+
+const restoreExpiredEightySixedItems = `-- name: RestoreExpiredEightySixedItems :execrows
+UPDATE menu_items
+SET
+    is_available = (NOT track_stock OR stock_quantity > 0) AND NOT plan_locked,
+    was_available_before_lock = CASE WHEN plan_locked THEN NOT track_stock OR stock_quantity > 0 ELSE was_available_before_lock END,
+    unavailable_until = NULL,
+    updated_at = NOW()
+WHERE restaurant_id = $1 AND unavailable_until IS NOT NULL AND unavailable_until <= $2
+`
+
+type RestoreExpiredEightySixedItemsParams struct {
+	RestaurantID     uuid.UUID        `db:"restaurant_id" json:"restaurant_id"`
+	UnavailableUntil pgtype.Timestamp `db:"unavailable_until" json:"unavailable_until"`
+}
+
+func (q *Queries) RestoreExpiredEightySixedItems(ctx context.Context, arg RestoreExpiredEightySixedItemsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, restoreExpiredEightySixedItems, arg.RestaurantID, arg.UnavailableUntil)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const restoreMenuItemAvailability = `-- name: RestoreMenuItemAvailability :one
+UPDATE menu_items
+SET
+    is_available = (NOT track_stock OR stock_quantity > 0) AND NOT plan_locked,
+    was_available_before_lock = CASE WHEN plan_locked THEN NOT track_stock OR stock_quantity > 0 ELSE was_available_before_lock END,
+    unavailable_until = NULL,
+    updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, restaurant_id, category_id, name, description, price, currency, images, allergens, dietary_tags, spice_level, calories, is_available, display_order, view_count, created_by, created_at, updated_at, rating_avg, rating_count, track_stock, stock_quantity, low_stock_threshold, low_stock_notified_at, unavailable_until, plan_locked, was_available_before_lock
+`
+
+func (q *Queries) RestoreMenuItemAvailability(ctx context.Context, id uuid.UUID) (MenuItem, error) {
+	row := q.db.QueryRow(ctx, restoreMenuItemAvailability, id)
+	var i MenuItem
+	err := row.Scan(
+		&i.ID,
+		&i.RestaurantID,
+		&i.CategoryID,
+		&i.Name,
+		&i.Description,
+		&i.Price,
+		&i.Currency,
+		&i.Images,
+		&i.Allergens,
+		&i.DietaryTags,
+		&i.SpiceLevel,
+		&i.Calories,
+		&i.IsAvailable,
+		&i.DisplayOrder,
+		&i.ViewCount,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RatingAvg,
+		&i.RatingCount,
+		&i.TrackStock,
+		&i.StockQuantity,
+		&i.LowStockThreshold,
+		&i.LowStockNotifiedAt,
+		&i.UnavailableUntil,
+		&i.PlanLocked,
+		&i.WasAvailableBeforeLock,
+	)
+	return i, err
+}
+
+const resumeSubscriptionByOwner = `-- name: ResumeSubscriptionByOwner :one
+UPDATE subscriptions
+SET cancel_at_period_end = FALSE, updated_at = NOW()
+WHERE owner_id = $1 AND status IN ('active', 'trialing') AND cancel_at_period_end = TRUE AND current_period_end > NOW()
+RETURNING id, owner_id, plan_id, status, current_period_start, current_period_end, trial_end, cancelled_at, payment_provider_subscription_id, created_at, updated_at, billing_interval, scheduled_plan_id, scheduled_billing_interval, cancel_at_period_end
+`
+
+func (q *Queries) ResumeSubscriptionByOwner(ctx context.Context, ownerID uuid.UUID) (Subscription, error) {
+	row := q.db.QueryRow(ctx, resumeSubscriptionByOwner, ownerID)
+	var i Subscription
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.PlanID,
+		&i.Status,
+		&i.CurrentPeriodStart,
+		&i.CurrentPeriodEnd,
+		&i.TrialEnd,
+		&i.CancelledAt,
+		&i.PaymentProviderSubscriptionID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.BillingInterval,
+		&i.ScheduledPlanID,
+		&i.ScheduledBillingInterval,
+		&i.CancelAtPeriodEnd,
 	)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []Restaurant
-	for rows.Next() {
-		var i Restaurant
-		if err := rows.Scan(
-			&i.ID,
-			&i.OwnerID,
-			&i.Name,
-			&i.Slug,
-			&i.Description,
-			&i.CuisineType,
-			&i.Phone,
-			&i.Email,
-			&i.Website,
-			&i.Address,
-			&i.City,
-			&i.Country,
-			&i.LogoUrl,
-			&i.CoverImageUrl,
-			&i.ThemeSettings,
-			&i.IsPublished,
-			&i.ViewCount,
-			&i.RankScore,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+	return i, err
 }
 
-const listStaffByOwner = `-- name: ListStaffByOwner :many
-SELECT id, email, password_hash, full_name, role, owner_id, restaurant_id, phone, avatar_url, email_verified, last_login_at, is_active, created_at, updated_at, email_verified_at, verification_token, verification_token_expires_at, trial_ends_at FROM users
-WHERE owner_id = $1 AND role = 'staff' 
-ORDER BY created_at DESC
+const revokeAPIKey = `-- name: RevokeAPIKey :execrows
+UPDATE api_keys
+SET revoked_at = NOW()
+WHERE id = $1 AND restaurant_id = $2 AND revoked_at IS NULL
 `
 
-func (q *Queries) ListStaffByOwner(ctx context.Context, ownerID uuid.UUID) ([]User, error) {
-	rows, err := q.db.Query(ctx, listStaffByOwner, ownerID)
+type RevokeAPIKeyParams struct {
+	ID           uuid.UUID `db:"id" json:"id"`
+	RestaurantID uuid.UUID `db:"restaurant_id" json:"restaurant_id"`
+}
+
+func (q *Queries) RevokeAPIKey(ctx context.Context, arg RevokeAPIKeyParams) (int64, error) {
+	result, err := q.db.Exec(ctx, revokeAPIKey, arg.ID, arg.RestaurantID)
 	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []User
-	for rows.Next() {
-		var i User
-		if err := rows.Scan(
-			&i.ID,
-			&i.Email,
-			&i.PasswordHash,
-			&i.FullName,
-			&i.Role,
-			&i.OwnerID,
-			&i.RestaurantID,
-			&i.Phone,
-			&i.AvatarUrl,
-			&i.EmailVerified,
-			&i.LastLoginAt,
-			&i.IsActive,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-			&i.EmailVerifiedAt,
-			&i.VerificationToken,
-			&i.VerificationTokenExpiresAt,
-			&i.TrialEndsAt,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
+		return 0, err
 	}
-	return items, nil
+	return result.RowsAffected(), nil
 }
 
-const listStaffByRestaurant = `-- name: ListStaffByRestaurant :many
-SELECT id, email, password_hash, full_name, role, owner_id, restaurant_id, phone, avatar_url, email_verified, last_login_at, is_active, created_at, updated_at, email_verified_at, verification_token, verification_token_expires_at, trial_ends_at FROM users
-WHERE restaurant_id = $1 AND role = 'staff' 
-ORDER BY created_at DESC
-LIMIT $2 OFFSET $3
+const revokeStaffInvitation = `-- name: RevokeStaffInvitation :execrows
+UPDATE staff_invitations
+SET status = 'revoked', updated_at = NOW()
+WHERE id = $1 AND restaurant_id = $2 AND status = 'pending'
 `
 
-type ListStaffByRestaurantParams struct {
+type RevokeStaffInvitationParams struct {
+	ID           uuid.UUID `db:"id" json:"id"`
 	RestaurantID uuid.UUID `db:"restaurant_id" json:"restaurant_id"`
-	Limit        int32     `db:"limit" json:"limit"`
-	Offset       int32     `db:"offset" json:"offset"`
 }
 
-func (q *Queries) ListStaffByRestaurant(ctx context.Context, arg ListStaffByRestaurantParams) ([]User, error) {
-	rows, err := q.db.Query(ctx, listStaffByRestaurant, arg.RestaurantID, arg.Limit, arg.Offset)
+func (q *Queries) RevokeStaffInvitation(ctx context.Context, arg RevokeStaffInvitationParams) (int64, error) {
+	result, err := q.db.Exec(ctx, revokeStaffInvitation, arg.ID, arg.RestaurantID)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	defer rows.Close()
-	var items []User
-	for rows.Next() {
-		var i User
-		if err := rows.Scan(
-			&i.ID,
-			&i.Email,
-			&i.PasswordHash,
-			&i.FullName,
-			&i.Role,
-			&i.OwnerID,
-			&i.RestaurantID,
-			&i.Phone,
-			&i.AvatarUrl,
-			&i.EmailVerified,
-			&i.LastLoginAt,
-			&i.IsActive,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-			&i.EmailVerifiedAt,
-			&i.VerificationToken,
-			&i.VerificationTokenExpiresAt,
-			&i.TrialEndsAt,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+	return result.RowsAffected(), nil
 }
 
-const listSubscriptionPlans = `-- name: ListSubscriptionPlans :many
-SELECT id, name, slug, description, price_monthly, price_annual, currency, features, display_order, is_active, created_at, updated_at FROM subscription_plans
-WHERE is_active = TRUE
-ORDER BY display_order ASC
+const revokeUserSession = `-- name: RevokeUserSession :exec
+UPDATE user_sessions
+SET revoked_at = NOW()
+WHERE id = $1 AND revoked_at IS NULL
 `
 
-func (q *Queries) ListSubscriptionPlans(ctx context.Context) ([]SubscriptionPlan, error) {
-	rows, err := q.db.Query(ctx, listSubscriptionPlans)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []SubscriptionPlan
-	for rows.Next() {
-		var i SubscriptionPlan
-		if err := rows.Scan(
-			&i.ID,
-			&i.Name,
-			&i.Slug,
-			&i.Description,
-			&i.PriceMonthly,
-			&i.PriceAnnual,
-			&i.Currency,
-			&i.Features,
-			&i.DisplayOrder,
-			&i.IsActive,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+func (q *Queries) RevokeUserSession(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, revokeUserSession, id)
+	return err
 }
 
-const listUsers = `-- name: ListUsers :many
-SELECT id, email, password_hash, full_name, role, owner_id, restaurant_id, phone, avatar_url, email_verified, last_login_at, is_active, created_at, updated_at, email_verified_at, verification_token, verification_token_expires_at, trial_ends_at FROM users
-ORDER BY created_at DESC
-LIMIT $1 OFFSET $2
+const scheduleSubscriptionChange = `-- name: ScheduleSubscriptionChange :exec
+UPDATE subscriptions
+SET scheduled_plan_id = $2, scheduled_billing_interval = $3, updated_at = NOW()
+WHERE id = $1
 `
 
-type ListUsersParams struct {
-	Limit  int32 `db:"limit" json:"limit"`
-	Offset int32 `db:"offset" json:"offset"`
+type ScheduleSubscriptionChangeParams struct {
+	ID                       uuid.UUID       `db:"id" json:"id"`
+	ScheduledPlanID          uuid.UUID       `db:"scheduled_plan_id" json:"scheduled_plan_id"`
+	ScheduledBillingInterval BillingInterval `db:"scheduled_billing_interval" json:"scheduled_billing_interval"`
 }
 
-func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error) {
-	rows, err := q.db.Query(ctx, listUsers, arg.Limit, arg.Offset)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []User
-	for rows.Next() {
-		var i User
-		if err := rows.Scan(
-			&i.ID,
-			&i.Email,
-			&i.PasswordHash,
-			&i.FullName,
-			&i.Role,
-			&i.OwnerID,
-			&i.RestaurantID,
-			&i.Phone,
-			&i.AvatarUrl,
-			&i.EmailVerified,
-			&i.LastLoginAt,
-			&i.IsActive,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-			&i.EmailVerifiedAt,
-			&i.VerificationToken,
-			&i.VerificationTokenExpiresAt,
-			&i.TrialEndsAt,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+func (q *Queries) ScheduleSubscriptionChange(ctx context.Context, arg ScheduleSubscriptionChangeParams) error {
+	_, err := q.db.Exec(ctx, scheduleSubscriptionChange, arg.ID, arg.ScheduledPlanID, arg.ScheduledBillingInterval)
+	return err
 }
 
-const listUsersWithFilters = `-- name: ListUsersWithFilters :many
-SELECT id, email, password_hash, full_name, role, owner_id, restaurant_id, phone, avatar_url, email_verified, last_login_at, is_active, created_at, updated_at, email_verified_at, verification_token, verification_token_expires_at, trial_ends_at FROM users
-WHERE 
-    ($3::text IS NULL OR email = $3) AND
-    ($4::user_role IS NULL OR role = $4) AND
-    ($5::boolean IS NULL OR is_active = $5) AND
-    ($6::text IS NULL OR full_name ILIKE '%' || $6 || '%' OR email ILIKE '%' || $6 || '%') 
-ORDER BY created_at DESC
-LIMIT $1 OFFSET $2
+const setPaymentRetryJobStatus = `-- name: SetPaymentRetryJobStatus :exec
+UPDATE payment_retry_jobs
+SET status = $2, updated_at = NOW()
+WHERE id = $1
+`
+
+type SetPaymentRetryJobStatusParams struct {
+	ID     uuid.UUID `db:"id" json:"id"`
+	Status string    `db:"status" json:"status"`
+}
+
+func (q *Queries) SetPaymentRetryJobStatus(ctx context.Context, arg SetPaymentRetryJobStatusParams) error {
+	_, err := q.db.Exec(ctx, setPaymentRetryJobStatus, arg.ID, arg.Status)
+	return err
+}
+
+const setReviewHidden = `-- name: SetReviewHidden :one
+UPDATE reviews
+SET
+    is_hidden = $2,
+    hidden_reason = $3,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING id, restaurant_id, menu_item_id, reviewer_name, rating, comment, photo_url, owner_reply, replied_by, replied_at, is_hidden, hidden_reason, report_count, ip_address, created_at, updated_at
+`
+
+type SetReviewHiddenParams struct {
+	ID           uuid.UUID   `db:"id" json:"id"`
+	IsHidden     bool        `db:"is_hidden" json:"is_hidden"`
+	HiddenReason pgtype.Text `db:"hidden_reason" json:"hidden_reason"`
+}
+
+func (q *Queries) SetReviewHidden(ctx context.Context, arg SetReviewHiddenParams) (Review, error) {
+	row := q.db.QueryRow(ctx, setReviewHidden, arg.ID, arg.IsHidden, arg.HiddenReason)
+	var i Review
+	err := row.Scan(
+		&i.ID,
+		&i.RestaurantID,
+		&i.MenuItemID,
+		&i.ReviewerName,
+		&i.Rating,
+		&i.Comment,
+		&i.PhotoUrl,
+		&i.OwnerReply,
+		&i.RepliedBy,
+		&i.RepliedAt,
+		&i.IsHidden,
+		&i.HiddenReason,
+		&i.ReportCount,
+		&i.IpAddress,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const touchAPIKey = `-- name: TouchAPIKey :exec
+UPDATE api_keys
+SET last_used_at = NOW(), last_used_ip = $2
+WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute')
+`
+
+type TouchAPIKeyParams struct {
+	ID         uuid.UUID   `db:"id" json:"id"`
+	LastUsedIp pgtype.Text `db:"last_used_ip" json:"last_used_ip"`
+}
+
+func (q *Queries) TouchAPIKey(ctx context.Context, arg TouchAPIKeyParams) error {
+	_, err := q.db.Exec(ctx, touchAPIKey, arg.ID, arg.LastUsedIp)
+	return err
+}
+
+const touchUserIdentity = `-- name: TouchUserIdentity :exec
+UPDATE user_identities
+SET last_login_at = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) TouchUserIdentity(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, touchUserIdentity, id)
+	return err
+}
+
+const unlockCategories = `-- name: UnlockCategories :execrows
+UPDATE categories
+SET is_active = TRUE, plan_locked = FALSE, updated_at = NOW()
+WHERE plan_locked = TRUE AND restaurant_id IN (SELECT id FROM restaurants WHERE owner_id = $1)
 `
 
-type ListUsersWithFiltersParams struct {
-	Limit    int32        `db:"limit" json:"limit"`
-	Offset   int32        `db:"offset" json:"offset"`
-	Email    pgtype.Text  `db:"email" json:"email"`
-	Role     NullUserRole `db:"role" json:"role"`
-	IsActive pgtype.Bool  `db:"is_active" json:"is_active"`
-	Search   pgtype.Text  `db:"search" json:"search"`
+func (q *Queries) UnlockCategories(ctx context.Context, ownerID uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, unlockCategories, ownerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-func (q *Queries) ListUsersWithFilters(ctx context.Context, arg ListUsersWithFiltersParams) ([]User, error) {
-	rows, err := q.db.Query(ctx, listUsersWithFilters,
-		arg.Limit,
-		arg.Offset,
-		arg.Email,
-		arg.Role,
-		arg.IsActive,
-		arg.Search,
-	)
+const unlockMenuItems = `-- name: UnlockMenuItems :execrows
+UPDATE menu_items
+SET
+    is_available = was_available_before_lock AND (NOT track_stock OR stock_quantity > 0) AND unavailable_until IS NULL,
+    was_available_before_lock = FALSE,
+    plan_locked = FALSE,
+    updated_at = NOW()
+WHERE plan_locked = TRUE AND restaurant_id IN (SELECT id FROM restaurants WHERE owner_id = $1)
+`
+
+func (q *Queries) UnlockMenuItems(ctx context.Context, ownerID uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, unlockMenuItems, ownerID)
 	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []User
-	for rows.Next() {
-		var i User
-		if err := rows.Scan(
-			&i.ID,
-			&i.Email,
-			&i.PasswordHash,
-			&i.FullName,
-			&i.Role,
-			&i.OwnerID,
-			&i.RestaurantID,
-			&i.Phone,
-			&i.AvatarUrl,
-			&i.EmailVerified,
-			&i.LastLoginAt,
-			&i.IsActive,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-			&i.EmailVerifiedAt,
-			&i.VerificationToken,
-			&i.VerificationTokenExpiresAt,
-			&i.TrialEndsAt,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
+		return 0, err
 	}
-	return items, nil
+	return result.RowsAffected(), nil
 }
 
-const markWebhookAsProcessed = `-- name: MarkWebhookAsProcessed :exec
-UPDATE payment_webhooks SET processed = TRUE WHERE provider_event_id = $1
+const unlockRestaurants = `-- name: UnlockRestaurants :execrows
+UPDATE restaurants
+SET is_published = TRUE, plan_locked = FALSE, updated_at = NOW()
+WHERE owner_id = $1 AND plan_locked = TRUE
 `
 
-func (q *Queries) MarkWebhookAsProcessed(ctx context.Context, providerEventID pgtype.Text) error {
-	_, err := q.db.Exec(ctx, markWebhookAsProcessed, providerEventID)
-	return err
+func (q *Queries) UnlockRestaurants(ctx context.Context, ownerID uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, unlockRestaurants, ownerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const updateCategory = `-- name: UpdateCategory :one
@@ -2310,7 +6629,7 @@ SET
     is_active = COALESCE($5, is_active),
     updated_at = NOW()
 WHERE id = $6
-RETURNING id, restaurant_id, name, description, icon, display_order, is_active, created_by, created_at, updated_at
+RETURNING id, restaurant_id, name, description, icon, display_order, is_active, created_by, created_at, updated_at, plan_locked
 `
 
 type UpdateCategoryParams struct {
@@ -2343,6 +6662,70 @@ func (q *Queries) UpdateCategory(ctx context.Context, arg UpdateCategoryParams)
 		&i.CreatedBy,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.PlanLocked,
+	)
+	return i, err
+}
+
+const updateCoupon = `-- name: UpdateCoupon :one
+UPDATE coupons
+SET
+    code = UPPER($2),
+    description = $3,
+    discount_type = $4,
+    discount_value = $5,
+    plan_ids = $6,
+    max_redemptions = $7,
+    duration_cycles = $8,
+    expires_at = $9,
+    is_active = $10,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING id, code, description, discount_type, discount_value, plan_ids, max_redemptions, redemption_count, duration_cycles, expires_at, is_active, created_by, created_at, updated_at
+`
+
+type UpdateCouponParams struct {
+	ID             uuid.UUID        `db:"id" json:"id"`
+	Code           string           `db:"code" json:"code"`
+	Description    pgtype.Text      `db:"description" json:"description"`
+	DiscountType   DiscountType     `db:"discount_type" json:"discount_type"`
+	DiscountValue  pgtype.Numeric   `db:"discount_value" json:"discount_value"`
+	PlanIds        []uuid.UUID      `db:"plan_ids" json:"plan_ids"`
+	MaxRedemptions pgtype.Int4      `db:"max_redemptions" json:"max_redemptions"`
+	DurationCycles pgtype.Int4      `db:"duration_cycles" json:"duration_cycles"`
+	ExpiresAt      pgtype.Timestamp `db:"expires_at" json:"expires_at"`
+	IsActive       bool             `db:"is_active" json:"is_active"`
+}
+
+func (q *Queries) UpdateCoupon(ctx context.Context, arg UpdateCouponParams) (Coupon, error) {
+	row := q.db.QueryRow(ctx, updateCoupon,
+		arg.ID,
+		arg.Code,
+		arg.Description,
+		arg.DiscountType,
+		arg.DiscountValue,
+		arg.PlanIds,
+		arg.MaxRedemptions,
+		arg.DurationCycles,
+		arg.ExpiresAt,
+		arg.IsActive,
+	)
+	var i Coupon
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.Description,
+		&i.DiscountType,
+		&i.DiscountValue,
+		&i.PlanIds,
+		&i.MaxRedemptions,
+		&i.RedemptionCount,
+		&i.DurationCycles,
+		&i.ExpiresAt,
+		&i.IsActive,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
@@ -2352,18 +6735,23 @@ UPDATE invoices
 SET 
     status = $2,
     paid_at = CASE WHEN $2 = 'paid'::invoice_status THEN NOW() ELSE paid_at END,
+    invoice_number = CASE
+        WHEN $2 = 'paid'::invoice_status AND invoice_number IS NULL
+        THEN 'INV-' || TO_CHAR(NOW(), 'YYYY') || '-' || LPAD(nextval('invoice_number_seq')::text, 6, '0')
+        ELSE invoice_number
+    END,
     updated_at = NOW()
-WHERE invoice_number = $1
-RETURNING id, subscription_id, owner_id, invoice_number, amount, currency, status, billing_period_start, billing_period_end, payment_provider_invoice_id, paid_at, created_at, updated_at
+WHERE tx_ref = $1
+RETURNING id, subscription_id, owner_id, invoice_number, amount, currency, status, billing_period_start, billing_period_end, payment_provider_invoice_id, paid_at, created_at, updated_at, prorated, credit_amount, coupon_id, discount_amount, tx_ref, vat_rate, refunded_amount
 `
 
 type UpdateInvoiceStatusParams struct {
-	InvoiceNumber string        `db:"invoice_number" json:"invoice_number"`
-	Status        InvoiceStatus `db:"status" json:"status"`
+	TxRef  string        `db:"tx_ref" json:"tx_ref"`
+	Status InvoiceStatus `db:"status" json:"status"`
 }
 
 func (q *Queries) UpdateInvoiceStatus(ctx context.Context, arg UpdateInvoiceStatusParams) (Invoice, error) {
-	row := q.db.QueryRow(ctx, updateInvoiceStatus, arg.InvoiceNumber, arg.Status)
+	row := q.db.QueryRow(ctx, updateInvoiceStatus, arg.TxRef, arg.Status)
 	var i Invoice
 	err := row.Scan(
 		&i.ID,
@@ -2379,6 +6767,13 @@ func (q *Queries) UpdateInvoiceStatus(ctx context.Context, arg UpdateInvoiceStat
 		&i.PaidAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Prorated,
+		&i.CreditAmount,
+		&i.CouponID,
+		&i.DiscountAmount,
+		&i.TxRef,
+		&i.VatRate,
+		&i.RefundedAmount,
 	)
 	return i, err
 }
@@ -2398,7 +6793,7 @@ SET
     display_order = COALESCE($10, display_order),
     updated_at = NOW()
 WHERE id = $11
-RETURNING id, restaurant_id, category_id, name, description, price, currency, images, allergens, dietary_tags, spice_level, calories, is_available, display_order, view_count, created_by, created_at, updated_at
+RETURNING id, restaurant_id, category_id, name, description, price, currency, images, allergens, dietary_tags, spice_level, calories, is_available, display_order, view_count, created_by, created_at, updated_at, rating_avg, rating_count, track_stock, stock_quantity, low_stock_threshold, low_stock_notified_at, unavailable_until, plan_locked, was_available_before_lock
 `
 
 type UpdateMenuItemParams struct {
@@ -2449,6 +6844,83 @@ func (q *Queries) UpdateMenuItem(ctx context.Context, arg UpdateMenuItemParams)
 		&i.CreatedBy,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.RatingAvg,
+		&i.RatingCount,
+		&i.TrackStock,
+		&i.StockQuantity,
+		&i.LowStockThreshold,
+		&i.LowStockNotifiedAt,
+		&i.UnavailableUntil,
+		&i.PlanLocked,
+		&i.WasAvailableBeforeLock,
+	)
+	return i, err
+}
+
+const updateMenuItemStock = `-- name: UpdateMenuItemStock :one
+UPDATE menu_items
+SET
+    track_stock = $2,
+    stock_quantity = $3,
+    low_stock_threshold = $4,
+    is_available = CASE
+        WHEN $2 AND $3 = 0 THEN FALSE
+        WHEN $2 AND unavailable_until IS NULL AND NOT plan_locked THEN TRUE
+        ELSE is_available
+    END,
+    was_available_before_lock = CASE
+        WHEN plan_locked AND $2 AND $3 > 0 AND unavailable_until IS NULL THEN TRUE
+        ELSE was_available_before_lock
+    END,
+    low_stock_notified_at = CASE WHEN $3 > $4 THEN NULL ELSE low_stock_notified_at END,
+    updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, restaurant_id, category_id, name, description, price, currency, images, allergens, dietary_tags, spice_level, calories, is_available, display_order, view_count, created_by, created_at, updated_at, rating_avg, rating_count, track_stock, stock_quantity, low_stock_threshold, low_stock_notified_at, unavailable_until, plan_locked, was_available_before_lock
+`
+
+type UpdateMenuItemStockParams struct {
+	ID                uuid.UUID `db:"id" json:"id"`
+	TrackStock        bool      `db:"track_stock" json:"track_stock"`
+	StockQuantity     int32     `db:"stock_quantity" json:"stock_quantity"`
+	LowStockThreshold int32     `db:"low_stock_threshold" json:"low_stock_threshold"`
+}
+
+func (q *Queries) UpdateMenuItemStock(ctx context.Context, arg UpdateMenuItemStockParams) (MenuItem, error) {
+	row := q.db.QueryRow(ctx, updateMenuItemStock,
+		arg.ID,
+		arg.TrackStock,
+		arg.StockQuantity,
+		arg.LowStockThreshold,
+	)
+	var i MenuItem
+	err := row.Scan(
+		&i.ID,
+		&i.RestaurantID,
+		&i.CategoryID,
+		&i.Name,
+		&i.Description,
+		&i.Price,
+		&i.Currency,
+		&i.Images,
+		&i.Allergens,
+		&i.DietaryTags,
+		&i.SpiceLevel,
+		&i.Calories,
+		&i.IsAvailable,
+		&i.DisplayOrder,
+		&i.ViewCount,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RatingAvg,
+		&i.RatingCount,
+		&i.TrackStock,
+		&i.StockQuantity,
+		&i.LowStockThreshold,
+		&i.LowStockNotifiedAt,
+		&i.UnavailableUntil,
+		&i.PlanLocked,
+		&i.WasAvailableBeforeLock,
 	)
 	return i, err
 }
@@ -2509,7 +6981,7 @@ SET
     provider_transaction_ref = COALESCE($3, provider_transaction_ref),
     updated_at = NOW()
 WHERE tx_ref = $1
-RETURNING id, owner_id, amount, currency, status, tx_ref, reference, provider_transaction_ref, created_at, updated_at
+RETURNING id, owner_id, amount, currency, status, tx_ref, reference, provider_transaction_ref, created_at, updated_at, refunded_amount
 `
 
 type UpdatePaymentTransactionStatusParams struct {
@@ -2532,6 +7004,157 @@ func (q *Queries) UpdatePaymentTransactionStatus(ctx context.Context, arg Update
 		&i.ProviderTransactionRef,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.RefundedAmount,
+	)
+	return i, err
+}
+
+const updatePlatformSettings = `-- name: UpdatePlatformSettings :one
+UPDATE platform_settings
+SET
+    require_admin_two_factor = $1,
+    updated_by = NULLIF($2::uuid, '00000000-0000-0000-0000-000000000000'::uuid),
+    updated_at = NOW()
+WHERE id = 1
+RETURNING id, require_admin_two_factor, updated_by, updated_at
+`
+
+type UpdatePlatformSettingsParams struct {
+	RequireAdminTwoFactor bool      `db:"require_admin_two_factor" json:"require_admin_two_factor"`
+	UpdatedBy             uuid.UUID `db:"updated_by" json:"updated_by"`
+}
+
+func (q *Queries) UpdatePlatformSettings(ctx context.Context, arg UpdatePlatformSettingsParams) (PlatformSetting, error) {
+	row := q.db.QueryRow(ctx, updatePlatformSettings, arg.RequireAdminTwoFactor, arg.UpdatedBy)
+	var i PlatformSetting
+	err := row.Scan(
+		&i.ID,
+		&i.RequireAdminTwoFactor,
+		&i.UpdatedBy,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updatePromotion = `-- name: UpdatePromotion :one
+UPDATE promotions
+SET
+    name = $3,
+    label = $4,
+    scope = $5,
+    category_id = NULLIF($6::uuid, '00000000-0000-0000-0000-000000000000'::uuid),
+    menu_item_id = NULLIF($7::uuid, '00000000-0000-0000-0000-000000000000'::uuid),
+    discount_type = $8,
+    discount_value = $9,
+    starts_on = $10,
+    ends_on = $11,
+    days_of_week = $12,
+    start_time = $13,
+    end_time = $14,
+    is_active = $15,
+    updated_at = NOW()
+WHERE id = $1 AND restaurant_id = $2
+RETURNING id, restaurant_id, name, label, scope, category_id, menu_item_id, discount_type, discount_value, starts_on, ends_on, days_of_week, start_time, end_time, is_active, created_by, created_at, updated_at
+`
+
+type UpdatePromotionParams struct {
+	ID            uuid.UUID      `db:"id" json:"id"`
+	RestaurantID  uuid.UUID      `db:"restaurant_id" json:"restaurant_id"`
+	Name          string         `db:"name" json:"name"`
+	Label         string         `db:"label" json:"label"`
+	Scope         PromotionScope `db:"scope" json:"scope"`
+	CategoryID    uuid.UUID      `db:"category_id" json:"category_id"`
+	MenuItemID    uuid.UUID      `db:"menu_item_id" json:"menu_item_id"`
+	DiscountType  DiscountType   `db:"discount_type" json:"discount_type"`
+	DiscountValue pgtype.Numeric `db:"discount_value" json:"discount_value"`
+	StartsOn      pgtype.Date    `db:"starts_on" json:"starts_on"`
+	EndsOn        pgtype.Date    `db:"ends_on" json:"ends_on"`
+	DaysOfWeek    []int32        `db:"days_of_week" json:"days_of_week"`
+	StartTime     pgtype.Time    `db:"start_time" json:"start_time"`
+	EndTime       pgtype.Time    `db:"end_time" json:"end_time"`
+	IsActive      bool           `db:"is_active" json:"is_active"`
+}
+
+func (q *Queries) UpdatePromotion(ctx context.Context, arg UpdatePromotionParams) (Promotion, error) {
+	row := q.db.QueryRow(ctx, updatePromotion,
+		arg.ID,
+		arg.RestaurantID,
+		arg.Name,
+		arg.Label,
+		arg.Scope,
+		arg.CategoryID,
+		arg.MenuItemID,
+		arg.DiscountType,
+		arg.DiscountValue,
+		arg.StartsOn,
+		arg.EndsOn,
+		arg.DaysOfWeek,
+		arg.StartTime,
+		arg.EndTime,
+		arg.IsActive,
+	)
+	var i Promotion
+	err := row.Scan(
+		&i.ID,
+		&i.RestaurantID,
+		&i.Name,
+		&i.Label,
+		&i.Scope,
+		&i.CategoryID,
+		&i.MenuItemID,
+		&i.DiscountType,
+		&i.DiscountValue,
+		&i.StartsOn,
+		&i.EndsOn,
+		&i.DaysOfWeek,
+		&i.StartTime,
+		&i.EndTime,
+		&i.IsActive,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateReservationStatus = `-- name: UpdateReservationStatus :one
+UPDATE reservations
+SET
+    status = $3,
+    seated_at = CASE WHEN $3 = 'seated'::reservation_status THEN NOW() ELSE seated_at END,
+    updated_at = NOW()
+WHERE id = $1 AND restaurant_id = $2
+RETURNING id, restaurant_id, table_id, guest_name, guest_phone, guest_email, party_size, reserved_at, ends_at, notes, status, confirmation_token, cancellation_token, confirmed_at, seated_at, cancelled_at, created_at, updated_at
+`
+
+type UpdateReservationStatusParams struct {
+	ID           uuid.UUID         `db:"id" json:"id"`
+	RestaurantID uuid.UUID         `db:"restaurant_id" json:"restaurant_id"`
+	Status       ReservationStatus `db:"status" json:"status"`
+}
+
+func (q *Queries) UpdateReservationStatus(ctx context.Context, arg UpdateReservationStatusParams) (Reservation, error) {
+	row := q.db.QueryRow(ctx, updateReservationStatus, arg.ID, arg.RestaurantID, arg.Status)
+	var i Reservation
+	err := row.Scan(
+		&i.ID,
+		&i.RestaurantID,
+		&i.TableID,
+		&i.GuestName,
+		&i.GuestPhone,
+		&i.GuestEmail,
+		&i.PartySize,
+		&i.ReservedAt,
+		&i.EndsAt,
+		&i.Notes,
+		&i.Status,
+		&i.ConfirmationToken,
+		&i.CancellationToken,
+		&i.ConfirmedAt,
+		&i.SeatedAt,
+		&i.CancelledAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
@@ -2552,9 +7175,10 @@ SET
     cover_image_url = COALESCE($11, cover_image_url),
     theme_settings = COALESCE($12, theme_settings),
     is_published = COALESCE($13, is_published),
+    timezone = COALESCE($14, timezone),
     updated_at = NOW()
-WHERE id = $14 AND (owner_id = $15 OR $16::boolean)
-RETURNING id, owner_id, name, slug, description, cuisine_type, phone, email, website, address, city, country, logo_url, cover_image_url, theme_settings, is_published, view_count, rank_score, created_at, updated_at
+WHERE id = $15 AND (owner_id = $16 OR $17::boolean)
+RETURNING id, owner_id, name, slug, description, cuisine_type, phone, email, website, address, city, country, logo_url, cover_image_url, theme_settings, is_published, view_count, rank_score, created_at, updated_at, rating_avg, rating_count, plan_locked, timezone
 `
 
 type UpdateRestaurantParams struct {
@@ -2571,6 +7195,7 @@ type UpdateRestaurantParams struct {
 	CoverImageUrl pgtype.Text `db:"cover_image_url" json:"cover_image_url"`
 	ThemeSettings []byte      `db:"theme_settings" json:"theme_settings"`
 	IsPublished   pgtype.Bool `db:"is_published" json:"is_published"`
+	Timezone      pgtype.Text `db:"timezone" json:"timezone"`
 	ID            uuid.UUID   `db:"id" json:"id"`
 	OwnerID       uuid.UUID   `db:"owner_id" json:"owner_id"`
 	IsAdmin       bool        `db:"is_admin" json:"is_admin"`
@@ -2591,6 +7216,7 @@ func (q *Queries) UpdateRestaurant(ctx context.Context, arg UpdateRestaurantPara
 		arg.CoverImageUrl,
 		arg.ThemeSettings,
 		arg.IsPublished,
+		arg.Timezone,
 		arg.ID,
 		arg.OwnerID,
 		arg.IsAdmin,
@@ -2617,6 +7243,47 @@ func (q *Queries) UpdateRestaurant(ctx context.Context, arg UpdateRestaurantPara
 		&i.RankScore,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.RatingAvg,
+		&i.RatingCount,
+		&i.PlanLocked,
+		&i.Timezone,
+	)
+	return i, err
+}
+
+const updateStaffRole = `-- name: UpdateStaffRole :one
+UPDATE staff_roles
+SET name = $3, description = $4, permissions = $5, updated_at = NOW()
+WHERE id = $1 AND restaurant_id = $2 AND preset IS NULL
+RETURNING id, restaurant_id, name, description, permissions, preset, created_at, updated_at
+`
+
+type UpdateStaffRoleParams struct {
+	ID           uuid.UUID   `db:"id" json:"id"`
+	RestaurantID uuid.UUID   `db:"restaurant_id" json:"restaurant_id"`
+	Name         string      `db:"name" json:"name"`
+	Description  pgtype.Text `db:"description" json:"description"`
+	Permissions  []string    `db:"permissions" json:"permissions"`
+}
+
+func (q *Queries) UpdateStaffRole(ctx context.Context, arg UpdateStaffRoleParams) (StaffRole, error) {
+	row := q.db.QueryRow(ctx, updateStaffRole,
+		arg.ID,
+		arg.RestaurantID,
+		arg.Name,
+		arg.Description,
+		arg.Permissions,
+	)
+	var i StaffRole
+	err := row.Scan(
+		&i.ID,
+		&i.RestaurantID,
+		&i.Name,
+		&i.Description,
+		&i.Permissions,
+		&i.Preset,
+		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
@@ -2647,9 +7314,10 @@ SET
     current_period_end = COALESCE($4, current_period_end),
     trial_end = COALESCE($5, trial_end),
     cancelled_at = COALESCE($6, cancelled_at),
+    billing_interval = COALESCE($7, billing_interval),
     updated_at = NOW()
-WHERE id = $7
-RETURNING id, owner_id, plan_id, status, current_period_start, current_period_end, trial_end, cancelled_at, payment_provider_subscription_id, created_at, updated_at
+WHERE id = $8
+RETURNING id, owner_id, plan_id, status, current_period_start, current_period_end, trial_end, cancelled_at, payment_provider_subscription_id, created_at, updated_at, billing_interval, scheduled_plan_id, scheduled_billing_interval, cancel_at_period_end
 `
 
 type UpdateSubscriptionParams struct {
@@ -2659,6 +7327,7 @@ type UpdateSubscriptionParams struct {
 	CurrentPeriodEnd   pgtype.Timestamp       `db:"current_period_end" json:"current_period_end"`
 	TrialEnd           pgtype.Timestamp       `db:"trial_end" json:"trial_end"`
 	CancelledAt        pgtype.Timestamp       `db:"cancelled_at" json:"cancelled_at"`
+	BillingInterval    NullBillingInterval    `db:"billing_interval" json:"billing_interval"`
 	ID                 uuid.UUID              `db:"id" json:"id"`
 }
 
@@ -2670,6 +7339,7 @@ func (q *Queries) UpdateSubscription(ctx context.Context, arg UpdateSubscription
 		arg.CurrentPeriodEnd,
 		arg.TrialEnd,
 		arg.CancelledAt,
+		arg.BillingInterval,
 		arg.ID,
 	)
 	var i Subscription
@@ -2685,6 +7355,10 @@ func (q *Queries) UpdateSubscription(ctx context.Context, arg UpdateSubscription
 		&i.PaymentProviderSubscriptionID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BillingInterval,
+		&i.ScheduledPlanID,
+		&i.ScheduledBillingInterval,
+		&i.CancelAtPeriodEnd,
 	)
 	return i, err
 }
@@ -2700,7 +7374,7 @@ SET
     is_active = COALESCE($6, is_active),
     updated_at = NOW()
 WHERE id = $7
-RETURNING id, email, password_hash, full_name, role, owner_id, restaurant_id, phone, avatar_url, email_verified, last_login_at, is_active, created_at, updated_at, email_verified_at, verification_token, verification_token_expires_at, trial_ends_at
+RETURNING id, email, password_hash, full_name, role, owner_id, restaurant_id, phone, avatar_url, email_verified, last_login_at, is_active, created_at, updated_at, email_verified_at, verification_token, verification_token_expires_at, trial_ends_at, must_change_password, password_changed_at, staff_role_id
 `
 
 type UpdateUserParams struct {
@@ -2743,10 +7417,33 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.VerificationToken,
 		&i.VerificationTokenExpiresAt,
 		&i.TrialEndsAt,
+		&i.MustChangePassword,
+		&i.PasswordChangedAt,
+		&i.StaffRoleID,
 	)
 	return i, err
 }
 
+const updateUserPassword = `-- name: UpdateUserPassword :exec
+UPDATE users
+SET
+    password_hash = $2,
+    must_change_password = FALSE,
+    password_changed_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1
+`
+
+type UpdateUserPasswordParams struct {
+	ID           uuid.UUID `db:"id" json:"id"`
+	PasswordHash string    `db:"password_hash" json:"password_hash"`
+}
+
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error {
+	_, err := q.db.Exec(ctx, updateUserPassword, arg.ID, arg.PasswordHash)
+	return err
+}
+
 const upsertAnalyticsAggregate = `-- name: UpsertAnalyticsAggregate :one
 INSERT INTO analytics_aggregates (
     restaurant_id, date, hour, metric_type, target_id, value
@@ -2791,3 +7488,117 @@ func (q *Queries) UpsertAnalyticsAggregate(ctx context.Context, arg UpsertAnalyt
 	)
 	return i, err
 }
+
+const upsertReservationSettings = `-- name: UpsertReservationSettings :one
+INSERT INTO reservation_settings (
+    restaurant_id, is_enabled, slot_interval_minutes, dining_duration_minutes, max_party_size, booking_window_days
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (restaurant_id) DO UPDATE SET
+    is_enabled = EXCLUDED.is_enabled,
+    slot_interval_minutes = EXCLUDED.slot_interval_minutes,
+    dining_duration_minutes = EXCLUDED.dining_duration_minutes,
+    max_party_size = EXCLUDED.max_party_size,
+    booking_window_days = EXCLUDED.booking_window_days,
+    updated_at = NOW()
+RETURNING restaurant_id, is_enabled, slot_interval_minutes, dining_duration_minutes, max_party_size, booking_window_days, created_at, updated_at
+`
+
+type UpsertReservationSettingsParams struct {
+	RestaurantID          uuid.UUID `db:"restaurant_id" json:"restaurant_id"`
+	IsEnabled             bool      `db:"is_enabled" json:"is_enabled"`
+	SlotIntervalMinutes   int32     `db:"slot_interval_minutes" json:"slot_interval_minutes"`
+	DiningDurationMinutes int32     `db:"dining_duration_minutes" json:"dining_duration_minutes"`
+	MaxPartySize          int32     `db:"max_party_size" json:"max_party_size"`
+	BookingWindowDays     int32     `db:"booking_window_days" json:"booking_window_days"`
+}
+
+func (q *Queries) UpsertReservationSettings(ctx context.Context, arg UpsertReservationSettingsParams) (ReservationSetting, error) {
+	row := q.db.QueryRow(ctx, upsertReservationSettings,
+		arg.RestaurantID,
+		arg.IsEnabled,
+		arg.SlotIntervalMinutes,
+		arg.DiningDurationMinutes,
+		arg.MaxPartySize,
+		arg.BookingWindowDays,
+	)
+	var i ReservationSetting
+	err := row.Scan(
+		&i.RestaurantID,
+		&i.IsEnabled,
+		&i.SlotIntervalMinutes,
+		&i.DiningDurationMinutes,
+		&i.MaxPartySize,
+		&i.BookingWindowDays,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertUserSession = `-- name: UpsertUserSession :exec
+INSERT INTO user_sessions (
+    id, user_id, ip_address, user_agent, device_type, browser, os, expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+)
+ON CONFLICT (id) DO UPDATE SET
+    ip_address = EXCLUDED.ip_address,
+    user_agent = EXCLUDED.user_agent,
+    device_type = EXCLUDED.device_type,
+    browser = EXCLUDED.browser,
+    os = EXCLUDED.os,
+    expires_at = EXCLUDED.expires_at,
+    last_seen_at = NOW()
+`
+
+type UpsertUserSessionParams struct {
+	ID         uuid.UUID        `db:"id" json:"id"`
+	UserID     uuid.UUID        `db:"user_id" json:"user_id"`
+	IpAddress  pgtype.Text      `db:"ip_address" json:"ip_address"`
+	UserAgent  pgtype.Text      `db:"user_agent" json:"user_agent"`
+	DeviceType pgtype.Text      `db:"device_type" json:"device_type"`
+	Browser    pgtype.Text      `db:"browser" json:"browser"`
+	Os         pgtype.Text      `db:"os" json:"os"`
+	ExpiresAt  pgtype.Timestamp `db:"expires_at" json:"expires_at"`
+}
+
+func (q *Queries) UpsertUserSession(ctx context.Context, arg UpsertUserSessionParams) error {
+	_, err := q.db.Exec(ctx, upsertUserSession,
+		arg.ID,
+		arg.UserID,
+		arg.IpAddress,
+		arg.UserAgent,
+		arg.DeviceType,
+		arg.Browser,
+		arg.Os,
+		arg.ExpiresAt,
+	)
+	return err
+}
+
+const upsertUserTwoFactor = `-- name: UpsertUserTwoFactor :one
+INSERT INTO user_two_factor (user_id, secret)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE SET
+    secret = EXCLUDED.secret,
+    enabled_at = NOW()
+RETURNING user_id, secret, enabled_at
+`
+
+type UpsertUserTwoFactorParams struct {
+	UserID uuid.UUID `db:"user_id" json:"user_id"`
+	Secret string    `db:"secret" json:"secret"`
+}
+
+func (q *Queries) UpsertUserTwoFactor(ctx context.Context, arg UpsertUserTwoFactorParams) (UserTwoFactor, error) {
+	row := q.db.QueryRow(ctx, upsertUserTwoFactor, arg.UserID, arg.Secret)
+	var i UserTwoFactor
+	err := row.Scan(
+		&i.UserID,
+		&i.Secret,
+		&i.EnabledAt,
+	)
+	return i, err
+}
@@ -0,0 +1,22 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type txBeginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// Begin starts a transaction on the connection pool the queries run on. Run queries in
+// it with WithTx. Inside a transaction it starts a savepoint.
+func (q *Queries) Begin(ctx context.Context) (pgx.Tx, error) {
+	db, ok := q.db.(txBeginner)
+	if !ok {
+		return nil, errors.New("database connection does not support transactions")
+	}
+	return db.Begin(ctx)
+}
@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"crypto/rand"
+	"math/big"
+	"strings"
+)
+
+// APIKeyPrefix marks MenuVista API keys so they are easy to spot in logs and secret scanners
+const APIKeyPrefix = "mvk_"
+
+const apiKeyCharset = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// GenerateAPIKey returns a key of the form mvk_<8 char id>_<32 char secret> and its
+// public prefix (mvk_<id>), which is stored in clear to identify the key in listings.
+func GenerateAPIKey() (key string, prefix string, err error) {
+	id, err := randomString(8)
+	if err != nil {
+		return "", "", err
+	}
+	secret, err := randomString(32)
+	if err != nil {
+		return "", "", err
+	}
+	prefix = APIKeyPrefix + id
+	return prefix + "_" + secret, prefix, nil
+}
+
+// IsAPIKey reports whether a credential looks like an API key rather than a JWT
+func IsAPIKey(credential string) bool {
+	return strings.HasPrefix(credential, APIKeyPrefix)
+}
+
+func randomString(length int) (string, error) {
+	b := make([]byte, length)
+	for i := range b {
+		num, err := rand.Int(rand.Reader, big.NewInt(int64(len(apiKeyCharset))))
+		if err != nil {
+			return "", err
+		}
+		b[i] = apiKeyCharset[num.Int64()]
+	}
+	return string(b), nil
+}
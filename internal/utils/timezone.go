@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+
+	// Embedded zone data, so restaurant timezones resolve on hosts without tzdata
+	_ "time/tzdata"
+)
+
+// DefaultTimezone is used for restaurants that have not set a timezone
+const DefaultTimezone = "Africa/Addis_Ababa"
+
+// LoadTimezone validates an IANA timezone name such as "Africa/Addis_Ababa"
+func LoadTimezone(name string) (*time.Location, error) {
+	if name == "" {
+		return nil, fmt.Errorf("timezone is required")
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q", name)
+	}
+	return loc, nil
+}
+
+// LocalWallClock converts t to the restaurant's timezone and drops the location, so it
+// compares directly with TIMESTAMP columns that hold restaurant-local wall-clock time.
+// Unknown timezones fall back to DefaultTimezone.
+func LocalWallClock(t time.Time, timezone string) time.Time {
+	loc, err := LoadTimezone(timezone)
+	if err != nil {
+		loc, _ = time.LoadLocation(DefaultTimezone)
+	}
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+}
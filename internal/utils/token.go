@@ -14,11 +14,14 @@ type TokenDetails struct {
 	RefreshToken string
 	AccessUUID   string
 	RefreshUUID  string
+	FamilyID     string
 	AtExpires    int64
 	RtExpires    int64
 }
 
-func CreateToken(userID uuid.UUID, role string, ownerID *uuid.UUID, restaurantID *uuid.UUID, subStatus string, subEnd *time.Time) (*TokenDetails, error) {
+// CreateToken issues an access/refresh pair. Refresh tokens rotated from the same login share
+// a family ID so the whole chain can be revoked at once; pass "" to start a new family.
+func CreateToken(userID uuid.UUID, role string, ownerID *uuid.UUID, restaurantID *uuid.UUID, subStatus string, subEnd *time.Time, familyID string) (*TokenDetails, error) {
 	td := &TokenDetails{}
 	td.AtExpires = time.Now().Add(time.Minute * 15).Unix()
 	td.AccessUUID = uuid.New().String()
@@ -26,11 +29,17 @@ func CreateToken(userID uuid.UUID, role string, ownerID *uuid.UUID, restaurantID
 	td.RtExpires = time.Now().Add(time.Hour * 24 * 7).Unix()
 	td.RefreshUUID = uuid.New().String()
 
+	td.FamilyID = familyID
+	if td.FamilyID == "" {
+		td.FamilyID = uuid.New().String()
+	}
+
 	var err error
 	// Creating Access Token
 	atClaims := jwt.MapClaims{}
 	atClaims["authorized"] = true
 	atClaims["access_uuid"] = td.AccessUUID
+	atClaims["family_id"] = td.FamilyID
 	atClaims["user_id"] = UUIDToString(userID)
 	atClaims["role"] = role
 	if ownerID != nil {
@@ -55,6 +64,7 @@ func CreateToken(userID uuid.UUID, role string, ownerID *uuid.UUID, restaurantID
 	// Creating Refresh Token
 	rtClaims := jwt.MapClaims{}
 	rtClaims["refresh_uuid"] = td.RefreshUUID
+	rtClaims["family_id"] = td.FamilyID
 	rtClaims["user_id"] = UUIDToString(userID)
 	rtClaims["exp"] = td.RtExpires
 	rt := jwt.NewWithClaims(jwt.SigningMethodHS256, rtClaims)
@@ -78,3 +88,36 @@ func ValidateToken(tokenString string) (*jwt.Token, error) {
 	}
 	return token, nil
 }
+
+type RefreshClaims struct {
+	RefreshUUID string
+	FamilyID    string
+	UserID      uuid.UUID
+}
+
+// ParseRefreshToken validates a refresh JWT and extracts its claims. Access tokens are rejected.
+func ParseRefreshToken(tokenString string) (*RefreshClaims, error) {
+	token, err := ValidateToken(tokenString)
+	if err != nil || !token.Valid {
+		return nil, fmt.Errorf("invalid refresh token: %w", err)
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, fmt.Errorf("invalid refresh token claims")
+	}
+
+	refreshUUID, _ := claims["refresh_uuid"].(string)
+	familyID, _ := claims["family_id"].(string)
+	userIDStr, _ := claims["user_id"].(string)
+	userID := ParseUUID(userIDStr)
+	if refreshUUID == "" || familyID == "" || userID == uuid.Nil {
+		return nil, fmt.Errorf("invalid refresh token claims")
+	}
+
+	return &RefreshClaims{
+		RefreshUUID: refreshUUID,
+		FamilyID:    familyID,
+		UserID:      userID,
+	}, nil
+}
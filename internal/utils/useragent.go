@@ -0,0 +1,104 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+// UserAgentInfo is the coarse device description shown in session and activity lists
+type UserAgentInfo struct {
+	DeviceType string // desktop, mobile, tablet, bot or unknown
+	Browser    string
+	OS         string
+}
+
+type uaPattern struct {
+	name string
+	re   *regexp.Regexp
+}
+
+// Order matters: Chromium based browsers also announce Chrome and Safari, and
+// Chrome announces Safari, so the more specific tokens are tried first.
+var browserPatterns = []uaPattern{
+	{"Edge", regexp.MustCompile(`Edg(?:e|A|iOS)?/(\d+)`)},
+	{"Opera", regexp.MustCompile(`(?:OPR|Opera)/(\d+)`)},
+	{"Samsung Internet", regexp.MustCompile(`SamsungBrowser/(\d+)`)},
+	{"Firefox", regexp.MustCompile(`(?:Firefox|FxiOS)/(\d+)`)},
+	{"Chrome", regexp.MustCompile(`(?:Chrome|CriOS)/(\d+)`)},
+	{"Safari", regexp.MustCompile(`Version/(\d+)[\d.]* (?:Mobile/\S+ )?Safari/`)},
+	{"Internet Explorer", regexp.MustCompile(`(?:MSIE |Trident/.*rv:)(\d+)`)},
+	{"Postman", regexp.MustCompile(`PostmanRuntime/(\d+)`)},
+	{"curl", regexp.MustCompile(`curl/(\d+)`)},
+	{"OkHttp", regexp.MustCompile(`okhttp/(\d+)`)},
+}
+
+var (
+	iosVersion     = regexp.MustCompile(`(?:iPhone|CPU) OS (\d+)(?:_(\d+))?`)
+	androidVersion = regexp.MustCompile(`Android (\d+(?:\.\d+)?)`)
+	macVersion     = regexp.MustCompile(`Mac OS X (\d+)[_.](\d+)`)
+	botPattern     = regexp.MustCompile(`(?i)bot|crawler|spider|slurp|facebookexternalhit|preview`)
+)
+
+// ParseUserAgent extracts device type, browser and OS from a User-Agent header. It only
+// covers the clients MenuVista sees in practice and falls back to "Unknown".
+func ParseUserAgent(ua string) UserAgentInfo {
+	info := UserAgentInfo{DeviceType: "unknown", Browser: "Unknown", OS: "Unknown"}
+	if ua == "" {
+		return info
+	}
+
+	for _, p := range browserPatterns {
+		if m := p.re.FindStringSubmatch(ua); m != nil {
+			info.Browser = p.name + " " + m[1]
+			break
+		}
+	}
+
+	lower := strings.ToLower(ua)
+	switch {
+	case strings.Contains(ua, "iPad"):
+		info.OS = "iPadOS" + versionSuffix(iosVersion.FindStringSubmatch(ua))
+	case strings.Contains(ua, "iPhone") || strings.Contains(ua, "iPod"):
+		info.OS = "iOS" + versionSuffix(iosVersion.FindStringSubmatch(ua))
+	case strings.Contains(ua, "Android"):
+		info.OS = "Android"
+		if m := androidVersion.FindStringSubmatch(ua); m != nil {
+			info.OS += " " + m[1]
+		}
+	case strings.Contains(ua, "Windows Phone"):
+		info.OS = "Windows Phone"
+	case strings.Contains(ua, "Windows"):
+		info.OS = "Windows"
+	case strings.Contains(ua, "CrOS"):
+		info.OS = "ChromeOS"
+	case strings.Contains(ua, "Mac OS X"):
+		info.OS = "macOS" + versionSuffix(macVersion.FindStringSubmatch(ua))
+	case strings.Contains(ua, "Linux"):
+		info.OS = "Linux"
+	}
+
+	switch {
+	case botPattern.MatchString(ua):
+		info.DeviceType = "bot"
+	case strings.Contains(ua, "iPad") || strings.Contains(lower, "tablet") ||
+		(strings.Contains(ua, "Android") && !strings.Contains(ua, "Mobile")):
+		info.DeviceType = "tablet"
+	case strings.Contains(ua, "Mobi") || strings.Contains(ua, "iPhone") || strings.Contains(ua, "iPod") ||
+		strings.Contains(ua, "Windows Phone"):
+		info.DeviceType = "mobile"
+	case info.OS != "Unknown" || info.Browser != "Unknown":
+		info.DeviceType = "desktop"
+	}
+
+	return info
+}
+
+func versionSuffix(m []string) string {
+	if m == nil {
+		return ""
+	}
+	if len(m) > 2 && m[2] != "" {
+		return " " + m[1] + "." + m[2]
+	}
+	return " " + m[1]
+}
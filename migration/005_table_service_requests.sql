@@ -0,0 +1,35 @@
+-- Migration: Add table QR tokens and guest service requests
+-- Version: 005
+-- Description: Tables with QR tokens per restaurant and "call waiter" / "request bill" requests raised from them
+
+CREATE TYPE service_request_type AS ENUM ('call_waiter', 'request_bill', 'custom');
+CREATE TYPE service_request_status AS ENUM ('open', 'acknowledged');
+
+-- Restaurant Tables (one QR code per physical table)
+CREATE TABLE restaurant_tables (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    restaurant_id UUID NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+    label VARCHAR(100) NOT NULL,
+    token VARCHAR(64) NOT NULL UNIQUE,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_restaurant_tables_restaurant_id ON restaurant_tables(restaurant_id);
+
+-- Service Requests raised by guests from a table
+CREATE TABLE service_requests (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    restaurant_id UUID NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+    table_id UUID NOT NULL REFERENCES restaurant_tables(id) ON DELETE CASCADE,
+    request_type service_request_type NOT NULL,
+    note TEXT,
+    status service_request_status NOT NULL DEFAULT 'open',
+    acknowledged_by UUID REFERENCES users(id),
+    acknowledged_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_service_requests_open ON service_requests(restaurant_id, created_at) WHERE status = 'open';
+CREATE INDEX idx_service_requests_acknowledged ON service_requests(restaurant_id, acknowledged_at) WHERE acknowledged_at IS NOT NULL;
@@ -0,0 +1,58 @@
+-- Migration: Add reservations
+-- Version: 006
+-- Description: Opening hours, reservation settings, table seat counts and guest reservations
+
+CREATE TYPE reservation_status AS ENUM ('pending', 'confirmed', 'seated', 'no_show', 'cancelled');
+
+-- Seat count per table, used to fit parties into slots
+ALTER TABLE restaurant_tables
+ADD COLUMN seats INTEGER NOT NULL DEFAULT 4;
+
+-- Opening Hours (day_of_week: 0 = Sunday ... 6 = Saturday, a day may have several periods)
+CREATE TABLE restaurant_opening_hours (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    restaurant_id UUID NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+    day_of_week INTEGER NOT NULL CHECK (day_of_week BETWEEN 0 AND 6),
+    opens_at TIME NOT NULL,
+    closes_at TIME NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_restaurant_opening_hours_restaurant_id ON restaurant_opening_hours(restaurant_id, day_of_week);
+
+-- Reservation Settings (one row per restaurant)
+CREATE TABLE reservation_settings (
+    restaurant_id UUID PRIMARY KEY REFERENCES restaurants(id) ON DELETE CASCADE,
+    is_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    slot_interval_minutes INTEGER NOT NULL DEFAULT 30,
+    dining_duration_minutes INTEGER NOT NULL DEFAULT 90,
+    max_party_size INTEGER NOT NULL DEFAULT 8,
+    booking_window_days INTEGER NOT NULL DEFAULT 30,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Reservations
+CREATE TABLE reservations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    restaurant_id UUID NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+    table_id UUID REFERENCES restaurant_tables(id) ON DELETE SET NULL,
+    guest_name VARCHAR(255) NOT NULL,
+    guest_phone VARCHAR(50) NOT NULL,
+    guest_email VARCHAR(255) NOT NULL,
+    party_size INTEGER NOT NULL,
+    reserved_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    notes TEXT,
+    status reservation_status NOT NULL DEFAULT 'pending',
+    confirmation_token VARCHAR(64) NOT NULL UNIQUE,
+    cancellation_token VARCHAR(64) NOT NULL UNIQUE,
+    confirmed_at TIMESTAMP,
+    seated_at TIMESTAMP,
+    cancelled_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_reservations_restaurant_day ON reservations(restaurant_id, reserved_at);
+CREATE INDEX idx_reservations_table_window ON reservations(table_id, reserved_at, ends_at);
@@ -0,0 +1,37 @@
+-- Migration: Add customer reviews and ratings
+-- Version: 007
+-- Description: Reviews on restaurants and menu items with owner replies, moderation and cached rating aggregates
+
+-- Cached aggregates (recomputed whenever a review is added, hidden or restored)
+ALTER TABLE restaurants
+ADD COLUMN rating_avg DECIMAL(3, 2) NOT NULL DEFAULT 0,
+ADD COLUMN rating_count INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE menu_items
+ADD COLUMN rating_avg DECIMAL(3, 2) NOT NULL DEFAULT 0,
+ADD COLUMN rating_count INTEGER NOT NULL DEFAULT 0;
+
+-- Reviews Table (menu_item_id is NULL for reviews of the restaurant itself)
+CREATE TABLE reviews (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    restaurant_id UUID NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+    menu_item_id UUID REFERENCES menu_items(id) ON DELETE CASCADE,
+    reviewer_name VARCHAR(100) NOT NULL,
+    rating INTEGER NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    comment TEXT,
+    photo_url TEXT,
+    owner_reply TEXT,
+    replied_by UUID REFERENCES users(id),
+    replied_at TIMESTAMP,
+    is_hidden BOOLEAN NOT NULL DEFAULT FALSE,
+    hidden_reason TEXT,
+    report_count INTEGER NOT NULL DEFAULT 0,
+    ip_address VARCHAR(45),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_reviews_restaurant_id ON reviews(restaurant_id, created_at DESC);
+CREATE INDEX idx_reviews_menu_item_id ON reviews(menu_item_id, created_at DESC) WHERE menu_item_id IS NOT NULL;
+CREATE INDEX idx_reviews_reported ON reviews(report_count DESC) WHERE report_count > 0;
+CREATE INDEX idx_restaurants_rating ON restaurants(rating_avg DESC, rating_count DESC);
@@ -0,0 +1,15 @@
+-- Migration: Add inventory tracking to menu items
+-- Version: 008
+-- Description: Optional stock levels, low-stock alerts and temporary "86" unavailability per menu item
+
+-- Stock tracking is opt-in per item; untracked items keep relying on the is_available toggle
+ALTER TABLE menu_items
+ADD COLUMN track_stock BOOLEAN NOT NULL DEFAULT FALSE,
+ADD COLUMN stock_quantity INTEGER NOT NULL DEFAULT 0 CHECK (stock_quantity >= 0),
+ADD COLUMN low_stock_threshold INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN low_stock_notified_at TIMESTAMP,
+-- Set when an item is 86'd; the item becomes available again once this wall-clock time passes
+ADD COLUMN unavailable_until TIMESTAMP;
+
+CREATE INDEX idx_menu_items_unavailable_until ON menu_items(restaurant_id, unavailable_until) WHERE unavailable_until IS NOT NULL;
+CREATE INDEX idx_menu_items_low_stock ON menu_items(restaurant_id) WHERE track_stock = TRUE AND stock_quantity <= low_stock_threshold;
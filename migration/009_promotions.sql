@@ -0,0 +1,35 @@
+-- Migration: Add promotions
+-- Version: 009
+-- Description: Time-bound percentage or fixed discounts on a whole restaurant, a category or a single item
+
+CREATE TYPE promotion_scope AS ENUM ('restaurant', 'category', 'item');
+CREATE TYPE discount_type AS ENUM ('percentage', 'fixed');
+
+-- Promotions Table
+-- A promotion is live when it is active, today falls within [starts_on, ends_on] (either bound optional),
+-- today's weekday is in days_of_week (empty = every day) and the current time is within
+-- [start_time, end_time) (both NULL = all day; end before start wraps past midnight).
+CREATE TABLE promotions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    restaurant_id UUID NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    label VARCHAR(100) NOT NULL,
+    scope promotion_scope NOT NULL,
+    category_id UUID REFERENCES categories(id) ON DELETE CASCADE,
+    menu_item_id UUID REFERENCES menu_items(id) ON DELETE CASCADE,
+    discount_type discount_type NOT NULL,
+    discount_value DECIMAL(10, 2) NOT NULL CHECK (discount_value > 0),
+    starts_on DATE,
+    ends_on DATE,
+    days_of_week INTEGER[] NOT NULL DEFAULT '{}',
+    start_time TIME,
+    end_time TIME,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (scope <> 'category' OR category_id IS NOT NULL),
+    CHECK (scope <> 'item' OR menu_item_id IS NOT NULL)
+);
+
+CREATE INDEX idx_promotions_restaurant_id ON promotions(restaurant_id, is_active);
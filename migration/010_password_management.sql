@@ -0,0 +1,7 @@
+-- Migration: Password management
+-- Version: 010
+-- Description: Track password changes and force generated passwords to be replaced on first login
+
+ALTER TABLE users
+ADD COLUMN must_change_password BOOLEAN NOT NULL DEFAULT FALSE,
+ADD COLUMN password_changed_at TIMESTAMP;
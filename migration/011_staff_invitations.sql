@@ -0,0 +1,24 @@
+-- Migration: Invite staff instead of emailing generated passwords
+-- Version: 011
+-- Description: Staff invitations with expiry; the invitee chooses their own password when accepting
+
+CREATE TYPE invitation_status AS ENUM ('pending', 'accepted', 'revoked');
+
+CREATE TABLE staff_invitations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    restaurant_id UUID NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    full_name VARCHAR(255) NOT NULL,
+    phone VARCHAR(20),
+    token_hash VARCHAR(64) NOT NULL UNIQUE, -- SHA-256 of the emailed token
+    status invitation_status NOT NULL DEFAULT 'pending',
+    expires_at TIMESTAMP NOT NULL,
+    accepted_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    accepted_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_staff_invitations_restaurant_id ON staff_invitations(restaurant_id, created_at DESC);
+CREATE UNIQUE INDEX idx_staff_invitations_pending_email ON staff_invitations(restaurant_id, LOWER(email)) WHERE status = 'pending';
@@ -0,0 +1,31 @@
+-- Migration: TOTP two-factor authentication
+-- Version: 012
+-- Description: TOTP secrets and hashed recovery codes for owners and admins, plus platform-wide security settings
+
+-- One row per user with 2FA enabled (secrets awaiting verification live in Redis)
+CREATE TABLE user_two_factor (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret VARCHAR(64) NOT NULL,
+    enabled_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Recovery Codes (bcrypt hashed, single use)
+CREATE TABLE user_recovery_codes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash VARCHAR(255) NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_user_recovery_codes_user_id ON user_recovery_codes(user_id) WHERE used_at IS NULL;
+
+-- Platform Settings (single row, managed by admins)
+CREATE TABLE platform_settings (
+    id INTEGER PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+    require_admin_two_factor BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO platform_settings (id) VALUES (1) ON CONFLICT (id) DO NOTHING;
@@ -0,0 +1,21 @@
+-- Migration: Authentication audit trail
+-- Version: 013
+-- Description: Record successful and failed logins, lockouts and admin unlocks
+
+CREATE TYPE auth_event AS ENUM ('login_success', 'login_failure', 'account_locked', 'account_unlocked');
+
+CREATE TABLE auth_audit_logs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL, -- NULL when the email matched no account
+    email VARCHAR(255) NOT NULL,
+    event auth_event NOT NULL,
+    reason TEXT,
+    ip_address VARCHAR(45),
+    user_agent TEXT,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL, -- Admin who performed an unlock
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_auth_audit_logs_user_id ON auth_audit_logs(user_id, created_at DESC);
+CREATE INDEX idx_auth_audit_logs_email ON auth_audit_logs(email, created_at DESC);
+CREATE INDEX idx_auth_audit_logs_ip_address ON auth_audit_logs(ip_address, created_at DESC);
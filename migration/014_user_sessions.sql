@@ -0,0 +1,19 @@
+-- Migration: User sessions
+-- Version: 014
+-- Description: One row per login (refresh token family) with device details and last activity
+
+CREATE TABLE user_sessions (
+    id UUID PRIMARY KEY, -- The token family ID carried by every token of the session
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    ip_address VARCHAR(45),
+    user_agent TEXT,
+    device_type VARCHAR(20),
+    browser VARCHAR(50),
+    os VARCHAR(50),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP
+);
+
+CREATE INDEX idx_user_sessions_user_id ON user_sessions(user_id, last_seen_at DESC) WHERE revoked_at IS NULL;
@@ -0,0 +1,45 @@
+-- Migration: Staff roles and permissions
+-- Version: 015
+-- Description: Per-restaurant roles holding named permissions; every staff member is assigned one role
+
+CREATE TABLE staff_roles (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    restaurant_id UUID NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    permissions TEXT[] NOT NULL DEFAULT '{}',
+    preset VARCHAR(50), -- Set for the built-in roles seeded with the restaurant; those cannot be edited
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_staff_roles_name ON staff_roles(restaurant_id, LOWER(name));
+CREATE UNIQUE INDEX idx_staff_roles_preset ON staff_roles(restaurant_id, preset) WHERE preset IS NOT NULL;
+
+ALTER TABLE users ADD COLUMN staff_role_id UUID REFERENCES staff_roles(id) ON DELETE SET NULL;
+ALTER TABLE staff_invitations ADD COLUMN staff_role_id UUID REFERENCES staff_roles(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_users_staff_role_id ON users(staff_role_id) WHERE staff_role_id IS NOT NULL;
+
+-- Seed the built-in roles for existing restaurants (new restaurants get them on creation)
+INSERT INTO staff_roles (restaurant_id, name, description, permissions, preset)
+SELECT r.id, p.name, p.description, p.permissions, p.preset
+FROM restaurants r
+CROSS JOIN (VALUES
+    ('floor_staff', 'Floor staff', 'Handles table service requests, reservations and item availability',
+        ARRAY['menu.view', 'menu.availability', 'service_requests.handle', 'reservations.manage']),
+    ('menu_editor', 'Menu editor', 'Manages categories, items and availability',
+        ARRAY['menu.view', 'menu.edit', 'menu.availability']),
+    ('availability_only', 'Availability only', 'Updates stock levels and 86s items',
+        ARRAY['menu.view', 'menu.availability']),
+    ('analytics_viewer', 'Analytics viewer', 'Read-only access to analytics, activity and reviews',
+        ARRAY['analytics.view', 'activity.view', 'reviews.view'])
+) AS p(preset, name, description, permissions);
+
+-- Existing staff keep what they could already do
+UPDATE users u
+SET staff_role_id = sr.id
+FROM staff_roles sr
+WHERE u.role = 'staff'
+  AND sr.restaurant_id = u.restaurant_id
+  AND sr.preset = 'floor_staff';
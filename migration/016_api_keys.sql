@@ -0,0 +1,20 @@
+-- Migration: API keys
+-- Version: 016
+-- Description: Owner-managed keys for third-party integrations, scoped to one restaurant and a set of permissions
+
+CREATE TABLE api_keys (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    restaurant_id UUID NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+    created_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    key_prefix VARCHAR(20) NOT NULL UNIQUE, -- Public part shown in listings, e.g. mvk_3f9a2c1b
+    key_hash VARCHAR(64) NOT NULL UNIQUE, -- SHA-256 of the full key
+    permissions TEXT[] NOT NULL DEFAULT '{}',
+    expires_at TIMESTAMP,
+    last_used_at TIMESTAMP,
+    last_used_ip VARCHAR(45),
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_api_keys_restaurant_id ON api_keys(restaurant_id, created_at DESC);
@@ -0,0 +1,16 @@
+-- Migration: External identities
+-- Version: 017
+-- Description: Links users to accounts at OpenID Connect providers (e.g. Google) for single sign-on
+
+CREATE TABLE user_identities (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider VARCHAR(50) NOT NULL, -- Provider name from OIDC_PROVIDERS, e.g. google
+    subject VARCHAR(255) NOT NULL, -- The provider's stable "sub" claim
+    email VARCHAR(255), -- Email the provider reported when the identity was linked
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_login_at TIMESTAMP,
+    UNIQUE (provider, subject)
+);
+
+CREATE INDEX idx_user_identities_user_id ON user_identities(user_id);
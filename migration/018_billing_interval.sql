@@ -0,0 +1,8 @@
+-- Migration: Billing interval
+-- Version: 018
+-- Description: Lets owners pay monthly or annually; annual subscriptions are charged price_annual for a one year period
+
+CREATE TYPE billing_interval AS ENUM ('monthly', 'annual');
+
+ALTER TABLE subscriptions
+ADD COLUMN billing_interval billing_interval NOT NULL DEFAULT 'monthly';
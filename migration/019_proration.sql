@@ -0,0 +1,11 @@
+-- Migration: Proration and scheduled plan changes
+-- Version: 019
+-- Description: Downgrades wait for the end of the paid period; upgrade invoices record the credit for unused time
+
+ALTER TABLE subscriptions
+ADD COLUMN scheduled_plan_id UUID REFERENCES subscription_plans(id),
+ADD COLUMN scheduled_billing_interval billing_interval; -- Set together with scheduled_plan_id
+
+ALTER TABLE invoices
+ADD COLUMN prorated BOOLEAN NOT NULL DEFAULT FALSE, -- Mid-cycle change that credited unused time; the invoiced period is kept on payment
+ADD COLUMN credit_amount DECIMAL(10, 2) NOT NULL DEFAULT 0; -- Unused time on the previous plan deducted from amount
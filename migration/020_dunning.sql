@@ -0,0 +1,11 @@
+-- Migration: Dunning for failed and expired renewals
+-- Version: 020
+-- Description: One open retry job per owner and an index for the worker's due-job scan
+
+-- A subscription is only dunned once at a time. CreatePaymentRetryJob also skips owners who
+-- already have an open job, so scheduling again while one runs is a no-op.
+CREATE UNIQUE INDEX idx_payment_retry_jobs_open_subscription
+ON payment_retry_jobs(subscription_id)
+WHERE status IN ('pending', 'retrying');
+
+CREATE INDEX idx_payment_retry_jobs_due ON payment_retry_jobs(status, scheduled_for);
@@ -0,0 +1,14 @@
+-- Migration: Subscription notification ledger
+-- Version: 021
+-- Description: Records trial and renewal reminders so each is sent once per subscription period
+
+CREATE TABLE subscription_notifications (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    subscription_id UUID NOT NULL REFERENCES subscriptions(id) ON DELETE CASCADE,
+    kind VARCHAR(50) NOT NULL, -- trial_warning_7d, trial_expired, renewal_warning_1d, ...
+    period_end TIMESTAMP NOT NULL, -- current_period_end the notification was about
+    sent_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (subscription_id, kind, period_end)
+);
+
+CREATE INDEX idx_subscriptions_status_period_end ON subscriptions(status, current_period_end);
@@ -0,0 +1,31 @@
+-- Migration: Free tier fallback
+-- Version: 022
+-- Description: Owners whose trial or paid subscription ends drop to a free plan; content above its limits is hidden until they renew
+
+-- Not listed for purchase (is_active = FALSE); only assigned by the subscription lifecycle job
+INSERT INTO subscription_plans (name, slug, description, price_monthly, price_annual, currency, features, display_order, is_active)
+VALUES (
+    'Free',
+    'free',
+    'Basic listing for owners without a paid plan.',
+    0,
+    0,
+    'ETB',
+    '{
+        "max_restaurants": 1,
+        "max_categories": 5,
+        "max_menu_items": 20,
+        "max_staff_accounts": 0,
+        "activity_log_enabled": false,
+        "analytics_enabled": false
+    }'::jsonb,
+    99,
+    FALSE
+)
+ON CONFLICT (slug) DO NOTHING;
+
+-- plan_locked marks content hidden because it is above the owner's plan limits, so exactly
+-- that content is shown again when the owner renews
+ALTER TABLE restaurants ADD COLUMN plan_locked BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE categories ADD COLUMN plan_locked BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE menu_items ADD COLUMN plan_locked BOOLEAN NOT NULL DEFAULT FALSE;
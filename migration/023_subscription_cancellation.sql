@@ -0,0 +1,20 @@
+-- Migration: Self-service subscription cancellation
+-- Version: 023
+-- Description: Owners can cancel at the end of the period or immediately and resume before the period ends; reasons are kept for churn analysis
+
+ALTER TABLE subscriptions ADD COLUMN cancel_at_period_end BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE subscription_cancellations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    subscription_id UUID NOT NULL REFERENCES subscriptions(id) ON DELETE CASCADE,
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    plan_id UUID NOT NULL REFERENCES subscription_plans(id),
+    reason VARCHAR(50) NOT NULL, -- too_expensive, missing_features, switched_service, not_using, technical_issues, other
+    feedback TEXT,
+    immediate BOOLEAN NOT NULL DEFAULT FALSE,
+    resumed_at TIMESTAMP, -- set when a cancellation at period end is taken back
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_subscription_cancellations_subscription ON subscription_cancellations(subscription_id);
+CREATE INDEX idx_subscription_cancellations_reason ON subscription_cancellations(reason, created_at);
@@ -0,0 +1,42 @@
+-- Migration: Subscription coupons
+-- Version: 024
+-- Description: Admin-managed promo codes owners can apply at subscription checkout, with a ledger of discounted invoices
+
+-- Coupons Table
+-- plan_ids restricts the coupon to those plans (empty = any plan). duration_cycles is how many
+-- paid billing cycles an owner gets the discount for once they redeem it (NULL = every cycle).
+-- max_redemptions caps the number of owners who can redeem it; expires_at only stops new owners.
+CREATE TABLE coupons (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    code VARCHAR(50) NOT NULL UNIQUE,
+    description TEXT,
+    discount_type discount_type NOT NULL,
+    discount_value DECIMAL(10, 2) NOT NULL CHECK (discount_value > 0),
+    plan_ids UUID[] NOT NULL DEFAULT '{}',
+    max_redemptions INTEGER CHECK (max_redemptions > 0),
+    redemption_count INTEGER NOT NULL DEFAULT 0,
+    duration_cycles INTEGER CHECK (duration_cycles > 0),
+    expires_at TIMESTAMP,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (discount_type <> 'percentage' OR discount_value <= 100)
+);
+
+-- Coupon Redemptions Table
+-- One row per paid invoice the coupon discounted; an owner's rows for a coupon are the cycles used
+CREATE TABLE coupon_redemptions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    coupon_id UUID NOT NULL REFERENCES coupons(id),
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    invoice_id UUID NOT NULL UNIQUE REFERENCES invoices(id) ON DELETE CASCADE,
+    discount_amount DECIMAL(10, 2) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_coupon_redemptions_owner ON coupon_redemptions(owner_id, coupon_id);
+CREATE INDEX idx_coupon_redemptions_coupon ON coupon_redemptions(coupon_id, created_at);
+
+ALTER TABLE invoices ADD COLUMN coupon_id UUID REFERENCES coupons(id);
+ALTER TABLE invoices ADD COLUMN discount_amount DECIMAL(10, 2) NOT NULL DEFAULT 0;
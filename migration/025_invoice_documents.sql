@@ -0,0 +1,30 @@
+-- Migration: Invoice documents
+-- Version: 025
+-- Description: Sequential invoice numbers given on payment, payment references kept apart from them, and VAT on invoices
+
+-- The payment reference used to live in invoice_number; it gets its own column
+ALTER TABLE invoices ADD COLUMN tx_ref VARCHAR(100);
+UPDATE invoices SET tx_ref = invoice_number;
+ALTER TABLE invoices ALTER COLUMN tx_ref SET NOT NULL;
+CREATE UNIQUE INDEX idx_invoices_tx_ref ON invoices(tx_ref);
+
+-- Invoice numbers (INV-<year>-<sequence>) are given when an invoice is paid, so abandoned
+-- checkouts leave no gaps. Invoices already paid are numbered in the order they were paid.
+CREATE SEQUENCE invoice_number_seq;
+
+ALTER TABLE invoices ALTER COLUMN invoice_number DROP NOT NULL;
+UPDATE invoices SET invoice_number = NULL WHERE paid_at IS NULL;
+
+UPDATE invoices i
+SET invoice_number = 'INV-' || TO_CHAR(numbered.paid_at, 'YYYY') || '-' || LPAD(numbered.n::text, 6, '0')
+FROM (
+    SELECT id, paid_at, ROW_NUMBER() OVER (ORDER BY paid_at, created_at) AS n
+    FROM invoices
+    WHERE paid_at IS NOT NULL
+) numbered
+WHERE i.id = numbered.id;
+
+SELECT setval('invoice_number_seq', COUNT(*) + 1, FALSE) FROM invoices WHERE paid_at IS NOT NULL;
+
+-- VAT rate (percent) included in the invoice amount, fixed when the invoice is issued
+ALTER TABLE invoices ADD COLUMN vat_rate DECIMAL(5, 2) NOT NULL DEFAULT 15.00;
@@ -0,0 +1,31 @@
+-- Migration: Refunds and credit notes
+-- Version: 026
+-- Description: Admin refunds through the payment provider, recorded as credit notes against the original invoice
+
+-- Running totals of what has been refunded. An invoice becomes 'refunded' once all of it has been
+-- returned; partially refunded invoices stay 'paid'. Transactions are 'partially_refunded' or 'refunded'.
+ALTER TABLE invoices ADD COLUMN refunded_amount DECIMAL(10, 2) NOT NULL DEFAULT 0;
+ALTER TABLE payment_transactions ADD COLUMN refunded_amount DECIMAL(10, 2) NOT NULL DEFAULT 0;
+
+-- Credit notes are numbered CN-<year>-<sequence>, like invoices
+CREATE SEQUENCE credit_note_number_seq;
+
+-- Credit Notes Table
+-- One row per refund issued; vat_rate is copied from the invoice so the credit reverses the same VAT
+CREATE TABLE credit_notes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    credit_note_number VARCHAR(50) NOT NULL UNIQUE,
+    invoice_id UUID NOT NULL REFERENCES invoices(id) ON DELETE CASCADE,
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    amount DECIMAL(10, 2) NOT NULL CHECK (amount > 0),
+    currency VARCHAR(3) NOT NULL,
+    vat_rate DECIMAL(5, 2) NOT NULL,
+    reason TEXT NOT NULL,
+    provider_refund_ref VARCHAR(255),
+    subscription_terminated BOOLEAN NOT NULL DEFAULT FALSE,
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_credit_notes_invoice ON credit_notes(invoice_id, created_at);
+CREATE INDEX idx_credit_notes_owner ON credit_notes(owner_id);
@@ -0,0 +1,7 @@
+-- Migration: Restaurant timezone
+-- Version: 027
+-- Description: Stores the IANA timezone each restaurant operates in so opening hours,
+-- reservations, 86'd items and promotion schedules compare against local time
+
+ALTER TABLE restaurants
+ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'Africa/Addis_Ababa';
@@ -0,0 +1,9 @@
+-- Migration: Menu item availability before plan locks
+-- Version: 028
+-- Description: Remembers whether a menu item was available when it was hidden for being
+-- above the plan limits, so items the owner switched off stay off when the lock is lifted
+
+ALTER TABLE menu_items ADD COLUMN was_available_before_lock BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Items locked before this migration were restored from their stock and 86 state
+UPDATE menu_items SET was_available_before_lock = TRUE WHERE plan_locked = TRUE;
@@ -0,0 +1,19 @@
+-- Migration: Coupon reservations
+-- Version: 029
+-- Description: Holds a coupon redemption for an owner while their checkout is open, so
+-- checkouts cannot discount more owners than max_redemptions allows
+
+-- A reservation is counted in coupons.redemption_count when it is made. Paying the
+-- checkout turns it into a redemption; a failed or abandoned checkout gives it back.
+CREATE TABLE coupon_reservations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    coupon_id UUID NOT NULL REFERENCES coupons(id),
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    tx_ref VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (coupon_id, owner_id)
+);
+
+CREATE INDEX idx_coupon_reservations_tx_ref ON coupon_reservations(tx_ref);
+CREATE INDEX idx_coupon_reservations_expires_at ON coupon_reservations(expires_at);
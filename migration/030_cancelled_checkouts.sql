@@ -0,0 +1,6 @@
+-- Migration: Cancelled checkouts
+-- Version: 030
+-- Description: Checkouts replaced by a newer renewal reminder are cancelled, so an owner
+-- in dunning only has one open checkout for their renewal
+
+ALTER TYPE invoice_status ADD VALUE IF NOT EXISTS 'cancelled';
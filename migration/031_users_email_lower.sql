@@ -0,0 +1,6 @@
+-- Migration: Case-insensitive email lookup
+-- Version: 031
+-- Description: Users are looked up by email regardless of case, so sign-in matches
+-- however the address was typed at registration
+
+CREATE INDEX idx_users_email_lower ON users(LOWER(email));
@@ -7,7 +7,7 @@ INSERT INTO users (
 
 -- name: GetUserByEmail :one
 SELECT * FROM users
-WHERE email = $1 AND deleted_at IS NULL LIMIT 1;
+WHERE LOWER(email) = LOWER($1) AND deleted_at IS NULL LIMIT 1;
 
 -- name: GetUserByID :one
 SELECT * FROM users
@@ -107,6 +107,7 @@ SET
     cover_image_url = COALESCE(sqlc.narg('cover_image_url'), cover_image_url),
     theme_settings = COALESCE(sqlc.narg('theme_settings'), theme_settings),
     is_published = COALESCE(sqlc.narg('is_published'), is_published),
+    timezone = COALESCE(sqlc.narg('timezone'), timezone),
     updated_at = NOW()
 WHERE id = sqlc.arg('id') AND (owner_id = sqlc.arg('owner_id') OR sqlc.arg('is_admin')::boolean)
 RETURNING *;
@@ -123,7 +124,12 @@ WHERE
     (sqlc.narg('is_published')::boolean IS NULL OR is_published = sqlc.narg('is_published')) AND
     (sqlc.narg('search')::text IS NULL OR name ILIKE '%' || sqlc.narg('search') || '%') AND
     deleted_at IS NULL
-ORDER BY created_at DESC
+ORDER BY
+    -- Bayesian average: a handful of 5-star reviews should not outrank a long track record
+    CASE WHEN sqlc.narg('sort_by')::text = 'rating' THEN (rating_avg * rating_count + 3.5 * 5) / (rating_count + 5) END DESC,
+    CASE WHEN sqlc.narg('sort_by')::text = 'reviews' THEN rating_count END DESC,
+    CASE WHEN sqlc.narg('sort_by')::text = 'popular' THEN view_count END DESC NULLS LAST,
+    created_at DESC
 LIMIT $1 OFFSET $2;
 
 -- name: CountRestaurantsWithFilters :one
@@ -330,9 +336,9 @@ WHERE slug = $1 LIMIT 1;
 
 -- name: CreateSubscription :one
 INSERT INTO subscriptions (
-    owner_id, plan_id, status, current_period_start, current_period_end, trial_end
+    owner_id, plan_id, status, current_period_start, current_period_end, trial_end, billing_interval
 ) VALUES (
-    $1, $2, $3, $4, $5, $6
+    $1, $2, $3, $4, $5, $6, $7
 ) RETURNING *;
 
 -- name: GetActiveSubscriptionByOwner :one
@@ -363,16 +369,20 @@ SET
     current_period_end = COALESCE(sqlc.narg('current_period_end'), current_period_end),
     trial_end = COALESCE(sqlc.narg('trial_end'), trial_end),
     cancelled_at = COALESCE(sqlc.narg('cancelled_at'), cancelled_at),
+    billing_interval = COALESCE(sqlc.narg('billing_interval'), billing_interval),
     updated_at = NOW()
 WHERE id = sqlc.arg('id')
 RETURNING *;
 
 -- name: CreateInvoice :one
 INSERT INTO invoices (
-    subscription_id, owner_id, invoice_number, amount, currency, status, billing_period_start, billing_period_end
+    subscription_id, owner_id, tx_ref, amount, currency, status, billing_period_start, billing_period_end, prorated, credit_amount,
+    coupon_id, discount_amount, vat_rate
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
+    NULLIF($11::uuid, '00000000-0000-0000-0000-000000000000'::uuid), $12, $13
 ) RETURNING *;
+
 -- name: CreatePaymentTransaction :one
 INSERT INTO payment_transactions (
     owner_id, amount, currency, status, tx_ref, reference
@@ -407,9 +417,16 @@ UPDATE payment_webhooks SET processed = TRUE WHERE provider_event_id = $1;
 -- name: CreatePaymentRetryJob :one
 INSERT INTO payment_retry_jobs (
     subscription_id, scheduled_for
-) VALUES (
-    $1, $2
-) RETURNING *;
+)
+SELECT $1::uuid, $2::timestamp
+WHERE NOT EXISTS (
+    SELECT 1 FROM payment_retry_jobs j
+    JOIN subscriptions s ON s.id = j.subscription_id
+    WHERE s.owner_id = (SELECT owner_id FROM subscriptions WHERE id = $1)
+      AND j.status IN ('pending', 'retrying')
+)
+ON CONFLICT (subscription_id) WHERE status IN ('pending', 'retrying') DO NOTHING
+RETURNING *;
 
 -- name: UpdatePaymentRetryJob :one
 UPDATE payment_retry_jobs
@@ -429,17 +446,17 @@ ORDER BY created_at DESC;
 -- name: ListInvoicesWithFilters :many
 SELECT * FROM invoices
 WHERE 
-    (sqlc.narg('owner_id')::uuid IS NULL OR owner_id = sqlc.narg('owner_id')) AND
-    (sqlc.narg('subscription_id')::uuid IS NULL OR subscription_id = sqlc.narg('subscription_id')) AND
+    owner_id = sqlc.arg('owner_id') AND
+    (NULLIF(sqlc.arg('subscription_id')::uuid, '00000000-0000-0000-0000-000000000000'::uuid) IS NULL OR subscription_id = sqlc.arg('subscription_id')) AND
     (sqlc.narg('status')::invoice_status IS NULL OR status = sqlc.narg('status'))
 ORDER BY created_at DESC
-LIMIT $1 OFFSET $2;
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
 -- name: CountInvoicesWithFilters :one
 SELECT COUNT(*) FROM invoices
 WHERE 
-    (sqlc.narg('owner_id')::uuid IS NULL OR owner_id = sqlc.narg('owner_id')) AND
-    (sqlc.narg('subscription_id')::uuid IS NULL OR subscription_id = sqlc.narg('subscription_id')) AND
+    owner_id = sqlc.arg('owner_id') AND
+    (NULLIF(sqlc.arg('subscription_id')::uuid, '00000000-0000-0000-0000-000000000000'::uuid) IS NULL OR subscription_id = sqlc.arg('subscription_id')) AND
     (sqlc.narg('status')::invoice_status IS NULL OR status = sqlc.narg('status'));
 
 -- name: UpdateInvoiceStatus :one
@@ -447,8 +464,13 @@ UPDATE invoices
 SET 
     status = $2,
     paid_at = CASE WHEN $2 = 'paid'::invoice_status THEN NOW() ELSE paid_at END,
+    invoice_number = CASE
+        WHEN $2 = 'paid'::invoice_status AND invoice_number IS NULL
+        THEN 'INV-' || TO_CHAR(NOW(), 'YYYY') || '-' || LPAD(nextval('invoice_number_seq')::text, 6, '0')
+        ELSE invoice_number
+    END,
     updated_at = NOW()
-WHERE invoice_number = $1
+WHERE tx_ref = $1
 RETURNING *;
 
 -- name: IncrementRestaurantViewCount :exec
@@ -472,11 +494,1013 @@ ORDER BY al.created_at DESC
 LIMIT $1;
 
 -- name: GetRestaurantDetailsForAdmin :one
-SELECT r.*, u.full_name as owner_name, u.email as owner_email
+SELECT r.*, u.full_name as owner_name, u.email as owner_email,
+    sub.status as subscription_status, sub.plan_name as subscription_plan,
+    sub.current_period_end as subscription_period_end, sub.cancel_at_period_end
 FROM restaurants r
 JOIN users u ON r.owner_id = u.id
+LEFT JOIN LATERAL (
+    SELECT s.status, sp.name AS plan_name, s.current_period_end, s.cancel_at_period_end
+    FROM subscriptions s
+    JOIN subscription_plans sp ON s.plan_id = sp.id
+    WHERE s.owner_id = r.owner_id AND s.status IN ('active', 'trialing', 'past_due')
+    ORDER BY CASE s.status WHEN 'active' THEN 0 WHEN 'past_due' THEN 1 ELSE 2 END, s.current_period_end DESC
+    LIMIT 1
+) sub ON TRUE
 WHERE r.id = $1;
 
 -- name: GetAllAdminEmails :many
 SELECT email FROM users
 WHERE role = 'admin' AND deleted_at IS NULL;
+
+-- name: CreateRestaurantTable :one
+INSERT INTO restaurant_tables (
+    restaurant_id, label, token, seats
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: ListRestaurantTables :many
+SELECT * FROM restaurant_tables
+WHERE restaurant_id = $1
+ORDER BY label ASC;
+
+-- name: GetRestaurantTableByToken :one
+SELECT * FROM restaurant_tables
+WHERE token = $1 AND is_active = TRUE;
+
+-- name: DeleteRestaurantTable :exec
+DELETE FROM restaurant_tables
+WHERE id = $1 AND restaurant_id = $2;
+
+-- name: CreateServiceRequest :one
+INSERT INTO service_requests (
+    restaurant_id, table_id, request_type, note
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: ListOpenServiceRequests :many
+SELECT sr.*, t.label as table_label
+FROM service_requests sr
+JOIN restaurant_tables t ON sr.table_id = t.id
+WHERE sr.restaurant_id = $1 AND sr.status = 'open'
+ORDER BY sr.created_at ASC;
+
+-- name: AcknowledgeServiceRequest :one
+UPDATE service_requests
+SET
+    status = 'acknowledged',
+    acknowledged_by = $3,
+    acknowledged_at = NOW()
+WHERE id = $1 AND restaurant_id = $2 AND status = 'open'
+RETURNING *;
+
+-- name: GetServiceRequestAckStats :one
+SELECT
+    COUNT(*) as acknowledged_count,
+    COALESCE(AVG(EXTRACT(EPOCH FROM (acknowledged_at - created_at))), 0)::float8 as avg_ack_seconds
+FROM service_requests
+WHERE restaurant_id = $1 AND acknowledged_at IS NOT NULL AND created_at >= $2;
+
+-- name: UpsertReservationSettings :one
+INSERT INTO reservation_settings (
+    restaurant_id, is_enabled, slot_interval_minutes, dining_duration_minutes, max_party_size, booking_window_days
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (restaurant_id) DO UPDATE SET
+    is_enabled = EXCLUDED.is_enabled,
+    slot_interval_minutes = EXCLUDED.slot_interval_minutes,
+    dining_duration_minutes = EXCLUDED.dining_duration_minutes,
+    max_party_size = EXCLUDED.max_party_size,
+    booking_window_days = EXCLUDED.booking_window_days,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: GetReservationSettings :one
+SELECT * FROM reservation_settings
+WHERE restaurant_id = $1;
+
+-- name: CreateOpeningHours :one
+INSERT INTO restaurant_opening_hours (
+    restaurant_id, day_of_week, opens_at, closes_at
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: DeleteOpeningHoursByRestaurant :exec
+DELETE FROM restaurant_opening_hours
+WHERE restaurant_id = $1;
+
+-- name: ListOpeningHoursByRestaurant :many
+SELECT * FROM restaurant_opening_hours
+WHERE restaurant_id = $1
+ORDER BY day_of_week ASC, opens_at ASC;
+
+-- name: CreateReservation :one
+INSERT INTO reservations (
+    restaurant_id, table_id, guest_name, guest_phone, guest_email, party_size,
+    reserved_at, ends_at, notes, confirmation_token, cancellation_token
+)
+SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+WHERE NOT EXISTS (
+    SELECT 1 FROM reservations r
+    WHERE r.table_id = $2
+      AND r.status IN ('pending', 'confirmed', 'seated')
+      AND r.reserved_at < $8
+      AND r.ends_at > $7
+)
+RETURNING *;
+
+-- name: ListBlockingReservations :many
+SELECT * FROM reservations
+WHERE restaurant_id = $1
+  AND status IN ('pending', 'confirmed', 'seated')
+  AND ends_at > sqlc.arg('window_start')
+  AND reserved_at < sqlc.arg('window_end');
+
+-- name: ListReservationsForDay :many
+SELECT r.*, t.label as table_label
+FROM reservations r
+LEFT JOIN restaurant_tables t ON r.table_id = t.id
+WHERE r.restaurant_id = $1
+  AND r.reserved_at >= sqlc.arg('day_start')
+  AND r.reserved_at < sqlc.arg('day_end')
+ORDER BY r.reserved_at ASC;
+
+-- name: GetReservationByID :one
+SELECT * FROM reservations
+WHERE id = $1 AND restaurant_id = $2;
+
+-- name: GetReservationByCancellationToken :one
+SELECT * FROM reservations
+WHERE cancellation_token = $1;
+
+-- name: ConfirmReservation :one
+UPDATE reservations
+SET
+    status = 'confirmed',
+    confirmed_at = NOW(),
+    updated_at = NOW()
+WHERE confirmation_token = $1 AND status = 'pending'
+RETURNING *;
+
+-- name: CancelReservationByToken :one
+UPDATE reservations
+SET
+    status = 'cancelled',
+    cancelled_at = NOW(),
+    updated_at = NOW()
+WHERE cancellation_token = $1 AND status IN ('pending', 'confirmed')
+RETURNING *;
+
+-- name: UpdateReservationStatus :one
+UPDATE reservations
+SET
+    status = $3,
+    seated_at = CASE WHEN $3 = 'seated'::reservation_status THEN NOW() ELSE seated_at END,
+    updated_at = NOW()
+WHERE id = $1 AND restaurant_id = $2
+RETURNING *;
+
+-- name: CreateReview :one
+INSERT INTO reviews (
+    restaurant_id, menu_item_id, reviewer_name, rating, comment, photo_url, ip_address
+) VALUES (
+    $1, NULLIF($2::uuid, '00000000-0000-0000-0000-000000000000'::uuid), $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetReviewByID :one
+SELECT * FROM reviews
+WHERE id = $1 LIMIT 1;
+
+-- name: ListRestaurantReviews :many
+SELECT * FROM reviews
+WHERE restaurant_id = $1 AND menu_item_id IS NULL AND is_hidden = FALSE
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: CountRestaurantReviews :one
+SELECT COUNT(*) FROM reviews
+WHERE restaurant_id = $1 AND menu_item_id IS NULL AND is_hidden = FALSE;
+
+-- name: ListMenuItemReviews :many
+SELECT * FROM reviews
+WHERE menu_item_id = $1 AND is_hidden = FALSE
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: CountMenuItemReviews :one
+SELECT COUNT(*) FROM reviews
+WHERE menu_item_id = $1 AND is_hidden = FALSE;
+
+-- name: ListReviewsByRestaurant :many
+SELECT rv.*, mi.name as menu_item_name
+FROM reviews rv
+LEFT JOIN menu_items mi ON rv.menu_item_id = mi.id
+WHERE rv.restaurant_id = $1
+ORDER BY rv.created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: CountReviewsByRestaurant :one
+SELECT COUNT(*) FROM reviews
+WHERE restaurant_id = $1;
+
+-- name: ReplyToReview :one
+UPDATE reviews
+SET
+    owner_reply = $2,
+    replied_by = $3,
+    replied_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: ReportReview :exec
+UPDATE reviews SET report_count = report_count + 1 WHERE id = $1;
+
+-- name: SetReviewHidden :one
+UPDATE reviews
+SET
+    is_hidden = $2,
+    hidden_reason = $3,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: ListReportedReviews :many
+SELECT * FROM reviews
+WHERE report_count > 0 OR is_hidden = TRUE
+ORDER BY report_count DESC, created_at DESC
+LIMIT $1 OFFSET $2;
+
+-- name: CountReportedReviews :one
+SELECT COUNT(*) FROM reviews
+WHERE report_count > 0 OR is_hidden = TRUE;
+
+-- name: RefreshRestaurantRating :exec
+UPDATE restaurants
+SET
+    rating_avg = COALESCE((SELECT AVG(rating) FROM reviews WHERE reviews.restaurant_id = restaurants.id AND menu_item_id IS NULL AND is_hidden = FALSE), 0),
+    rating_count = (SELECT COUNT(*) FROM reviews WHERE reviews.restaurant_id = restaurants.id AND menu_item_id IS NULL AND is_hidden = FALSE)
+WHERE id = $1;
+
+-- name: RefreshMenuItemRating :exec
+UPDATE menu_items
+SET
+    rating_avg = COALESCE((SELECT AVG(rating) FROM reviews WHERE reviews.menu_item_id = menu_items.id AND is_hidden = FALSE), 0),
+    rating_count = (SELECT COUNT(*) FROM reviews WHERE reviews.menu_item_id = menu_items.id AND is_hidden = FALSE)
+WHERE id = $1;
+
+-- name: UpdateMenuItemStock :one
+UPDATE menu_items
+SET
+    track_stock = $2,
+    stock_quantity = $3,
+    low_stock_threshold = $4,
+    is_available = CASE
+        WHEN $2 AND $3 = 0 THEN FALSE
+        WHEN $2 AND unavailable_until IS NULL AND NOT plan_locked THEN TRUE
+        ELSE is_available
+    END,
+    was_available_before_lock = CASE
+        WHEN plan_locked AND $2 AND $3 > 0 AND unavailable_until IS NULL THEN TRUE
+        ELSE was_available_before_lock
+    END,
+    low_stock_notified_at = CASE WHEN $3 > $4 THEN NULL ELSE low_stock_notified_at END,
+    updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: AdjustMenuItemStock :one
+UPDATE menu_items
+SET
+    stock_quantity = GREATEST(stock_quantity + sqlc.arg('delta'), 0),
+    is_available = CASE
+        WHEN stock_quantity + sqlc.arg('delta') <= 0 THEN FALSE
+        WHEN stock_quantity = 0 AND unavailable_until IS NULL AND NOT plan_locked THEN TRUE
+        ELSE is_available
+    END,
+    was_available_before_lock = CASE
+        WHEN plan_locked AND stock_quantity = 0 AND stock_quantity + sqlc.arg('delta') > 0 AND unavailable_until IS NULL THEN TRUE
+        ELSE was_available_before_lock
+    END,
+    low_stock_notified_at = CASE WHEN stock_quantity + sqlc.arg('delta') > low_stock_threshold THEN NULL ELSE low_stock_notified_at END,
+    updated_at = NOW()
+WHERE id = sqlc.arg('id') AND track_stock = TRUE AND deleted_at IS NULL
+RETURNING *;
+
+-- name: MarkLowStockNotified :execrows
+UPDATE menu_items
+SET low_stock_notified_at = NOW()
+WHERE id = $1 AND low_stock_notified_at IS NULL;
+
+-- name: ListLowStockMenuItems :many
+SELECT * FROM menu_items
+WHERE restaurant_id = $1 AND track_stock = TRUE AND stock_quantity <= low_stock_threshold AND deleted_at IS NULL
+ORDER BY stock_quantity ASC, name ASC;
+
+-- name: EightySixMenuItem :one
+UPDATE menu_items
+SET
+    is_available = FALSE,
+    unavailable_until = $2,
+    updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: RestoreMenuItemAvailability :one
+UPDATE menu_items
+SET
+    is_available = (NOT track_stock OR stock_quantity > 0) AND NOT plan_locked,
+    was_available_before_lock = CASE WHEN plan_locked THEN NOT track_stock OR stock_quantity > 0 ELSE was_available_before_lock END,
+    unavailable_until = NULL,
+    updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: RestoreExpiredEightySixedItems :execrows
+UPDATE menu_items
+SET
+    is_available = (NOT track_stock OR stock_quantity > 0) AND NOT plan_locked,
+    was_available_before_lock = CASE WHEN plan_locked THEN NOT track_stock OR stock_quantity > 0 ELSE was_available_before_lock END,
+    unavailable_until = NULL,
+    updated_at = NOW()
+WHERE restaurant_id = $1 AND unavailable_until IS NOT NULL AND unavailable_until <= $2;
+
+-- name: CreatePromotion :one
+INSERT INTO promotions (
+    restaurant_id, name, label, scope, category_id, menu_item_id, discount_type, discount_value,
+    starts_on, ends_on, days_of_week, start_time, end_time, is_active, created_by
+) VALUES (
+    $1, $2, $3, $4, NULLIF($5::uuid, '00000000-0000-0000-0000-000000000000'::uuid), NULLIF($6::uuid, '00000000-0000-0000-0000-000000000000'::uuid), $7, $8,
+    $9, $10, $11, $12, $13, $14, $15
+) RETURNING *;
+
+-- name: UpdatePromotion :one
+UPDATE promotions
+SET
+    name = $3,
+    label = $4,
+    scope = $5,
+    category_id = NULLIF($6::uuid, '00000000-0000-0000-0000-000000000000'::uuid),
+    menu_item_id = NULLIF($7::uuid, '00000000-0000-0000-0000-000000000000'::uuid),
+    discount_type = $8,
+    discount_value = $9,
+    starts_on = $10,
+    ends_on = $11,
+    days_of_week = $12,
+    start_time = $13,
+    end_time = $14,
+    is_active = $15,
+    updated_at = NOW()
+WHERE id = $1 AND restaurant_id = $2
+RETURNING *;
+
+-- name: GetPromotionByID :one
+SELECT * FROM promotions
+WHERE id = $1 AND restaurant_id = $2 LIMIT 1;
+
+-- name: ListPromotionsByRestaurant :many
+SELECT * FROM promotions
+WHERE restaurant_id = $1
+ORDER BY created_at DESC;
+
+-- name: DeletePromotion :execrows
+DELETE FROM promotions
+WHERE id = $1 AND restaurant_id = $2;
+
+-- name: ListCurrentPromotions :many
+SELECT * FROM promotions
+WHERE restaurant_id = sqlc.arg('restaurant_id')
+  AND is_active = TRUE
+  AND (starts_on IS NULL OR starts_on <= sqlc.arg('today')::date)
+  AND (ends_on IS NULL OR ends_on >= sqlc.arg('today')::date);
+
+-- name: UpdateUserPassword :exec
+UPDATE users
+SET
+    password_hash = $2,
+    must_change_password = FALSE,
+    password_changed_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1;
+
+-- name: RequireUserPasswordChange :exec
+UPDATE users
+SET must_change_password = TRUE, updated_at = NOW()
+WHERE id = $1;
+
+-- name: CreateStaffInvitation :one
+INSERT INTO staff_invitations (
+    restaurant_id, owner_id, email, full_name, phone, token_hash, expires_at, staff_role_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, NULLIF($8::uuid, '00000000-0000-0000-0000-000000000000'::uuid)
+) RETURNING *;
+
+-- name: GetStaffInvitationByID :one
+SELECT * FROM staff_invitations
+WHERE id = $1 AND restaurant_id = $2 LIMIT 1;
+
+-- name: GetStaffInvitationByTokenHash :one
+SELECT * FROM staff_invitations
+WHERE token_hash = $1 LIMIT 1;
+
+-- name: GetPendingStaffInvitationByEmail :one
+SELECT * FROM staff_invitations
+WHERE restaurant_id = $1 AND LOWER(email) = LOWER($2) AND status = 'pending'
+LIMIT 1;
+
+-- name: ListStaffInvitationsByRestaurant :many
+SELECT * FROM staff_invitations
+WHERE restaurant_id = $1
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: CountStaffInvitationsByRestaurant :one
+SELECT COUNT(*) FROM staff_invitations
+WHERE restaurant_id = $1;
+
+-- name: CountPendingStaffInvitations :one
+SELECT COUNT(*) FROM staff_invitations
+WHERE restaurant_id = $1 AND status = 'pending' AND expires_at > NOW();
+
+-- name: RenewStaffInvitation :one
+UPDATE staff_invitations
+SET token_hash = $3, expires_at = $4, updated_at = NOW()
+WHERE id = $1 AND restaurant_id = $2 AND status = 'pending'
+RETURNING *;
+
+-- name: RevokeStaffInvitation :execrows
+UPDATE staff_invitations
+SET status = 'revoked', updated_at = NOW()
+WHERE id = $1 AND restaurant_id = $2 AND status = 'pending';
+
+-- name: AcceptStaffInvitation :execrows
+UPDATE staff_invitations
+SET status = 'accepted', accepted_user_id = $2, accepted_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND status = 'pending';
+
+-- name: UpsertUserTwoFactor :one
+INSERT INTO user_two_factor (user_id, secret)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE SET
+    secret = EXCLUDED.secret,
+    enabled_at = NOW()
+RETURNING *;
+
+-- name: GetUserTwoFactor :one
+SELECT * FROM user_two_factor
+WHERE user_id = $1 LIMIT 1;
+
+-- name: DeleteUserTwoFactor :exec
+DELETE FROM user_two_factor
+WHERE user_id = $1;
+
+-- name: CreateRecoveryCode :exec
+INSERT INTO user_recovery_codes (user_id, code_hash)
+VALUES ($1, $2);
+
+-- name: DeleteRecoveryCodes :exec
+DELETE FROM user_recovery_codes
+WHERE user_id = $1;
+
+-- name: ListUnusedRecoveryCodes :many
+SELECT * FROM user_recovery_codes
+WHERE user_id = $1 AND used_at IS NULL
+ORDER BY created_at;
+
+-- name: MarkRecoveryCodeUsed :execrows
+UPDATE user_recovery_codes
+SET used_at = NOW()
+WHERE id = $1 AND used_at IS NULL;
+
+-- name: GetPlatformSettings :one
+SELECT * FROM platform_settings
+WHERE id = 1 LIMIT 1;
+
+-- name: UpdatePlatformSettings :one
+UPDATE platform_settings
+SET
+    require_admin_two_factor = $1,
+    updated_by = NULLIF($2::uuid, '00000000-0000-0000-0000-000000000000'::uuid),
+    updated_at = NOW()
+WHERE id = 1
+RETURNING *;
+
+-- name: CreateAuthAuditLog :exec
+INSERT INTO auth_audit_logs (
+    user_id, email, event, reason, ip_address, user_agent, actor_id
+) VALUES (
+    NULLIF($1::uuid, '00000000-0000-0000-0000-000000000000'::uuid), $2, $3, $4, $5, $6, NULLIF($7::uuid, '00000000-0000-0000-0000-000000000000'::uuid)
+);
+
+-- name: UpsertUserSession :exec
+INSERT INTO user_sessions (
+    id, user_id, ip_address, user_agent, device_type, browser, os, expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+)
+ON CONFLICT (id) DO UPDATE SET
+    ip_address = EXCLUDED.ip_address,
+    user_agent = EXCLUDED.user_agent,
+    device_type = EXCLUDED.device_type,
+    browser = EXCLUDED.browser,
+    os = EXCLUDED.os,
+    expires_at = EXCLUDED.expires_at,
+    last_seen_at = NOW();
+
+-- name: ListActiveUserSessions :many
+SELECT * FROM user_sessions
+WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > NOW()
+ORDER BY last_seen_at DESC;
+
+-- name: GetUserSession :one
+SELECT * FROM user_sessions
+WHERE id = $1 AND user_id = $2 LIMIT 1;
+
+-- name: RevokeUserSession :exec
+UPDATE user_sessions
+SET revoked_at = NOW()
+WHERE id = $1 AND revoked_at IS NULL;
+
+-- name: CreateStaffRole :one
+INSERT INTO staff_roles (
+    restaurant_id, name, description, permissions, preset
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetStaffRole :one
+SELECT * FROM staff_roles
+WHERE id = $1 AND restaurant_id = $2 LIMIT 1;
+
+-- name: GetStaffRoleByPreset :one
+SELECT * FROM staff_roles
+WHERE restaurant_id = $1 AND preset = $2 LIMIT 1;
+
+-- name: ListStaffRolesByRestaurant :many
+SELECT r.id, r.restaurant_id, r.name, r.description, r.permissions, r.preset, r.created_at, r.updated_at,
+    (SELECT COUNT(*) FROM users u WHERE u.staff_role_id = r.id AND u.deleted_at IS NULL) AS staff_count
+FROM staff_roles r
+WHERE r.restaurant_id = $1
+ORDER BY r.preset IS NULL, r.name;
+
+-- name: UpdateStaffRole :one
+UPDATE staff_roles
+SET name = $3, description = $4, permissions = $5, updated_at = NOW()
+WHERE id = $1 AND restaurant_id = $2 AND preset IS NULL
+RETURNING *;
+
+-- name: DeleteStaffRole :execrows
+DELETE FROM staff_roles
+WHERE id = $1 AND restaurant_id = $2 AND preset IS NULL;
+
+-- name: CountStaffByRole :one
+SELECT COUNT(*) FROM users
+WHERE staff_role_id = $1 AND deleted_at IS NULL;
+
+-- name: AssignStaffRole :execrows
+UPDATE users
+SET staff_role_id = $3, updated_at = NOW()
+WHERE id = $1 AND restaurant_id = $2 AND role = 'staff' AND deleted_at IS NULL;
+
+-- name: GetStaffPermissions :one
+SELECT u.restaurant_id, COALESCE(r.permissions, '{}')::TEXT[] AS permissions
+FROM users u
+LEFT JOIN staff_roles r ON r.id = u.staff_role_id
+WHERE u.id = $1 AND u.role = 'staff' AND u.is_active = TRUE AND u.deleted_at IS NULL
+LIMIT 1;
+
+-- name: CreateAPIKey :one
+INSERT INTO api_keys (
+    restaurant_id, created_by, name, key_prefix, key_hash, permissions, expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetAPIKeyByHash :one
+SELECT * FROM api_keys
+WHERE key_hash = $1 LIMIT 1;
+
+-- name: ListAPIKeysByRestaurant :many
+SELECT * FROM api_keys
+WHERE restaurant_id = $1
+ORDER BY revoked_at IS NOT NULL, created_at DESC;
+
+-- name: RevokeAPIKey :execrows
+UPDATE api_keys
+SET revoked_at = NOW()
+WHERE id = $1 AND restaurant_id = $2 AND revoked_at IS NULL;
+
+-- name: TouchAPIKey :exec
+UPDATE api_keys
+SET last_used_at = NOW(), last_used_ip = $2
+WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute');
+
+-- name: CreateUserIdentity :one
+INSERT INTO user_identities (
+    user_id, provider, subject, email, last_login_at
+) VALUES (
+    $1, $2, $3, $4, NOW()
+) RETURNING *;
+
+-- name: GetUserIdentity :one
+SELECT * FROM user_identities
+WHERE provider = $1 AND subject = $2 LIMIT 1;
+
+-- name: TouchUserIdentity :exec
+UPDATE user_identities
+SET last_login_at = NOW()
+WHERE id = $1;
+
+-- name: GetSubscriptionByID :one
+SELECT * FROM subscriptions
+WHERE id = $1 LIMIT 1;
+
+-- name: GetSubscriptionPlanByID :one
+SELECT * FROM subscription_plans
+WHERE id = $1 LIMIT 1;
+
+-- name: ScheduleSubscriptionChange :exec
+UPDATE subscriptions
+SET scheduled_plan_id = $2, scheduled_billing_interval = $3, updated_at = NOW()
+WHERE id = $1;
+
+-- name: ClearScheduledSubscriptionChange :execrows
+UPDATE subscriptions
+SET scheduled_plan_id = NULL, scheduled_billing_interval = NULL, updated_at = NOW()
+WHERE owner_id = $1 AND status = 'active' AND scheduled_plan_id IS NOT NULL;
+
+-- name: ApplyScheduledSubscriptionChange :execrows
+UPDATE subscriptions
+SET
+    plan_id = scheduled_plan_id,
+    billing_interval = COALESCE(scheduled_billing_interval, billing_interval),
+    scheduled_plan_id = NULL,
+    scheduled_billing_interval = NULL,
+    updated_at = NOW()
+WHERE owner_id = $1 AND status = 'active' AND scheduled_plan_id IS NOT NULL AND current_period_end <= NOW();
+
+-- name: ListDuePaymentRetryJobs :many
+SELECT * FROM payment_retry_jobs
+WHERE status IN ('pending', 'retrying') AND scheduled_for <= NOW()
+ORDER BY scheduled_for
+LIMIT $1;
+
+-- name: ClaimPaymentRetryJob :one
+UPDATE payment_retry_jobs
+SET status = 'retrying', scheduled_for = $2, updated_at = NOW()
+WHERE id = $1 AND status IN ('pending', 'retrying') AND scheduled_for <= NOW()
+RETURNING *;
+
+-- name: SetPaymentRetryJobStatus :exec
+UPDATE payment_retry_jobs
+SET status = $2, updated_at = NOW()
+WHERE id = $1;
+
+-- name: CompletePaymentRetryJobsByOwner :exec
+UPDATE payment_retry_jobs
+SET status = 'completed', updated_at = NOW()
+WHERE status IN ('pending', 'retrying')
+  AND subscription_id IN (SELECT id FROM subscriptions WHERE owner_id = $1);
+
+-- name: CancelPastDueSubscriptionsByOwner :execrows
+UPDATE subscriptions
+SET status = 'cancelled', cancelled_at = NOW(), updated_at = NOW()
+WHERE owner_id = $1 AND status = 'past_due';
+
+-- name: ApplyDueScheduledSubscriptionChanges :execrows
+UPDATE subscriptions
+SET
+    plan_id = scheduled_plan_id,
+    billing_interval = COALESCE(scheduled_billing_interval, billing_interval),
+    scheduled_plan_id = NULL,
+    scheduled_billing_interval = NULL,
+    updated_at = NOW()
+WHERE status = 'active' AND scheduled_plan_id IS NOT NULL AND current_period_end <= NOW();
+
+-- name: MarkExpiredSubscriptionsPastDue :many
+UPDATE subscriptions
+SET status = 'past_due', updated_at = NOW()
+WHERE status = 'active' AND current_period_end <= NOW() AND cancel_at_period_end = FALSE
+  AND plan_id IN (SELECT id FROM subscription_plans WHERE price_monthly > 0)
+RETURNING *;
+
+-- name: ListSubscriptionsEndingBetween :many
+SELECT s.id, s.owner_id, s.current_period_end, sp.name AS plan_name, sp.price_monthly, u.email, u.full_name
+FROM subscriptions s
+JOIN subscription_plans sp ON s.plan_id = sp.id
+JOIN users u ON s.owner_id = u.id
+WHERE s.status = sqlc.arg('status') AND s.current_period_end > sqlc.arg('ends_after') AND s.current_period_end <= sqlc.arg('ends_before')
+  AND s.cancel_at_period_end = FALSE AND u.is_active = TRUE
+ORDER BY s.current_period_end;
+
+-- name: RecordSubscriptionNotification :execrows
+INSERT INTO subscription_notifications (
+    subscription_id, kind, period_end
+) VALUES (
+    $1, $2, $3
+) ON CONFLICT (subscription_id, kind, period_end) DO NOTHING;
+
+-- name: DeleteSubscriptionNotification :exec
+DELETE FROM subscription_notifications
+WHERE subscription_id = $1 AND kind = $2 AND period_end = $3;
+
+-- name: GetEffectiveSubscriptionByOwner :one
+SELECT s.*, sp.name as plan_name, sp.slug as plan_slug, sp.features
+FROM subscriptions s
+JOIN subscription_plans sp ON s.plan_id = sp.id
+WHERE s.owner_id = $1 AND (
+    s.status = 'past_due' OR
+    (s.status IN ('active', 'trialing') AND s.current_period_end > NOW())
+)
+ORDER BY CASE s.status WHEN 'active' THEN 0 WHEN 'past_due' THEN 1 ELSE 2 END, s.current_period_end DESC
+LIMIT 1;
+
+-- name: ExpireTrialSubscriptions :many
+UPDATE subscriptions
+SET status = 'cancelled', cancelled_at = NOW(), updated_at = NOW()
+WHERE status = 'trialing' AND current_period_end <= NOW()
+RETURNING *;
+
+-- name: ListOwnersWithoutSubscription :many
+SELECT DISTINCT s.owner_id
+FROM subscriptions s
+JOIN users u ON s.owner_id = u.id
+WHERE s.status = 'cancelled' AND u.is_active = TRUE AND NOT EXISTS (
+    SELECT 1 FROM subscriptions o
+    WHERE o.owner_id = s.owner_id AND o.status IN ('active', 'trialing', 'past_due')
+);
+
+-- name: LockRestaurantsOverLimit :execrows
+UPDATE restaurants
+SET is_published = FALSE, plan_locked = TRUE, updated_at = NOW()
+WHERE id IN (
+    SELECT id FROM restaurants
+    WHERE owner_id = $1 AND is_published = TRUE
+    ORDER BY created_at ASC
+    OFFSET $2
+);
+
+-- name: LockCategoriesOverLimit :execrows
+UPDATE categories
+SET is_active = FALSE, plan_locked = TRUE, updated_at = NOW()
+WHERE id IN (
+    SELECT ranked.id FROM (
+        SELECT c.id, ROW_NUMBER() OVER (PARTITION BY c.restaurant_id ORDER BY c.display_order, c.created_at) AS position
+        FROM categories c
+        JOIN restaurants r ON c.restaurant_id = r.id
+        WHERE r.owner_id = sqlc.arg('owner_id') AND c.is_active = TRUE
+    ) ranked
+    WHERE ranked.position > sqlc.arg('keep')::int
+);
+
+-- name: LockMenuItemsOverLimit :execrows
+UPDATE menu_items
+SET was_available_before_lock = is_available, is_available = FALSE, plan_locked = TRUE, updated_at = NOW()
+WHERE id IN (
+    SELECT ranked.id FROM (
+        SELECT m.id, ROW_NUMBER() OVER (PARTITION BY m.restaurant_id ORDER BY m.display_order, m.created_at) AS position
+        FROM menu_items m
+        JOIN restaurants r ON m.restaurant_id = r.id
+        WHERE r.owner_id = sqlc.arg('owner_id') AND m.deleted_at IS NULL
+    ) ranked
+    WHERE ranked.position > sqlc.arg('keep')::int
+) AND NOT plan_locked;
+
+-- name: UnlockRestaurants :execrows
+UPDATE restaurants
+SET is_published = TRUE, plan_locked = FALSE, updated_at = NOW()
+WHERE owner_id = $1 AND plan_locked = TRUE;
+
+-- name: UnlockCategories :execrows
+UPDATE categories
+SET is_active = TRUE, plan_locked = FALSE, updated_at = NOW()
+WHERE plan_locked = TRUE AND restaurant_id IN (SELECT id FROM restaurants WHERE owner_id = $1);
+
+-- name: UnlockMenuItems :execrows
+UPDATE menu_items
+SET
+    is_available = was_available_before_lock AND (NOT track_stock OR stock_quantity > 0) AND unavailable_until IS NULL,
+    was_available_before_lock = FALSE,
+    plan_locked = FALSE,
+    updated_at = NOW()
+WHERE plan_locked = TRUE AND restaurant_id IN (SELECT id FROM restaurants WHERE owner_id = $1);
+
+-- name: CancelSubscriptionAtPeriodEnd :one
+UPDATE subscriptions
+SET cancel_at_period_end = TRUE, scheduled_plan_id = NULL, scheduled_billing_interval = NULL, updated_at = NOW()
+WHERE id = $1 AND status IN ('active', 'trialing') AND cancel_at_period_end = FALSE
+RETURNING *;
+
+-- name: CancelSubscriptionNow :one
+UPDATE subscriptions
+SET status = 'cancelled', cancelled_at = NOW(), cancel_at_period_end = FALSE,
+    scheduled_plan_id = NULL, scheduled_billing_interval = NULL, updated_at = NOW()
+WHERE id = $1 AND status IN ('active', 'trialing', 'past_due')
+RETURNING *;
+
+-- name: ResumeSubscriptionByOwner :one
+UPDATE subscriptions
+SET cancel_at_period_end = FALSE, updated_at = NOW()
+WHERE owner_id = $1 AND status IN ('active', 'trialing') AND cancel_at_period_end = TRUE AND current_period_end > NOW()
+RETURNING *;
+
+-- name: CancelSubscriptionsAtPeriodEnd :many
+UPDATE subscriptions
+SET status = 'cancelled', cancelled_at = NOW(), cancel_at_period_end = FALSE, updated_at = NOW()
+WHERE status IN ('active', 'trialing') AND cancel_at_period_end = TRUE AND current_period_end <= NOW()
+RETURNING *;
+
+-- name: CreateSubscriptionCancellation :one
+INSERT INTO subscription_cancellations (
+    subscription_id, owner_id, plan_id, reason, feedback, immediate
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: MarkSubscriptionCancellationResumed :exec
+UPDATE subscription_cancellations
+SET resumed_at = NOW()
+WHERE subscription_id = $1 AND immediate = FALSE AND resumed_at IS NULL;
+
+-- name: CreateCoupon :one
+INSERT INTO coupons (
+    code, description, discount_type, discount_value, plan_ids, max_redemptions, duration_cycles, expires_at, is_active, created_by
+) VALUES (
+    UPPER($1), $2, $3, $4, $5, $6, $7, $8, $9, $10
+) RETURNING *;
+
+-- name: UpdateCoupon :one
+UPDATE coupons
+SET
+    code = UPPER($2),
+    description = $3,
+    discount_type = $4,
+    discount_value = $5,
+    plan_ids = $6,
+    max_redemptions = $7,
+    duration_cycles = $8,
+    expires_at = $9,
+    is_active = $10,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: GetCouponByID :one
+SELECT * FROM coupons
+WHERE id = $1;
+
+-- name: GetCouponByCode :one
+SELECT * FROM coupons
+WHERE code = UPPER($1);
+
+-- name: ListCoupons :many
+SELECT * FROM coupons
+ORDER BY created_at DESC;
+
+-- name: DeactivateCoupon :execrows
+UPDATE coupons
+SET is_active = FALSE, updated_at = NOW()
+WHERE id = $1;
+
+-- name: IncrementCouponRedemptions :execrows
+UPDATE coupons
+SET redemption_count = redemption_count + 1, updated_at = NOW()
+WHERE id = $1 AND (max_redemptions IS NULL OR redemption_count < max_redemptions);
+
+-- name: CountCouponCyclesByOwner :one
+SELECT COUNT(*) FROM coupon_redemptions
+WHERE coupon_id = $1 AND owner_id = $2;
+
+-- name: GetLatestCouponRedemptionByOwner :one
+SELECT * FROM coupon_redemptions
+WHERE owner_id = $1
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: CreateCouponRedemption :execrows
+INSERT INTO coupon_redemptions (
+    coupon_id, owner_id, invoice_id, discount_amount
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (invoice_id) DO NOTHING;
+
+-- name: ListCouponRedemptions :many
+SELECT cr.*, u.email AS owner_email, u.full_name AS owner_name, i.invoice_number
+FROM coupon_redemptions cr
+JOIN users u ON cr.owner_id = u.id
+JOIN invoices i ON cr.invoice_id = i.id
+WHERE cr.coupon_id = $1
+ORDER BY cr.created_at DESC;
+
+-- name: GetInvoiceDocument :one
+SELECT i.*, u.full_name AS owner_name, u.email AS owner_email, u.phone AS owner_phone,
+    p.name AS plan_name, s.billing_interval, c.code AS coupon_code
+FROM invoices i
+JOIN users u ON i.owner_id = u.id
+JOIN subscriptions s ON i.subscription_id = s.id
+JOIN subscription_plans p ON s.plan_id = p.id
+LEFT JOIN coupons c ON i.coupon_id = c.id
+WHERE i.id = $1;
+
+-- name: ReserveInvoiceRefund :one
+UPDATE invoices
+SET
+    refunded_amount = refunded_amount + sqlc.arg('amount'),
+    status = CASE WHEN refunded_amount + sqlc.arg('amount') >= amount THEN 'refunded'::invoice_status ELSE status END,
+    updated_at = NOW()
+WHERE id = sqlc.arg('id') AND status = 'paid' AND refunded_amount + sqlc.arg('amount') <= amount
+RETURNING *;
+
+-- name: ReleaseInvoiceRefund :exec
+UPDATE invoices
+SET
+    refunded_amount = refunded_amount - sqlc.arg('amount'),
+    status = 'paid',
+    updated_at = NOW()
+WHERE id = sqlc.arg('id');
+
+-- name: AddPaymentTransactionRefund :one
+UPDATE payment_transactions
+SET
+    refunded_amount = refunded_amount + sqlc.arg('amount'),
+    status = CASE WHEN refunded_amount + sqlc.arg('amount') >= amount THEN 'refunded' ELSE 'partially_refunded' END,
+    updated_at = NOW()
+WHERE tx_ref = sqlc.arg('tx_ref')
+RETURNING *;
+
+-- name: CreateCreditNote :one
+INSERT INTO credit_notes (
+    credit_note_number, invoice_id, owner_id, amount, currency, vat_rate, reason, provider_refund_ref, subscription_terminated, created_by
+) VALUES (
+    'CN-' || TO_CHAR(NOW(), 'YYYY') || '-' || LPAD(nextval('credit_note_number_seq')::text, 6, '0'),
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING *;
+
+-- name: ListCreditNotesByInvoice :many
+SELECT * FROM credit_notes
+WHERE invoice_id = $1
+ORDER BY created_at;
+
+-- name: GetInvoiceByID :one
+SELECT * FROM invoices
+WHERE id = $1 LIMIT 1;
+
+-- name: KeepLockedMenuItemUnavailable :exec
+UPDATE menu_items
+SET was_available_before_lock = FALSE, updated_at = NOW()
+WHERE id = $1 AND plan_locked = TRUE;
+
+-- name: CreateCouponReservation :execrows
+INSERT INTO coupon_reservations (coupon_id, owner_id, tx_ref, expires_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (coupon_id, owner_id) DO NOTHING;
+
+-- name: ExtendCouponReservation :execrows
+UPDATE coupon_reservations
+SET tx_ref = $3, expires_at = $4
+WHERE coupon_id = $1 AND owner_id = $2;
+
+-- name: HasCouponReservation :one
+SELECT EXISTS (
+    SELECT 1 FROM coupon_reservations WHERE coupon_id = $1 AND owner_id = $2
+);
+
+-- name: ConsumeCouponReservation :execrows
+DELETE FROM coupon_reservations
+WHERE coupon_id = $1 AND owner_id = $2;
+
+-- name: ReleaseCouponReservation :execrows
+WITH released AS (
+    DELETE FROM coupon_reservations WHERE tx_ref = $1 RETURNING coupon_id
+)
+UPDATE coupons
+SET redemption_count = GREATEST(redemption_count - 1, 0), updated_at = NOW()
+WHERE id IN (SELECT coupon_id FROM released);
+
+-- name: ReleaseExpiredCouponReservations :execrows
+WITH released AS (
+    DELETE FROM coupon_reservations WHERE expires_at <= NOW() RETURNING coupon_id
+), totals AS (
+    SELECT coupon_id, COUNT(*) AS released_count FROM released GROUP BY coupon_id
+)
+UPDATE coupons
+SET redemption_count = GREATEST(coupons.redemption_count - totals.released_count, 0), updated_at = NOW()
+FROM totals
+WHERE coupons.id = totals.coupon_id;
+
+-- name: CancelPendingRenewalCheckouts :many
+WITH cancelled AS (
+    UPDATE payment_transactions
+    SET status = 'cancelled', updated_at = NOW()
+    WHERE owner_id = $1 AND status = 'pending' AND reference LIKE 'renewal:%'
+    RETURNING tx_ref
+)
+UPDATE invoices
+SET status = 'cancelled', updated_at = NOW()
+WHERE tx_ref IN (SELECT tx_ref FROM cancelled) AND status = 'pending'
+RETURNING tx_ref;
@@ -34,3 +34,88 @@ func (r *RedisClient) Set(ctx context.Context, key string, value interface{}, ex
 func (r *RedisClient) Get(ctx context.Context, key string) (string, error) {
 	return r.Client.Get(ctx, key).Result()
 }
+
+// Exists reports whether the key is present.
+func (r *RedisClient) Exists(ctx context.Context, key string) (bool, error) {
+	n, err := r.Client.Exists(ctx, key).Result()
+	return n > 0, err
+}
+
+// SAdd adds a member to a set and (re)sets the set's expiry.
+func (r *RedisClient) SAdd(ctx context.Context, key string, member string, expiration int) error {
+	if err := r.Client.SAdd(ctx, key, member).Err(); err != nil {
+		return err
+	}
+	return r.Client.Expire(ctx, key, time.Duration(expiration)*time.Second).Err()
+}
+
+func (r *RedisClient) SMembers(ctx context.Context, key string) ([]string, error) {
+	return r.Client.SMembers(ctx, key).Result()
+}
+
+func (r *RedisClient) SRem(ctx context.Context, key string, member string) error {
+	return r.Client.SRem(ctx, key, member).Err()
+}
+
+// Del removes the given keys and reports how many existed.
+func (r *RedisClient) Del(ctx context.Context, keys ...string) (int64, error) {
+	return r.Client.Del(ctx, keys...).Result()
+}
+
+// Incr increments a counter and starts its expiry window on first use.
+func (r *RedisClient) Incr(ctx context.Context, key string, expiration int) (int64, error) {
+	count, err := r.Client.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if count == 1 {
+		if err := r.Client.Expire(ctx, key, time.Duration(expiration)*time.Second).Err(); err != nil {
+			return count, err
+		}
+	}
+	return count, nil
+}
+
+// TTL returns the remaining lifetime of a key, or zero if it does not exist or never expires.
+func (r *RedisClient) TTL(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := r.Client.TTL(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+// renewLockScript extends a lock only while the caller still holds it
+var renewLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+
+// releaseLockScript deletes a lock only while the caller still holds it
+var releaseLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+// AcquireLock takes the lock for owner if it is free, or extends it if owner already
+// holds it. It reports whether owner holds the lock afterwards.
+func (r *RedisClient) AcquireLock(ctx context.Context, key, owner string, ttl time.Duration) (bool, error) {
+	renewed, err := renewLockScript.Run(ctx, r.Client, []string{key}, owner, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, err
+	}
+	if renewed == 1 {
+		return true, nil
+	}
+	return r.Client.SetNX(ctx, key, owner, ttl).Result()
+}
+
+// ReleaseLock gives up a lock held by owner.
+func (r *RedisClient) ReleaseLock(ctx context.Context, key, owner string) error {
+	return releaseLockScript.Run(ctx, r.Client, []string{key}, owner).Err()
+}